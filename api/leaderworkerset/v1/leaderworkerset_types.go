@@ -17,6 +17,7 @@ limitations under the License.
 package v1
 
 import (
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -73,6 +74,23 @@ const (
 	// the index/identity of the pod in the group.
 	LwsWorkerIndex string = "LWS_WORKER_INDEX"
 
+	// Environment variable added to all containers in the LeaderWorkerSet when
+	// LeaderWorkerSet.Spec.LeaderWorkerTemplate.InjectPeerAddresses is true, it's
+	// a comma-separated list of the addresses of all the pods in the group.
+	LwsPeerAddresses string = "LWS_PEER_ADDRESSES"
+
+	// Environment variable added to all containers in the LeaderWorkerSet to
+	// address the pod itself, it's stable across restarts of the pod.
+	LwsPodFQDN string = "LWS_POD_FQDN"
+
+	// Environment variable added to all containers in the LeaderWorkerSet, it's "true"
+	// in the leader pod, i.e. the pod with worker index 0, and "false" otherwise.
+	LwsIsLeader string = "LWS_IS_LEADER"
+
+	// Environment variable added to all containers in the LeaderWorkerSet to track the
+	// rank of the pod across the group, the leader pod is rank 0.
+	LwsRank string = "LWS_RANK"
+
 	// Subgroup index tracks which subgroup the pod is part of. It will be added
 	// as a label to the pod only if LeaderWorkerSet.Spec.SubGroupSize is set.
 	SubGroupIndexLabelKey string = "leaderworkerset.sigs.k8s.io/subgroup-index"
@@ -91,12 +109,187 @@ const (
 	// Leader pods will have an annotation that determines what type of domain
 	// will be injected. Corresponds to LeaderWorkerSet.Spec.NetworkConfig.SubdomainPolicy
 	SubdomainPolicyAnnotationKey string = "leaderworkerset.sigs.k8s.io/subdomainPolicy"
+
+	// Scheduling gate added to leader pods when StartupPolicy is WorkersFirst. It will
+	// be removed once all the worker pods in the group are ready.
+	WorkersReadySchedulingGate string = "leaderworkerset.sigs.k8s.io/workers-ready"
+
+	// Drain deadline will be added to old leader pods as an annotation during rolling
+	// update when DrainGracePeriodSeconds is set. The group will not be deleted until
+	// the deadline, which is in RFC3339 format, has passed.
+	DrainDeadlineAnnotationKey string = "leaderworkerset.sigs.k8s.io/drain-deadline"
+
+	// Workers termination start will be added to leader pods as an annotation when their
+	// group is about to be deleted and OrderedTermination is true. The worker statefulset
+	// is not recreated once it's set, and the leader pod is deleted after the workers are
+	// gone or the termination grace period of the workers, counted from the annotated
+	// time in RFC3339 format, has passed.
+	WorkersTerminationStartAnnotationKey string = "leaderworkerset.sigs.k8s.io/workers-termination-start"
+
+	// Group failed will be added to leader pods as an annotation, holding the name of the
+	// failed pod, when their group fails and FailedGroupRetention is Retain. The group is
+	// not recreated until it's restarted with the restart-group annotation.
+	GroupFailedAnnotationKey string = "leaderworkerset.sigs.k8s.io/group-failed"
+
+	// Group start times will be added to the leader statefulset as an annotation when
+	// ActiveDeadlineSeconds is set, the JSON encoded time each group started at, by group
+	// index. It's recorded from the first leader pod of the group, so that recreating the
+	// leader pod doesn't restart the deadline of the group.
+	GroupStartTimesAnnotationKey string = "leaderworkerset.sigs.k8s.io/group-start-times"
+
+	// Deadline exceeded groups will be added to the leader statefulset as an annotation, the
+	// JSON encoded indexes of the groups which ran for longer than ActiveDeadlineSeconds.
+	// Their leader pods are recreated with the DeadlineExceededSchedulingGate, so that the
+	// groups stay down until ActiveDeadlineSeconds is raised or unset.
+	DeadlineExceededGroupsAnnotationKey string = "leaderworkerset.sigs.k8s.io/deadline-exceeded-groups"
+
+	// Scheduling gate added to the leader pods of the groups recorded by the
+	// DeadlineExceededGroupsAnnotationKey annotation. Their worker statefulset is not
+	// created either.
+	DeadlineExceededSchedulingGate string = "leaderworkerset.sigs.k8s.io/deadline-exceeded"
+
+	// Leader pods will have this annotation, the JSON encoded node names the scheduled pods
+	// of the group landed on, by pod name. It's kept up to date as the pods get scheduled,
+	// e.g. to verify the exclusive placement of the group.
+	GroupNodesAnnotationKey string = "leaderworkerset.sigs.k8s.io/group-nodes"
+
+	// Worker pods will have this annotation when
+	// LeaderWorkerSet.Spec.LeaderWorkerTemplate.WorkerReadinessFollowsLeader is true.
+	WorkerReadinessFollowsLeaderAnnotationKey string = "leaderworkerset.sigs.k8s.io/worker-readiness-follows-leader"
+
+	// Readiness gate condition type added to worker pods when WorkerReadinessFollowsLeader
+	// is true. The condition is True only when the leader pod of the group is ready.
+	LeaderReadyPodConditionType corev1.PodConditionType = "leaderworkerset.sigs.k8s.io/leader-ready"
+
+	// Pods will have this annotation when
+	// LeaderWorkerSet.Spec.LeaderWorkerTemplate.InjectPeerAddresses is true.
+	InjectPeerAddressesAnnotationKey string = "leaderworkerset.sigs.k8s.io/inject-peer-addresses"
+
+	// Pods will have this annotation, the JSON encoded overrides of the injected environment
+	// variable names, when LeaderWorkerSet.Spec.LeaderWorkerTemplate.NetworkEnvNames is set.
+	NetworkEnvNamesAnnotationKey string = "leaderworkerset.sigs.k8s.io/network-env-names"
+
+	// Leader pods will have this annotation when
+	// LeaderWorkerSet.Spec.LeaderWorkerTemplate.LeaderPodDeletionCost is set, it's
+	// translated into the controller.kubernetes.io/pod-deletion-cost annotation.
+	LeaderPodDeletionCostAnnotationKey string = "leaderworkerset.sigs.k8s.io/leader-pod-deletion-cost"
+
+	// Leader pods will have this annotation, the name of the PriorityClass set on them,
+	// when LeaderWorkerSet.Spec.LeaderWorkerTemplate.LeaderPriorityClassName is set.
+	LeaderPriorityClassNameAnnotationKey string = "leaderworkerset.sigs.k8s.io/leader-priority-class-name"
+
+	// Leader pods will have this annotation, the terminationGracePeriodSeconds set on them, when
+	// LeaderWorkerSet.Spec.LeaderWorkerTemplate.LeaderTerminationGracePeriodSeconds is set.
+	LeaderTerminationGracePeriodSecondsAnnotationKey string = "leaderworkerset.sigs.k8s.io/leader-termination-grace-period-seconds"
+
+	// Pods will have this annotation, the name of the ServiceAccount set on them, when
+	// LeaderWorkerSet.Spec.LeaderWorkerTemplate.LeaderServiceAccountName is set for the leader
+	// pods or LeaderWorkerSet.Spec.LeaderWorkerTemplate.WorkerServiceAccountName for the workers.
+	ServiceAccountNameAnnotationKey string = "leaderworkerset.sigs.k8s.io/service-account-name"
+
+	// Pods will have this annotation, the label key gang schedulers group the pods by,
+	// when LeaderWorkerSet.Spec.LeaderWorkerTemplate.GangSchedulingLabelKey is set.
+	GangSchedulingLabelKeyAnnotationKey string = "leaderworkerset.sigs.k8s.io/gang-scheduling-label-key"
+
+	// Leader pods will have this annotation, the JSON encoded topology spread constraints,
+	// when LeaderWorkerSet.Spec.LeaderWorkerTemplate.GroupSpreadConstraints is set.
+	GroupSpreadConstraintsAnnotationKey string = "leaderworkerset.sigs.k8s.io/group-spread-constraints"
+
+	// Pods will have this annotation, the JSON encoded containers appended to them,
+	// when LeaderWorkerSet.Spec.LeaderWorkerTemplate.CommonContainers is set.
+	CommonContainersAnnotationKey string = "leaderworkerset.sigs.k8s.io/common-containers"
+
+	// Pods of the groups targeted by LeaderWorkerSet.Spec.LeaderWorkerTemplate.PerGroupEnv will
+	// have this annotation, the JSON encoded environment variables of their group keyed by its
+	// group index label. It's set on the leader pods by the pod webhook when they're created.
+	PerGroupEnvAnnotationKey string = "leaderworkerset.sigs.k8s.io/per-group-env"
+
+	// Leader pods will have this annotation, holding the JSON encoded init containers,
+	// when LeaderWorkerSet.Spec.LeaderWorkerTemplate.LeaderGroupInitContainers is set.
+	LeaderGroupInitContainersAnnotationKey string = "leaderworkerset.sigs.k8s.io/leader-group-init-containers"
+
+	// Pods will have this annotation when
+	// LeaderWorkerSet.Spec.LeaderWorkerTemplate.PublishMembershipConfigMap is true.
+	MembershipConfigMapAnnotationKey string = "leaderworkerset.sigs.k8s.io/membership-configmap"
+
+	// Name of the volume of the membership ConfigMap of the group, added to the pods when
+	// LeaderWorkerSet.Spec.LeaderWorkerTemplate.PublishMembershipConfigMap is true.
+	MembershipVolumeName string = "lws-membership"
+
+	// Key of the membership ConfigMap holding the addresses of the pods of the group,
+	// one per line with the leader first.
+	MembershipHostsKey string = "hosts"
+
+	// Key of the membership ConfigMap holding the size of the group.
+	MembershipSizeKey string = "size"
+
+	// When present on an update, the webhook returns a warning with the number of groups
+	// that the rollout would create and delete. The value of the annotation is ignored.
+	DryRunPlanAnnotationKey string = "leaderworkerset.sigs.k8s.io/dry-run-plan"
+
+	// When set to "true" on a LeaderWorkerSet, the controllers stop creating, updating and
+	// deleting any of its resources, only the status is still updated. Removing it or
+	// setting it to any other value resumes the reconciliation.
+	PausedAnnotationKey string = "leaderworkerset.sigs.k8s.io/paused"
+
+	// Approves the rollout of a LeaderWorkerSet with requireApproval set, the value is the hash
+	// of the update revision to roll out, as reported in status.updateRevision.
+	ApproveRevisionAnnotationKey string = "leaderworkerset.sigs.k8s.io/approve-revision"
+
+	// Prefix of the annotations requesting the restart of a group, suffixed by the group index,
+	// e.g. leaderworkerset.sigs.k8s.io/restart-group-0. The value is an RFC 3339 timestamp, the
+	// group is deleted and recreated once for every timestamp newer than the last one processed.
+	RestartGroupAnnotationKeyPrefix string = "leaderworkerset.sigs.k8s.io/restart-group-"
+
+	// Prefix of the annotations pinning a group to a revision, suffixed by the group index,
+	// e.g. leaderworkerset.sigs.k8s.io/pin-group-0. The value is the revision hash the group
+	// runs, the rolling updates don't update the group, nor the groups with a lower index,
+	// until the annotation is removed.
+	PinGroupAnnotationKeyPrefix string = "leaderworkerset.sigs.k8s.io/pin-group-"
+
+	// Maintained by the controller on the LeaderWorkerSet with its aggregate health for external
+	// tooling, one of Healthy, Degraded or Unhealthy. It's derived from the ready groups and the
+	// crashing pods reported in the status.
+	HealthAnnotationKey string = "leaderworkerset.sigs.k8s.io/health"
+
+	// Set to "true" on the pods of the groups kept as hot standbys when
+	// LeaderWorkerSet.Spec.StandbyReplicas is set.
+	StandbyLabelKey string = "leaderworkerset.sigs.k8s.io/standby"
+)
+
+// Values of the HealthAnnotationKey annotation.
+const (
+	// HealthHealthy means all the groups are ready and no pod is crash looping.
+	HealthHealthy string = "Healthy"
+
+	// HealthDegraded means some but not all of the groups are ready, or a pod is crash looping.
+	HealthDegraded string = "Degraded"
+
+	// HealthUnhealthy means none of the groups are ready.
+	HealthUnhealthy string = "Unhealthy"
+)
+
+// Placeholders that can be used in the annotation values and the container commands and
+// args of the leader and worker templates, e.g. {{.GroupIndex}}. They are expanded when
+// the pods are created.
+const (
+	// GroupIndexPlaceholder is expanded to the index of the group the pod belongs to.
+	GroupIndexPlaceholder string = "GroupIndex"
+
+	// WorkerIndexPlaceholder is expanded to the index of the pod in the group,
+	// it is always 0 for the leader pod.
+	WorkerIndexPlaceholder string = "WorkerIndex"
+
+	// SizePlaceholder is expanded to the number of pods in the group.
+	SizePlaceholder string = "Size"
 )
 
 // One group consists of a single leader and M workers, and the total number of pods in a group is M+1.
 // LeaderWorkerSet will create N replicas of leader-worker pod groups (hereinafter referred to as group).
 //
-// Each group has a unique index between 0 and N-1. We call this the leaderIndex.
+// Each group has a unique index between 0 and N-1. We call this the leaderIndex. When groups
+// are deleted with the LowestIndexFirst scale down policy, the indexes start from the lowest
+// remaining one instead of 0.
 // The leaderIndex is used to uniquely name the leader pod of each group in the following format:
 // leaderWorkerSetName-leaderIndex. This is considered as the name of the group too.
 //
@@ -116,6 +309,38 @@ type LeaderWorkerSetSpec struct {
 	// +kubebuilder:default=1
 	Replicas *int32 `json:"replicas,omitempty"`
 
+	// MaxReplicas is the upper bound of the number of leader-workers groups, including the
+	// standby groups. Creating or updating a LeaderWorkerSet with replicas plus standbyReplicas
+	// greater than maxReplicas will be rejected, and if replicas exceeds it anyway, e.g. set
+	// via the scale subresource, the controller will only reconcile up to maxReplicas groups.
+	// When unset, the number of groups is unbounded.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+
+	// MinReplicas is the minimum number of ready groups for the LeaderWorkerSet to be
+	// Available when the other groups can't be scheduled for lack of capacity, i.e. their
+	// pods have been unschedulable for longer than the unschedulable timeout of the
+	// controller. Those groups are reported by the InsufficientCapacity condition, and
+	// the groups that could be scheduled are kept running meanwhile. It must not be
+	// greater than replicas. When unset, all the replicas have to be ready.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// StandbyReplicas is the number of extra groups created beyond replicas and kept
+	// running as hot standbys. Their pods are labeled with the standby label and they
+	// aren't counted in the readyReplicas. When a group serving the replicas isn't ready
+	// while a standby is, the standby is promoted in its place, and the unready group
+	// becomes a standby. The standby groups count towards maxReplicas.
+	// Default to 0.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	StandbyReplicas *int32 `json:"standbyReplicas,omitempty"`
+
 	// LeaderWorkerTemplate defines the template for leader/worker pods
 	LeaderWorkerTemplate LeaderWorkerTemplate `json:"leaderWorkerTemplate"`
 
@@ -126,13 +351,55 @@ type LeaderWorkerSetSpec struct {
 
 	// StartupPolicy determines the startup policy for the worker statefulset.
 	// +kubebuilder:default=LeaderCreated
-	// +kubebuilder:validation:Enum={LeaderCreated,LeaderReady}
+	// +kubebuilder:validation:Enum={LeaderCreated,LeaderReady,WorkersFirst}
 	// +optional
 	StartupPolicy StartupPolicyType `json:"startupPolicy"`
 
+	// LeaderReadyConfiguration defines the signal the leader pod is considered ready by
+	// when startupPolicy is LeaderReady. When unset, the Ready condition of the leader pod is used.
+	// +optional
+	LeaderReadyConfiguration *LeaderReadyConfiguration `json:"leaderReadyConfiguration,omitempty"`
+
+	// LeaderStartupDelaySeconds gives the leader pod a head start, the workers of a group are only
+	// created once the given number of seconds has elapsed since the leader pod was created. With
+	// the LeaderReady startup policy, the leader must also be ready. It can't be set with the
+	// WorkersFirst startup policy. Defaults to 0, the workers are created without delay.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	LeaderStartupDelaySeconds *int32 `json:"leaderStartupDelaySeconds,omitempty"`
+
+	// ScaleDownPolicy determines which groups are deleted first when replicas decrease.
+	// With HighestIndexFirst, the groups with the highest indexes are deleted. With
+	// LowestIndexFirst, the groups with the lowest indexes are deleted instead, and the
+	// indexes of the remaining groups no longer start from 0.
+	// +kubebuilder:default=HighestIndexFirst
+	// +kubebuilder:validation:Enum={HighestIndexFirst,LowestIndexFirst}
+	// +optional
+	ScaleDownPolicy ScaleDownPolicyType `json:"scaleDownPolicy,omitempty"`
+
 	// NetworkConfig defines the network configuration of the group
 	// +optional
 	NetworkConfig *NetworkConfig `json:"networkConfig,omitempty"`
+
+	// RevisionHistoryLimit is the maximum number of old controller revisions kept
+	// for rollbacks. Revisions still referenced by the statefulsets or pods of the
+	// LeaderWorkerSet, e.g. during a rolling update, are never deleted and don't
+	// count towards the limit.
+	// Default to 10.
+	//
+	// +optional
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=0
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+
+	// Suspend stops the controller from creating any group or pod while retaining the
+	// existing ones, like the suspend field of Jobs. Unlike the paused annotation, the
+	// Suspended condition reports it in the status. Setting it back to false resumes the
+	// reconciliation. Default to false.
+	//
+	// +optional
+	// +kubebuilder:default=false
+	Suspend *bool `json:"suspend,omitempty"`
 }
 
 // Template of the leader/worker pods, the group will include at least one leader pod.
@@ -143,14 +410,19 @@ type LeaderWorkerSetSpec struct {
 // API whenever possible.
 type LeaderWorkerTemplate struct {
 	// LeaderTemplate defines the pod template for leader pods.
+	// Annotation values may contain the placeholders {{.GroupIndex}}, {{.WorkerIndex}}
+	// and {{.Size}}, which are expanded when the pods are created.
 	LeaderTemplate *corev1.PodTemplateSpec `json:"leaderTemplate,omitempty"`
 
 	// WorkerTemplate defines the pod template for worker pods.
+	// Annotation values may contain the placeholders {{.GroupIndex}}, {{.WorkerIndex}}
+	// and {{.Size}}, which are expanded when the pods are created.
 	WorkerTemplate corev1.PodTemplateSpec `json:"workerTemplate"`
 
 	// Number of pods to create. It is the total number of pods in each group.
 	// The minimum is 1 which represent the leader. When set to 1, the leader
 	// pod is created for each group as well as a 0-replica StatefulSet for the workers.
+	// Size must be greater than 1 when leaderTemplate is set.
 	// Default to 1.
 	//
 	// +optional
@@ -161,22 +433,220 @@ type LeaderWorkerTemplate struct {
 	// The former named Default policy is deprecated, will be removed in the future,
 	// replace with None policy for the same behavior.
 	// +kubebuilder:default=RecreateGroupOnPodRestart
-	// +kubebuilder:validation:Enum={Default,RecreateGroupOnPodRestart,None}
+	// +kubebuilder:validation:Enum={Default,RecreateGroupOnPodRestart,RecreateGroupOnLeaderRestart,None}
 	// +optional
 	RestartPolicy RestartPolicyType `json:"restartPolicy,omitempty"`
 
+	// PodFailurePolicy marks a group as failed, instead of recreating it according to
+	// the restartPolicy, when a container of any of its pods terminates with an exit code
+	// matching one of the rules, e.g. for non-retriable errors. Failed groups are reported
+	// by the GroupFailed condition.
+	// +optional
+	PodFailurePolicy *PodFailurePolicy `json:"podFailurePolicy,omitempty"`
+
+	// FailedGroupRetention determines what happens to a group the restartPolicy would
+	// recreate. Recreate recreates it, while Retain keeps its pods in place for inspection
+	// and reports it by the GroupFailed condition, until it's restarted with the
+	// restart-group annotation. Defaults to Recreate.
+	// +kubebuilder:default=Recreate
+	// +kubebuilder:validation:Enum={Recreate,Retain}
+	// +optional
+	FailedGroupRetention FailedGroupRetentionType `json:"failedGroupRetention,omitempty"`
+
 	// SubGroupPolicy describes the policy that will be applied when creating subgroups
 	// in each replica.
 	// +optional
 	SubGroupPolicy *SubGroupPolicy `json:"subGroupPolicy,omitempty"`
+
+	// WorkerReadinessFollowsLeader determines whether worker pods are only considered ready
+	// when the leader pod of the group is ready as well. When set to true, a readiness gate
+	// with condition type leaderworkerset.sigs.k8s.io/leader-ready is injected into worker pods.
+	// +optional
+	WorkerReadinessFollowsLeader bool `json:"workerReadinessFollowsLeader,omitempty"`
+
+	// ExclusiveTopology places each group exclusively in a single topology domain,
+	// e.g. a node, a zone or a rack. It takes precedence over the
+	// leaderworkerset.sigs.k8s.io/exclusive-topology annotation.
+	// +optional
+	ExclusiveTopology *ExclusiveTopology `json:"exclusiveTopology,omitempty"`
+
+	// InjectPeerAddresses determines whether the LWS_PEER_ADDRESSES environment variable,
+	// a comma-separated list of the addresses of all the pods in the group with the leader
+	// first, is injected into every container.
+	// +optional
+	InjectPeerAddresses bool `json:"injectPeerAddresses,omitempty"`
+
+	// PublishMembershipConfigMap determines whether a ConfigMap named <lws>-<groupIndex>-membership
+	// is maintained for every group, with the addresses of all the pods in the group, the leader
+	// first, one per line under the "hosts" key, and the group size under the "size" key. It's
+	// mounted into every pod as the lws-membership volume, which the containers can refer to in
+	// their volumeMounts. The ConfigMap of a group is deleted when the group is scaled down.
+	// +optional
+	PublishMembershipConfigMap bool `json:"publishMembershipConfigMap,omitempty"`
+
+	// NetworkEnvNames renames the environment variables injected into every container,
+	// e.g. {"LWS_GROUP_SIZE": "WORLD_SIZE"}. The keys are one of LWS_GROUP_SIZE,
+	// LWS_LEADER_ADDRESS and LWS_WORKER_INDEX, the values are the names injected instead.
+	// Variables without an override keep their default names.
+	// +optional
+	NetworkEnvNames map[string]string `json:"networkEnvNames,omitempty"`
+
+	// LeaderPodDeletionCost is set as the controller.kubernetes.io/pod-deletion-cost
+	// annotation on the leader pods, a higher cost than the workers makes the leaders
+	// less likely to be evicted, e.g. on scale-down by the cluster-autoscaler, which
+	// would disrupt the whole group.
+	// +optional
+	LeaderPodDeletionCost *int32 `json:"leaderPodDeletionCost,omitempty"`
+
+	// LeaderPriorityClassName is the priorityClassName of the leader pods, overriding the
+	// one of the leader template when set, e.g. to protect the leaders from preemption
+	// since losing the leader restarts the whole group. The worker pods are not affected.
+	// +optional
+	LeaderPriorityClassName string `json:"leaderPriorityClassName,omitempty"`
+
+	// LeaderServiceAccountName is the serviceAccountName of the leader pods, overriding the
+	// one of the leader template when set, so that the leaders can be granted different
+	// permissions than the workers without duplicating the whole template.
+	// +optional
+	LeaderServiceAccountName string `json:"leaderServiceAccountName,omitempty"`
+
+	// LeaderTerminationGracePeriodSeconds is the terminationGracePeriodSeconds of the leader
+	// pods, overriding the one of the leader template when set, e.g. to give the leaders more
+	// time to flush their state. The worker pods keep the one of the worker template.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	LeaderTerminationGracePeriodSeconds *int64 `json:"leaderTerminationGracePeriodSeconds,omitempty"`
+
+	// WorkerServiceAccountName is the serviceAccountName of the worker pods, overriding the
+	// one of the worker template when set.
+	// +optional
+	WorkerServiceAccountName string `json:"workerServiceAccountName,omitempty"`
+
+	// GangSchedulingLabelKey is the label key gang scheduling plugins, e.g. the coscheduling
+	// plugin, group the pods by. When set, all the pods of a group carry this label with the
+	// same value, <lws name>-<group index>, and the pod-group.scheduling.sigs.k8s.io/min-available
+	// annotation set to the size of the group.
+	// +optional
+	GangSchedulingLabelKey string `json:"gangSchedulingLabelKey,omitempty"`
+
+	// GroupSpreadConstraints spread the groups across topology domains, e.g. zones. They're
+	// applied to the leader pods with a label selector matching all the leader pods of the
+	// LeaderWorkerSet, so labelSelector must not be set. The revision label is added to their
+	// matchLabelKeys, so that the groups of each revision are spread separately during rollouts.
+	// +optional
+	// +listType=atomic
+	GroupSpreadConstraints []corev1.TopologySpreadConstraint `json:"groupSpreadConstraints,omitempty"`
+
+	// CommonContainers are appended to both the leader and the worker pods, e.g. a
+	// logging or metrics sidecar, instead of duplicating them in both templates.
+	// Containers with restartPolicy Always are appended to the init containers as
+	// native sidecars. Their names must not collide with the containers of the templates.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	CommonContainers []corev1.Container `json:"commonContainers,omitempty"`
+
+	// LeaderGroupInitContainers are appended to the init containers of the leader pod only,
+	// e.g. a one-time coordination step of the group run before the leader starts. The
+	// worker pods are not affected. Their names must not collide with the containers of
+	// the leader pod, and they must not set a restartPolicy.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	LeaderGroupInitContainers []corev1.Container `json:"leaderGroupInitContainers,omitempty"`
+
+	// InheritLabels lists the keys of labels of the LeaderWorkerSet copied onto all the leader
+	// and worker pods, e.g. cost allocation labels. Keys missing on the LeaderWorkerSet are
+	// ignored, and labels set in the templates take precedence. Keys with the
+	// leaderworkerset.sigs.k8s.io/ prefix are not allowed.
+	// +optional
+	// +listType=set
+	InheritLabels []string `json:"inheritLabels,omitempty"`
+
+	// MinReadySeconds is the minimum number of seconds all the pods of a group must have been
+	// ready for, without any of them becoming unready, for the group to be counted as ready.
+	// It only affects the status, changing it doesn't trigger a rolling update.
+	// Defaults to 0, the group is counted as ready as soon as all its pods are ready.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinReadySeconds int32 `json:"minReadySeconds,omitempty"`
+
+	// OrderedTermination determines whether the workers of a group are terminated before its
+	// leader when the group is deleted by a scale down or a rolling update, e.g. when the workers rely
+	// on the leader to coordinate their shutdown. The worker statefulset of the group is deleted
+	// first, and the leader pod is only deleted once the workers are gone, or once the
+	// terminationGracePeriodSeconds of the worker template has elapsed. It only affects how the
+	// groups are deleted, changing it doesn't trigger a rolling update. The groups deleted at once
+	// by the Recreate rollout strategy are not affected.
+	// +optional
+	OrderedTermination bool `json:"orderedTermination,omitempty"`
+
+	// ActiveDeadlineSeconds is the number of seconds a group may run, counted from the
+	// creation of its first leader pod, e.g. for batch-style distributed jobs. Once a group
+	// exceeds it, its pods are deleted and the GroupDeadlineExceeded condition is set, the
+	// group is then kept down until the deadline is raised or unset. It only affects how long
+	// the groups run, changing it doesn't trigger a rolling update.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+
+	// VolumeClaimTemplates are the claims the leader and the worker pods can mount by the
+	// name of the template, the same as the volumeClaimTemplates of a StatefulSet. A claim
+	// is created for every pod, named <template>-<pod name>, i.e. keyed by the group and the
+	// worker index, so it's reused when the pod is recreated, e.g. when its group is recreated
+	// while the claims are retained. It can't be changed after creation.
+	// +optional
+	// +listType=atomic
+	VolumeClaimTemplates []corev1.PersistentVolumeClaim `json:"volumeClaimTemplates,omitempty"`
+
+	// PersistentVolumeClaimRetentionPolicy describes the lifecycle of the claims created from
+	// volumeClaimTemplates. WhenDeleted applies to the claims of the workers when a group is
+	// recreated, which deletes its worker statefulset, and to all the claims when the
+	// LeaderWorkerSet is deleted. The claims of a leader are always kept when the group is
+	// recreated, since the leader pod keeps its name. WhenScaled applies to the claims of the
+	// leaders of the groups removed by a scale down, the claims of their workers follow
+	// WhenDeleted. Both default to Retain. It can't be changed after creation.
+	// +optional
+	PersistentVolumeClaimRetentionPolicy *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy `json:"persistentVolumeClaimRetentionPolicy,omitempty"`
+
+	// PerGroupEnv are extra environment variables of the pods of specific groups, e.g. to give
+	// every group a distinct model shard. They're set on all the containers of the leader and
+	// the worker pods of the group when the pods are created, overriding the variables of the
+	// templates with the same name. The group indexes must be unique and lower than replicas.
+	// Editing them doesn't roll the groups, they apply once the targeted groups are recreated.
+	// +optional
+	// +listType=map
+	// +listMapKey=groupIndex
+	PerGroupEnv []PerGroupEnv `json:"perGroupEnv,omitempty"`
+}
+
+// PerGroupEnv describes the extra environment variables of the pods of a group.
+type PerGroupEnv struct {
+	// GroupIndex is the index of the group, counted from networkConfig.startIndex, i.e. 0
+	// is the first group whatever the start index.
+	// +kubebuilder:validation:Minimum=0
+	GroupIndex int32 `json:"groupIndex"`
+
+	// Env are the environment variables set on the containers of the pods of the group.
+	// +listType=map
+	// +listMapKey=name
+	Env []corev1.EnvVar `json:"env"`
+}
+
+// ExclusiveTopology describes the topology domain a group is exclusively placed in.
+type ExclusiveTopology struct {
+	// TopologyKey is the node label key identifying the topology domain, e.g.
+	// kubernetes.io/hostname, topology.kubernetes.io/zone or a custom rack label.
+	// +kubebuilder:validation:MinLength=1
+	TopologyKey string `json:"topologyKey"`
 }
 
 // RolloutStrategy defines the strategy that the leaderWorkerSet controller
 // will use to perform replica updates.
 type RolloutStrategy struct {
-	// Type defines the rollout strategy, it can only be “RollingUpdate” for now.
+	// Type defines the rollout strategy, it can be “RollingUpdate” or “Recreate”.
 	//
-	// +kubebuilder:validation:Enum={RollingUpdate}
+	// +kubebuilder:validation:Enum={RollingUpdate,Recreate}
 	// +kubebuilder:default=RollingUpdate
 	Type RolloutStrategyType `json:"type"`
 
@@ -201,8 +671,19 @@ type SubGroupPolicy struct {
 	// Size must be divisible by subGroupSize in which case the
 	// subgroups will be of equal size. Or size - 1 is divisible
 	// by subGroupSize, in which case the leader is considered as
-	// the extra pod, and will be part of the first subgroup.
+	// the extra pod, and will be part of the first subgroup. When
+	// the type is LeaderExcluded, size - 1 must be divisible by
+	// subGroupSize, since the leader is not part of any subgroup.
 	SubGroupSize *int32 `json:"subGroupSize,omitempty"`
+
+	// SubGroupTopologyKey places each subgroup exclusively in a single sub-domain
+	// of the group topology domain, e.g. each subgroup on its own host within the
+	// rack of the group. It requires the group to be exclusively placed as well, with
+	// exclusiveTopology or the leaderworkerset.sigs.k8s.io/exclusive-topology annotation,
+	// and takes precedence over the leaderworkerset.sigs.k8s.io/subgroup-exclusive-topology
+	// annotation.
+	// +optional
+	SubGroupTopologyKey *string `json:"subGroupTopologyKey,omitempty"`
 }
 
 type SubGroupPolicyType string
@@ -226,9 +707,34 @@ const (
 
 type NetworkConfig struct {
 	// SubdomainPolicy determines the policy that will be used when creating
-	// the headless service, defaults to shared
-	// +kubebuilder:validation:Enum={Shared,UniquePerReplica}
+	// the headless service, defaults to shared. None opts out of the headless
+	// service, e.g. when it is managed outside of the lws controller. It can't be
+	// changed after creation, since it changes the DNS names of the pods.
+	// +kubebuilder:validation:Enum={Shared,UniquePerReplica,None}
 	SubdomainPolicy *SubdomainPolicy `json:"subdomainPolicy"`
+
+	// HostnamePrefix is used instead of the LeaderWorkerSet name when naming the
+	// leader pods, and therefore their hostnames, as <hostnamePrefix>-<index>. It's
+	// useful when the LeaderWorkerSet name is too long for a hostname. Defaults to
+	// the LeaderWorkerSet name, and it can't be changed once set.
+	// +optional
+	HostnamePrefix string `json:"hostnamePrefix,omitempty"`
+
+	// PerGroupService makes the controller create a ClusterIP service per group,
+	// named <lws>-<index>, that selects the leader pod of the group and exposes
+	// its container ports. It gives each group a stable endpoint, e.g. for an
+	// inference router. It can't be combined with subdomainPolicy UniquePerReplica,
+	// whose headless services use the same names.
+	// +optional
+	PerGroupService bool `json:"perGroupService,omitempty"`
+
+	// StartIndex is the index of the first group, it shifts the group indexes, and
+	// therefore the leader pod names and the injected environment variables, e.g.
+	// to 1-based indexes with StartIndex 1. Worker indexes within a group aren't
+	// shifted. Defaults to 0, and it can't be changed once set.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	StartIndex int32 `json:"startIndex,omitempty"`
 }
 
 type SubdomainPolicy string
@@ -244,13 +750,16 @@ const (
 	// Replica 0: my-lws-0.my-lws-0,my-lws-0-1.my-lws-0, my-lws-0-2.my-lws-0
 	// Replica 1: my-lws-1.my-lws-1,my-lws-1-1.my-lws-1, my-lws-1-2.my-lws-1
 	SubdomainUniquePerReplica SubdomainPolicy = "UniquePerReplica"
+	// SubdomainNone will not create any headless service, and the pods will
+	// not have a subdomain set.
+	SubdomainNone SubdomainPolicy = "None"
 )
 
 // RollingUpdateConfiguration defines the parameters to be used for RollingUpdateStrategyType.
 type RollingUpdateConfiguration struct {
 	// The maximum number of replicas that can be unavailable during the update.
 	// Value can be an absolute number (ex: 5) or a percentage of total replicas at the start of update (ex: 10%).
-	// Absolute number is calculated from percentage by rounding down.
+	// Absolute number is calculated from percentage by rounding down, unless set otherwise by roundingPolicy.
 	// This can not be 0 if MaxSurge is 0.
 	// By default, a fixed value of 1 is used.
 	// Example: when this is set to 30%, the old replicas can be scaled down by 30%
@@ -267,7 +776,7 @@ type RollingUpdateConfiguration struct {
 	// replicas.
 	// Value can be an absolute number (ex: 5) or a percentage of total replicas at
 	// the start of the update (ex: 10%).
-	// Absolute number is calculated from percentage by rounding up.
+	// Absolute number is calculated from percentage by rounding up, unless set otherwise by roundingPolicy.
 	// By default, a value of 0 is used.
 	// Example: when this is set to 30%, the new replicas can be scaled up by 30%
 	// immediately when the rolling update starts. Once old replicas have been deleted,
@@ -278,8 +787,86 @@ type RollingUpdateConfiguration struct {
 	// +kubebuilder:validation:XIntOrString
 	// +kubebuilder:default=0
 	MaxSurge intstr.IntOrString `json:"maxSurge,omitempty"`
+
+	// The number of seconds to wait before deleting an old replica once the rolling update
+	// is ready to replace it, which gives the old replica time to finish in-flight requests.
+	// The deadline is recorded on the old leader pod with the
+	// leaderworkerset.sigs.k8s.io/drain-deadline annotation.
+	// By default, old replicas are deleted immediately.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	DrainGracePeriodSeconds *int32 `json:"drainGracePeriodSeconds,omitempty"`
+
+	// Partition indicates the index at which the groups are partitioned for a canary rollout.
+	// Groups with an index greater than or equal to the partition are updated, while groups
+	// with a lower index stay on their current revision, the same as the partition of a StatefulSet.
+	// It must be between 0 and replicas. By default, all the groups are updated.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Partition *int32 `json:"partition,omitempty"`
+
+	// Granularity is the unit maxUnavailable is expressed in, it can be "Group" or "SubGroup".
+	// With SubGroup, which requires a subGroupPolicy, maxUnavailable counts subgroups and its
+	// percentages are computed against the total number of subgroups, and the number of updated
+	// subgroups of each group is reported in its status. Since the workers of a group are owned
+	// by its leader pod, a group is still recreated as a whole, so the groups updated at a time
+	// are maxUnavailable divided by the number of subgroups per group, and at least one.
+	// Defaults to Group.
+	//
+	// +kubebuilder:validation:Enum={Group,SubGroup}
+	// +optional
+	Granularity RolloutGranularity `json:"granularity,omitempty"`
+
+	// RequireApproval halts a rollout before any group is updated, until the
+	// leaderworkerset.sigs.k8s.io/approve-revision annotation is set to the update revision
+	// reported in status.updateRevision. The PendingApproval condition is true meanwhile.
+	// Scaling is still carried out while the rollout waits for approval.
+	// Defaults to false.
+	//
+	// +optional
+	RequireApproval bool `json:"requireApproval,omitempty"`
+
+	// ProgressDeadlineSeconds is the number of seconds a group of the update revision has to
+	// become ready after its creation. Once a group exceeds it, the rollout is halted, no
+	// further group is updated or created for the rollout, and the RolloutStalled condition
+	// is set. The rollout resumes once the stalled groups become ready, or a new revision is
+	// rolled out. By default, the rollout waits for the groups indefinitely.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+
+	// RoundingPolicy is how the percentages of maxUnavailable and maxSurge are converted to
+	// absolute numbers, it can be "Floor" or "Ceil", and applies to both of them. By default,
+	// maxUnavailable is rounded down and maxSurge is rounded up.
+	//
+	// +kubebuilder:validation:Enum={Floor,Ceil}
+	// +optional
+	RoundingPolicy RolloutRoundingPolicy `json:"roundingPolicy,omitempty"`
 }
 
+type RolloutGranularity string
+
+const (
+	// GroupRolloutGranularity expresses maxUnavailable in groups.
+	GroupRolloutGranularity RolloutGranularity = "Group"
+
+	// SubGroupRolloutGranularity expresses maxUnavailable in subgroups.
+	SubGroupRolloutGranularity RolloutGranularity = "SubGroup"
+)
+
+type RolloutRoundingPolicy string
+
+const (
+	// FloorRolloutRoundingPolicy rounds the percentages down.
+	FloorRolloutRoundingPolicy RolloutRoundingPolicy = "Floor"
+
+	// CeilRolloutRoundingPolicy rounds the percentages up.
+	CeilRolloutRoundingPolicy RolloutRoundingPolicy = "Ceil"
+)
+
 type RolloutStrategyType string
 
 const (
@@ -287,6 +874,22 @@ const (
 	// by RollingUpdateConfiguration), the latter one will not start the update until the
 	// former one(leader+workers) is ready.
 	RollingUpdateStrategyType RolloutStrategyType = "RollingUpdate"
+
+	// RecreateStrategyType indicates that all the replicas will be deleted before
+	// they are recreated with the new revision, so that replicas of the old and the
+	// new revision never coexist.
+	RecreateStrategyType RolloutStrategyType = "Recreate"
+)
+
+type FailedGroupRetentionType string
+
+const (
+	// RecreateFailedGroupRetention recreates the failed groups according to the restartPolicy.
+	RecreateFailedGroupRetention FailedGroupRetentionType = "Recreate"
+
+	// RetainFailedGroupRetention keeps the pods of the failed groups in place, e.g. to
+	// inspect their logs and state, until the groups are restarted.
+	RetainFailedGroupRetention FailedGroupRetentionType = "Retain"
 )
 
 type RestartPolicyType string
@@ -296,8 +899,16 @@ const (
 	// 1. Any individual pod in the group is recreated; 2. Any containers/init-containers
 	// in a pod is restarted. This is to ensure all pods/containers in the group will be
 	// started in the same time.
+	// Container restarts are detected by their restart count, so it works with the pod
+	// restartPolicy Always, which is the only value StatefulSets support.
 	RecreateGroupOnPodRestart RestartPolicyType = "RecreateGroupOnPodRestart"
 
+	// RecreateGroupOnLeaderRestart will recreate all the pods in the group only if
+	// any containers/init-containers in the leader pod is restarted. Worker pods are
+	// restarted on their own according to the pod restartPolicy, e.g. when they are
+	// stateless.
+	RecreateGroupOnLeaderRestart RestartPolicyType = "RecreateGroupOnLeaderRestart"
+
 	// Default will follow the same behavior as the StatefulSet where only the failed pod
 	// will be restarted on failure and other pods in the group will not be impacted.
 	//
@@ -309,6 +920,55 @@ const (
 	NoneRestartPolicy RestartPolicyType = "None"
 )
 
+// PodFailurePolicy describes how container exit codes fail the group, similar to the
+// podFailurePolicy of Jobs.
+type PodFailurePolicy struct {
+	// Rules are evaluated against the terminated containers of the pods of a group, the
+	// group fails as soon as one of them matches.
+	// +listType=atomic
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=20
+	Rules []PodFailurePolicyRule `json:"rules"`
+}
+
+// PodFailurePolicyRule describes a requirement a terminated container must satisfy for
+// the group to fail.
+type PodFailurePolicyRule struct {
+	// OnExitCodes is the requirement on the exit code of the container.
+	OnExitCodes PodFailurePolicyOnExitCodesRequirement `json:"onExitCodes"`
+}
+
+type PodFailurePolicyOnExitCodesOperator string
+
+const (
+	// PodFailurePolicyOnExitCodesOpIn matches exit codes in the values.
+	PodFailurePolicyOnExitCodesOpIn PodFailurePolicyOnExitCodesOperator = "In"
+
+	// PodFailurePolicyOnExitCodesOpNotIn matches exit codes not in the values.
+	PodFailurePolicyOnExitCodesOpNotIn PodFailurePolicyOnExitCodesOperator = "NotIn"
+)
+
+// PodFailurePolicyOnExitCodesRequirement describes a requirement on the exit code of a
+// terminated container. Containers terminating with exit code 0 never match.
+type PodFailurePolicyOnExitCodesRequirement struct {
+	// ContainerName restricts the requirement to the container with the given name, which
+	// must be a container or init container of the templates. All the containers are
+	// checked when unset.
+	// +optional
+	ContainerName *string `json:"containerName,omitempty"`
+
+	// Operator is the relationship between the exit code and the values.
+	// +kubebuilder:validation:Enum={In,NotIn}
+	Operator PodFailurePolicyOnExitCodesOperator `json:"operator"`
+
+	// Values are the exit codes, they must be unique and in increasing order. The In
+	// operator must not include 0.
+	// +listType=set
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=255
+	Values []int32 `json:"values"`
+}
+
 type StartupPolicyType string
 
 const (
@@ -317,6 +977,35 @@ const (
 
 	// LeaderCreated creates the workers statefulset immediately after the leader pod is created.
 	LeaderCreatedStartupPolicy StartupPolicyType = "LeaderCreated"
+
+	// WorkersFirst creates the workers statefulset immediately after the leader pod is created,
+	// but holds the leader pod from being scheduled until all the worker pods are ready.
+	WorkersFirstStartupPolicy StartupPolicyType = "WorkersFirst"
+)
+
+// LeaderReadyConfiguration defines the signal that gates the creation of the worker
+// statefulset for the LeaderReady startup policy. Exactly one of the fields must be set.
+type LeaderReadyConfiguration struct {
+	// ConditionType is the type of a pod condition, e.g. one set through a readiness gate,
+	// that must be True on the leader pod before the workers are created.
+	// +optional
+	ConditionType *corev1.PodConditionType `json:"conditionType,omitempty"`
+
+	// Annotation is the key of an annotation that the leader pod sets to "true" once
+	// the workers can be created.
+	// +optional
+	Annotation *string `json:"annotation,omitempty"`
+}
+
+type ScaleDownPolicyType string
+
+const (
+	// HighestIndexFirst deletes the groups with the highest indexes first on scale down.
+	HighestIndexFirstScaleDownPolicy ScaleDownPolicyType = "HighestIndexFirst"
+
+	// LowestIndexFirst deletes the groups with the lowest indexes first on scale down, the
+	// groups created on scale up still get the indexes following the highest one.
+	LowestIndexFirstScaleDownPolicy ScaleDownPolicyType = "LowestIndexFirst"
 )
 
 // LeaderWorkerSetStatus defines the observed state of LeaderWorkerSet
@@ -324,12 +1013,23 @@ type LeaderWorkerSetStatus struct {
 	// Conditions track the condition of the leaderworkerset.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
-	// ReadyReplicas track the number of groups that are in ready state (updated or not).
+	// ObservedGeneration is the most recent generation of the LeaderWorkerSet whose spec
+	// has been applied by the controller. It isn't updated while the LeaderWorkerSet is paused.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ReadyReplicas track the number of groups that are in ready state (updated or not),
+	// the standby groups are not counted.
 	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
 
 	// UpdatedReplicas track the number of groups that have been updated (ready or not).
 	UpdatedReplicas int32 `json:"updatedReplicas,omitempty"`
 
+	// ProgressingReplicas track the number of groups that have at least one but
+	// not all of their pods in ready state.
+	ProgressingReplicas int32 `json:"progressingReplicas,omitempty"`
+
 	// Replicas track the total number of groups that have been created (updated or not, ready or not)
 	Replicas int32 `json:"replicas,omitempty"`
 
@@ -337,8 +1037,97 @@ type LeaderWorkerSetStatus struct {
 	// needed for HPA to know what pods belong to the LeaderWorkerSet object. Here
 	// we only select the leader pods.
 	HPAPodSelector string `json:"hpaPodSelector,omitempty"`
+
+	// GroupStatuses track the status of each group, sorted by the group index.
+	// Only the first 1000 groups are tracked.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=index
+	// +kubebuilder:validation:MaxItems=1000
+	GroupStatuses []GroupStatus `json:"groupStatuses,omitempty"`
+
+	// RolloutStartTime is the time when the rollout in progress started, i.e. when
+	// a new revision was first observed. It's cleared once all the groups are updated.
+	//
+	// +optional
+	RolloutStartTime *metav1.Time `json:"rolloutStartTime,omitempty"`
+
+	// LastRolloutCompletionTime is the time when the last rollout completed, i.e. when all
+	// the groups were updated to the new revision. It's kept until the next rollout completes.
+	//
+	// +optional
+	LastRolloutCompletionTime *metav1.Time `json:"lastRolloutCompletionTime,omitempty"`
+
+	// CurrentRevision is the revision key of the ControllerRevision the groups are serving,
+	// it's only set to the updateRevision once all the groups are updated.
+	//
+	// +optional
+	CurrentRevision string `json:"currentRevision,omitempty"`
+
+	// UpdateRevision is the revision key of the ControllerRevision matching the current
+	// leaderWorkerTemplate and networkConfig, that the groups are being updated to.
+	//
+	// +optional
+	UpdateRevision string `json:"updateRevision,omitempty"`
+
+	// CrashingPods is the number of pods across all the groups with a container
+	// waiting in CrashLoopBackOff.
+	//
+	// +optional
+	CrashingPods int32 `json:"crashingPods,omitempty"`
+
+	// StandbyGroups are the indexes of the groups kept as hot standbys, sorted.
+	//
+	// +optional
+	// +listType=set
+	StandbyGroups []int32 `json:"standbyGroups,omitempty"`
 }
 
+// GroupStatus is the status of a single group.
+type GroupStatus struct {
+	// Index is the index of the group.
+	Index int32 `json:"index"`
+
+	// Phase is the phase of the group.
+	Phase GroupPhase `json:"phase"`
+
+	// Revision is the revision hash of the leaderWorkerTemplate the group is running.
+	//
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
+	// LastRestartRequest is the timestamp of the restart-group annotation last processed
+	// for the group.
+	//
+	// +optional
+	LastRestartRequest string `json:"lastRestartRequest,omitempty"`
+
+	// UpdatedSubGroups is the number of subgroups of the group whose pods all run the
+	// update revision. It's only reported with the SubGroup rollout granularity.
+	//
+	// +optional
+	UpdatedSubGroups int32 `json:"updatedSubGroups,omitempty"`
+}
+
+type GroupPhase string
+
+const (
+	// GroupPending means the leader pod of the group hasn't been scheduled yet.
+	GroupPending GroupPhase = "Pending"
+
+	// GroupCreating means the leader pod of the group has been scheduled, but
+	// not all the pods of the group are ready yet.
+	GroupCreating GroupPhase = "Creating"
+
+	// GroupReady means all the pods of the group are ready.
+	GroupReady GroupPhase = "Ready"
+
+	// GroupUpdating means the group is running an old revision and is waiting
+	// to be updated.
+	GroupUpdating GroupPhase = "Updating"
+)
+
 type LeaderWorkerSetConditionType string
 
 // These are built-in conditions of a LWS.
@@ -358,6 +1147,50 @@ const (
 	// is true when the lws is in upgrade process after the (leader/worker) template is updated. If only replicas is modified, it will
 	// not be considered as UpdateInProgress.
 	LeaderWorkerSetUpdateInProgress LeaderWorkerSetConditionType = "UpdateInProgress"
+
+	// LeaderWorkerSetUpdateComplete means all the groups are at the latest revision, i.e.
+	// UpdatedReplicas equals Replicas. It turns false once a new revision is detected.
+	LeaderWorkerSetUpdateComplete LeaderWorkerSetConditionType = "UpdateComplete"
+
+	// LeaderWorkerSetPaused means the reconciliation of the lws is paused by the
+	// leaderworkerset.sigs.k8s.io/paused annotation.
+	LeaderWorkerSetPaused LeaderWorkerSetConditionType = "Paused"
+
+	// LeaderWorkerSetGroupUnschedulable means at least one pod of a group has been unschedulable
+	// for longer than the unschedulable timeout of the controller. It turns false once all the
+	// pods are scheduled.
+	LeaderWorkerSetGroupUnschedulable LeaderWorkerSetConditionType = "GroupUnschedulable"
+
+	// LeaderWorkerSetGroupFailed means a container of at least one group terminated with an
+	// exit code matching the podFailurePolicy, those groups are not recreated.
+	LeaderWorkerSetGroupFailed LeaderWorkerSetConditionType = "GroupFailed"
+
+	// LeaderWorkerSetGroupDeadlineExceeded means at least one group ran for longer than the
+	// activeDeadlineSeconds and is kept down. It turns false once no group exceeds the
+	// activeDeadlineSeconds, e.g. once it's raised or unset.
+	LeaderWorkerSetGroupDeadlineExceeded LeaderWorkerSetConditionType = "GroupDeadlineExceeded"
+
+	// LeaderWorkerSetInsufficientCapacity means minReplicas is set and at least one group
+	// can't be scheduled for lack of capacity, the groups that could be scheduled are kept.
+	// It turns false once all the groups are scheduled.
+	LeaderWorkerSetInsufficientCapacity LeaderWorkerSetConditionType = "InsufficientCapacity"
+
+	// LeaderWorkerSetPendingApproval means a rollout requiring approval is halted until the
+	// leaderworkerset.sigs.k8s.io/approve-revision annotation is set to the update revision.
+	LeaderWorkerSetPendingApproval LeaderWorkerSetConditionType = "PendingApproval"
+
+	// LeaderWorkerSetRolloutStalled means a group of the update revision didn't become ready
+	// within the progressDeadlineSeconds, the rollout is halted until it does.
+	LeaderWorkerSetRolloutStalled LeaderWorkerSetConditionType = "RolloutStalled"
+
+	// LeaderWorkerSetSuspended means the lws is suspended by spec.suspend, no group or pod is
+	// created until it's set back to false.
+	LeaderWorkerSetSuspended LeaderWorkerSetConditionType = "Suspended"
+
+	// LeaderWorkerSetDuplicateLeader means more than one leader pod was found for at least one
+	// group index, the newer duplicates are deleted unless they're controlled by another
+	// controller. It turns false once every group has a single leader pod.
+	LeaderWorkerSetDuplicateLeader LeaderWorkerSetConditionType = "DuplicateLeader"
 )
 
 // +genclient
@@ -365,6 +1198,14 @@ const (
 //+kubebuilder:subresource:status
 //+kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.hpaPodSelector
 //+kubebuilder:resource:shortName={lws}
+//+kubebuilder:printcolumn:name="Desired",type=integer,JSONPath=".spec.replicas"
+//+kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=".status.readyReplicas"
+//+kubebuilder:printcolumn:name="Progressing",type=integer,JSONPath=".status.progressingReplicas"
+//+kubebuilder:printcolumn:name="Updated",type=integer,JSONPath=".status.updatedReplicas"
+//+kubebuilder:printcolumn:name="Crashing",type=integer,JSONPath=".status.crashingPods"
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=".metadata.creationTimestamp"
+//+kubebuilder:printcolumn:name="Current Revision",type=string,JSONPath=".status.currentRevision",priority=1
+//+kubebuilder:printcolumn:name="Update Revision",type=string,JSONPath=".status.updateRevision",priority=1
 
 // LeaderWorkerSet is the Schema for the leaderworkersets API
 type LeaderWorkerSet struct {
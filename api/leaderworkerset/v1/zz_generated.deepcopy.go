@@ -21,11 +21,67 @@ limitations under the License.
 package v1
 
 import (
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExclusiveTopology) DeepCopyInto(out *ExclusiveTopology) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExclusiveTopology.
+func (in *ExclusiveTopology) DeepCopy() *ExclusiveTopology {
+	if in == nil {
+		return nil
+	}
+	out := new(ExclusiveTopology)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupStatus) DeepCopyInto(out *GroupStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupStatus.
+func (in *GroupStatus) DeepCopy() *GroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LeaderReadyConfiguration) DeepCopyInto(out *LeaderReadyConfiguration) {
+	*out = *in
+	if in.ConditionType != nil {
+		in, out := &in.ConditionType, &out.ConditionType
+		*out = new(corev1.PodConditionType)
+		**out = **in
+	}
+	if in.Annotation != nil {
+		in, out := &in.Annotation, &out.Annotation
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeaderReadyConfiguration.
+func (in *LeaderReadyConfiguration) DeepCopy() *LeaderReadyConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(LeaderReadyConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LeaderWorkerSet) DeepCopyInto(out *LeaderWorkerSet) {
 	*out = *in
@@ -93,13 +149,48 @@ func (in *LeaderWorkerSetSpec) DeepCopyInto(out *LeaderWorkerSetSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.MaxReplicas != nil {
+		in, out := &in.MaxReplicas, &out.MaxReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.StandbyReplicas != nil {
+		in, out := &in.StandbyReplicas, &out.StandbyReplicas
+		*out = new(int32)
+		**out = **in
+	}
 	in.LeaderWorkerTemplate.DeepCopyInto(&out.LeaderWorkerTemplate)
 	in.RolloutStrategy.DeepCopyInto(&out.RolloutStrategy)
+	if in.LeaderReadyConfiguration != nil {
+		in, out := &in.LeaderReadyConfiguration, &out.LeaderReadyConfiguration
+		*out = new(LeaderReadyConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LeaderStartupDelaySeconds != nil {
+		in, out := &in.LeaderStartupDelaySeconds, &out.LeaderStartupDelaySeconds
+		*out = new(int32)
+		**out = **in
+	}
 	if in.NetworkConfig != nil {
 		in, out := &in.NetworkConfig, &out.NetworkConfig
 		*out = new(NetworkConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RevisionHistoryLimit != nil {
+		in, out := &in.RevisionHistoryLimit, &out.RevisionHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Suspend != nil {
+		in, out := &in.Suspend, &out.Suspend
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeaderWorkerSetSpec.
@@ -122,6 +213,24 @@ func (in *LeaderWorkerSetStatus) DeepCopyInto(out *LeaderWorkerSetStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.GroupStatuses != nil {
+		in, out := &in.GroupStatuses, &out.GroupStatuses
+		*out = make([]GroupStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.RolloutStartTime != nil {
+		in, out := &in.RolloutStartTime, &out.RolloutStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastRolloutCompletionTime != nil {
+		in, out := &in.LastRolloutCompletionTime, &out.LastRolloutCompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.StandbyGroups != nil {
+		in, out := &in.StandbyGroups, &out.StandbyGroups
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeaderWorkerSetStatus.
@@ -148,11 +257,88 @@ func (in *LeaderWorkerTemplate) DeepCopyInto(out *LeaderWorkerTemplate) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.PodFailurePolicy != nil {
+		in, out := &in.PodFailurePolicy, &out.PodFailurePolicy
+		*out = new(PodFailurePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SubGroupPolicy != nil {
 		in, out := &in.SubGroupPolicy, &out.SubGroupPolicy
 		*out = new(SubGroupPolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ExclusiveTopology != nil {
+		in, out := &in.ExclusiveTopology, &out.ExclusiveTopology
+		*out = new(ExclusiveTopology)
+		**out = **in
+	}
+	if in.NetworkEnvNames != nil {
+		in, out := &in.NetworkEnvNames, &out.NetworkEnvNames
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LeaderPodDeletionCost != nil {
+		in, out := &in.LeaderPodDeletionCost, &out.LeaderPodDeletionCost
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LeaderTerminationGracePeriodSeconds != nil {
+		in, out := &in.LeaderTerminationGracePeriodSeconds, &out.LeaderTerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.GroupSpreadConstraints != nil {
+		in, out := &in.GroupSpreadConstraints, &out.GroupSpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CommonContainers != nil {
+		in, out := &in.CommonContainers, &out.CommonContainers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LeaderGroupInitContainers != nil {
+		in, out := &in.LeaderGroupInitContainers, &out.LeaderGroupInitContainers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InheritLabels != nil {
+		in, out := &in.InheritLabels, &out.InheritLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.VolumeClaimTemplates != nil {
+		in, out := &in.VolumeClaimTemplates, &out.VolumeClaimTemplates
+		*out = make([]corev1.PersistentVolumeClaim, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PersistentVolumeClaimRetentionPolicy != nil {
+		in, out := &in.PersistentVolumeClaimRetentionPolicy, &out.PersistentVolumeClaimRetentionPolicy
+		*out = new(appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy)
+		**out = **in
+	}
+	if in.PerGroupEnv != nil {
+		in, out := &in.PerGroupEnv, &out.PerGroupEnv
+		*out = make([]PerGroupEnv, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeaderWorkerTemplate.
@@ -185,11 +371,111 @@ func (in *NetworkConfig) DeepCopy() *NetworkConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerGroupEnv) DeepCopyInto(out *PerGroupEnv) {
+	*out = *in
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerGroupEnv.
+func (in *PerGroupEnv) DeepCopy() *PerGroupEnv {
+	if in == nil {
+		return nil
+	}
+	out := new(PerGroupEnv)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodFailurePolicy) DeepCopyInto(out *PodFailurePolicy) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]PodFailurePolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodFailurePolicy.
+func (in *PodFailurePolicy) DeepCopy() *PodFailurePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PodFailurePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodFailurePolicyOnExitCodesRequirement) DeepCopyInto(out *PodFailurePolicyOnExitCodesRequirement) {
+	*out = *in
+	if in.ContainerName != nil {
+		in, out := &in.ContainerName, &out.ContainerName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodFailurePolicyOnExitCodesRequirement.
+func (in *PodFailurePolicyOnExitCodesRequirement) DeepCopy() *PodFailurePolicyOnExitCodesRequirement {
+	if in == nil {
+		return nil
+	}
+	out := new(PodFailurePolicyOnExitCodesRequirement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodFailurePolicyRule) DeepCopyInto(out *PodFailurePolicyRule) {
+	*out = *in
+	in.OnExitCodes.DeepCopyInto(&out.OnExitCodes)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodFailurePolicyRule.
+func (in *PodFailurePolicyRule) DeepCopy() *PodFailurePolicyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(PodFailurePolicyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RollingUpdateConfiguration) DeepCopyInto(out *RollingUpdateConfiguration) {
 	*out = *in
 	out.MaxUnavailable = in.MaxUnavailable
 	out.MaxSurge = in.MaxSurge
+	if in.DrainGracePeriodSeconds != nil {
+		in, out := &in.DrainGracePeriodSeconds, &out.DrainGracePeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Partition != nil {
+		in, out := &in.Partition, &out.Partition
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ProgressDeadlineSeconds != nil {
+		in, out := &in.ProgressDeadlineSeconds, &out.ProgressDeadlineSeconds
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateConfiguration.
@@ -208,7 +494,7 @@ func (in *RolloutStrategy) DeepCopyInto(out *RolloutStrategy) {
 	if in.RollingUpdateConfiguration != nil {
 		in, out := &in.RollingUpdateConfiguration, &out.RollingUpdateConfiguration
 		*out = new(RollingUpdateConfiguration)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -235,6 +521,11 @@ func (in *SubGroupPolicy) DeepCopyInto(out *SubGroupPolicy) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.SubGroupTopologyKey != nil {
+		in, out := &in.SubGroupTopologyKey, &out.SubGroupTopologyKey
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubGroupPolicy.
@@ -24,11 +24,19 @@ import (
 // LeaderWorkerSetSpecApplyConfiguration represents a declarative configuration of the LeaderWorkerSetSpec type for use
 // with apply.
 type LeaderWorkerSetSpecApplyConfiguration struct {
-	Replicas             *int32                                  `json:"replicas,omitempty"`
-	LeaderWorkerTemplate *LeaderWorkerTemplateApplyConfiguration `json:"leaderWorkerTemplate,omitempty"`
-	RolloutStrategy      *RolloutStrategyApplyConfiguration      `json:"rolloutStrategy,omitempty"`
-	StartupPolicy        *leaderworkersetv1.StartupPolicyType    `json:"startupPolicy,omitempty"`
-	NetworkConfig        *NetworkConfigApplyConfiguration        `json:"networkConfig,omitempty"`
+	Replicas                  *int32                                      `json:"replicas,omitempty"`
+	MaxReplicas               *int32                                      `json:"maxReplicas,omitempty"`
+	MinReplicas               *int32                                      `json:"minReplicas,omitempty"`
+	StandbyReplicas           *int32                                      `json:"standbyReplicas,omitempty"`
+	LeaderWorkerTemplate      *LeaderWorkerTemplateApplyConfiguration     `json:"leaderWorkerTemplate,omitempty"`
+	RolloutStrategy           *RolloutStrategyApplyConfiguration          `json:"rolloutStrategy,omitempty"`
+	StartupPolicy             *leaderworkersetv1.StartupPolicyType        `json:"startupPolicy,omitempty"`
+	LeaderReadyConfiguration  *LeaderReadyConfigurationApplyConfiguration `json:"leaderReadyConfiguration,omitempty"`
+	LeaderStartupDelaySeconds *int32                                      `json:"leaderStartupDelaySeconds,omitempty"`
+	ScaleDownPolicy           *leaderworkersetv1.ScaleDownPolicyType      `json:"scaleDownPolicy,omitempty"`
+	NetworkConfig             *NetworkConfigApplyConfiguration            `json:"networkConfig,omitempty"`
+	RevisionHistoryLimit      *int32                                      `json:"revisionHistoryLimit,omitempty"`
+	Suspend                   *bool                                       `json:"suspend,omitempty"`
 }
 
 // LeaderWorkerSetSpecApplyConfiguration constructs a declarative configuration of the LeaderWorkerSetSpec type for use with
@@ -45,6 +53,30 @@ func (b *LeaderWorkerSetSpecApplyConfiguration) WithReplicas(value int32) *Leade
 	return b
 }
 
+// WithMaxReplicas sets the MaxReplicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxReplicas field is set to the value of the last call.
+func (b *LeaderWorkerSetSpecApplyConfiguration) WithMaxReplicas(value int32) *LeaderWorkerSetSpecApplyConfiguration {
+	b.MaxReplicas = &value
+	return b
+}
+
+// WithMinReplicas sets the MinReplicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MinReplicas field is set to the value of the last call.
+func (b *LeaderWorkerSetSpecApplyConfiguration) WithMinReplicas(value int32) *LeaderWorkerSetSpecApplyConfiguration {
+	b.MinReplicas = &value
+	return b
+}
+
+// WithStandbyReplicas sets the StandbyReplicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StandbyReplicas field is set to the value of the last call.
+func (b *LeaderWorkerSetSpecApplyConfiguration) WithStandbyReplicas(value int32) *LeaderWorkerSetSpecApplyConfiguration {
+	b.StandbyReplicas = &value
+	return b
+}
+
 // WithLeaderWorkerTemplate sets the LeaderWorkerTemplate field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the LeaderWorkerTemplate field is set to the value of the last call.
@@ -69,6 +101,30 @@ func (b *LeaderWorkerSetSpecApplyConfiguration) WithStartupPolicy(value leaderwo
 	return b
 }
 
+// WithLeaderReadyConfiguration sets the LeaderReadyConfiguration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LeaderReadyConfiguration field is set to the value of the last call.
+func (b *LeaderWorkerSetSpecApplyConfiguration) WithLeaderReadyConfiguration(value *LeaderReadyConfigurationApplyConfiguration) *LeaderWorkerSetSpecApplyConfiguration {
+	b.LeaderReadyConfiguration = value
+	return b
+}
+
+// WithLeaderStartupDelaySeconds sets the LeaderStartupDelaySeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LeaderStartupDelaySeconds field is set to the value of the last call.
+func (b *LeaderWorkerSetSpecApplyConfiguration) WithLeaderStartupDelaySeconds(value int32) *LeaderWorkerSetSpecApplyConfiguration {
+	b.LeaderStartupDelaySeconds = &value
+	return b
+}
+
+// WithScaleDownPolicy sets the ScaleDownPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ScaleDownPolicy field is set to the value of the last call.
+func (b *LeaderWorkerSetSpecApplyConfiguration) WithScaleDownPolicy(value leaderworkersetv1.ScaleDownPolicyType) *LeaderWorkerSetSpecApplyConfiguration {
+	b.ScaleDownPolicy = &value
+	return b
+}
+
 // WithNetworkConfig sets the NetworkConfig field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the NetworkConfig field is set to the value of the last call.
@@ -76,3 +132,19 @@ func (b *LeaderWorkerSetSpecApplyConfiguration) WithNetworkConfig(value *Network
 	b.NetworkConfig = value
 	return b
 }
+
+// WithRevisionHistoryLimit sets the RevisionHistoryLimit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RevisionHistoryLimit field is set to the value of the last call.
+func (b *LeaderWorkerSetSpecApplyConfiguration) WithRevisionHistoryLimit(value int32) *LeaderWorkerSetSpecApplyConfiguration {
+	b.RevisionHistoryLimit = &value
+	return b
+}
+
+// WithSuspend sets the Suspend field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Suspend field is set to the value of the last call.
+func (b *LeaderWorkerSetSpecApplyConfiguration) WithSuspend(value bool) *LeaderWorkerSetSpecApplyConfiguration {
+	b.Suspend = &value
+	return b
+}
@@ -18,17 +18,27 @@ limitations under the License.
 package v1
 
 import (
-	metav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	applyconfigurationsmetav1 "k8s.io/client-go/applyconfigurations/meta/v1"
 )
 
 // LeaderWorkerSetStatusApplyConfiguration represents a declarative configuration of the LeaderWorkerSetStatus type for use
 // with apply.
 type LeaderWorkerSetStatusApplyConfiguration struct {
-	Conditions      []metav1.ConditionApplyConfiguration `json:"conditions,omitempty"`
-	ReadyReplicas   *int32                               `json:"readyReplicas,omitempty"`
-	UpdatedReplicas *int32                               `json:"updatedReplicas,omitempty"`
-	Replicas        *int32                               `json:"replicas,omitempty"`
-	HPAPodSelector  *string                              `json:"hpaPodSelector,omitempty"`
+	Conditions                []applyconfigurationsmetav1.ConditionApplyConfiguration `json:"conditions,omitempty"`
+	ObservedGeneration        *int64                                                  `json:"observedGeneration,omitempty"`
+	ReadyReplicas             *int32                                                  `json:"readyReplicas,omitempty"`
+	UpdatedReplicas           *int32                                                  `json:"updatedReplicas,omitempty"`
+	ProgressingReplicas       *int32                                                  `json:"progressingReplicas,omitempty"`
+	Replicas                  *int32                                                  `json:"replicas,omitempty"`
+	HPAPodSelector            *string                                                 `json:"hpaPodSelector,omitempty"`
+	GroupStatuses             []GroupStatusApplyConfiguration                         `json:"groupStatuses,omitempty"`
+	RolloutStartTime          *metav1.Time                                            `json:"rolloutStartTime,omitempty"`
+	LastRolloutCompletionTime *metav1.Time                                            `json:"lastRolloutCompletionTime,omitempty"`
+	CurrentRevision           *string                                                 `json:"currentRevision,omitempty"`
+	UpdateRevision            *string                                                 `json:"updateRevision,omitempty"`
+	CrashingPods              *int32                                                  `json:"crashingPods,omitempty"`
+	StandbyGroups             []int32                                                 `json:"standbyGroups,omitempty"`
 }
 
 // LeaderWorkerSetStatusApplyConfiguration constructs a declarative configuration of the LeaderWorkerSetStatus type for use with
@@ -40,7 +50,7 @@ func LeaderWorkerSetStatus() *LeaderWorkerSetStatusApplyConfiguration {
 // WithConditions adds the given value to the Conditions field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Conditions field.
-func (b *LeaderWorkerSetStatusApplyConfiguration) WithConditions(values ...*metav1.ConditionApplyConfiguration) *LeaderWorkerSetStatusApplyConfiguration {
+func (b *LeaderWorkerSetStatusApplyConfiguration) WithConditions(values ...*applyconfigurationsmetav1.ConditionApplyConfiguration) *LeaderWorkerSetStatusApplyConfiguration {
 	for i := range values {
 		if values[i] == nil {
 			panic("nil value passed to WithConditions")
@@ -50,6 +60,14 @@ func (b *LeaderWorkerSetStatusApplyConfiguration) WithConditions(values ...*meta
 	return b
 }
 
+// WithObservedGeneration sets the ObservedGeneration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ObservedGeneration field is set to the value of the last call.
+func (b *LeaderWorkerSetStatusApplyConfiguration) WithObservedGeneration(value int64) *LeaderWorkerSetStatusApplyConfiguration {
+	b.ObservedGeneration = &value
+	return b
+}
+
 // WithReadyReplicas sets the ReadyReplicas field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ReadyReplicas field is set to the value of the last call.
@@ -66,6 +84,14 @@ func (b *LeaderWorkerSetStatusApplyConfiguration) WithUpdatedReplicas(value int3
 	return b
 }
 
+// WithProgressingReplicas sets the ProgressingReplicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ProgressingReplicas field is set to the value of the last call.
+func (b *LeaderWorkerSetStatusApplyConfiguration) WithProgressingReplicas(value int32) *LeaderWorkerSetStatusApplyConfiguration {
+	b.ProgressingReplicas = &value
+	return b
+}
+
 // WithReplicas sets the Replicas field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Replicas field is set to the value of the last call.
@@ -81,3 +107,66 @@ func (b *LeaderWorkerSetStatusApplyConfiguration) WithHPAPodSelector(value strin
 	b.HPAPodSelector = &value
 	return b
 }
+
+// WithGroupStatuses adds the given value to the GroupStatuses field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the GroupStatuses field.
+func (b *LeaderWorkerSetStatusApplyConfiguration) WithGroupStatuses(values ...*GroupStatusApplyConfiguration) *LeaderWorkerSetStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithGroupStatuses")
+		}
+		b.GroupStatuses = append(b.GroupStatuses, *values[i])
+	}
+	return b
+}
+
+// WithRolloutStartTime sets the RolloutStartTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RolloutStartTime field is set to the value of the last call.
+func (b *LeaderWorkerSetStatusApplyConfiguration) WithRolloutStartTime(value metav1.Time) *LeaderWorkerSetStatusApplyConfiguration {
+	b.RolloutStartTime = &value
+	return b
+}
+
+// WithLastRolloutCompletionTime sets the LastRolloutCompletionTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastRolloutCompletionTime field is set to the value of the last call.
+func (b *LeaderWorkerSetStatusApplyConfiguration) WithLastRolloutCompletionTime(value metav1.Time) *LeaderWorkerSetStatusApplyConfiguration {
+	b.LastRolloutCompletionTime = &value
+	return b
+}
+
+// WithCurrentRevision sets the CurrentRevision field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CurrentRevision field is set to the value of the last call.
+func (b *LeaderWorkerSetStatusApplyConfiguration) WithCurrentRevision(value string) *LeaderWorkerSetStatusApplyConfiguration {
+	b.CurrentRevision = &value
+	return b
+}
+
+// WithUpdateRevision sets the UpdateRevision field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UpdateRevision field is set to the value of the last call.
+func (b *LeaderWorkerSetStatusApplyConfiguration) WithUpdateRevision(value string) *LeaderWorkerSetStatusApplyConfiguration {
+	b.UpdateRevision = &value
+	return b
+}
+
+// WithCrashingPods sets the CrashingPods field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CrashingPods field is set to the value of the last call.
+func (b *LeaderWorkerSetStatusApplyConfiguration) WithCrashingPods(value int32) *LeaderWorkerSetStatusApplyConfiguration {
+	b.CrashingPods = &value
+	return b
+}
+
+// WithStandbyGroups adds the given value to the StandbyGroups field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the StandbyGroups field.
+func (b *LeaderWorkerSetStatusApplyConfiguration) WithStandbyGroups(values ...int32) *LeaderWorkerSetStatusApplyConfiguration {
+	for i := range values {
+		b.StandbyGroups = append(b.StandbyGroups, values[i])
+	}
+	return b
+}
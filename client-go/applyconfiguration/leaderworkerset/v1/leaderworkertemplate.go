@@ -18,6 +18,7 @@ limitations under the License.
 package v1
 
 import (
+	appsv1 "k8s.io/client-go/applyconfigurations/apps/v1"
 	corev1 "k8s.io/client-go/applyconfigurations/core/v1"
 	leaderworkersetv1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
 )
@@ -25,11 +26,34 @@ import (
 // LeaderWorkerTemplateApplyConfiguration represents a declarative configuration of the LeaderWorkerTemplate type for use
 // with apply.
 type LeaderWorkerTemplateApplyConfiguration struct {
-	LeaderTemplate *corev1.PodTemplateSpecApplyConfiguration `json:"leaderTemplate,omitempty"`
-	WorkerTemplate *corev1.PodTemplateSpecApplyConfiguration `json:"workerTemplate,omitempty"`
-	Size           *int32                                    `json:"size,omitempty"`
-	RestartPolicy  *leaderworkersetv1.RestartPolicyType      `json:"restartPolicy,omitempty"`
-	SubGroupPolicy *SubGroupPolicyApplyConfiguration         `json:"subGroupPolicy,omitempty"`
+	LeaderTemplate                       *corev1.PodTemplateSpecApplyConfiguration                                 `json:"leaderTemplate,omitempty"`
+	WorkerTemplate                       *corev1.PodTemplateSpecApplyConfiguration                                 `json:"workerTemplate,omitempty"`
+	Size                                 *int32                                                                    `json:"size,omitempty"`
+	RestartPolicy                        *leaderworkersetv1.RestartPolicyType                                      `json:"restartPolicy,omitempty"`
+	PodFailurePolicy                     *PodFailurePolicyApplyConfiguration                                       `json:"podFailurePolicy,omitempty"`
+	FailedGroupRetention                 *leaderworkersetv1.FailedGroupRetentionType                               `json:"failedGroupRetention,omitempty"`
+	SubGroupPolicy                       *SubGroupPolicyApplyConfiguration                                         `json:"subGroupPolicy,omitempty"`
+	WorkerReadinessFollowsLeader         *bool                                                                     `json:"workerReadinessFollowsLeader,omitempty"`
+	ExclusiveTopology                    *ExclusiveTopologyApplyConfiguration                                      `json:"exclusiveTopology,omitempty"`
+	InjectPeerAddresses                  *bool                                                                     `json:"injectPeerAddresses,omitempty"`
+	PublishMembershipConfigMap           *bool                                                                     `json:"publishMembershipConfigMap,omitempty"`
+	NetworkEnvNames                      map[string]string                                                         `json:"networkEnvNames,omitempty"`
+	LeaderPodDeletionCost                *int32                                                                    `json:"leaderPodDeletionCost,omitempty"`
+	LeaderPriorityClassName              *string                                                                   `json:"leaderPriorityClassName,omitempty"`
+	LeaderServiceAccountName             *string                                                                   `json:"leaderServiceAccountName,omitempty"`
+	LeaderTerminationGracePeriodSeconds  *int64                                                                    `json:"leaderTerminationGracePeriodSeconds,omitempty"`
+	WorkerServiceAccountName             *string                                                                   `json:"workerServiceAccountName,omitempty"`
+	GangSchedulingLabelKey               *string                                                                   `json:"gangSchedulingLabelKey,omitempty"`
+	GroupSpreadConstraints               []corev1.TopologySpreadConstraintApplyConfiguration                       `json:"groupSpreadConstraints,omitempty"`
+	CommonContainers                     []corev1.ContainerApplyConfiguration                                      `json:"commonContainers,omitempty"`
+	LeaderGroupInitContainers            []corev1.ContainerApplyConfiguration                                      `json:"leaderGroupInitContainers,omitempty"`
+	InheritLabels                        []string                                                                  `json:"inheritLabels,omitempty"`
+	MinReadySeconds                      *int32                                                                    `json:"minReadySeconds,omitempty"`
+	OrderedTermination                   *bool                                                                     `json:"orderedTermination,omitempty"`
+	ActiveDeadlineSeconds                *int64                                                                    `json:"activeDeadlineSeconds,omitempty"`
+	VolumeClaimTemplates                 []corev1.PersistentVolumeClaimApplyConfiguration                          `json:"volumeClaimTemplates,omitempty"`
+	PersistentVolumeClaimRetentionPolicy *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicyApplyConfiguration `json:"persistentVolumeClaimRetentionPolicy,omitempty"`
+	PerGroupEnv                          []PerGroupEnvApplyConfiguration                                           `json:"perGroupEnv,omitempty"`
 }
 
 // LeaderWorkerTemplateApplyConfiguration constructs a declarative configuration of the LeaderWorkerTemplate type for use with
@@ -70,6 +94,22 @@ func (b *LeaderWorkerTemplateApplyConfiguration) WithRestartPolicy(value leaderw
 	return b
 }
 
+// WithPodFailurePolicy sets the PodFailurePolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PodFailurePolicy field is set to the value of the last call.
+func (b *LeaderWorkerTemplateApplyConfiguration) WithPodFailurePolicy(value *PodFailurePolicyApplyConfiguration) *LeaderWorkerTemplateApplyConfiguration {
+	b.PodFailurePolicy = value
+	return b
+}
+
+// WithFailedGroupRetention sets the FailedGroupRetention field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FailedGroupRetention field is set to the value of the last call.
+func (b *LeaderWorkerTemplateApplyConfiguration) WithFailedGroupRetention(value leaderworkersetv1.FailedGroupRetentionType) *LeaderWorkerTemplateApplyConfiguration {
+	b.FailedGroupRetention = &value
+	return b
+}
+
 // WithSubGroupPolicy sets the SubGroupPolicy field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the SubGroupPolicy field is set to the value of the last call.
@@ -77,3 +117,204 @@ func (b *LeaderWorkerTemplateApplyConfiguration) WithSubGroupPolicy(value *SubGr
 	b.SubGroupPolicy = value
 	return b
 }
+
+// WithWorkerReadinessFollowsLeader sets the WorkerReadinessFollowsLeader field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the WorkerReadinessFollowsLeader field is set to the value of the last call.
+func (b *LeaderWorkerTemplateApplyConfiguration) WithWorkerReadinessFollowsLeader(value bool) *LeaderWorkerTemplateApplyConfiguration {
+	b.WorkerReadinessFollowsLeader = &value
+	return b
+}
+
+// WithExclusiveTopology sets the ExclusiveTopology field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ExclusiveTopology field is set to the value of the last call.
+func (b *LeaderWorkerTemplateApplyConfiguration) WithExclusiveTopology(value *ExclusiveTopologyApplyConfiguration) *LeaderWorkerTemplateApplyConfiguration {
+	b.ExclusiveTopology = value
+	return b
+}
+
+// WithInjectPeerAddresses sets the InjectPeerAddresses field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the InjectPeerAddresses field is set to the value of the last call.
+func (b *LeaderWorkerTemplateApplyConfiguration) WithInjectPeerAddresses(value bool) *LeaderWorkerTemplateApplyConfiguration {
+	b.InjectPeerAddresses = &value
+	return b
+}
+
+// WithPublishMembershipConfigMap sets the PublishMembershipConfigMap field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PublishMembershipConfigMap field is set to the value of the last call.
+func (b *LeaderWorkerTemplateApplyConfiguration) WithPublishMembershipConfigMap(value bool) *LeaderWorkerTemplateApplyConfiguration {
+	b.PublishMembershipConfigMap = &value
+	return b
+}
+
+// WithNetworkEnvNames puts the entries into the NetworkEnvNames field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the NetworkEnvNames field,
+// overwriting an existing map entries in NetworkEnvNames field with the same key.
+func (b *LeaderWorkerTemplateApplyConfiguration) WithNetworkEnvNames(entries map[string]string) *LeaderWorkerTemplateApplyConfiguration {
+	if b.NetworkEnvNames == nil && len(entries) > 0 {
+		b.NetworkEnvNames = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.NetworkEnvNames[k] = v
+	}
+	return b
+}
+
+// WithLeaderPodDeletionCost sets the LeaderPodDeletionCost field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LeaderPodDeletionCost field is set to the value of the last call.
+func (b *LeaderWorkerTemplateApplyConfiguration) WithLeaderPodDeletionCost(value int32) *LeaderWorkerTemplateApplyConfiguration {
+	b.LeaderPodDeletionCost = &value
+	return b
+}
+
+// WithLeaderPriorityClassName sets the LeaderPriorityClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LeaderPriorityClassName field is set to the value of the last call.
+func (b *LeaderWorkerTemplateApplyConfiguration) WithLeaderPriorityClassName(value string) *LeaderWorkerTemplateApplyConfiguration {
+	b.LeaderPriorityClassName = &value
+	return b
+}
+
+// WithLeaderServiceAccountName sets the LeaderServiceAccountName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LeaderServiceAccountName field is set to the value of the last call.
+func (b *LeaderWorkerTemplateApplyConfiguration) WithLeaderServiceAccountName(value string) *LeaderWorkerTemplateApplyConfiguration {
+	b.LeaderServiceAccountName = &value
+	return b
+}
+
+// WithLeaderTerminationGracePeriodSeconds sets the LeaderTerminationGracePeriodSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LeaderTerminationGracePeriodSeconds field is set to the value of the last call.
+func (b *LeaderWorkerTemplateApplyConfiguration) WithLeaderTerminationGracePeriodSeconds(value int64) *LeaderWorkerTemplateApplyConfiguration {
+	b.LeaderTerminationGracePeriodSeconds = &value
+	return b
+}
+
+// WithWorkerServiceAccountName sets the WorkerServiceAccountName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the WorkerServiceAccountName field is set to the value of the last call.
+func (b *LeaderWorkerTemplateApplyConfiguration) WithWorkerServiceAccountName(value string) *LeaderWorkerTemplateApplyConfiguration {
+	b.WorkerServiceAccountName = &value
+	return b
+}
+
+// WithGangSchedulingLabelKey sets the GangSchedulingLabelKey field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GangSchedulingLabelKey field is set to the value of the last call.
+func (b *LeaderWorkerTemplateApplyConfiguration) WithGangSchedulingLabelKey(value string) *LeaderWorkerTemplateApplyConfiguration {
+	b.GangSchedulingLabelKey = &value
+	return b
+}
+
+// WithGroupSpreadConstraints adds the given value to the GroupSpreadConstraints field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the GroupSpreadConstraints field.
+func (b *LeaderWorkerTemplateApplyConfiguration) WithGroupSpreadConstraints(values ...*corev1.TopologySpreadConstraintApplyConfiguration) *LeaderWorkerTemplateApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithGroupSpreadConstraints")
+		}
+		b.GroupSpreadConstraints = append(b.GroupSpreadConstraints, *values[i])
+	}
+	return b
+}
+
+// WithCommonContainers adds the given value to the CommonContainers field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the CommonContainers field.
+func (b *LeaderWorkerTemplateApplyConfiguration) WithCommonContainers(values ...*corev1.ContainerApplyConfiguration) *LeaderWorkerTemplateApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithCommonContainers")
+		}
+		b.CommonContainers = append(b.CommonContainers, *values[i])
+	}
+	return b
+}
+
+// WithLeaderGroupInitContainers adds the given value to the LeaderGroupInitContainers field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the LeaderGroupInitContainers field.
+func (b *LeaderWorkerTemplateApplyConfiguration) WithLeaderGroupInitContainers(values ...*corev1.ContainerApplyConfiguration) *LeaderWorkerTemplateApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithLeaderGroupInitContainers")
+		}
+		b.LeaderGroupInitContainers = append(b.LeaderGroupInitContainers, *values[i])
+	}
+	return b
+}
+
+// WithInheritLabels adds the given value to the InheritLabels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the InheritLabels field.
+func (b *LeaderWorkerTemplateApplyConfiguration) WithInheritLabels(values ...string) *LeaderWorkerTemplateApplyConfiguration {
+	for i := range values {
+		b.InheritLabels = append(b.InheritLabels, values[i])
+	}
+	return b
+}
+
+// WithMinReadySeconds sets the MinReadySeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MinReadySeconds field is set to the value of the last call.
+func (b *LeaderWorkerTemplateApplyConfiguration) WithMinReadySeconds(value int32) *LeaderWorkerTemplateApplyConfiguration {
+	b.MinReadySeconds = &value
+	return b
+}
+
+// WithOrderedTermination sets the OrderedTermination field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OrderedTermination field is set to the value of the last call.
+func (b *LeaderWorkerTemplateApplyConfiguration) WithOrderedTermination(value bool) *LeaderWorkerTemplateApplyConfiguration {
+	b.OrderedTermination = &value
+	return b
+}
+
+// WithActiveDeadlineSeconds sets the ActiveDeadlineSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ActiveDeadlineSeconds field is set to the value of the last call.
+func (b *LeaderWorkerTemplateApplyConfiguration) WithActiveDeadlineSeconds(value int64) *LeaderWorkerTemplateApplyConfiguration {
+	b.ActiveDeadlineSeconds = &value
+	return b
+}
+
+// WithVolumeClaimTemplates adds the given value to the VolumeClaimTemplates field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the VolumeClaimTemplates field.
+func (b *LeaderWorkerTemplateApplyConfiguration) WithVolumeClaimTemplates(values ...*corev1.PersistentVolumeClaimApplyConfiguration) *LeaderWorkerTemplateApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithVolumeClaimTemplates")
+		}
+		b.VolumeClaimTemplates = append(b.VolumeClaimTemplates, *values[i])
+	}
+	return b
+}
+
+// WithPersistentVolumeClaimRetentionPolicy sets the PersistentVolumeClaimRetentionPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PersistentVolumeClaimRetentionPolicy field is set to the value of the last call.
+func (b *LeaderWorkerTemplateApplyConfiguration) WithPersistentVolumeClaimRetentionPolicy(value *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicyApplyConfiguration) *LeaderWorkerTemplateApplyConfiguration {
+	b.PersistentVolumeClaimRetentionPolicy = value
+	return b
+}
+
+// WithPerGroupEnv adds the given value to the PerGroupEnv field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PerGroupEnv field.
+func (b *LeaderWorkerTemplateApplyConfiguration) WithPerGroupEnv(values ...*PerGroupEnvApplyConfiguration) *LeaderWorkerTemplateApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithPerGroupEnv")
+		}
+		b.PerGroupEnv = append(b.PerGroupEnv, *values[i])
+	}
+	return b
+}
@@ -25,6 +25,9 @@ import (
 // with apply.
 type NetworkConfigApplyConfiguration struct {
 	SubdomainPolicy *leaderworkersetv1.SubdomainPolicy `json:"subdomainPolicy,omitempty"`
+	HostnamePrefix  *string                            `json:"hostnamePrefix,omitempty"`
+	PerGroupService *bool                              `json:"perGroupService,omitempty"`
+	StartIndex      *int32                             `json:"startIndex,omitempty"`
 }
 
 // NetworkConfigApplyConfiguration constructs a declarative configuration of the NetworkConfig type for use with
@@ -40,3 +43,27 @@ func (b *NetworkConfigApplyConfiguration) WithSubdomainPolicy(value leaderworker
 	b.SubdomainPolicy = &value
 	return b
 }
+
+// WithHostnamePrefix sets the HostnamePrefix field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HostnamePrefix field is set to the value of the last call.
+func (b *NetworkConfigApplyConfiguration) WithHostnamePrefix(value string) *NetworkConfigApplyConfiguration {
+	b.HostnamePrefix = &value
+	return b
+}
+
+// WithPerGroupService sets the PerGroupService field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PerGroupService field is set to the value of the last call.
+func (b *NetworkConfigApplyConfiguration) WithPerGroupService(value bool) *NetworkConfigApplyConfiguration {
+	b.PerGroupService = &value
+	return b
+}
+
+// WithStartIndex sets the StartIndex field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StartIndex field is set to the value of the last call.
+func (b *NetworkConfigApplyConfiguration) WithStartIndex(value int32) *NetworkConfigApplyConfiguration {
+	b.StartIndex = &value
+	return b
+}
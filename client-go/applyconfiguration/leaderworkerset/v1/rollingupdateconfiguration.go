@@ -19,13 +19,20 @@ package v1
 
 import (
 	intstr "k8s.io/apimachinery/pkg/util/intstr"
+	leaderworkersetv1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
 )
 
 // RollingUpdateConfigurationApplyConfiguration represents a declarative configuration of the RollingUpdateConfiguration type for use
 // with apply.
 type RollingUpdateConfigurationApplyConfiguration struct {
-	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
-	MaxSurge       *intstr.IntOrString `json:"maxSurge,omitempty"`
+	MaxUnavailable          *intstr.IntOrString                      `json:"maxUnavailable,omitempty"`
+	MaxSurge                *intstr.IntOrString                      `json:"maxSurge,omitempty"`
+	DrainGracePeriodSeconds *int32                                   `json:"drainGracePeriodSeconds,omitempty"`
+	Partition               *int32                                   `json:"partition,omitempty"`
+	Granularity             *leaderworkersetv1.RolloutGranularity    `json:"granularity,omitempty"`
+	RequireApproval         *bool                                    `json:"requireApproval,omitempty"`
+	ProgressDeadlineSeconds *int32                                   `json:"progressDeadlineSeconds,omitempty"`
+	RoundingPolicy          *leaderworkersetv1.RolloutRoundingPolicy `json:"roundingPolicy,omitempty"`
 }
 
 // RollingUpdateConfigurationApplyConfiguration constructs a declarative configuration of the RollingUpdateConfiguration type for use with
@@ -49,3 +56,51 @@ func (b *RollingUpdateConfigurationApplyConfiguration) WithMaxSurge(value intstr
 	b.MaxSurge = &value
 	return b
 }
+
+// WithDrainGracePeriodSeconds sets the DrainGracePeriodSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DrainGracePeriodSeconds field is set to the value of the last call.
+func (b *RollingUpdateConfigurationApplyConfiguration) WithDrainGracePeriodSeconds(value int32) *RollingUpdateConfigurationApplyConfiguration {
+	b.DrainGracePeriodSeconds = &value
+	return b
+}
+
+// WithPartition sets the Partition field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Partition field is set to the value of the last call.
+func (b *RollingUpdateConfigurationApplyConfiguration) WithPartition(value int32) *RollingUpdateConfigurationApplyConfiguration {
+	b.Partition = &value
+	return b
+}
+
+// WithGranularity sets the Granularity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Granularity field is set to the value of the last call.
+func (b *RollingUpdateConfigurationApplyConfiguration) WithGranularity(value leaderworkersetv1.RolloutGranularity) *RollingUpdateConfigurationApplyConfiguration {
+	b.Granularity = &value
+	return b
+}
+
+// WithRequireApproval sets the RequireApproval field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RequireApproval field is set to the value of the last call.
+func (b *RollingUpdateConfigurationApplyConfiguration) WithRequireApproval(value bool) *RollingUpdateConfigurationApplyConfiguration {
+	b.RequireApproval = &value
+	return b
+}
+
+// WithProgressDeadlineSeconds sets the ProgressDeadlineSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ProgressDeadlineSeconds field is set to the value of the last call.
+func (b *RollingUpdateConfigurationApplyConfiguration) WithProgressDeadlineSeconds(value int32) *RollingUpdateConfigurationApplyConfiguration {
+	b.ProgressDeadlineSeconds = &value
+	return b
+}
+
+// WithRoundingPolicy sets the RoundingPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RoundingPolicy field is set to the value of the last call.
+func (b *RollingUpdateConfigurationApplyConfiguration) WithRoundingPolicy(value leaderworkersetv1.RolloutRoundingPolicy) *RollingUpdateConfigurationApplyConfiguration {
+	b.RoundingPolicy = &value
+	return b
+}
@@ -24,8 +24,9 @@ import (
 // SubGroupPolicyApplyConfiguration represents a declarative configuration of the SubGroupPolicy type for use
 // with apply.
 type SubGroupPolicyApplyConfiguration struct {
-	Type         *leaderworkersetv1.SubGroupPolicyType `json:"subGroupPolicyType,omitempty"`
-	SubGroupSize *int32                                `json:"subGroupSize,omitempty"`
+	Type                *leaderworkersetv1.SubGroupPolicyType `json:"subGroupPolicyType,omitempty"`
+	SubGroupSize        *int32                                `json:"subGroupSize,omitempty"`
+	SubGroupTopologyKey *string                               `json:"subGroupTopologyKey,omitempty"`
 }
 
 // SubGroupPolicyApplyConfiguration constructs a declarative configuration of the SubGroupPolicy type for use with
@@ -49,3 +50,11 @@ func (b *SubGroupPolicyApplyConfiguration) WithSubGroupSize(value int32) *SubGro
 	b.SubGroupSize = &value
 	return b
 }
+
+// WithSubGroupTopologyKey sets the SubGroupTopologyKey field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SubGroupTopologyKey field is set to the value of the last call.
+func (b *SubGroupPolicyApplyConfiguration) WithSubGroupTopologyKey(value string) *SubGroupPolicyApplyConfiguration {
+	b.SubGroupTopologyKey = &value
+	return b
+}
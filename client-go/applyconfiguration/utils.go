@@ -31,6 +31,12 @@ import (
 func ForKind(kind schema.GroupVersionKind) interface{} {
 	switch kind {
 	// Group=leaderworkerset.x-k8s.io, Version=v1
+	case v1.SchemeGroupVersion.WithKind("ExclusiveTopology"):
+		return &leaderworkersetv1.ExclusiveTopologyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("GroupStatus"):
+		return &leaderworkersetv1.GroupStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("LeaderReadyConfiguration"):
+		return &leaderworkersetv1.LeaderReadyConfigurationApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("LeaderWorkerSet"):
 		return &leaderworkersetv1.LeaderWorkerSetApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("LeaderWorkerSetSpec"):
@@ -41,6 +47,14 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &leaderworkersetv1.LeaderWorkerTemplateApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("NetworkConfig"):
 		return &leaderworkersetv1.NetworkConfigApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("PerGroupEnv"):
+		return &leaderworkersetv1.PerGroupEnvApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("PodFailurePolicy"):
+		return &leaderworkersetv1.PodFailurePolicyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("PodFailurePolicyOnExitCodesRequirement"):
+		return &leaderworkersetv1.PodFailurePolicyOnExitCodesRequirementApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("PodFailurePolicyRule"):
+		return &leaderworkersetv1.PodFailurePolicyRuleApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RollingUpdateConfiguration"):
 		return &leaderworkersetv1.RollingUpdateConfigurationApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RolloutStrategy"):
@@ -19,6 +19,8 @@ package main
 import (
 	"crypto/tls"
 	"flag"
+	"fmt"
+	"math"
 	"os"
 	"time"
 
@@ -29,6 +31,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -39,6 +42,7 @@ import (
 	"sigs.k8s.io/lws/pkg/cert"
 	"sigs.k8s.io/lws/pkg/config"
 	"sigs.k8s.io/lws/pkg/controllers"
+	"sigs.k8s.io/lws/pkg/metrics"
 	"sigs.k8s.io/lws/pkg/utils"
 	"sigs.k8s.io/lws/pkg/utils/useragent"
 	"sigs.k8s.io/lws/pkg/version"
@@ -52,12 +56,21 @@ var (
 	flagsSet = make(map[string]bool)
 )
 
+// The controller-runtime defaults of the leader election.
+const (
+	defaultLeaseDuration = 15 * time.Second
+	defaultRenewDeadline = 10 * time.Second
+	defaultRetryPeriod   = 2 * time.Second
+)
+
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(leaderworkersetv1.AddToScheme(scheme))
 	utilruntime.Must(configapi.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
+
+	metrics.Register()
 }
 
 func main() {
@@ -75,6 +88,17 @@ func main() {
 		leaderElectResourceLock  string
 		leaderElectionID         string
 		configFile               string
+
+		enableHeadlessService   bool
+		unschedulableTimeout    time.Duration
+		maxReplicasPerLws       int
+		maxGroupRecreateBackoff time.Duration
+
+		requireLeaderReadinessProbe      bool
+		requireLeaderRestartPolicyAlways bool
+		decisionLogVerbosity             int
+		statusResyncPeriod               time.Duration
+		maxConcurrentGroupCreates        int
 	)
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8443", "DEPRECATED(please pass configuration file via --config flag): The address the metric endpoint binds to.")
@@ -84,14 +108,14 @@ func main() {
 	flag.BoolVar(&enableLeaderElection, "leader-elect", true,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
-	flag.DurationVar(&leaderElectLeaseDuration, "leader-elect-lease-duration", 15*time.Second,
+	flag.DurationVar(&leaderElectLeaseDuration, "leader-elect-lease-duration", defaultLeaseDuration,
 		"DEPRECATED(please pass configuration file via --config flag): The duration that non-leader candidates will wait after observing a leadership renewal until attempting to acquire "+
 			"leadership of a led but unrenewed leader slot. This is effectively the maximum duration that a leader can be stopped"+
 			" before it is replaced by another candidate. This is only applicable if leader election is enabled.")
-	flag.DurationVar(&leaderElectRenewDeadline, "leader-elect-renew-deadline", 10*time.Second,
+	flag.DurationVar(&leaderElectRenewDeadline, "leader-elect-renew-deadline", defaultRenewDeadline,
 		"DEPRECATED(please pass configuration file via --config flag): The interval between attempts by the acting master to renew a leadership slot before it stops leading. This"+
 			"must be less than or equal to the lease duration. This is only applicable if leader election is enabled.")
-	flag.DurationVar(&leaderElectRetryPeriod, "leader-elect-retry-period", 2*time.Second,
+	flag.DurationVar(&leaderElectRetryPeriod, "leader-elect-retry-period", defaultRetryPeriod,
 		"DEPRECATED(please pass configuration file via --config flag): The duration the clients should wait between attempting acquisition and renewal of a leadership. This is only"+
 			"applicable if leader election is enabled.")
 	flag.StringVar(&leaderElectResourceLock, "leader-elect-resource-lock", "leases",
@@ -99,6 +123,35 @@ func main() {
 			"'endpoints', 'configmaps', 'leases', 'endpointsleases' and 'configmapsleases'")
 	flag.StringVar(&leaderElectionID, "leader-elect-resource-name", "b8b2488c.x-k8s.io",
 		"DEPRECATED(please pass configuration file via --config flag): The name of resource object that is used for locking during leader election. ")
+	flag.BoolVar(&enableHeadlessService, "enable-headless-service", true,
+		"Create headless services for the LeaderWorkerSets. When disabled, all the LeaderWorkerSets are handled "+
+			"as if their subdomainPolicy was None, so no headless service is created and pods don't have a subdomain.")
+	flag.DurationVar(&unschedulableTimeout, "unschedulable-timeout", controllers.DefaultUnschedulableTimeout,
+		"How long a pod of a group can be unschedulable before the GroupUnschedulable condition is set on the LeaderWorkerSet.")
+	flag.IntVar(&maxReplicasPerLws, "max-replicas-per-lws", 0,
+		"The maximum replicas of a LeaderWorkerSet, including its standby replicas, creating or scaling up a LeaderWorkerSet beyond it is rejected by the webhook. "+
+			"0 means no limit.")
+	flag.DurationVar(&maxGroupRecreateBackoff, "max-group-recreate-backoff", controllers.DefaultMaxGroupRecreateBackoff,
+		"The maximum backoff between the recreations of a group restarted with RecreateGroupOnPodRestart or RecreateGroupOnLeaderRestart. "+
+			"The backoff starts at 10s and doubles on every recreation, it's reset once the group isn't recreated for twice this duration. "+
+			"0 disables the backoff.")
+	flag.BoolVar(&requireLeaderReadinessProbe, "require-leader-readiness-probe", false,
+		"Reject the LeaderWorkerSets with the LeaderReady startupPolicy whose leader defines no readinessProbe, "+
+			"instead of only returning a warning.")
+	flag.BoolVar(&requireLeaderRestartPolicyAlways, "require-leader-restart-policy-always", false,
+		"Reject the LeaderWorkerSets with the LeaderReady startupPolicy whose leader sets a restartPolicy other than Always, "+
+			"instead of only returning a warning.")
+	flag.IntVar(&decisionLogVerbosity, "decision-log-verbosity", controllers.DefaultDecisionLogVerbosity,
+		"The verbosity of the log line stating, for every reconcile of a LeaderWorkerSet, how many groups were created, "+
+			"deleted and updated, and why.")
+	flag.DurationVar(&statusResyncPeriod, "status-resync-period", controllers.DefaultStatusResyncPeriod,
+		"The maximum interval a LeaderWorkerSet is reconciled again at while a time-dependent status condition is pending, "+
+			"e.g. the minReadySeconds of a group. LeaderWorkerSets without any pending condition are not requeued. "+
+			"0 means no bound, they are requeued once the condition is due.")
+	flag.IntVar(&maxConcurrentGroupCreates, "max-concurrent-group-creates", 0,
+		"The maximum number of groups of a LeaderWorkerSet created by a single reconcile, the remaining groups are created by "+
+			"the following reconciles, to avoid bursts of requests to the API server when many groups are created at once. "+
+			"0 means no limit.")
 	flag.StringVar(&configFile, "config", "",
 		"The controller will load its initial configuration from this file. "+
 			"Command-line flags will override any configurations set in this file. "+
@@ -116,11 +169,32 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if maxReplicasPerLws < 0 || maxReplicasPerLws > math.MaxInt32 {
+		setupLog.Error(nil, "invalid --max-replicas-per-lws, must be between 0 and 2147483647", "maxReplicasPerLws", maxReplicasPerLws)
+		os.Exit(1)
+	}
+	if decisionLogVerbosity < 0 {
+		setupLog.Error(nil, "invalid --decision-log-verbosity, must not be negative", "decisionLogVerbosity", decisionLogVerbosity)
+		os.Exit(1)
+	}
+	if statusResyncPeriod < 0 {
+		setupLog.Error(nil, "invalid --status-resync-period, must not be negative", "statusResyncPeriod", statusResyncPeriod)
+		os.Exit(1)
+	}
+	if maxGroupRecreateBackoff < 0 {
+		setupLog.Error(nil, "invalid --max-group-recreate-backoff, must not be negative", "maxGroupRecreateBackoff", maxGroupRecreateBackoff)
+		os.Exit(1)
+	}
+
 	options, cfg, err := apply(configFile, probeAddr, enableLeaderElection, leaderElectLeaseDuration, leaderElectRenewDeadline, leaderElectRetryPeriod, leaderElectResourceLock, leaderElectionID, metricsAddr)
 	if err != nil {
 		setupLog.Error(err, "unable to load the configuration")
 		os.Exit(1)
 	}
+	if err := validateLeaderElection(options); err != nil {
+		setupLog.Error(err, "invalid leader election configuration")
+		os.Exit(1)
+	}
 
 	kubeConfig := ctrl.GetConfigOrDie()
 
@@ -160,7 +234,17 @@ func main() {
 	// Cert won't be ready until manager starts, so start a goroutine here which
 	// will block until the cert is ready before setting up the controllers.
 	// Controllers who register after manager starts will start directly.
-	go setupControllers(mgr, certsReady)
+	go setupControllers(mgr, certsReady, controllerOptions{
+		enableHeadlessService:            enableHeadlessService,
+		unschedulableTimeout:             unschedulableTimeout,
+		maxReplicasPerLws:                int32(maxReplicasPerLws),
+		maxGroupRecreateBackoff:          maxGroupRecreateBackoff,
+		requireLeaderReadinessProbe:      requireLeaderReadinessProbe,
+		requireLeaderRestartPolicyAlways: requireLeaderRestartPolicyAlways,
+		decisionLogVerbosity:             decisionLogVerbosity,
+		statusResyncPeriod:               statusResyncPeriod,
+		maxConcurrentGroupCreates:        int32(maxConcurrentGroupCreates),
+	})
 
 	setupHealthzAndReadyzCheck(mgr)
 	setupLog.Info("starting manager")
@@ -171,29 +255,51 @@ func main() {
 	}
 
 }
-func setupControllers(mgr ctrl.Manager, certsReady chan struct{}) {
+
+// controllerOptions are the flags the controllers and the webhooks are set up with.
+type controllerOptions struct {
+	enableHeadlessService            bool
+	unschedulableTimeout             time.Duration
+	maxReplicasPerLws                int32
+	maxGroupRecreateBackoff          time.Duration
+	requireLeaderReadinessProbe      bool
+	requireLeaderRestartPolicyAlways bool
+	decisionLogVerbosity             int
+	statusResyncPeriod               time.Duration
+	maxConcurrentGroupCreates        int32
+}
+
+func setupControllers(mgr ctrl.Manager, certsReady chan struct{}, opts controllerOptions) {
 	// The controllers won't work until the webhooks are operating,
 	// and the webhook won't work until the certs are all in places.
 	setupLog.Info("waiting for the cert generation to complete")
 	<-certsReady
 	setupLog.Info("certs ready")
 
-	if err := controllers.NewLeaderWorkerSetReconciler(
+	lwsController := controllers.NewLeaderWorkerSetReconciler(
 		mgr.GetClient(),
 		mgr.GetScheme(),
 		mgr.GetEventRecorderFor("leaderworkerset"),
-	).SetupWithManager(mgr); err != nil {
+	)
+	lwsController.DisableHeadlessService = !opts.enableHeadlessService
+	lwsController.UnschedulableTimeout = opts.unschedulableTimeout
+	lwsController.DecisionLogVerbosity = opts.decisionLogVerbosity
+	lwsController.StatusResyncPeriod = opts.statusResyncPeriod
+	lwsController.MaxConcurrentGroupCreates = opts.maxConcurrentGroupCreates
+	if err := lwsController.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "LeaderWorkerSet")
 		os.Exit(1)
 	}
 	// Set up pod reconciler.
 	podController := controllers.NewPodReconciler(mgr.GetClient(), mgr.GetScheme(), mgr.GetEventRecorderFor("leaderworkerset"))
+	podController.DisableHeadlessService = !opts.enableHeadlessService
+	podController.MaxGroupRecreateBackoff = opts.maxGroupRecreateBackoff
 	if err := podController.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Pod")
 		os.Exit(1)
 	}
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
-		if err := webhooks.SetupLeaderWorkerSetWebhook(mgr); err != nil {
+		if err := webhooks.SetupLeaderWorkerSetWebhook(mgr, opts.maxReplicasPerLws, opts.requireLeaderReadinessProbe, opts.requireLeaderRestartPolicyAlways); err != nil {
 			setupLog.Error(err, "unable to create leaderworkerset webhook", "webhook", "LeaderWorkerSet")
 			os.Exit(1)
 		}
@@ -217,6 +323,24 @@ func setupHealthzAndReadyzCheck(mgr ctrl.Manager) {
 	}
 }
 
+// validateLeaderElection fails fast when the lease duration, renew deadline and retry period of the
+// leader election, unset ones taking the controller-runtime defaults, don't satisfy lease > renew > retry.
+func validateLeaderElection(options ctrl.Options) error {
+	if !options.LeaderElection {
+		return nil
+	}
+	leaseDuration := ptr.Deref(options.LeaseDuration, defaultLeaseDuration)
+	renewDeadline := ptr.Deref(options.RenewDeadline, defaultRenewDeadline)
+	retryPeriod := ptr.Deref(options.RetryPeriod, defaultRetryPeriod)
+	if leaseDuration <= renewDeadline {
+		return fmt.Errorf("leader election lease duration %s must be greater than the renew deadline %s", leaseDuration, renewDeadline)
+	}
+	if renewDeadline <= retryPeriod {
+		return fmt.Errorf("leader election renew deadline %s must be greater than the retry period %s", renewDeadline, retryPeriod)
+	}
+	return nil
+}
+
 func apply(configFile string,
 	probeAddr string,
 	enableLeaderElection bool,
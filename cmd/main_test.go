@@ -181,3 +181,59 @@ internalCertManagement:
 		})
 	}
 }
+
+func TestValidateLeaderElection(t *testing.T) {
+	testCases := []struct {
+		name    string
+		options ctrl.Options
+		wantErr bool
+	}{
+		{
+			name:    "defaults",
+			options: ctrl.Options{LeaderElection: true},
+		},
+		{
+			name: "valid durations",
+			options: ctrl.Options{
+				LeaderElection: true,
+				LeaseDuration:  ptr.To(60 * time.Second),
+				RenewDeadline:  ptr.To(40 * time.Second),
+				RetryPeriod:    ptr.To(5 * time.Second),
+			},
+		},
+		{
+			name: "lease duration not greater than renew deadline",
+			options: ctrl.Options{
+				LeaderElection: true,
+				LeaseDuration:  ptr.To(10 * time.Second),
+				RenewDeadline:  ptr.To(10 * time.Second),
+			},
+			wantErr: true,
+		},
+		{
+			name: "renew deadline not greater than retry period",
+			options: ctrl.Options{
+				LeaderElection: true,
+				RetryPeriod:    ptr.To(10 * time.Second),
+			},
+			wantErr: true,
+		},
+		{
+			name: "leader election disabled",
+			options: ctrl.Options{
+				LeaseDuration: ptr.To(5 * time.Minute),
+				RenewDeadline: ptr.To(5 * time.Minute),
+				RetryPeriod:   ptr.To(5 * time.Minute),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateLeaderElection(tc.options)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("unexpected error, want error: %t, got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	leaderworkerset "sigs.k8s.io/lws/api/leaderworkerset/v1"
+)
+
+// testScheme registers the types the controllers read and write, it's shared by the tests.
+var testScheme = func() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(leaderworkerset.AddToScheme(scheme))
+	utilruntime.Must(corev1.AddToScheme(scheme))
+	utilruntime.Must(appsv1.AddToScheme(scheme))
+	return scheme
+}()
+
+// newFakeClientBuilder returns a fake client builder with the testScheme.
+func newFakeClientBuilder() *fake.ClientBuilder {
+	return fake.NewClientBuilder().WithScheme(testScheme)
+}
+
+// lwsPods lists the pods of the lws, which Reconcile passes to its helpers.
+func lwsPods(t *testing.T, r *LeaderWorkerSetReconciler, lws *leaderworkerset.LeaderWorkerSet) []corev1.Pod {
+	t.Helper()
+	pods, err := r.listPods(context.TODO(), lws)
+	if err != nil {
+		t.Fatalf("Listing pods: %v", err)
+	}
+	return pods
+}
+
+// updateStatusWithPods updates the status of the lws with its pods, as Reconcile does.
+func updateStatusWithPods(r *LeaderWorkerSetReconciler, lws *leaderworkerset.LeaderWorkerSet, revisionKey string, specApplied bool) (bool, time.Duration, error) {
+	pods, err := r.listPods(context.TODO(), lws)
+	if err != nil {
+		return false, 0, err
+	}
+	return r.updateStatus(context.TODO(), lws, pods, revisionKey, specApplied)
+}
@@ -17,34 +17,51 @@ limitations under the License.
 package controllers
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
 	"fmt"
+	"hash/fnv"
+	"maps"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	appsapplyv1 "k8s.io/client-go/applyconfigurations/apps/v1"
 	coreapplyv1 "k8s.io/client-go/applyconfigurations/core/v1"
 	metaapplyv1 "k8s.io/client-go/applyconfigurations/meta/v1"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	leaderworkerset "sigs.k8s.io/lws/api/leaderworkerset/v1"
+	"sigs.k8s.io/lws/pkg/metrics"
 	"sigs.k8s.io/lws/pkg/utils"
 	controllerutils "sigs.k8s.io/lws/pkg/utils/controller"
 	podutils "sigs.k8s.io/lws/pkg/utils/pod"
 	revisionutils "sigs.k8s.io/lws/pkg/utils/revision"
+	rolloututils "sigs.k8s.io/lws/pkg/utils/rollout"
 	statefulsetutils "sigs.k8s.io/lws/pkg/utils/statefulset"
 )
 
@@ -53,6 +70,26 @@ type LeaderWorkerSetReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
 	Record record.EventRecorder
+	// DisableHeadlessService handles all the leaderWorkerSets as if their subdomainPolicy was None.
+	DisableHeadlessService bool
+	// UnschedulableTimeout is how long a pod of a group can be unschedulable before the
+	// GroupUnschedulable condition is set.
+	UnschedulableTimeout time.Duration
+	// DecisionLogVerbosity is the verbosity of the log line stating how each reconcile changes the groups.
+	DecisionLogVerbosity int
+	// StatusResyncPeriod bounds how long a reconcile is requeued for while a time-dependent status
+	// condition is pending, e.g. the minReadySeconds of a group, 0 means no bound.
+	StatusResyncPeriod time.Duration
+	// MaxConcurrentGroupCreates bounds how many groups a reconcile creates, the remaining ones
+	// are created by the following reconciles, 0 means no bound.
+	MaxConcurrentGroupCreates int32
+	Clock                     clock.Clock
+	// steadyFingerprints holds, by leaderworkerset, the fingerprint of the objects observed by the
+	// last reconcile which found all the groups ready at the current revision.
+	steadyFingerprints sync.Map
+	// affectedGroups holds, by leaderworkerset and condition type, the groups named by the condition
+	// at the last reconcile, so that an event is only emitted for the newly affected groups.
+	affectedGroups sync.Map
 }
 
 var (
@@ -62,6 +99,15 @@ var (
 const (
 	lwsOwnerKey  = ".metadata.controller"
 	fieldManager = "lws"
+	// maxGroupStatuses is the maximum number of groups tracked in the status, aligned with
+	// the validation of status.groupStatuses.
+	maxGroupStatuses = 1000
+	// DefaultUnschedulableTimeout is the default of UnschedulableTimeout.
+	DefaultUnschedulableTimeout = 5 * time.Minute
+	// DefaultDecisionLogVerbosity is the default of DecisionLogVerbosity.
+	DefaultDecisionLogVerbosity = 2
+	// DefaultStatusResyncPeriod is the default of StatusResyncPeriod.
+	DefaultStatusResyncPeriod = time.Minute
 )
 
 const (
@@ -71,13 +117,59 @@ const (
 	GroupsProgressing = "GroupsProgressing"
 	GroupsUpdating    = "GroupsUpdating"
 	CreatingRevision  = "CreatingRevision"
+	// GroupRecreated Event reason used when a group is deleted to be recreated
+	// because one of its pods restarted with RecreateGroupOnPodRestart policy.
+	GroupRecreated = "GroupRecreated"
+	// ReplicasClamped Event reason used when spec.replicas exceeds spec.maxReplicas
+	// and the controller only reconciles up to spec.maxReplicas groups.
+	ReplicasClamped = "ReplicasClamped"
+	// GroupUnschedulable Event reason used when a pod of a group has been unschedulable
+	// for longer than the unschedulable timeout.
+	GroupUnschedulable = "GroupUnschedulable"
+
+	// InsufficientCapacity Condition reason used when groups can't be scheduled for lack of
+	// capacity while at least minReplicas groups are kept.
+	InsufficientCapacity = "InsufficientCapacity"
+	// GroupFailed Event reason used when a container of a group terminated with an exit
+	// code matching the podFailurePolicy.
+	GroupFailed = "GroupFailed"
+	// GroupDeadlineExceeded Event reason used when the pods of a group are deleted because
+	// the group ran for longer than the activeDeadlineSeconds.
+	GroupDeadlineExceeded = "GroupDeadlineExceeded"
+	// DuplicateLeader Event reason used when more than one leader pod is found for a group
+	// index and the newer duplicates are deleted.
+	DuplicateLeader = "DuplicateLeader"
+	// StandbyGroupPromoted Event reason used when a standby group is promoted in place of
+	// a group serving the replicas which isn't ready.
+	StandbyGroupPromoted = "StandbyGroupPromoted"
+	// GroupRestarted Event reason used when a group is deleted to be recreated because
+	// its restart was requested by the restart-group annotation.
+	GroupRestarted = "GroupRestarted"
+	// WorkerIndexLabelRepaired Event reason used when the worker index label of a pod
+	// was missing or didn't match the pod name, and was patched back.
+	WorkerIndexLabelRepaired = "WorkerIndexLabelRepaired"
+	// RolloutStalled Event reason used when a group of the update revision didn't become
+	// ready within the progressDeadlineSeconds and the rollout is halted.
+	RolloutStalled = "RolloutStalled"
+	// ProgressDeadlineExceeded is the reason of the RolloutStalled condition.
+	ProgressDeadlineExceeded = "ProgressDeadlineExceeded"
+	// PodAdopted Event reason used when an orphan pod matching the selector and revision
+	// of a statefulset is adopted by it.
+	PodAdopted = "PodAdopted"
+	// PinnedRevisionNotFound Event reason used when a group is pinned by the pin-group
+	// annotation to a revision which doesn't exist, the annotation is ignored then.
+	PinnedRevisionNotFound = "PinnedRevisionNotFound"
 )
 
 func NewLeaderWorkerSetReconciler(client client.Client, scheme *runtime.Scheme, record record.EventRecorder) *LeaderWorkerSetReconciler {
 	return &LeaderWorkerSetReconciler{
-		Client: client,
-		Scheme: scheme,
-		Record: record,
+		Client:               client,
+		Scheme:               scheme,
+		Record:               record,
+		UnschedulableTimeout: DefaultUnschedulableTimeout,
+		DecisionLogVerbosity: DefaultDecisionLogVerbosity,
+		StatusResyncPeriod:   DefaultStatusResyncPeriod,
+		Clock:                clock.RealClock{},
 	}
 }
 
@@ -89,6 +181,7 @@ func NewLeaderWorkerSetReconciler(client client.Client, scheme *runtime.Scheme,
 //+kubebuilder:rbac:groups=apps,resources=statefulsets/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=apps,resources=statefulsets/finalizers,verbs=update
 //+kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;patch
 // +kubebuilder:rbac:groups=apps,resources=controllerrevisions,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=controllerrevisions/status,verbs=get;update;patch
@@ -98,17 +191,64 @@ func (r *LeaderWorkerSetReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	// Get leaderworkerset object
 	lws := &leaderworkerset.LeaderWorkerSet{}
 	if err := r.Get(ctx, types.NamespacedName{Name: req.Name, Namespace: req.Namespace}, lws); err != nil {
+		if apierrors.IsNotFound(err) {
+			metrics.LeaderWorkerSetDeleted(req.Namespace, req.Name)
+			r.steadyFingerprints.Delete(req.NamespacedName)
+			r.forgetAffectedGroups(req.NamespacedName)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 	log := ctrl.LoggerFrom(ctx).WithValues("leaderworkerset", klog.KObj(lws))
 	ctx = ctrl.LoggerInto(ctx, log)
 
+	// The pods are listed once and passed to the helpers, which only filter them.
+	pods, err := r.listPods(ctx, lws)
+	if err != nil {
+		log.Error(err, "Listing pods")
+		return ctrl.Result{}, err
+	}
+
+	// Nothing changed since the last reconcile found all the groups ready at the current revision,
+	// e.g. the event is the status update of that reconcile, skip the rest of the reconcile.
+	fingerprint, err := r.steadyFingerprint(ctx, lws, pods)
+	if err != nil {
+		log.Error(err, "Computing the fingerprint of the leaderworkerset")
+		return ctrl.Result{}, err
+	}
+	if last, found := r.steadyFingerprints.Load(req.NamespacedName); found && last == fingerprint {
+		log.V(2).Info("Skipping reconciliation of steady leaderworkerset")
+		return ctrl.Result{}, nil
+	}
+	r.steadyFingerprints.Delete(req.NamespacedName)
+
+	// The scale subresource bypasses the validation webhook, so replicas may still exceed
+	// maxReplicas, e.g. when set by HPA. Reconcile against the capped value in that case.
+	clampReplicas(lws, r.Record)
+	addStandbyReplicas(lws)
+
+	disableHeadlessService(lws, r.DisableHeadlessService)
+
 	leaderSts, err := r.getLeaderStatefulSet(ctx, lws)
 	if err != nil {
 		log.Error(err, "Fetching leader statefulset")
 		return ctrl.Result{}, err
 	}
 
+	if paused(lws) || suspended(lws) {
+		log.V(2).Info("Skipping reconciliation of paused or suspended leaderworkerset")
+		if err := r.updatePausedStatus(ctx, lws, leaderSts, pods); err != nil {
+			if apierrors.IsConflict(err) {
+				return ctrl.Result{Requeue: true}, nil
+			}
+			return ctrl.Result{}, err
+		}
+		if err := r.updateHealthAnnotation(ctx, lws); err != nil {
+			log.Error(err, "Updating health annotation")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
 	// Handles two cases:
 	// Case 1: Upgrading the LWS controller from a version that doesn't support controller revision
 	// Case 2: Creating the controller revision for a newly created LWS object
@@ -133,25 +273,86 @@ func (r *LeaderWorkerSetReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		r.Record.Eventf(lws, corev1.EventTypeNormal, CreatingRevision, fmt.Sprintf("Creating revision with key %s for updated LWS", revisionutils.GetRevisionKey(revision)))
 	}
 
-	partition, replicas, err := r.rollingUpdateParameters(ctx, lws, leaderSts, revisionutils.GetRevisionKey(revision), lwsUpdated)
+	start := desiredStartOrdinal(lws, leaderSts)
+	var partition, replicas int32
+	if lws.Spec.RolloutStrategy.Type == leaderworkerset.RecreateStrategyType {
+		partition, replicas, err = r.recreateParameters(ctx, lws, leaderSts, pods, lwsUpdated)
+	} else {
+		partition, replicas, err = r.rollingUpdateParameters(ctx, lws, leaderSts, pods, revisionutils.GetRevisionKey(revision), lwsUpdated, start)
+	}
 	if err != nil {
 		log.Error(err, "Rolling partition error")
 		return ctrl.Result{}, err
 	}
+	if leaderSts != nil && awaitingApproval(lws, revisionutils.GetRevisionKey(revision)) {
+		log.V(2).Info("Holding rollout until the update revision is approved", "revision", revisionutils.GetRevisionKey(revision))
+		partition, replicas = holdRollout(lws, leaderSts, start, partition, replicas)
+	}
+	// A new revision starts a new rollout, which isn't held by the groups of the stalled one.
+	if leaderSts != nil && !lwsUpdated && meta.IsStatusConditionTrue(lws.Status.Conditions, string(leaderworkerset.LeaderWorkerSetRolloutStalled)) {
+		log.V(2).Info("Holding stalled rollout", "revision", revisionutils.GetRevisionKey(revision))
+		partition, replicas = holdRollout(lws, leaderSts, start, partition, replicas)
+	}
+	// Keep the pinned groups at their revision, the rollout is held at the highest pinned group.
+	if leaderSts != nil && lws.Spec.RolloutStrategy.Type == leaderworkerset.RollingUpdateStrategyType {
+		pinned, err := r.pinnedPartition(ctx, lws, start, revisionutils.GetRevisionKey(revision))
+		if err != nil {
+			log.Error(err, "Computing the partition of the pinned groups")
+			return ctrl.Result{}, err
+		}
+		if pinned > partition {
+			log.V(2).Info("Holding rollout at the pinned groups", "partition", partition, "pinnedPartition", pinned)
+			partition = pinned
+		}
+	}
+
+	// Create the groups in batches, to avoid bursts of requests to the API server.
+	var createRequeueAfter time.Duration
+	if capped := capGroupCreates(leaderSts, replicas, r.MaxConcurrentGroupCreates); capped < replicas {
+		log.V(2).Info("Capping the groups created by this reconcile", "replicas", replicas, "cappedReplicas", capped)
+		replicas, createRequeueAfter = capped, groupCreatesRequeueAfter
+	}
+
+	// Hold the partition until the old groups to be replaced have been drained.
+	var drainRequeueAfter time.Duration
+	if leaderSts != nil && !lwsUpdated && partition < currentPartition(leaderSts, start) {
+		drainRequeueAfter, err = r.drainOldGroups(ctx, lws, start+partition, start+currentPartition(leaderSts, start), revisionutils.GetRevisionKey(revision))
+		if err != nil {
+			log.Error(err, "Draining old groups")
+			return ctrl.Result{}, err
+		}
+		if drainRequeueAfter > 0 {
+			partition = currentPartition(leaderSts, start)
+		}
+	}
+
+	// Hold the leader statefulset until the workers of the groups to be deleted have terminated.
+	var terminationRequeueAfter time.Duration
+	if leaderSts != nil && !lwsUpdated {
+		terminationRequeueAfter, err = r.terminateWorkersFirst(ctx, lws, leaderSts, pods, start, partition, replicas, revisionutils.GetRevisionKey(revision))
+		if err != nil {
+			log.Error(err, "Terminating workers of deleted groups")
+			return ctrl.Result{}, err
+		}
+	}
 
-	if err := r.SSAWithStatefulset(ctx, lws, partition, replicas, revisionutils.GetRevisionKey(revision)); err != nil {
+	if terminationRequeueAfter > 0 {
+		log.V(2).Info("Holding leader statefulset until the workers of the deleted groups have terminated", "requeueAfter", terminationRequeueAfter)
+		start, partition, replicas = startOrdinal(leaderSts), currentPartition(leaderSts, startOrdinal(leaderSts)), *leaderSts.Spec.Replicas
+	} else if err := r.SSAWithStatefulset(ctx, lws, start, partition, replicas, revisionutils.GetRevisionKey(revision)); err != nil {
 		if leaderSts == nil {
-			r.Record.Eventf(lws, corev1.EventTypeWarning, FailedCreate, fmt.Sprintf("Failed to create leader statefulset %s", lws.Name))
+			r.Record.Eventf(lws, corev1.EventTypeWarning, FailedCreate, fmt.Sprintf("Failed to create leader statefulset %s", controllerutils.LeaderStatefulSetName(lws)))
 		}
 		return ctrl.Result{}, err
 	}
+	r.logDecision(ctx, makeReconcileDecision(lws, leaderSts, start, partition, replicas, lwsUpdated))
 
 	if leaderSts == nil {
 		// An event is logged to track sts creation.
-		r.Record.Eventf(lws, corev1.EventTypeNormal, GroupsProgressing, fmt.Sprintf("Created leader statefulset %s", lws.Name))
-	} else if !lwsUpdated && partition != *leaderSts.Spec.UpdateStrategy.RollingUpdate.Partition {
+		r.Record.Eventf(lws, corev1.EventTypeNormal, GroupsProgressing, fmt.Sprintf("Created leader statefulset %s", controllerutils.LeaderStatefulSetName(lws)))
+	} else if !lwsUpdated && partition != currentPartition(leaderSts, start) {
 		// An event is logged to track update progress.
-		r.Record.Eventf(lws, corev1.EventTypeNormal, GroupsUpdating, fmt.Sprintf("Updating replicas %d to %d", *leaderSts.Spec.UpdateStrategy.RollingUpdate.Partition, partition))
+		r.Record.Eventf(lws, corev1.EventTypeNormal, GroupsUpdating, fmt.Sprintf("Updating replicas %d to %d", start+currentPartition(leaderSts, start), start+partition))
 	}
 
 	// Create headless service if it does not exist.
@@ -162,7 +363,25 @@ func (r *LeaderWorkerSetReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, err
 	}
 
-	updateDone, err := r.updateStatus(ctx, lws, revisionutils.GetRevisionKey(revision))
+	if err := r.reconcilePerGroupServices(ctx, lws, start, replicas); err != nil {
+		log.Error(err, "Reconciling per group services")
+		return ctrl.Result{}, err
+	}
+
+	if err := r.reconcileMembershipConfigMaps(ctx, lws, pods, start, replicas); err != nil {
+		log.Error(err, "Reconciling membership configmaps")
+		return ctrl.Result{}, err
+	}
+
+	if err := r.restartRequestedGroups(ctx, lws); err != nil {
+		if apierrors.IsConflict(err) {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		log.Error(err, "Restarting requested groups")
+		return ctrl.Result{}, err
+	}
+
+	updateDone, statusRequeueAfter, err := r.updateStatus(ctx, lws, pods, revisionutils.GetRevisionKey(revision), true)
 	if err != nil {
 		if apierrors.IsConflict(err) {
 			return ctrl.Result{Requeue: true}, nil
@@ -175,8 +394,271 @@ func (r *LeaderWorkerSetReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 			return ctrl.Result{}, err
 		}
 	}
+	if err := r.updateHealthAnnotation(ctx, lws); err != nil {
+		log.Error(err, "Updating health annotation")
+		return ctrl.Result{}, err
+	}
 	log.V(2).Info("Leader Reconcile completed.")
-	return ctrl.Result{}, nil
+	requeueAfter := shorterRequeueAfter(drainRequeueAfter, terminationRequeueAfter)
+	requeueAfter = shorterRequeueAfter(requeueAfter, createRequeueAfter)
+	requeueAfter = shorterRequeueAfter(requeueAfter, boundedStatusRequeueAfter(statusRequeueAfter, r.StatusResyncPeriod))
+	if requeueAfter == 0 && !lwsUpdated && steady(lws, revisionutils.GetRevisionKey(revision)) {
+		r.steadyFingerprints.Store(req.NamespacedName, fingerprint)
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// steady returns true if all the groups are ready and updated to revisionKey, and the status
+// reflects the latest spec. The replicas are expected to include the standby groups, see
+// addStandbyReplicas, which aren't counted in the ready replicas.
+func steady(lws *leaderworkerset.LeaderWorkerSet, revisionKey string) bool {
+	replicas := *lws.Spec.Replicas
+	return lws.Status.ObservedGeneration == lws.Generation &&
+		lws.Status.CurrentRevision == revisionKey && lws.Status.UpdateRevision == revisionKey &&
+		lws.Status.Replicas == replicas && lws.Status.UpdatedReplicas == replicas &&
+		lws.Status.ReadyReplicas == replicas-ptr.Deref(lws.Spec.StandbyReplicas, 0)
+}
+
+// steadyFingerprint returns a fingerprint of the resource versions of the leaderworkerset and of
+// the statefulsets, services and configmaps of its groups, and of the names of its leader pods among pods. The
+// readiness of the pods is tracked by the status of their statefulsets, so the fingerprint changes
+// whenever a pod is deleted or its readiness changes, and the leader pod names whenever a duplicate
+// leader shows up, which the statefulsets don't track.
+func (r *LeaderWorkerSetReconciler) steadyFingerprint(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet, pods []corev1.Pod) (string, error) {
+	selector := []client.ListOption{client.InNamespace(lws.Namespace), client.MatchingLabels{leaderworkerset.SetNameLabelKey: lws.Name}}
+	versions := []string{"LeaderWorkerSet/" + lws.Name + "/" + lws.ResourceVersion}
+	var stsList appsv1.StatefulSetList
+	if err := r.List(ctx, &stsList, selector...); err != nil {
+		return "", err
+	}
+	for _, sts := range stsList.Items {
+		versions = append(versions, "StatefulSet/"+sts.Name+"/"+sts.ResourceVersion)
+	}
+	var serviceList corev1.ServiceList
+	if err := r.List(ctx, &serviceList, selector...); err != nil {
+		return "", err
+	}
+	for _, service := range serviceList.Items {
+		versions = append(versions, "Service/"+service.Name+"/"+service.ResourceVersion)
+	}
+	var configMapList corev1.ConfigMapList
+	if err := r.List(ctx, &configMapList, selector...); err != nil {
+		return "", err
+	}
+	for _, configMap := range configMapList.Items {
+		versions = append(versions, "ConfigMap/"+configMap.Name+"/"+configMap.ResourceVersion)
+	}
+	for _, pod := range podutils.LeaderPods(pods) {
+		versions = append(versions, "Pod/"+pod.Name)
+	}
+	slices.Sort(versions)
+	hash := fnv.New64a()
+	for _, version := range versions {
+		hash.Write([]byte(version + "\n"))
+	}
+	return strconv.FormatUint(hash.Sum64(), 16), nil
+}
+
+// groupCreatesRequeueAfter is how long a reconcile which capped the groups it created is requeued
+// after, to create the next ones.
+const groupCreatesRequeueAfter = time.Second
+
+// capGroupCreates caps the replicas of the leader statefulset so that at most maxCreates groups are
+// created on top of its current replicas, 0 meaning no cap.
+func capGroupCreates(leaderSts *appsv1.StatefulSet, replicas, maxCreates int32) int32 {
+	if maxCreates <= 0 {
+		return replicas
+	}
+	var currentReplicas int32
+	if leaderSts != nil {
+		currentReplicas = ptr.Deref(leaderSts.Spec.Replicas, 0)
+	}
+	return min(replicas, currentReplicas+maxCreates)
+}
+
+// clampReplicas caps the replicas so that, together with the standby groups, they don't exceed
+// maxReplicas.
+func clampReplicas(lws *leaderworkerset.LeaderWorkerSet, recorder record.EventRecorder) {
+	if lws.Spec.MaxReplicas == nil {
+		return
+	}
+	standby := ptr.Deref(lws.Spec.StandbyReplicas, 0)
+	maxReplicas := max(0, *lws.Spec.MaxReplicas-standby)
+	if *lws.Spec.Replicas <= maxReplicas {
+		return
+	}
+	if standby > 0 {
+		recorder.Eventf(lws, corev1.EventTypeWarning, ReplicasClamped, fmt.Sprintf("Replicas %d plus standbyReplicas %d exceeds maxReplicas %d, clamping to %d", *lws.Spec.Replicas, standby, *lws.Spec.MaxReplicas, maxReplicas))
+	} else {
+		recorder.Eventf(lws, corev1.EventTypeWarning, ReplicasClamped, fmt.Sprintf("Replicas %d exceeds maxReplicas %d, clamping to %d", *lws.Spec.Replicas, *lws.Spec.MaxReplicas, maxReplicas))
+	}
+	lws.Spec.Replicas = ptr.To(maxReplicas)
+}
+
+// addStandbyReplicas adds the standby groups to the replicas, from then on they're created, updated
+// and deleted as any other group, only the status tells them apart.
+func addStandbyReplicas(lws *leaderworkerset.LeaderWorkerSet) {
+	if standby := ptr.Deref(lws.Spec.StandbyReplicas, 0); standby > 0 {
+		lws.Spec.Replicas = ptr.To(*lws.Spec.Replicas + standby)
+	}
+}
+
+// Reasons of the reconcile decisions.
+const (
+	decisionNoChange = "NoChange"
+	decisionScale    = "Scale"
+	decisionRollout  = "Rollout"
+	decisionRecreate = "Recreate"
+)
+
+// reconcileDecision is how a reconcile changes the groups through the leader statefulset.
+type reconcileDecision struct {
+	// Created is the number of groups created by raising the replicas.
+	Created int32
+	// Deleted is the number of groups deleted by lowering the replicas.
+	Deleted int32
+	// Updated is the number of groups released for update by lowering the partition.
+	Updated int32
+	// Reason is why the groups are changed, one of Scale, Rollout, Recreate or NoChange.
+	Reason string
+}
+
+// makeReconcileDecision compares the leader statefulset before the reconcile with the partition and
+// replicas applied to it.
+func makeReconcileDecision(lws *leaderworkerset.LeaderWorkerSet, leaderSts *appsv1.StatefulSet, start, partition, replicas int32, lwsUpdated bool) reconcileDecision {
+	if leaderSts == nil {
+		decision := reconcileDecision{Created: replicas, Reason: decisionNoChange}
+		if replicas > 0 {
+			decision.Reason = decisionScale
+		}
+		return decision
+	}
+	currentReplicas := ptr.Deref(leaderSts.Spec.Replicas, 0)
+	decision := reconcileDecision{
+		Created: max(replicas-currentReplicas, 0),
+		Deleted: max(currentReplicas-replicas, 0),
+	}
+	if !lwsUpdated {
+		decision.Updated = max(currentPartition(leaderSts, start)-partition, 0)
+	}
+	switch {
+	case lws.Spec.RolloutStrategy.Type == leaderworkerset.RecreateStrategyType && (lwsUpdated || recreating(lws, leaderSts)):
+		decision.Reason = decisionRecreate
+	case lwsUpdated || decision.Updated > 0:
+		decision.Reason = decisionRollout
+	case decision.Created > 0 || decision.Deleted > 0:
+		decision.Reason = decisionScale
+	default:
+		decision.Reason = decisionNoChange
+	}
+	return decision
+}
+
+// logDecision logs the decision of the reconcile at the DecisionLogVerbosity.
+func (r *LeaderWorkerSetReconciler) logDecision(ctx context.Context, decision reconcileDecision) {
+	ctrl.LoggerFrom(ctx).V(r.DecisionLogVerbosity).Info("Reconcile decision",
+		"reason", decision.Reason, "created", decision.Created, "deleted", decision.Deleted, "updated", decision.Updated)
+}
+
+// paused returns true if the reconciliation of the lws is paused by the paused annotation.
+func paused(lws *leaderworkerset.LeaderWorkerSet) bool {
+	return lws.Annotations[leaderworkerset.PausedAnnotationKey] == "true"
+}
+
+// suspended returns true if the lws is suspended by spec.suspend, no group or pod is created then.
+func suspended(lws *leaderworkerset.LeaderWorkerSet) bool {
+	return ptr.Deref(lws.Spec.Suspend, false)
+}
+
+// setHaltConditions sets the Paused and Suspended conditions of the lws, and returns true if either
+// of them changed.
+func setHaltConditions(lws *leaderworkerset.LeaderWorkerSet) bool {
+	pausedCondition := makeCondition(leaderworkerset.LeaderWorkerSetPaused)
+	if !paused(lws) {
+		pausedCondition.Status = metav1.ConditionFalse
+		pausedCondition.Reason = "Resumed"
+		pausedCondition.Message = "Reconciliation is resumed"
+	}
+	suspendedCondition := makeCondition(leaderworkerset.LeaderWorkerSetSuspended)
+	if !suspended(lws) {
+		suspendedCondition.Status = metav1.ConditionFalse
+		suspendedCondition.Reason = "Resumed"
+		suspendedCondition.Message = "LeaderWorkerSet is not suspended"
+	}
+	pausedChanged := setCondition(lws, pausedCondition)
+	suspendedChanged := setCondition(lws, suspendedCondition)
+	return pausedChanged || suspendedChanged
+}
+
+// updatePausedStatus only updates the status of a paused or suspended lws. No revision is created
+// then, so the status is computed against the revision of the leader statefulset.
+func (r *LeaderWorkerSetReconciler) updatePausedStatus(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet, leaderSts *appsv1.StatefulSet, pods []corev1.Pod) error {
+	if leaderSts == nil {
+		if !setHaltConditions(lws) {
+			return nil
+		}
+		return r.writeStatus(ctx, lws)
+	}
+	_, _, err := r.updateStatus(ctx, lws, pods, revisionutils.GetRevisionKey(leaderSts), false)
+	return err
+}
+
+// writeStatus updates the status of the lws through a copy, so that the response doesn't reset the
+// in-memory spec the reconcile works with, e.g. the replicas including the standby groups, see
+// addStandbyReplicas. Only the status and the resource version are taken from the response.
+func (r *LeaderWorkerSetReconciler) writeStatus(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet) error {
+	updated := lws.DeepCopy()
+	if err := r.Status().Update(ctx, updated); err != nil {
+		return err
+	}
+	lws.ResourceVersion = updated.ResourceVersion
+	lws.Status = updated.Status
+	return nil
+}
+
+// updateHealthAnnotation sets the health annotation of the lws from its status. It's only patched
+// when the value changes, so that the reconcile triggered by the patch doesn't patch it again.
+// The patch is sent through a copy, for the same reason as writeStatus.
+func (r *LeaderWorkerSetReconciler) updateHealthAnnotation(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet) error {
+	value := health(lws)
+	if lws.Annotations[leaderworkerset.HealthAnnotationKey] == value {
+		return nil
+	}
+	patched := lws.DeepCopy()
+	if patched.Annotations == nil {
+		patched.Annotations = map[string]string{}
+	}
+	patched.Annotations[leaderworkerset.HealthAnnotationKey] = value
+	if err := r.Patch(ctx, patched, client.MergeFrom(lws)); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	lws.ResourceVersion = patched.ResourceVersion
+	lws.Annotations = patched.Annotations
+	return nil
+}
+
+// health returns the aggregate health of the lws: Unhealthy when none of the replicas are ready,
+// Healthy when all of them are and no pod is crash looping, Degraded otherwise. The standby groups
+// added to the in-memory replicas aren't counted in the ready replicas, so they're left out.
+func health(lws *leaderworkerset.LeaderWorkerSet) string {
+	replicas := *lws.Spec.Replicas - ptr.Deref(lws.Spec.StandbyReplicas, 0)
+	switch {
+	case replicas > 0 && lws.Status.ReadyReplicas == 0:
+		return leaderworkerset.HealthUnhealthy
+	case lws.Status.ReadyReplicas >= replicas && lws.Status.CrashingPods == 0:
+		return leaderworkerset.HealthHealthy
+	default:
+		return leaderworkerset.HealthDegraded
+	}
+}
+
+// disableHeadlessService overrides the subdomainPolicy of the in-memory lws with None when headless
+// services are disabled for the whole controller.
+func disableHeadlessService(lws *leaderworkerset.LeaderWorkerSet, disabled bool) {
+	if disabled {
+		perGroupService := lws.Spec.NetworkConfig != nil && lws.Spec.NetworkConfig.PerGroupService
+		lws.Spec.NetworkConfig = &leaderworkerset.NetworkConfig{SubdomainPolicy: ptr.To(leaderworkerset.SubdomainNone), PerGroupService: perGroupService}
+	}
 }
 
 func (r *LeaderWorkerSetReconciler) reconcileHeadlessServices(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet) error {
@@ -189,12 +671,279 @@ func (r *LeaderWorkerSetReconciler) reconcileHeadlessServices(ctx context.Contex
 	return nil
 }
 
+// reconcilePerGroupServices creates or updates a ClusterIP service for each group in [start, start+replicas)
+// when perGroupService is enabled, and deletes the services of the groups out of that range.
+func (r *LeaderWorkerSetReconciler) reconcilePerGroupServices(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet, start, replicas int32) error {
+	log := ctrl.LoggerFrom(ctx)
+	enabled := lws.Spec.NetworkConfig != nil && lws.Spec.NetworkConfig.PerGroupService
+
+	desired := sets.New[string]()
+	if enabled {
+		ports := perGroupServicePorts(lws)
+		for i := start; i < start+replicas; i++ {
+			service, err := r.constructPerGroupService(lws, strconv.Itoa(int(i)), ports)
+			if err != nil {
+				return err
+			}
+			desired.Insert(service.Name)
+
+			var existing corev1.Service
+			if err := r.Get(ctx, types.NamespacedName{Name: service.Name, Namespace: lws.Namespace}, &existing); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return err
+				}
+				log.V(2).Info("Creating per group service", "service", klog.KObj(service))
+				if err := r.Create(ctx, service); err != nil {
+					return err
+				}
+				continue
+			}
+			if !metav1.IsControlledBy(&existing, lws) {
+				return fmt.Errorf("service %s already exists and is not controlled by the leaderworkerset", service.Name)
+			}
+			if equality.Semantic.DeepEqual(existing.Spec.Selector, service.Spec.Selector) && equality.Semantic.DeepEqual(existing.Spec.Ports, service.Spec.Ports) {
+				continue
+			}
+			existing.Spec.Selector = service.Spec.Selector
+			existing.Spec.Ports = service.Spec.Ports
+			log.V(2).Info("Updating per group service", "service", klog.KObj(&existing))
+			if err := r.Update(ctx, &existing); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Delete the services of the groups that have been scaled down, or all of them once perGroupService is disabled.
+	var services corev1.ServiceList
+	if err := r.List(ctx, &services, client.InNamespace(lws.Namespace), client.MatchingLabels{leaderworkerset.SetNameLabelKey: lws.Name}, client.HasLabels{leaderworkerset.GroupIndexLabelKey}); err != nil {
+		return err
+	}
+	for i := range services.Items {
+		service := &services.Items[i]
+		if desired.Has(service.Name) || !metav1.IsControlledBy(service, lws) {
+			continue
+		}
+		log.V(2).Info("Deleting per group service", "service", klog.KObj(service))
+		if err := r.Delete(ctx, service); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// constructPerGroupService returns the ClusterIP service of the group, named <lws>-<groupIndex> and
+// selecting the leader pod of the group.
+func (r *LeaderWorkerSetReconciler) constructPerGroupService(lws *leaderworkerset.LeaderWorkerSet, groupIndex string, ports []corev1.ServicePort) (*corev1.Service, error) {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", lws.Name, groupIndex),
+			Namespace: lws.Namespace,
+			Labels: map[string]string{
+				leaderworkerset.SetNameLabelKey:    lws.Name,
+				leaderworkerset.GroupIndexLabelKey: groupIndex,
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+			Selector: map[string]string{
+				leaderworkerset.SetNameLabelKey:     lws.Name,
+				leaderworkerset.GroupIndexLabelKey:  groupIndex,
+				leaderworkerset.WorkerIndexLabelKey: "0",
+			},
+			Ports: ports,
+		},
+	}
+	if err := ctrl.SetControllerReference(lws, service, r.Scheme); err != nil {
+		return nil, err
+	}
+	return service, nil
+}
+
+// reconcileMembershipConfigMaps creates or updates the membership ConfigMap of each group in
+// [start, start+replicas) when publishMembershipConfigMap is enabled, and deletes the ConfigMaps
+// of the groups out of that range.
+func (r *LeaderWorkerSetReconciler) reconcileMembershipConfigMaps(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet, pods []corev1.Pod, start, replicas int32) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	desired := sets.New[string]()
+	if lws.Spec.LeaderWorkerTemplate.PublishMembershipConfigMap {
+		sizes, err := r.groupSizes(ctx, lws, pods)
+		if err != nil {
+			return err
+		}
+		for i := start; i < start+replicas; i++ {
+			groupIndex := strconv.Itoa(int(i))
+			configMap, err := r.constructMembershipConfigMap(lws, groupIndex, groupSize(lws, sizes, groupIndex))
+			if err != nil {
+				return err
+			}
+			desired.Insert(configMap.Name)
+
+			var existing corev1.ConfigMap
+			if err := r.Get(ctx, types.NamespacedName{Name: configMap.Name, Namespace: lws.Namespace}, &existing); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return err
+				}
+				log.V(2).Info("Creating membership configmap", "configmap", klog.KObj(configMap))
+				if err := r.Create(ctx, configMap); err != nil {
+					return err
+				}
+				continue
+			}
+			if !metav1.IsControlledBy(&existing, lws) {
+				return fmt.Errorf("configmap %s already exists and is not controlled by the leaderworkerset", configMap.Name)
+			}
+			if equality.Semantic.DeepEqual(existing.Data, configMap.Data) {
+				continue
+			}
+			existing.Data = configMap.Data
+			log.V(2).Info("Updating membership configmap", "configmap", klog.KObj(&existing))
+			if err := r.Update(ctx, &existing); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Delete the configmaps of the groups that have been scaled down, or all of them once publishMembershipConfigMap is disabled.
+	var configMaps corev1.ConfigMapList
+	if err := r.List(ctx, &configMaps, client.InNamespace(lws.Namespace), client.MatchingLabels{leaderworkerset.SetNameLabelKey: lws.Name}, client.HasLabels{leaderworkerset.GroupIndexLabelKey}); err != nil {
+		return err
+	}
+	for i := range configMaps.Items {
+		configMap := &configMaps.Items[i]
+		if desired.Has(configMap.Name) || !metav1.IsControlledBy(configMap, lws) {
+			continue
+		}
+		log.V(2).Info("Deleting membership configmap", "configmap", klog.KObj(configMap))
+		if err := r.Delete(ctx, configMap); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// groupSizes returns the size of the groups by group index, read from the revision of their leader
+// pod among pods, since the groups not updated yet keep the size of the revision they run. The groups without a
+// leader pod or a revision are left out.
+func (r *LeaderWorkerSetReconciler) groupSizes(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet, pods []corev1.Pod) (map[string]int32, error) {
+	leaderPods := podutils.LeaderPods(pods)
+	sizes := make(map[string]int32, len(leaderPods))
+	revisionSizes := map[string]int32{}
+	for i := range leaderPods {
+		pod := &leaderPods[i]
+		revisionKey := revisionutils.GetRevisionKey(pod)
+		size, found := revisionSizes[revisionKey]
+		if !found {
+			revision, err := revisionutils.GetRevision(ctx, r.Client, lws, revisionKey)
+			if err != nil {
+				return nil, err
+			}
+			if revision == nil {
+				continue
+			}
+			revisionLws, err := revisionutils.ApplyRevision(lws, revision)
+			if err != nil {
+				return nil, err
+			}
+			size = *revisionLws.Spec.LeaderWorkerTemplate.Size
+			revisionSizes[revisionKey] = size
+		}
+		sizes[pod.Labels[leaderworkerset.GroupIndexLabelKey]] = size
+	}
+	return sizes, nil
+}
+
+// groupSize returns the size of the group from sizes, see groupSizes, or the size of the lws for the
+// groups left out of them.
+func groupSize(lws *leaderworkerset.LeaderWorkerSet, sizes map[string]int32, groupIndex string) int32 {
+	if size, found := sizes[groupIndex]; found {
+		return size
+	}
+	return *lws.Spec.LeaderWorkerTemplate.Size
+}
+
+// constructMembershipConfigMap returns the membership ConfigMap of the group, with the addresses
+// of the pods of the group and the group size. The addresses only depend on the group index, so
+// the ConfigMap stays the same when the group is recreated with the same size.
+func (r *LeaderWorkerSetReconciler) constructMembershipConfigMap(lws *leaderworkerset.LeaderWorkerSet, groupIndex string, groupSize int32) (*corev1.ConfigMap, error) {
+	leaderName := fmt.Sprintf("%s-%s", controllerutils.LeaderStatefulSetName(lws), groupIndex)
+	subdomain := lws.Name
+	if lws.Spec.NetworkConfig != nil && lws.Spec.NetworkConfig.SubdomainPolicy != nil {
+		switch *lws.Spec.NetworkConfig.SubdomainPolicy {
+		case leaderworkerset.SubdomainUniquePerReplica:
+			subdomain = leaderName
+		case leaderworkerset.SubdomainNone:
+			subdomain = ""
+		}
+	}
+	size := int(groupSize)
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      controllerutils.MembershipConfigMapName(lws.Name, groupIndex),
+			Namespace: lws.Namespace,
+			Labels: map[string]string{
+				leaderworkerset.SetNameLabelKey:    lws.Name,
+				leaderworkerset.GroupIndexLabelKey: groupIndex,
+			},
+		},
+		Data: map[string]string{
+			leaderworkerset.MembershipHostsKey: strings.Join(podutils.PeerAddresses(leaderName, subdomain, lws.Namespace, size), "\n") + "\n",
+			leaderworkerset.MembershipSizeKey:  strconv.Itoa(size),
+		},
+	}
+	if err := ctrl.SetControllerReference(lws, configMap, r.Scheme); err != nil {
+		return nil, err
+	}
+	return configMap, nil
+}
+
+// addMembershipVolume adds the volume of the membership ConfigMap to the pod template, so that
+// the containers can mount it. The volume is pointed at the ConfigMap of the group of each pod by
+// the pod webhook, since the leader statefulset is shared by all the groups.
+func addMembershipVolume(podTemplateApplyConfiguration *coreapplyv1.PodTemplateSpecApplyConfiguration, configMapName string) {
+	if podTemplateApplyConfiguration.Spec == nil {
+		podTemplateApplyConfiguration.WithSpec(coreapplyv1.PodSpec())
+	}
+	podTemplateApplyConfiguration.Spec.WithVolumes(coreapplyv1.Volume().
+		WithName(leaderworkerset.MembershipVolumeName).
+		WithConfigMap(coreapplyv1.ConfigMapVolumeSource().WithName(configMapName)))
+}
+
+// perGroupServicePorts returns a service port for each container port of the leader template,
+// named after the container port, or <protocol>-<port> when the container port is unnamed.
+func perGroupServicePorts(lws *leaderworkerset.LeaderWorkerSet) []corev1.ServicePort {
+	podSpec := lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec
+	if lws.Spec.LeaderWorkerTemplate.LeaderTemplate != nil {
+		podSpec = lws.Spec.LeaderWorkerTemplate.LeaderTemplate.Spec
+	}
+	var ports []corev1.ServicePort
+	names := sets.New[string]()
+	for _, container := range podSpec.Containers {
+		for _, containerPort := range container.Ports {
+			protocol := cmp.Or(containerPort.Protocol, corev1.ProtocolTCP)
+			name := cmp.Or(containerPort.Name, fmt.Sprintf("%s-%d", strings.ToLower(string(protocol)), containerPort.ContainerPort))
+			if names.Has(name) {
+				continue
+			}
+			names.Insert(name)
+			ports = append(ports, corev1.ServicePort{
+				Name:       name,
+				Protocol:   protocol,
+				Port:       containerPort.ContainerPort,
+				TargetPort: intstr.FromInt32(containerPort.ContainerPort),
+			})
+		}
+	}
+	return ports
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *LeaderWorkerSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&leaderworkerset.LeaderWorkerSet{}).
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&corev1.Service{}).
+		Owns(&corev1.ConfigMap{}).
 		Watches(&appsv1.StatefulSet{},
 			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, a client.Object) []reconcile.Request {
 				return []reconcile.Request{
@@ -204,9 +953,51 @@ func (r *LeaderWorkerSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
 					}},
 				}
 			})).
+		// Pods entering or leaving CrashLoopBackOff don't necessarily change the statefulset status,
+		// watch them to keep status.crashingPods up to date, and the pods becoming the leader of a
+		// group to catch duplicate leaders.
+		Watches(&corev1.Pod{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, a client.Object) []reconcile.Request {
+				return []reconcile.Request{
+					{NamespacedName: types.NamespacedName{
+						Name:      a.GetLabels()[leaderworkerset.SetNameLabelKey],
+						Namespace: a.GetNamespace(),
+					}},
+				}
+			}), builder.WithPredicates(predicate.Funcs{
+				CreateFunc: func(e event.CreateEvent) bool {
+					return e.Object.GetLabels()[leaderworkerset.SetNameLabelKey] != "" && leaderGroupIndex(e.Object) != ""
+				},
+				DeleteFunc:  func(e event.DeleteEvent) bool { return crashLooping(e.Object) },
+				GenericFunc: func(event.GenericEvent) bool { return false },
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					return e.ObjectNew.GetLabels()[leaderworkerset.SetNameLabelKey] != "" &&
+						(crashLooping(e.ObjectOld) != crashLooping(e.ObjectNew) || leaderGroupIndex(e.ObjectOld) != leaderGroupIndex(e.ObjectNew) ||
+							retainedAsFailed(e.ObjectOld) != retainedAsFailed(e.ObjectNew))
+				},
+			})).
 		Complete(r)
 }
 
+func crashLooping(obj client.Object) bool {
+	pod, ok := obj.(*corev1.Pod)
+	return ok && pod.Labels[leaderworkerset.SetNameLabelKey] != "" && podutils.CrashLooping(*pod)
+}
+
+// leaderGroupIndex returns the group index of a leader pod, and an empty string for the other pods.
+func leaderGroupIndex(obj client.Object) string {
+	if obj.GetLabels()[leaderworkerset.WorkerIndexLabelKey] != "0" {
+		return ""
+	}
+	return obj.GetLabels()[leaderworkerset.GroupIndexLabelKey]
+}
+
+// retainedAsFailed returns true when the pod is the leader of a group retained as failed.
+func retainedAsFailed(obj client.Object) bool {
+	_, ok := obj.GetAnnotations()[leaderworkerset.GroupFailedAnnotationKey]
+	return ok && leaderGroupIndex(obj) != ""
+}
+
 func SetupIndexes(indexer client.FieldIndexer) error {
 	return indexer.IndexField(context.Background(), &appsv1.StatefulSet{}, lwsOwnerKey, func(rawObj client.Object) []string {
 		// grab the statefulSet object, extract the owner...
@@ -225,8 +1016,7 @@ func SetupIndexes(indexer client.FieldIndexer) error {
 }
 
 // Rolling update will always wait for the former replica to be ready then process the next one,
-// we didn't consider rollout strategy type here since we only support rollingUpdate now,
-// once we have more policies, we should update the logic here.
+// this is only used for the RollingUpdate rollout strategy type, see recreateParameters for Recreate.
 // Possible scenarios for Partition:
 //   - When sts is under creation, partition is always 0 because pods are created in parallel, rolling update is not relevant here.
 //   - When sts is in rolling update, the partition will start from the last index to the index 0 processing in maxUnavailable step.
@@ -246,7 +1036,9 @@ func SetupIndexes(indexer client.FieldIndexer) error {
 //   - Otherwise, Replicas is equal to spec.Replicas
 //   - One exception here is when unready replicas of leaderWorkerSet is equal to MaxSurge,
 //     we should reclaim the extra replicas gradually to accommodate for the new replicas.
-func (r *LeaderWorkerSetReconciler) rollingUpdateParameters(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet, sts *appsv1.StatefulSet, revisionKey string, leaderWorkerSetUpdated bool) (int32, int32, error) {
+//
+// start is the start ordinal the leader statefulset is reconciled with, the returned partition is relative to it.
+func (r *LeaderWorkerSetReconciler) rollingUpdateParameters(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet, sts *appsv1.StatefulSet, pods []corev1.Pod, revisionKey string, leaderWorkerSetUpdated bool, start int32) (int32, int32, error) {
 	log := ctrl.LoggerFrom(ctx).WithValues("leaderworkerset", klog.KObj(lws))
 	ctx = ctrl.LoggerInto(ctx, log)
 	lwsReplicas := *lws.Spec.Replicas
@@ -259,24 +1051,25 @@ func (r *LeaderWorkerSetReconciler) rollingUpdateParameters(ctx context.Context,
 	}
 
 	stsReplicas := *sts.Spec.Replicas
-	maxSurge, err := intstr.GetScaledValueFromIntOrPercent(&lws.Spec.RolloutStrategy.RollingUpdateConfiguration.MaxSurge, int(lwsReplicas), true)
+	maxSurge, err := rolloututils.MaxSurge(lws.Spec.RolloutStrategy.RollingUpdateConfiguration, lwsReplicas)
 	if err != nil {
 		return 0, 0, err
 	}
-	// No need to burst more than the replicas.
-	if maxSurge > int(lwsReplicas) {
-		maxSurge = int(lwsReplicas)
+	burstReplicas := lwsReplicas + maxSurge
+
+	// Groups below the partition of the rollout strategy stay on their current revision.
+	partitioned := func(partition int32) int32 {
+		return max(partition, rolloutPartition(lws))
 	}
-	burstReplicas := lwsReplicas + int32(maxSurge)
 
 	// wantReplicas calculates the final replicas if needed.
 	wantReplicas := func(unreadyReplicas int32) int32 {
-		if unreadyReplicas <= int32(maxSurge) {
+		if unreadyReplicas <= maxSurge {
 			// When we have n unready replicas and n bursted replicas, we should
 			// start to release the burst replica gradually for the accommodation of
 			// the unready ones.
 			finalReplicas := lwsReplicas + utils.NonZeroValue(int32(unreadyReplicas)-1)
-			r.Record.Eventf(lws, corev1.EventTypeNormal, GroupsProgressing, fmt.Sprintf("deleting surge replica %s-%d", lws.Name, finalReplicas))
+			r.Record.Eventf(lws, corev1.EventTypeNormal, GroupsProgressing, fmt.Sprintf("deleting surge replica %s-%d", controllerutils.LeaderStatefulSetName(lws), start+finalReplicas))
 			return finalReplicas
 		}
 		return burstReplicas
@@ -286,229 +1079,1549 @@ func (r *LeaderWorkerSetReconciler) rollingUpdateParameters(ctx context.Context,
 	// Indicates a new rolling update here.
 	if leaderWorkerSetUpdated {
 		// Processing scaling up/down first prior to rolling update.
-		return min(lwsReplicas, stsReplicas), wantReplicas(lwsReplicas), nil
+		return partitioned(min(lwsReplicas, stsReplicas)), wantReplicas(lwsReplicas), nil
 	}
 
-	partition := *sts.Spec.UpdateStrategy.RollingUpdate.Partition
-	rollingUpdateCompleted := partition == 0 && stsReplicas == lwsReplicas
+	partition := currentPartition(sts, start)
+	rollingUpdateCompleted := partition <= rolloutPartition(lws) && stsReplicas == lwsReplicas
 	// Case 3:
 	// In normal cases, return the values directly.
 	if rollingUpdateCompleted {
-		return 0, lwsReplicas, nil
+		return partitioned(0), lwsReplicas, nil
+	}
+
+	continuousReadyReplicas, lwsUnreadyReplicas, err := r.iterateReplicas(ctx, lws, pods, start, stsReplicas, revisionKey)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	originalLwsReplicas, err := strconv.Atoi(sts.Annotations[leaderworkerset.ReplicasAnnotationKey])
+	if err != nil {
+		return 0, 0, err
+	}
+	replicasUpdated := originalLwsReplicas != int(*lws.Spec.Replicas)
+	// Case 4:
+	// Replicas changed during rolling update.
+	if replicasUpdated {
+		return partitioned(min(partition, burstReplicas)), wantReplicas(lwsUnreadyReplicas), nil
+	}
+
+	// Case 5:
+	// Calculating the Partition during rolling update, no leaderWorkerSet updates happens.
+
+	rollingStep, err := rolloututils.MaxUnavailableGroups(lws, lwsReplicas)
+	if err != nil {
+		return 0, 0, err
+	}
+	// Make sure that we always respect the maxUnavailable, or
+	// we'll violate it when reclaiming bursted replicas.
+	rollingStep += maxSurge - (burstReplicas - stsReplicas)
+
+	// When updated replicas become not ready again or scaled up replicas are not ready yet,
+	// we'll not modify the Partition field. That means Partition moves in one direction to make it simple.
+	return partitioned(min(partition, utils.NonZeroValue(stsReplicas-rollingStep-continuousReadyReplicas))), wantReplicas(lwsUnreadyReplicas), nil
+}
+
+// recreateParameters returns the partition and replicas of the leader statefulset for the Recreate
+// rollout strategy. Once the leaderWorkerSet is updated, the leader statefulset is scaled down to 0,
+// which deletes all the groups, and it's only scaled back up after all the old leader pods are gone,
+// so that the groups are all recreated with the new revision. Partition is always 0.
+func (r *LeaderWorkerSetReconciler) recreateParameters(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet, sts *appsv1.StatefulSet, pods []corev1.Pod, leaderWorkerSetUpdated bool) (int32, int32, error) {
+	lwsReplicas := *lws.Spec.Replicas
+
+	// If sts not created yet, there is nothing to recreate.
+	if sts == nil {
+		return 0, lwsReplicas, nil
+	}
+
+	if leaderWorkerSetUpdated {
+		if *sts.Spec.Replicas > 0 {
+			r.Record.Eventf(lws, corev1.EventTypeNormal, GroupsUpdating, fmt.Sprintf("Deleting all %d groups to recreate them", *sts.Spec.Replicas))
+		}
+		return 0, 0, nil
+	}
+
+	if !recreating(lws, sts) {
+		return 0, lwsReplicas, nil
+	}
+
+	// Wait for all the old leader pods to be deleted before scaling back up.
+	if len(podutils.LeaderPods(pods)) > 0 {
+		return 0, 0, nil
+	}
+	r.Record.Eventf(lws, corev1.EventTypeNormal, GroupsProgressing, fmt.Sprintf("Recreating %d groups", lwsReplicas))
+	return 0, lwsReplicas, nil
+}
+
+// rolloutPartition returns the partition of the rollout strategy, groups with a lower index are
+// not updated.
+func rolloutPartition(lws *leaderworkerset.LeaderWorkerSet) int32 {
+	config := lws.Spec.RolloutStrategy.RollingUpdateConfiguration
+	if config == nil || config.Partition == nil {
+		return 0
+	}
+	return *config.Partition
+}
+
+// awaitingApproval returns true if the rollout strategy requires approval and the groups aren't all
+// at the revisionKey yet, while the approve-revision annotation doesn't match it.
+func awaitingApproval(lws *leaderworkerset.LeaderWorkerSet, revisionKey string) bool {
+	config := lws.Spec.RolloutStrategy.RollingUpdateConfiguration
+	if lws.Spec.RolloutStrategy.Type != leaderworkerset.RollingUpdateStrategyType || config == nil || !config.RequireApproval {
+		return false
+	}
+	return lws.Annotations[leaderworkerset.ApproveRevisionAnnotationKey] != revisionKey && groupsOutdated(lws, revisionKey)
+}
+
+// holdRollout keeps the partition of the leader statefulset from moving down and doesn't create any
+// further surge replica, so that no more group is updated or created for the rollout while it's held.
+// Scaling up to the replicas of the lws is still carried out.
+func holdRollout(lws *leaderworkerset.LeaderWorkerSet, sts *appsv1.StatefulSet, start, partition, replicas int32) (int32, int32) {
+	return max(partition, currentPartition(sts, start)), min(replicas, max(*sts.Spec.Replicas, *lws.Spec.Replicas))
+}
+
+// progressDeadline returns the progressDeadlineSeconds of the rollout strategy, 0 if unset.
+func progressDeadline(lws *leaderworkerset.LeaderWorkerSet) time.Duration {
+	config := lws.Spec.RolloutStrategy.RollingUpdateConfiguration
+	if lws.Spec.RolloutStrategy.Type != leaderworkerset.RollingUpdateStrategyType || config == nil || config.ProgressDeadlineSeconds == nil {
+		return 0
+	}
+	return time.Duration(*config.ProgressDeadlineSeconds) * time.Second
+}
+
+// startOrdinal returns the ordinal of the first group of the leader statefulset.
+func startOrdinal(sts *appsv1.StatefulSet) int32 {
+	if sts == nil || sts.Spec.Ordinals == nil {
+		return 0
+	}
+	return sts.Spec.Ordinals.Start
+}
+
+// startIndex returns the index of the first group set in the network config, 0 if unset.
+func startIndex(lws *leaderworkerset.LeaderWorkerSet) int32 {
+	if lws.Spec.NetworkConfig == nil {
+		return 0
+	}
+	return lws.Spec.NetworkConfig.StartIndex
+}
+
+// desiredStartOrdinal returns the ordinal of the first group the leader statefulset is reconciled with.
+// A new statefulset starts at the startIndex of the network config. With the LowestIndexFirst scale
+// down policy, the start ordinal is moved up by the number of removed replicas, so that the statefulset
+// deletes the groups with the lowest indexes. Scaling up still adds groups after the highest index.
+func desiredStartOrdinal(lws *leaderworkerset.LeaderWorkerSet, sts *appsv1.StatefulSet) int32 {
+	if sts == nil {
+		return startIndex(lws)
+	}
+	start := startOrdinal(sts)
+	if lws.Spec.ScaleDownPolicy != leaderworkerset.LowestIndexFirstScaleDownPolicy {
+		return start
+	}
+	originalLwsReplicas, err := strconv.Atoi(sts.Annotations[leaderworkerset.ReplicasAnnotationKey])
+	if err != nil || int32(originalLwsReplicas) <= *lws.Spec.Replicas {
+		return start
+	}
+	return start + int32(originalLwsReplicas) - *lws.Spec.Replicas
+}
+
+// currentPartition returns the partition of the leader statefulset relative to the start ordinal.
+// The partition of a statefulset is relative to its start ordinal, so when the start ordinal is moved
+// up, the partition is lowered accordingly to keep the same groups updated.
+func currentPartition(sts *appsv1.StatefulSet, start int32) int32 {
+	return utils.NonZeroValue(*sts.Spec.UpdateStrategy.RollingUpdate.Partition - (start - startOrdinal(sts)))
+}
+
+// recreating returns true if the groups are being deleted by a Recreate rollout, i.e. the leader
+// statefulset has been scaled down to 0 while the leaderWorkerSet still wants replicas.
+func recreating(lws *leaderworkerset.LeaderWorkerSet, sts *appsv1.StatefulSet) bool {
+	return lws.Spec.RolloutStrategy.Type == leaderworkerset.RecreateStrategyType && *sts.Spec.Replicas == 0 && *lws.Spec.Replicas > 0
+}
+
+// pinnedPartition returns the lowest partition of the leader statefulset, relative to start, which
+// keeps the groups pinned by a pin-group annotation at their revision, 0 if none is. The statefulset
+// only updates the groups from the partition up, so the groups with a lower index than a pinned one
+// are held as well. A group which doesn't run the pinned revision anymore can't be rolled back to
+// it, the annotation is ignored then, as is a pin to a revision which doesn't exist.
+func (r *LeaderWorkerSetReconciler) pinnedPartition(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet, start int32, revisionKey string) (int32, error) {
+	log := ctrl.LoggerFrom(ctx)
+	var partition int32
+	for key, pinnedRevision := range lws.Annotations {
+		indexStr, found := strings.CutPrefix(key, leaderworkerset.PinGroupAnnotationKeyPrefix)
+		if !found {
+			continue
+		}
+		index, err := strconv.Atoi(indexStr)
+		if err != nil || int32(index) < start {
+			log.V(2).Info("Ignoring pin-group annotation with an invalid group index", "annotation", key)
+			continue
+		}
+		// The rollout updates the groups to this revision anyway.
+		if pinnedRevision == revisionKey {
+			continue
+		}
+		revision, err := revisionutils.GetRevision(ctx, r.Client, lws, pinnedRevision)
+		if err != nil {
+			return 0, err
+		}
+		if revision == nil {
+			r.Record.Eventf(lws, corev1.EventTypeWarning, PinnedRevisionNotFound, fmt.Sprintf("Revision %s group %d is pinned to doesn't exist", pinnedRevision, index))
+			continue
+		}
+		var leader corev1.Pod
+		leaderName := fmt.Sprintf("%s-%d", controllerutils.LeaderStatefulSetName(lws), index)
+		if err := r.Get(ctx, types.NamespacedName{Name: leaderName, Namespace: lws.Namespace}, &leader); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return 0, err
+		}
+		if leaderRevision := revisionutils.GetRevisionKey(&leader); leaderRevision != pinnedRevision {
+			log.V(2).Info("Ignoring pin-group annotation of a group not running the pinned revision", "annotation", key, "revision", leaderRevision)
+			continue
+		}
+		partition = max(partition, int32(index)-start+1)
+	}
+	return partition, nil
+}
+
+// restartRequestedGroups deletes the leader pod of the groups whose restart is requested by a
+// restart-group annotation with a timestamp newer than the last one processed, so that they are
+// recreated, and records the processed timestamps in the group statuses. Leader pods created after
+// the requested timestamp are not deleted, in case the processed timestamp was lost.
+func (r *LeaderWorkerSetReconciler) restartRequestedGroups(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet) error {
+	log := ctrl.LoggerFrom(ctx)
+	processed := false
+	for key, value := range lws.Annotations {
+		indexStr, found := strings.CutPrefix(key, leaderworkerset.RestartGroupAnnotationKeyPrefix)
+		if !found {
+			continue
+		}
+		index, err := strconv.Atoi(indexStr)
+		if err != nil {
+			log.V(2).Info("Ignoring restart-group annotation with an invalid group index", "annotation", key)
+			continue
+		}
+		requestedAt, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			log.V(2).Info("Ignoring restart-group annotation with an invalid timestamp", "annotation", key, "value", value)
+			continue
+		}
+		statusIndex := slices.IndexFunc(lws.Status.GroupStatuses, func(status leaderworkerset.GroupStatus) bool {
+			return status.Index == int32(index)
+		})
+		// Only groups tracked in the status can record the processed timestamp.
+		if statusIndex == -1 {
+			continue
+		}
+		if last := lws.Status.GroupStatuses[statusIndex].LastRestartRequest; last != "" {
+			if lastAt, err := time.Parse(time.RFC3339, last); err == nil && !requestedAt.After(lastAt) {
+				continue
+			}
+		}
+
+		var leader corev1.Pod
+		leaderName := fmt.Sprintf("%s-%d", controllerutils.LeaderStatefulSetName(lws), index)
+		if err := r.Get(ctx, types.NamespacedName{Name: leaderName, Namespace: lws.Namespace}, &leader); client.IgnoreNotFound(err) != nil {
+			return err
+		} else if err == nil && leader.DeletionTimestamp == nil && leader.CreationTimestamp.Time.Before(requestedAt) {
+			if err := r.Delete(ctx, &leader, client.PropagationPolicy(metav1.DeletePropagationForeground)); client.IgnoreNotFound(err) != nil {
+				return err
+			}
+			r.Record.Eventf(lws, corev1.EventTypeNormal, GroupRestarted, fmt.Sprintf("Deleted leader pod %s to restart group %d as requested at %s", leaderName, index, value))
+		}
+		lws.Status.GroupStatuses[statusIndex].LastRestartRequest = value
+		processed = true
+	}
+	if !processed {
+		return nil
+	}
+	return r.writeStatus(ctx, lws)
+}
+
+// lastRestartRequest returns the restart-group annotation timestamp last processed for the group.
+func lastRestartRequest(lws *leaderworkerset.LeaderWorkerSet, index int32) string {
+	for _, status := range lws.Status.GroupStatuses {
+		if status.Index == index {
+			return status.LastRestartRequest
+		}
+	}
+	return ""
+}
+
+// drainOldGroups records a drain deadline on the leader pods of the old groups in [partition, currentPartition),
+// which are about to be deleted by lowering the partition. It returns how long to wait until all of them
+// are drained, or 0 if they can be deleted now.
+func (r *LeaderWorkerSetReconciler) drainOldGroups(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet, partition, currentPartition int32, revisionKey string) (time.Duration, error) {
+	config := lws.Spec.RolloutStrategy.RollingUpdateConfiguration
+	if config == nil || config.DrainGracePeriodSeconds == nil || *config.DrainGracePeriodSeconds == 0 {
+		return 0, nil
+	}
+	log := ctrl.LoggerFrom(ctx)
+	now := r.Clock.Now()
+
+	var requeueAfter time.Duration
+	for i := partition; i < currentPartition; i++ {
+		var leaderPod corev1.Pod
+		if err := r.Get(ctx, types.NamespacedName{Namespace: lws.Namespace, Name: fmt.Sprintf("%s-%d", controllerutils.LeaderStatefulSetName(lws), i)}, &leaderPod); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return 0, err
+		}
+		if revisionutils.GetRevisionKey(&leaderPod) == revisionKey {
+			continue
+		}
+
+		deadline, err := time.Parse(time.RFC3339, leaderPod.Annotations[leaderworkerset.DrainDeadlineAnnotationKey])
+		if err != nil {
+			deadline = now.Add(time.Duration(*config.DrainGracePeriodSeconds) * time.Second)
+			patch := client.MergeFrom(leaderPod.DeepCopy())
+			if leaderPod.Annotations == nil {
+				leaderPod.Annotations = map[string]string{}
+			}
+			leaderPod.Annotations[leaderworkerset.DrainDeadlineAnnotationKey] = deadline.Format(time.RFC3339)
+			if err := r.Patch(ctx, &leaderPod, patch); err != nil {
+				return 0, err
+			}
+			log.V(2).Info("Draining old group", "leader pod", klog.KObj(&leaderPod), "deadline", deadline)
+		}
+		if wait := deadline.Sub(now); wait > requeueAfter {
+			requeueAfter = wait
+		}
+	}
+	return requeueAfter, nil
+}
+
+// deletedGroups returns the ordinals of the groups the leader statefulset deletes once start, partition
+// and replicas are applied to it, the groups removed by a scale down, and the groups released for update
+// whose leader pods are recreated.
+func deletedGroups(sts *appsv1.StatefulSet, start, partition, replicas int32) (scaledDown, updated []int32) {
+	currentStart := startOrdinal(sts)
+	for i := currentStart; i < currentStart+*sts.Spec.Replicas; i++ {
+		if i < start || i >= start+replicas {
+			scaledDown = append(scaledDown, i)
+		}
+	}
+	for i := start + partition; i < start+min(currentPartition(sts, start), replicas); i++ {
+		updated = append(updated, i)
+	}
+	return scaledDown, updated
+}
+
+// terminateWorkersFirst deletes the worker statefulsets of the groups about to be deleted when
+// orderedTermination is set, so that their workers terminate before their leaders. It returns how long
+// to hold the leader statefulset until the workers of all of them are gone, or 0 if the leader pods can
+// be deleted now. Groups no longer about to be deleted, e.g. scaled back up, get their workers back.
+func (r *LeaderWorkerSetReconciler) terminateWorkersFirst(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet, sts *appsv1.StatefulSet, pods []corev1.Pod, start, partition, replicas int32, revisionKey string) (time.Duration, error) {
+	var scaledDown, updated []int32
+	if lws.Spec.LeaderWorkerTemplate.OrderedTermination {
+		scaledDown, updated = deletedGroups(sts, start, partition, replicas)
+	}
+	if err := r.releaseKeptGroups(ctx, pods, sets.New(append(scaledDown, updated...)...)); err != nil {
+		return 0, err
+	}
+	if len(scaledDown) == 0 && len(updated) == 0 {
+		return 0, nil
+	}
+
+	// The groups of size 1 have no workers, the size is the one of the revision of each group.
+	sizes, err := r.groupSizes(ctx, lws, pods)
+	if err != nil {
+		return 0, err
+	}
+	var requeueAfter time.Duration
+	for _, i := range scaledDown {
+		if groupSize(lws, sizes, strconv.Itoa(int(i))) == 1 {
+			continue
+		}
+		wait, err := r.terminateGroupWorkers(ctx, lws, i, "")
+		if err != nil {
+			return 0, err
+		}
+		requeueAfter = max(requeueAfter, wait)
+	}
+	for _, i := range updated {
+		if groupSize(lws, sizes, strconv.Itoa(int(i))) == 1 {
+			continue
+		}
+		wait, err := r.terminateGroupWorkers(ctx, lws, i, revisionKey)
+		if err != nil {
+			return 0, err
+		}
+		requeueAfter = max(requeueAfter, wait)
+	}
+	return requeueAfter, nil
+}
+
+// releaseKeptGroups removes the workers termination start annotation from the leader pods among pods of
+// the groups which are not in deleted, so that the pod controller recreates their worker statefulsets.
+func (r *LeaderWorkerSetReconciler) releaseKeptGroups(ctx context.Context, pods []corev1.Pod, deleted sets.Set[int32]) error {
+	leaderPods := podutils.LeaderPods(pods)
+	for i := range leaderPods {
+		leaderPod := &leaderPods[i]
+		if _, ok := leaderPod.Annotations[leaderworkerset.WorkersTerminationStartAnnotationKey]; !ok || leaderPod.DeletionTimestamp != nil {
+			continue
+		}
+		groupIndex, err := strconv.Atoi(leaderPod.Labels[leaderworkerset.GroupIndexLabelKey])
+		if err != nil || deleted.Has(int32(groupIndex)) {
+			continue
+		}
+		patch := client.MergeFrom(leaderPod.DeepCopy())
+		delete(leaderPod.Annotations, leaderworkerset.WorkersTerminationStartAnnotationKey)
+		if err := r.Patch(ctx, leaderPod, patch); err != nil {
+			return err
+		}
+		ctrl.LoggerFrom(ctx).V(2).Info("Group kept, recreating its workers", "leader pod", klog.KObj(leaderPod))
+	}
+	return nil
+}
+
+// terminateGroupWorkers deletes the worker statefulset of the group with the given ordinal, unless its
+// leader pod is already at the updateRevisionKey. It returns how long to wait for the workers to be gone,
+// bounded by the termination grace period of the workers counted from the first deletion.
+func (r *LeaderWorkerSetReconciler) terminateGroupWorkers(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet, ordinal int32, updateRevisionKey string) (time.Duration, error) {
+	name := fmt.Sprintf("%s-%d", controllerutils.LeaderStatefulSetName(lws), ordinal)
+	var leaderPod corev1.Pod
+	if err := r.Get(ctx, types.NamespacedName{Namespace: lws.Namespace, Name: name}, &leaderPod); err != nil {
+		return 0, client.IgnoreNotFound(err)
+	}
+	if leaderPod.DeletionTimestamp != nil || (updateRevisionKey != "" && revisionutils.GetRevisionKey(&leaderPod) == updateRevisionKey) {
+		return 0, nil
+	}
+
+	now := r.Clock.Now()
+	startTime, err := time.Parse(time.RFC3339, leaderPod.Annotations[leaderworkerset.WorkersTerminationStartAnnotationKey])
+	if err != nil {
+		// The annotation stops the pod controller from recreating the worker statefulset.
+		startTime = now
+		patch := client.MergeFrom(leaderPod.DeepCopy())
+		if leaderPod.Annotations == nil {
+			leaderPod.Annotations = map[string]string{}
+		}
+		leaderPod.Annotations[leaderworkerset.WorkersTerminationStartAnnotationKey] = startTime.Format(time.RFC3339)
+		if err := r.Patch(ctx, &leaderPod, patch); err != nil {
+			return 0, err
+		}
+		ctrl.LoggerFrom(ctx).V(2).Info("Terminating workers before the leader", "leader pod", klog.KObj(&leaderPod))
+	}
+
+	var workerSts appsv1.StatefulSet
+	if err := r.Get(ctx, types.NamespacedName{Namespace: lws.Namespace, Name: name}, &workerSts); err != nil {
+		return 0, client.IgnoreNotFound(err)
+	}
+	if workerSts.DeletionTimestamp == nil {
+		// Foreground deletion keeps the worker statefulset until all its pods are gone.
+		if err := r.Delete(ctx, &workerSts, client.PropagationPolicy(metav1.DeletePropagationForeground)); client.IgnoreNotFound(err) != nil {
+			return 0, err
+		}
+	}
+	gracePeriod := time.Duration(ptr.Deref(lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec.TerminationGracePeriodSeconds, corev1.DefaultTerminationGracePeriodSeconds)) * time.Second
+	return max(startTime.Add(gracePeriod).Sub(now), 0), nil
+}
+
+func (r *LeaderWorkerSetReconciler) SSAWithStatefulset(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet, start, partition, replicas int32, revisionKey string) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	// construct the statefulset apply configuration
+	leaderStatefulSetApplyConfig, err := constructLeaderStatefulSetApplyConfiguration(lws, start, partition, replicas, revisionKey)
+	if err != nil {
+		log.Error(err, "Constructing StatefulSet apply configuration.")
+		return err
+	}
+	if err := setControllerReferenceWithStatefulSet(lws, leaderStatefulSetApplyConfig, r.Scheme); err != nil {
+		log.Error(err, "Setting controller reference.")
+		return err
+	}
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(leaderStatefulSetApplyConfig)
+	if err != nil {
+		log.Error(err, "Converting StatefulSet configuration to json.")
+		return err
+	}
+	patch := &unstructured.Unstructured{
+		Object: obj,
+	}
+	// Use server side apply and add fieldmanager to the lws owned fields
+	// If there are conflicts in the fields owned by the lws controller, lws will obtain the ownership and force override
+	// these fields to the ones desired by the lws controller
+	// TODO b/316776287 add E2E test for SSA
+	err = r.Patch(ctx, patch, client.Apply, &client.PatchOptions{
+		FieldManager: fieldManager,
+		Force:        ptr.To[bool](true),
+	})
+	if err != nil {
+		log.Error(err, "Using server side apply to update leader statefulset")
+		return err
+	}
+
+	return nil
+}
+
+// updates the condition of the leaderworkerset to either Progressing or Available, from its pods.
+// recreateInProgress is true when all the groups are being deleted by a Recreate rollout, and start
+// is the index of the first group.
+func (r *LeaderWorkerSetReconciler) updateConditions(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet, pods []corev1.Pod, revisionKey string, recreateInProgress bool, start int32) (bool, bool, time.Duration, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// The groups not updated yet keep the size of their revision.
+	sizes, err := r.groupSizes(ctx, lws, pods)
+	if err != nil {
+		return false, false, 0, err
+	}
+
+	// With minReadySeconds, the groups are only counted as ready once all their pods have been
+	// ready for long enough, and the status is re-evaluated when the first of them gets there.
+	minReady := time.Duration(lws.Spec.LeaderWorkerTemplate.MinReadySeconds) * time.Second
+	var groupsReadySince map[string]time.Time
+	var requeueAfter time.Duration
+	if minReady > 0 {
+		groupsReadySince = groupsReadySinceOf(lws, pods, sizes)
+	}
+
+	// With minReplicas, the groups lacking capacity don't hold the lws from being available, as long
+	// as at least minReplicas of the other groups are ready.
+	var unschedulableGroups []int
+	if lws.Spec.MinReplicas != nil {
+		if unschedulableGroups, _, err = r.unschedulableGroups(pods); err != nil {
+			return false, false, 0, err
+		}
+	}
+
+	var updatedSubGroups map[int32]int32
+	if config := lws.Spec.RolloutStrategy.RollingUpdateConfiguration; config != nil && config.Granularity == leaderworkerset.SubGroupRolloutGranularity {
+		if updatedSubGroups, err = updatedSubGroupsOf(pods, revisionKey); err != nil {
+			return false, false, 0, err
+		}
+	}
+
+	updateStatus := false
+	readyCount, updatedCount, progressingCount, updatedNonBurstWorkerCount, currentNonBurstWorkerCount, updatedAndReadyCount := 0, 0, 0, 0, 0, 0
+	// Groups below the partition of the rollout strategy which are not updated.
+	pinnedCount, pinnedAndReadyCount := 0, 0
+	var groupStatuses []leaderworkerset.GroupStatus
+
+	// Iterate through all leaderPods.
+	for _, pod := range podutils.LeaderPods(pods) {
+		index, err := strconv.Atoi(pod.Labels[leaderworkerset.GroupIndexLabelKey])
+		if err != nil {
+			return false, false, 0, err
+		}
+		// Bursted replicas and groups below the start index which are being deleted are not counted.
+		nonBurst := index >= int(start) && index < int(start+*lws.Spec.Replicas)
+		if nonBurst {
+			currentNonBurstWorkerCount++
+		}
+		size := groupSize(lws, sizes, pod.Labels[leaderworkerset.GroupIndexLabelKey])
+		noWorkerSts := size == 1
+
+		var sts appsv1.StatefulSet
+		if !noWorkerSts {
+			if err := r.Get(ctx, client.ObjectKey{Namespace: lws.Namespace, Name: pod.Name}, &sts); err != nil {
+				if client.IgnoreNotFound(err) != nil {
+					log.Error(err, "Fetching worker statefulSet")
+					return false, false, 0, err
+				}
+				if groupProgressing(pod, nil, size) {
+					progressingCount++
+				}
+				updated := revisionutils.GetRevisionKey(&pod) == revisionKey
+				if nonBurst && !updated && index-int(start) < int(rolloutPartition(lws)) {
+					pinnedCount++
+					updated = true
+				}
+				groupStatuses = append(groupStatuses, makeGroupStatus(index, pod, updated, false))
+				continue
+			}
+		}
+
+		workerSts := &sts
+		if noWorkerSts {
+			workerSts = nil
+		}
+		if groupProgressing(pod, workerSts, size) {
+			progressingCount++
+		}
+
+		var ready, updated bool
+		if (noWorkerSts || statefulsetutils.StatefulsetReady(sts)) && podutils.PodRunningAndReady(pod) {
+			ready = true
+			if minReady > 0 {
+				readySince, found := groupsReadySince[pod.Labels[leaderworkerset.GroupIndexLabelKey]]
+				remaining := readySince.Add(minReady).Sub(r.Clock.Now())
+				if !found {
+					ready = false
+				} else if remaining > 0 {
+					ready = false
+					requeueAfter = shorterRequeueAfter(requeueAfter, remaining)
+				}
+			}
+		}
+		// The standby groups don't serve the replicas.
+		if ready && !slices.Contains(lws.Status.StandbyGroups, int32(index)) {
+			readyCount++
+		}
+		if (noWorkerSts || revisionutils.GetRevisionKey(&sts) == revisionKey) && revisionutils.GetRevisionKey(&pod) == revisionKey {
+			updated = true
+			updatedCount++
+			if nonBurst {
+				// Bursted replicas do not count when determining if rollingUpdate has been completed.
+				updatedNonBurstWorkerCount++
+			}
+		}
+
+		if ready && updated {
+			// Bursted replicas should not be counted here.
+			if nonBurst {
+				updatedAndReadyCount++
+			}
+		}
+		if nonBurst && !updated && index-int(start) < int(rolloutPartition(lws)) {
+			pinnedCount++
+			if ready {
+				pinnedAndReadyCount++
+			}
+			// The group is not expected to be updated, report it the same as an updated group.
+			updated = true
+		}
+		groupStatuses = append(groupStatuses, makeGroupStatus(index, pod, updated, ready))
+	}
+
+	if lws.Status.ReadyReplicas != int32(readyCount) {
+		lws.Status.ReadyReplicas = int32(readyCount)
+		updateStatus = true
+	}
+
+	if lws.Status.UpdatedReplicas != int32(updatedCount) {
+		lws.Status.UpdatedReplicas = int32(updatedCount)
+		updateStatus = true
+	}
+
+	if lws.Status.ProgressingReplicas != int32(progressingCount) {
+		lws.Status.ProgressingReplicas = int32(progressingCount)
+		updateStatus = true
+	}
+
+	// The unschedulable groups are not ready, bursted replicas are not counted.
+	insufficientCapacityCount := 0
+	for _, index := range unschedulableGroups {
+		if index >= int(start) && index < int(start+*lws.Spec.Replicas) {
+			insufficientCapacityCount++
+		}
+	}
+
+	// The restart requests processed are kept as long as the groups are tracked.
+	for i := range groupStatuses {
+		groupStatuses[i].LastRestartRequest = lastRestartRequest(lws, groupStatuses[i].Index)
+		groupStatuses[i].UpdatedSubGroups = updatedSubGroups[groupStatuses[i].Index]
+	}
+	// Sort by index for stable diffs, and only keep the first maxGroupStatuses groups to bound the status size.
+	slices.SortFunc(groupStatuses, func(a, b leaderworkerset.GroupStatus) int { return cmp.Compare(a.Index, b.Index) })
+	if len(groupStatuses) > maxGroupStatuses {
+		groupStatuses = groupStatuses[:maxGroupStatuses]
+	}
+	if !slices.Equal(lws.Status.GroupStatuses, groupStatuses) {
+		lws.Status.GroupStatuses = groupStatuses
+		updateStatus = true
+	}
+
+	var conditions []metav1.Condition
+	updateDone := false
+	if updatedNonBurstWorkerCount < currentNonBurstWorkerCount-pinnedCount || recreateInProgress {
+		// upgradeInProgress is true when the upgrade replicas is smaller than the expected
+		// number of total replicas not including the burst replicas and the groups below the partition
+		conditions = append(conditions, makeCondition(leaderworkerset.LeaderWorkerSetUpdateInProgress))
+		conditions = append(conditions, makeCondition(leaderworkerset.LeaderWorkerSetProgressing))
+	} else if updatedAndReadyCount+pinnedAndReadyCount == int(*lws.Spec.Replicas) {
+		conditions = append(conditions, makeCondition(leaderworkerset.LeaderWorkerSetAvailable))
+		// The old revisions are still in use by the groups below the partition.
+		updateDone = pinnedCount == 0
+	} else if lws.Spec.MinReplicas != nil && insufficientCapacityCount > 0 && updatedAndReadyCount+pinnedAndReadyCount >= int(min(*lws.Spec.MinReplicas, *lws.Spec.Replicas)) &&
+		updatedAndReadyCount+pinnedAndReadyCount+insufficientCapacityCount == int(*lws.Spec.Replicas) {
+		// All the groups that could be scheduled are ready, the others lack capacity.
+		conditions = append(conditions, makeCondition(leaderworkerset.LeaderWorkerSetAvailable))
+	} else {
+		conditions = append(conditions, makeCondition(leaderworkerset.LeaderWorkerSetProgressing))
+	}
+
+	updateCondition := setConditions(lws, conditions)
+	// if condition changed, record events
+	if updateCondition {
+		r.Record.Eventf(lws, corev1.EventTypeNormal, conditions[0].Reason, conditions[0].Message+fmt.Sprintf(", with %d groups ready of total %d groups", readyCount, int(*lws.Spec.Replicas)))
+	}
+
+	// UpdateComplete is independent of the conditions above, so it's set on its own.
+	updateCompleteCondition := makeCondition(leaderworkerset.LeaderWorkerSetUpdateComplete)
+	if lws.Status.UpdatedReplicas != lws.Status.Replicas {
+		updateCompleteCondition.Status = metav1.ConditionFalse
+		updateCompleteCondition.Reason = GroupsUpdating
+		updateCompleteCondition.Message = fmt.Sprintf("%d of %d replicas are at the latest revision", lws.Status.UpdatedReplicas, lws.Status.Replicas)
+	}
+	updateCompleteChanged := setCondition(lws, updateCompleteCondition)
+
+	capacityCondition := makeCondition(leaderworkerset.LeaderWorkerSetInsufficientCapacity)
+	if insufficientCapacityCount == 0 {
+		capacityCondition.Status = metav1.ConditionFalse
+		capacityCondition.Reason = "SufficientCapacity"
+		capacityCondition.Message = "No group lacks capacity"
+	} else {
+		capacityCondition.Message = fmt.Sprintf("%d of %d groups can't be scheduled for lack of capacity", insufficientCapacityCount, *lws.Spec.Replicas)
+	}
+	capacityChanged := setCondition(lws, capacityCondition)
+
+	haltChanged := setHaltConditions(lws)
+
+	approvalCondition := makeCondition(leaderworkerset.LeaderWorkerSetPendingApproval)
+	if awaitingApproval(lws, revisionKey) {
+		approvalCondition.Message = fmt.Sprintf("Waiting for the %s annotation to be set to %s", leaderworkerset.ApproveRevisionAnnotationKey, revisionKey)
+	} else {
+		approvalCondition.Status = metav1.ConditionFalse
+		approvalCondition.Reason = "Approved"
+		approvalCondition.Message = "No rollout is waiting for approval"
+	}
+	approvalChanged := setCondition(lws, approvalCondition)
+	return updateStatus || updateCondition || updateCompleteChanged || capacityChanged || haltChanged || approvalChanged, updateDone, requeueAfter, nil
+}
+
+// groupsReadySinceOf returns, by group index, since when all the pods of the group among pods have
+// been ready, for the size of the group from sizes, see groupSizes. Groups that aren't ready are omitted.
+func groupsReadySinceOf(lws *leaderworkerset.LeaderWorkerSet, pods []corev1.Pod, sizes map[string]int32) map[string]time.Time {
+	groupPods := map[string][]corev1.Pod{}
+	for _, pod := range pods {
+		group := pod.Labels[leaderworkerset.GroupIndexLabelKey]
+		groupPods[group] = append(groupPods[group], pod)
+	}
+	readySince := map[string]time.Time{}
+	for group, pods := range groupPods {
+		if !podutils.GroupReady(pods, groupSize(lws, sizes, group), true) {
+			continue
+		}
+		for _, pod := range pods {
+			if condition := podutils.GetPodReadyCondition(pod.Status); condition != nil && condition.LastTransitionTime.Time.After(readySince[group]) {
+				readySince[group] = condition.LastTransitionTime.Time
+			}
+		}
+	}
+	return readySince
+}
+
+// updatedSubGroupsOf returns, by group index, the number of subgroups whose pods among pods all run
+// the revisionKey. Pods outside of any subgroup, like the leader with the LeaderExcluded subgroup
+// policy, are not counted.
+func updatedSubGroupsOf(pods []corev1.Pod, revisionKey string) (map[int32]int32, error) {
+	// subGroupsUpdated tracks, by group and subgroup index, whether all the pods of the subgroup are updated.
+	subGroupsUpdated := map[int32]map[string]bool{}
+	for i := range pods {
+		pod := &pods[i]
+		subGroup, found := pod.Labels[leaderworkerset.SubGroupIndexLabelKey]
+		if !found {
+			continue
+		}
+		index, err := strconv.Atoi(pod.Labels[leaderworkerset.GroupIndexLabelKey])
+		if err != nil {
+			return nil, err
+		}
+		if subGroupsUpdated[int32(index)] == nil {
+			subGroupsUpdated[int32(index)] = map[string]bool{}
+		}
+		updated, seen := subGroupsUpdated[int32(index)][subGroup]
+		subGroupsUpdated[int32(index)][subGroup] = (updated || !seen) && revisionutils.GetRevisionKey(pod) == revisionKey
+	}
+	updatedSubGroups := map[int32]int32{}
+	for index, subGroups := range subGroupsUpdated {
+		for _, updated := range subGroups {
+			if updated {
+				updatedSubGroups[index]++
+			}
+		}
+	}
+	return updatedSubGroups, nil
+}
+
+// boundedStatusRequeueAfter bounds the requeue of a pending time-dependent status condition by the
+// resync period. Nothing is requeued when no condition is pending, so that idle leaderWorkerSets
+// are only reconciled on watch events.
+func boundedStatusRequeueAfter(requeueAfter, resyncPeriod time.Duration) time.Duration {
+	if requeueAfter == 0 || resyncPeriod <= 0 {
+		return requeueAfter
+	}
+	return min(requeueAfter, resyncPeriod)
+}
+
+// shorterRequeueAfter returns the shorter of the two requeue durations, where 0 means no requeue.
+func shorterRequeueAfter(a, b time.Duration) time.Duration {
+	if a == 0 || (b > 0 && b < a) {
+		return b
+	}
+	return a
+}
+
+// makeGroupStatus returns the status of the group led by leaderPod. updated is whether the group
+// is running the update revision, and ready is whether all the pods of the group are ready.
+func makeGroupStatus(index int, leaderPod corev1.Pod, updated, ready bool) leaderworkerset.GroupStatus {
+	status := leaderworkerset.GroupStatus{
+		Index:    int32(index),
+		Revision: revisionutils.GetRevisionKey(&leaderPod),
+	}
+	switch {
+	case !updated:
+		status.Phase = leaderworkerset.GroupUpdating
+	case ready:
+		status.Phase = leaderworkerset.GroupReady
+	case leaderPod.Spec.NodeName == "":
+		status.Phase = leaderworkerset.GroupPending
+	default:
+		status.Phase = leaderworkerset.GroupCreating
+	}
+	return status
+}
+
+// groupProgressing returns true if at least one but not all of the pods in the group are ready.
+// workerSts is nil when the worker statefulset doesn't exist.
+func groupProgressing(leaderPod corev1.Pod, workerSts *appsv1.StatefulSet, size int32) bool {
+	var readyPods int32
+	if podutils.PodRunningAndReady(leaderPod) {
+		readyPods++
+	}
+	if workerSts != nil {
+		readyPods += workerSts.Status.ReadyReplicas
+	}
+	return readyPods > 0 && readyPods < size
+}
+
+// Updates status and condition of LeaderWorkerSet and returns whether or not an update actually occurred,
+// and how long until a pod exceeds the unschedulable timeout, if any. specApplied tells whether the spec
+// of the current generation has been applied, in which case it's recorded as the observed generation.
+// pods are the pods of the lws, listed by the reconcile.
+func (r *LeaderWorkerSetReconciler) updateStatus(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet, pods []corev1.Pod, revisionKey string, specApplied bool) (bool, time.Duration, error) {
+	updateStatus := false
+	log := ctrl.LoggerFrom(ctx)
+
+	// The generation is only observed once its spec has been applied, i.e. not while paused.
+	if specApplied && lws.Status.ObservedGeneration != lws.Generation {
+		lws.Status.ObservedGeneration = lws.Generation
+		updateStatus = true
+	}
+
+	// Retrieve the leader StatefulSet.
+	sts := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, types.NamespacedName{Name: controllerutils.LeaderStatefulSetName(lws), Namespace: lws.Namespace}, sts); err != nil {
+		log.Error(err, "Error retrieving leader StatefulSet")
+		return false, 0, err
+	}
+
+	// retrieve the current number of replicas -- the number of leaders
+	replicas := int(sts.Status.Replicas)
+	if lws.Status.Replicas != int32(replicas) {
+		lws.Status.Replicas = int32(replicas)
+		updateStatus = true
+	}
+
+	if lws.Status.HPAPodSelector == "" {
+		labelSelector := &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				leaderworkerset.SetNameLabelKey:     lws.Name,
+				leaderworkerset.WorkerIndexLabelKey: "0", // select leaders
+			},
+		}
+		selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+		if err != nil {
+			log.Error(err, "Converting label selector to selector")
+			return false, 0, err
+		}
+
+		lws.Status.HPAPodSelector = selector.String()
+		updateStatus = true
+	}
+
+	// The standby groups are only promoted once the spec is applied, they're counted by the conditions.
+	var updateStandby bool
+	if specApplied {
+		var err error
+		if updateStandby, err = r.updateStandbyGroups(ctx, lws, pods, startOrdinal(sts)); err != nil {
+			return false, 0, err
+		}
+	}
+
+	// check if an update is needed
+	updateConditions, updateDone, minReadyRequeueAfter, err := r.updateConditions(ctx, lws, pods, revisionKey, recreating(lws, sts), startOrdinal(sts))
+	if err != nil {
+		return false, 0, err
+	}
+	rolloutStartTime := lws.Status.RolloutStartTime
+	updateRolloutStartTime := updateRolloutStartTime(lws, revisionKey, r.Clock.Now())
+	updateRevisions := updateRevisions(lws, revisionKey)
+	updateUnschedulable, unschedulableRequeueAfter, err := r.updateGroupUnschedulableCondition(lws, pods)
+	if err != nil {
+		return false, 0, err
+	}
+	updateCrashingPods := updateCrashingPods(lws, pods)
+	updateFailed, err := r.updateGroupFailedCondition(lws, pods)
+	if err != nil {
+		return false, 0, err
+	}
+	updateStalled, stalledRequeueAfter, err := r.updateRolloutStalledCondition(ctx, lws, pods, revisionKey)
+	if err != nil {
+		return false, 0, err
+	}
+	// The groups and the duplicate leaders are only deleted once the spec is applied, i.e. not while paused.
+	var updateDeadlineExceeded, updateDuplicateLeader bool
+	var deadlineRequeueAfter time.Duration
+	if specApplied {
+		if updateDeadlineExceeded, deadlineRequeueAfter, err = r.updateGroupDeadlineExceededCondition(ctx, lws, sts, pods); err != nil {
+			return false, 0, err
+		}
+		if updateDuplicateLeader, err = r.updateDuplicateLeaderCondition(ctx, lws, pods); err != nil {
+			return false, 0, err
+		}
+	}
+
+	if updateStatus || updateStandby || updateConditions || updateRolloutStartTime || updateRevisions || updateUnschedulable || updateCrashingPods || updateFailed || updateStalled || updateDeadlineExceeded || updateDuplicateLeader {
+		if err := r.writeStatus(ctx, lws); err != nil {
+			if !apierrors.IsConflict(err) {
+				log.Error(err, "Updating LeaderWorkerSet status and/or condition.")
+			}
+			return false, 0, err
+		}
+	}
+	metrics.GroupsObserved(lws.Namespace, lws.Name, lws.Status.Replicas, lws.Status.ReadyReplicas)
+	// The pods are only relabeled once the standby groups are persisted, recreated pods are
+	// labeled again from the status.
+	if specApplied {
+		if err := r.labelStandbyPods(ctx, lws, pods); err != nil {
+			return false, 0, err
+		}
+	}
+	// Only record the rollout once the cleared start time is persisted, to not record it twice.
+	if rolloutStartTime != nil && lws.Status.RolloutStartTime == nil {
+		metrics.RolloutCompleted(lws.Namespace, lws.Name, r.Clock.Since(rolloutStartTime.Time))
+	}
+	requeueAfter := shorterRequeueAfter(shorterRequeueAfter(minReadyRequeueAfter, unschedulableRequeueAfter), stalledRequeueAfter)
+	return updateDone, shorterRequeueAfter(requeueAfter, deadlineRequeueAfter), nil
+}
+
+// updateStandbyGroups keeps status.standbyGroups to spec.standbyReplicas groups, and promotes the ready
+// standby groups in place of the groups serving the replicas which aren't ready. The replicas are expected
+// to include the standby groups, see addStandbyReplicas. It returns whether the standby groups changed.
+func (r *LeaderWorkerSetReconciler) updateStandbyGroups(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet, pods []corev1.Pod, start int32) (bool, error) {
+	standby := desiredStandbyGroups(lws.Status.StandbyGroups, start, *lws.Spec.Replicas, ptr.Deref(lws.Spec.StandbyReplicas, 0))
+	if len(standby) > 0 {
+		ready, err := r.readyGroups(ctx, lws, pods)
+		if err != nil {
+			return false, err
+		}
+		var promotions []standbyPromotion
+		standby, promotions = promoteStandbyGroups(standby, start, *lws.Spec.Replicas, ready)
+		for _, promotion := range promotions {
+			r.Record.Eventf(lws, corev1.EventTypeNormal, StandbyGroupPromoted, fmt.Sprintf("Promoted standby group %d in place of group %d which isn't ready", promotion.promoted, promotion.demoted))
+		}
+	}
+	if slices.Equal(lws.Status.StandbyGroups, standby) {
+		return false, nil
+	}
+	lws.Status.StandbyGroups = standby
+	return true, nil
+}
+
+// desiredStandbyGroups returns the sorted indexes of the standby groups among the groups [start, start+groups):
+// the current standby groups still in range, topped up from the highest indexes up to standbyReplicas.
+func desiredStandbyGroups(current []int32, start, groups, standbyReplicas int32) []int32 {
+	var standby []int32
+	for _, index := range current {
+		if index >= start && index < start+groups && int32(len(standby)) < standbyReplicas && !slices.Contains(standby, index) {
+			standby = append(standby, index)
+		}
+	}
+	for index := start + groups - 1; index >= start && int32(len(standby)) < standbyReplicas; index-- {
+		if !slices.Contains(standby, index) {
+			standby = append(standby, index)
+		}
+	}
+	slices.Sort(standby)
+	return standby
+}
+
+// standbyPromotion is a standby group promoted in place of a demoted group.
+type standbyPromotion struct {
+	promoted int32
+	demoted  int32
+}
+
+// promoteStandbyGroups swaps every group among [start, start+groups) serving the replicas which isn't ready
+// with a ready standby group, as long as there are any. It returns the sorted standby groups after the swaps.
+func promoteStandbyGroups(standby []int32, start, groups int32, ready sets.Set[int32]) ([]int32, []standbyPromotion) {
+	standby = slices.Clone(standby)
+	var promotions []standbyPromotion
+	for index := start; index < start+groups; index++ {
+		if ready.Has(index) || slices.Contains(standby, index) {
+			continue
+		}
+		i := slices.IndexFunc(standby, ready.Has)
+		if i == -1 {
+			break
+		}
+		promotions = append(promotions, standbyPromotion{promoted: standby[i], demoted: index})
+		standby[i] = index
+	}
+	slices.Sort(standby)
+	return standby, promotions
+}
+
+// readyGroups returns the indexes of the groups whose leader pod among pods and worker statefulset are ready.
+func (r *LeaderWorkerSetReconciler) readyGroups(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet, pods []corev1.Pod) (sets.Set[int32], error) {
+	sizes, err := r.groupSizes(ctx, lws, pods)
+	if err != nil {
+		return nil, err
+	}
+	ready := sets.New[int32]()
+	for _, pod := range podutils.LeaderPods(pods) {
+		if !podutils.PodRunningAndReady(pod) {
+			continue
+		}
+		index, err := strconv.Atoi(pod.Labels[leaderworkerset.GroupIndexLabelKey])
+		if err != nil {
+			return nil, err
+		}
+		if groupSize(lws, sizes, pod.Labels[leaderworkerset.GroupIndexLabelKey]) > 1 {
+			var sts appsv1.StatefulSet
+			if err := r.Get(ctx, client.ObjectKey{Namespace: lws.Namespace, Name: pod.Name}, &sts); client.IgnoreNotFound(err) != nil {
+				return nil, err
+			} else if err != nil || !statefulsetutils.StatefulsetReady(sts) {
+				continue
+			}
+		}
+		ready.Insert(int32(index))
+	}
+	return ready, nil
+}
+
+// labelStandbyPods sets the standby label on the pods of the standby groups among pods, and removes it
+// from the pods of the other groups.
+func (r *LeaderWorkerSetReconciler) labelStandbyPods(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet, pods []corev1.Pod) error {
+	for i := range pods {
+		pod := &pods[i]
+		index, err := strconv.Atoi(pod.Labels[leaderworkerset.GroupIndexLabelKey])
+		if err != nil {
+			return err
+		}
+		standby := slices.Contains(lws.Status.StandbyGroups, int32(index))
+		if (pod.Labels[leaderworkerset.StandbyLabelKey] == "true") == standby {
+			continue
+		}
+		patch := client.MergeFrom(pod.DeepCopy())
+		if standby {
+			pod.Labels[leaderworkerset.StandbyLabelKey] = "true"
+		} else {
+			delete(pod.Labels, leaderworkerset.StandbyLabelKey)
+		}
+		if err := r.Patch(ctx, pod, patch); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateCrashingPods counts the pods of the lws with a container in CrashLoopBackOff, and returns
+// whether the count changed.
+func updateCrashingPods(lws *leaderworkerset.LeaderWorkerSet, pods []corev1.Pod) bool {
+	var crashingPods int32
+	for _, pod := range pods {
+		if podutils.CrashLooping(pod) {
+			crashingPods++
+		}
+	}
+	if lws.Status.CrashingPods == crashingPods {
+		return false
+	}
+	lws.Status.CrashingPods = crashingPods
+	return true
+}
+
+// unschedulableGroups returns the sorted indexes of the groups with a pod among pods unschedulable for longer than
+// UnschedulableTimeout, and how long until the next unschedulable pod exceeds the timeout, or 0 if there
+// is none. Pods unschedulable for less than the timeout are considered transient, e.g. while a node is
+// being provisioned, the others are deemed to lack capacity.
+func (r *LeaderWorkerSetReconciler) unschedulableGroups(pods []corev1.Pod) ([]int, time.Duration, error) {
+	var unschedulableGroups []int
+	var requeueAfter time.Duration
+	for _, pod := range pods {
+		since, unschedulable := podutils.UnschedulableSince(pod)
+		if !unschedulable {
+			continue
+		}
+		if remaining := r.UnschedulableTimeout - r.Clock.Since(since); remaining > 0 {
+			if requeueAfter == 0 || remaining < requeueAfter {
+				requeueAfter = remaining
+			}
+			continue
+		}
+		index, err := strconv.Atoi(pod.Labels[leaderworkerset.GroupIndexLabelKey])
+		if err != nil {
+			return nil, 0, err
+		}
+		if !slices.Contains(unschedulableGroups, index) {
+			unschedulableGroups = append(unschedulableGroups, index)
+		}
+	}
+	slices.Sort(unschedulableGroups)
+	return unschedulableGroups, requeueAfter, nil
+}
+
+// updateGroupUnschedulableCondition sets the GroupUnschedulable condition when a pod of any group has
+// been unschedulable for longer than UnschedulableTimeout, and clears it once they are all scheduled.
+// An event is emitted for every group once it becomes unschedulable. It returns whether the condition changed, and how long until the next unschedulable pod exceeds the
+// timeout, so that the lws is reconciled again by then, or 0 if there is none.
+func (r *LeaderWorkerSetReconciler) updateGroupUnschedulableCondition(lws *leaderworkerset.LeaderWorkerSet, pods []corev1.Pod) (bool, time.Duration, error) {
+	unschedulableGroups, requeueAfter, err := r.unschedulableGroups(pods)
+	if err != nil {
+		return false, 0, err
+	}
+
+	for _, index := range r.newlyAffectedGroups(lws, leaderworkerset.LeaderWorkerSetGroupUnschedulable, unschedulableGroups) {
+		r.Record.Eventf(lws, corev1.EventTypeWarning, GroupUnschedulable, fmt.Sprintf("Group %s-%d has been unschedulable for more than %s", controllerutils.LeaderStatefulSetName(lws), index, r.UnschedulableTimeout))
+	}
+
+	condition := makeCondition(leaderworkerset.LeaderWorkerSetGroupUnschedulable)
+	if len(unschedulableGroups) == 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "GroupsScheduled"
+		condition.Message = "No group is unschedulable"
+		return setCondition(lws, condition), requeueAfter, nil
+	}
+
+	groupNames := make([]string, 0, len(unschedulableGroups))
+	for _, index := range unschedulableGroups {
+		groupNames = append(groupNames, fmt.Sprintf("%s-%d", controllerutils.LeaderStatefulSetName(lws), index))
+	}
+	condition.Message = fmt.Sprintf("Groups %s have been unschedulable for more than %s", strings.Join(groupNames, ", "), r.UnschedulableTimeout)
+	return setCondition(lws, condition), requeueAfter, nil
+}
+
+// affectedGroupsKey identifies the groups named by a condition of a leaderworkerset.
+type affectedGroupsKey struct {
+	lws           types.NamespacedName
+	conditionType leaderworkerset.LeaderWorkerSetConditionType
+}
+
+// newlyAffectedGroups returns the groups which weren't named by the condition at the last reconcile of
+// the lws, and records groups for the next one. They're only recorded in memory, so the groups are
+// reported again once the controller restarts.
+func (r *LeaderWorkerSetReconciler) newlyAffectedGroups(lws *leaderworkerset.LeaderWorkerSet, conditionType leaderworkerset.LeaderWorkerSetConditionType, groups []int) []int {
+	key := affectedGroupsKey{lws: types.NamespacedName{Namespace: lws.Namespace, Name: lws.Name}, conditionType: conditionType}
+	var previous []int
+	if value, found := r.affectedGroups.Load(key); found {
+		previous = value.([]int)
+	}
+	if len(groups) == 0 {
+		r.affectedGroups.Delete(key)
+	} else {
+		r.affectedGroups.Store(key, slices.Clone(groups))
+	}
+	var newlyAffected []int
+	for _, index := range groups {
+		if !slices.Contains(previous, index) {
+			newlyAffected = append(newlyAffected, index)
+		}
+	}
+	return newlyAffected
+}
+
+// forgetAffectedGroups drops the groups recorded by newlyAffectedGroups for the deleted lws.
+func (r *LeaderWorkerSetReconciler) forgetAffectedGroups(lws types.NamespacedName) {
+	r.affectedGroups.Range(func(key, _ any) bool {
+		if key.(affectedGroupsKey).lws == lws {
+			r.affectedGroups.Delete(key)
+		}
+		return true
+	})
+}
+
+// updateRolloutStalledCondition sets the RolloutStalled condition when a group of the update revision
+// hasn't become ready within the progressDeadlineSeconds of its creation while a rollout is in progress,
+// and clears it otherwise. An event is emitted for every group once it stalls the rollout. It returns
+// whether the condition changed, and how long until the next group of the update revision exceeds the
+// deadline, if any.
+func (r *LeaderWorkerSetReconciler) updateRolloutStalledCondition(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet, pods []corev1.Pod, revisionKey string) (bool, time.Duration, error) {
+	deadline := progressDeadline(lws)
+	rolloutInProgress := groupsOutdated(lws, revisionKey) || lws.Status.UpdatedReplicas != lws.Status.Replicas
+	var stalledGroups []int
+	var requeueAfter time.Duration
+	if deadline > 0 && rolloutInProgress {
+		ready, err := r.readyGroups(ctx, lws, pods)
+		if err != nil {
+			return false, 0, err
+		}
+		for _, pod := range podutils.LeaderPods(pods) {
+			if revisionutils.GetRevisionKey(&pod) != revisionKey {
+				continue
+			}
+			index, err := strconv.Atoi(pod.Labels[leaderworkerset.GroupIndexLabelKey])
+			if err != nil {
+				return false, 0, err
+			}
+			if ready.Has(int32(index)) {
+				continue
+			}
+			if remaining := deadline - r.Clock.Since(pod.CreationTimestamp.Time); remaining > 0 {
+				if requeueAfter == 0 || remaining < requeueAfter {
+					requeueAfter = remaining
+				}
+				continue
+			}
+			stalledGroups = append(stalledGroups, index)
+		}
 	}
 
-	continuousReadyReplicas, lwsUnreadyReplicas, err := r.iterateReplicas(ctx, lws, stsReplicas, revisionKey)
-	if err != nil {
-		return 0, 0, err
+	slices.Sort(stalledGroups)
+	for _, index := range r.newlyAffectedGroups(lws, leaderworkerset.LeaderWorkerSetRolloutStalled, stalledGroups) {
+		r.Record.Eventf(lws, corev1.EventTypeWarning, RolloutStalled, fmt.Sprintf("Group %s-%d of revision %s didn't become ready within %s", controllerutils.LeaderStatefulSetName(lws), index, revisionKey, deadline))
 	}
 
-	originalLwsReplicas, err := strconv.Atoi(sts.Annotations[leaderworkerset.ReplicasAnnotationKey])
-	if err != nil {
-		return 0, 0, err
-	}
-	replicasUpdated := originalLwsReplicas != int(*lws.Spec.Replicas)
-	// Case 4:
-	// Replicas changed during rolling update.
-	if replicasUpdated {
-		return min(partition, burstReplicas), wantReplicas(lwsUnreadyReplicas), nil
+	condition := makeCondition(leaderworkerset.LeaderWorkerSetRolloutStalled)
+	if len(stalledGroups) == 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "RolloutProgressing"
+		condition.Message = "No group of the update revision exceeded the progress deadline"
+		return setCondition(lws, condition), requeueAfter, nil
 	}
 
-	// Case 5:
-	// Calculating the Partition during rolling update, no leaderWorkerSet updates happens.
-
-	rollingStep, err := intstr.GetScaledValueFromIntOrPercent(&lws.Spec.RolloutStrategy.RollingUpdateConfiguration.MaxUnavailable, int(lwsReplicas), false)
-	if err != nil {
-		return 0, 0, err
+	groupNames := make([]string, 0, len(stalledGroups))
+	for _, index := range stalledGroups {
+		groupNames = append(groupNames, fmt.Sprintf("%s-%d", controllerutils.LeaderStatefulSetName(lws), index))
 	}
-	// Make sure that we always respect the maxUnavailable, or
-	// we'll violate it when reclaiming bursted replicas.
-	rollingStep += maxSurge - (int(burstReplicas) - int(stsReplicas))
-
-	// When updated replicas become not ready again or scaled up replicas are not ready yet,
-	// we'll not modify the Partition field. That means Partition moves in one direction to make it simple.
-	return min(partition, utils.NonZeroValue(stsReplicas-int32(rollingStep)-continuousReadyReplicas)), wantReplicas(lwsUnreadyReplicas), nil
+	condition.Message = fmt.Sprintf("Groups %s of revision %s didn't become ready within %s", strings.Join(groupNames, ", "), revisionKey, deadline)
+	return setCondition(lws, condition), requeueAfter, nil
 }
 
-func (r *LeaderWorkerSetReconciler) SSAWithStatefulset(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet, partition, replicas int32, revisionKey string) error {
-	log := ctrl.LoggerFrom(ctx)
+// updateGroupFailedCondition sets the GroupFailed condition when a container of any group terminated
+// with an exit code matching the podFailurePolicy, or any group is retained as failed by the
+// failedGroupRetention, and clears it once none is, e.g. after the failed groups were deleted or
+// restarted. It returns whether the condition changed.
+func (r *LeaderWorkerSetReconciler) updateGroupFailedCondition(lws *leaderworkerset.LeaderWorkerSet, pods []corev1.Pod) (bool, error) {
+	var failedGroups, retainedGroups []int
+	for _, pod := range pods {
+		matched := podutils.MatchesPodFailurePolicy(pod, lws.Spec.LeaderWorkerTemplate.PodFailurePolicy)
+		_, retained := pod.Annotations[leaderworkerset.GroupFailedAnnotationKey]
+		retained = retained && podutils.LeaderPod(pod)
+		if !matched && !retained {
+			continue
+		}
+		index, err := strconv.Atoi(pod.Labels[leaderworkerset.GroupIndexLabelKey])
+		if err != nil {
+			return false, err
+		}
+		if matched && !slices.Contains(failedGroups, index) {
+			failedGroups = append(failedGroups, index)
+		}
+		if retained {
+			retainedGroups = append(retainedGroups, index)
+		}
+	}
 
-	// construct the statefulset apply configuration
-	leaderStatefulSetApplyConfig, err := constructLeaderStatefulSetApplyConfiguration(lws, partition, replicas, revisionKey)
-	if err != nil {
-		log.Error(err, "Constructing StatefulSet apply configuration.")
-		return err
+	condition := makeCondition(leaderworkerset.LeaderWorkerSetGroupFailed)
+	if len(failedGroups) == 0 && len(retainedGroups) == 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "NoGroupFailed"
+		condition.Message = "No group matched the pod failure policy"
+		return setCondition(lws, condition), nil
 	}
-	if err := setControllerReferenceWithStatefulSet(lws, leaderStatefulSetApplyConfig, r.Scheme); err != nil {
-		log.Error(err, "Setting controller reference.")
-		return err
+
+	groupNames := func(indexes []int) string {
+		slices.Sort(indexes)
+		names := make([]string, 0, len(indexes))
+		for _, index := range indexes {
+			names = append(names, fmt.Sprintf("%s-%d", controllerutils.LeaderStatefulSetName(lws), index))
+		}
+		return strings.Join(names, ", ")
 	}
-	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(leaderStatefulSetApplyConfig)
-	if err != nil {
-		log.Error(err, "Converting StatefulSet configuration to json.")
-		return err
+	var messages []string
+	if len(failedGroups) > 0 {
+		messages = append(messages, fmt.Sprintf("Groups %s failed with an exit code matching the pod failure policy", groupNames(failedGroups)))
 	}
-	patch := &unstructured.Unstructured{
-		Object: obj,
+	if len(retainedGroups) > 0 {
+		messages = append(messages, fmt.Sprintf("Groups %s failed and are retained for inspection", groupNames(retainedGroups)))
 	}
-	// Use server side apply and add fieldmanager to the lws owned fields
-	// If there are conflicts in the fields owned by the lws controller, lws will obtain the ownership and force override
-	// these fields to the ones desired by the lws controller
-	// TODO b/316776287 add E2E test for SSA
-	err = r.Patch(ctx, patch, client.Apply, &client.PatchOptions{
-		FieldManager: fieldManager,
-		Force:        ptr.To[bool](true),
-	})
-	if err != nil {
-		log.Error(err, "Using server side apply to update leader statefulset")
-		return err
+	condition.Message = strings.Join(messages, "; ")
+	changed := setCondition(lws, condition)
+	if changed {
+		r.Record.Eventf(lws, corev1.EventTypeWarning, GroupFailed, condition.Message)
 	}
-
-	return nil
+	return changed, nil
 }
 
-// updates the condition of the leaderworkerset to either Progressing or Available.
-func (r *LeaderWorkerSetReconciler) updateConditions(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet, revisionKey string) (bool, bool, error) {
-	log := ctrl.LoggerFrom(ctx)
-	podSelector := client.MatchingLabels(map[string]string{
-		leaderworkerset.SetNameLabelKey:     lws.Name,
-		leaderworkerset.WorkerIndexLabelKey: "0",
-	})
-	leaderPodList := &corev1.PodList{}
-	if err := r.List(ctx, leaderPodList, podSelector, client.InNamespace(lws.Namespace)); err != nil {
-		log.Error(err, "Fetching leaderPods")
-		return false, false, err
+// updateGroupDeadlineExceededCondition keeps down the groups which have been running for longer than the
+// activeDeadlineSeconds. The start of every group is recorded on the leader statefulset from its first
+// leader pod, so that recreating the leader pod doesn't restart the deadline of the group. The groups past
+// their deadline are recorded on the leader statefulset as well, and their leader pod is deleted, which
+// takes down their workers, to be recreated with the DeadlineExceededSchedulingGate by the pod webhook.
+// They're released once the deadline is raised or unset. It sets the GroupDeadlineExceeded condition
+// while any group is kept down, with an event for every group once it exceeds the deadline, and returns
+// whether the condition changed, and how long until the next group exceeds the deadline.
+func (r *LeaderWorkerSetReconciler) updateGroupDeadlineExceededCondition(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet, leaderSts *appsv1.StatefulSet, pods []corev1.Pod) (bool, time.Duration, error) {
+	leaderPodList := podutils.LeaderPods(pods)
+	leaderPods := map[int]*corev1.Pod{}
+	for i := range leaderPodList {
+		leaderPod := &leaderPodList[i]
+		if leaderPod.DeletionTimestamp != nil {
+			continue
+		}
+		index, err := strconv.Atoi(leaderPod.Labels[leaderworkerset.GroupIndexLabelKey])
+		if err != nil {
+			return false, 0, err
+		}
+		leaderPods[index] = leaderPod
 	}
 
-	updateStatus := false
-	readyCount, updatedCount, updatedNonBurstWorkerCount, currentNonBurstWorkerCount, updatedAndReadyCount := 0, 0, 0, 0, 0
-	noWorkerSts := *lws.Spec.LeaderWorkerTemplate.Size == 1
-
-	// Iterate through all leaderPods.
-	for _, pod := range leaderPodList.Items {
-		index, err := strconv.Atoi(pod.Labels[leaderworkerset.GroupIndexLabelKey])
-		if err != nil {
-			return false, false, err
+	previouslyExceeded, err := controllerutils.DeadlineExceededGroups(leaderSts)
+	if err != nil {
+		return false, 0, err
+	}
+	startTimes, err := groupStartTimes(leaderSts)
+	if err != nil {
+		return false, 0, err
+	}
+	var deadline, requeueAfter time.Duration
+	var exceededGroups []int
+	if lws.Spec.LeaderWorkerTemplate.ActiveDeadlineSeconds == nil {
+		startTimes = nil
+	} else {
+		deadline = time.Duration(*lws.Spec.LeaderWorkerTemplate.ActiveDeadlineSeconds) * time.Second
+		// The groups scaled down are forgotten, they start over once scaled up again.
+		start := startOrdinal(leaderSts)
+		for index := range startTimes {
+			if _, found := leaderPods[index]; !found && (index < int(start) || index >= int(start+*lws.Spec.Replicas)) {
+				delete(startTimes, index)
+			}
 		}
-		if index < int(*lws.Spec.Replicas) {
-			currentNonBurstWorkerCount++
+		for index, leaderPod := range leaderPods {
+			if _, found := startTimes[index]; !found {
+				startTimes[index] = leaderPod.CreationTimestamp
+			}
 		}
-
-		var sts appsv1.StatefulSet
-		if !noWorkerSts {
-			if err := r.Get(ctx, client.ObjectKey{Namespace: lws.Namespace, Name: pod.Name}, &sts); err != nil {
-				if client.IgnoreNotFound(err) != nil {
-					log.Error(err, "Fetching worker statefulSet")
-					return false, false, err
-				}
+		now := r.Clock.Now()
+		for index, startTime := range startTimes {
+			if remaining := startTime.Add(deadline).Sub(now); remaining > 0 {
+				requeueAfter = shorterRequeueAfter(requeueAfter, remaining)
 				continue
 			}
+			exceededGroups = append(exceededGroups, index)
 		}
+		slices.Sort(exceededGroups)
+	}
+	if err := r.recordGroupDeadlines(ctx, leaderSts, startTimes, exceededGroups); err != nil {
+		return false, 0, err
+	}
 
-		var ready, updated bool
-		if (noWorkerSts || statefulsetutils.StatefulsetReady(sts)) && podutils.PodRunningAndReady(pod) {
-			ready = true
-			readyCount++
+	// The leader pods are only deleted once the groups are recorded, so that they're recreated with or
+	// without the scheduling gate by the pod webhook.
+	for index, leaderPod := range leaderPods {
+		exceeded := slices.Contains(exceededGroups, index)
+		if exceeded == podutils.HasSchedulingGate(*leaderPod, leaderworkerset.DeadlineExceededSchedulingGate) {
+			continue
 		}
-		if (noWorkerSts || revisionutils.GetRevisionKey(&sts) == revisionKey) && revisionutils.GetRevisionKey(&pod) == revisionKey {
-			updated = true
-			updatedCount++
-			if index < int(*lws.Spec.Replicas) {
-				// Bursted replicas do not count when determining if rollingUpdate has been completed.
-				updatedNonBurstWorkerCount++
-			}
+		if err := r.Delete(ctx, leaderPod, client.PropagationPolicy(metav1.DeletePropagationForeground)); client.IgnoreNotFound(err) != nil {
+			return false, 0, err
 		}
-
-		if ready && updated {
-			// Bursted replicas should not be counted here.
-			if index < int(*lws.Spec.Replicas) {
-				updatedAndReadyCount++
-			}
+	}
+	for _, index := range exceededGroups {
+		if !slices.Contains(previouslyExceeded, index) {
+			r.Record.Eventf(lws, corev1.EventTypeWarning, GroupDeadlineExceeded, fmt.Sprintf("Group %s-%d exceeded the active deadline of %s and is kept down", controllerutils.LeaderStatefulSetName(lws), index, deadline))
 		}
 	}
 
-	if lws.Status.ReadyReplicas != int32(readyCount) {
-		lws.Status.ReadyReplicas = int32(readyCount)
-		updateStatus = true
+	condition := makeCondition(leaderworkerset.LeaderWorkerSetGroupDeadlineExceeded)
+	if len(exceededGroups) == 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "NoGroupDeadlineExceeded"
+		condition.Message = "No group exceeded the active deadline"
+		if deadline == 0 {
+			condition.Message = "No active deadline is set"
+		}
+		return setCondition(lws, condition), requeueAfter, nil
 	}
-
-	if lws.Status.UpdatedReplicas != int32(updatedCount) {
-		lws.Status.UpdatedReplicas = int32(updatedCount)
-		updateStatus = true
+	groupNames := make([]string, 0, len(exceededGroups))
+	for _, index := range exceededGroups {
+		groupNames = append(groupNames, fmt.Sprintf("%s-%d", controllerutils.LeaderStatefulSetName(lws), index))
 	}
+	condition.Message = fmt.Sprintf("Groups %s exceeded the active deadline of %s and are kept down", strings.Join(groupNames, ", "), deadline)
+	return setCondition(lws, condition), requeueAfter, nil
+}
 
-	var conditions []metav1.Condition
-	updateDone := false
-	if updatedNonBurstWorkerCount < currentNonBurstWorkerCount {
-		// upgradeInProgress is true when the upgrade replicas is smaller than the expected
-		// number of total replicas not including the burst replicas
-		conditions = append(conditions, makeCondition(leaderworkerset.LeaderWorkerSetUpdateInProgress))
-		conditions = append(conditions, makeCondition(leaderworkerset.LeaderWorkerSetProgressing))
-	} else if updatedAndReadyCount == int(*lws.Spec.Replicas) {
-		conditions = append(conditions, makeCondition(leaderworkerset.LeaderWorkerSetAvailable))
-		updateDone = true
-	} else {
-		conditions = append(conditions, makeCondition(leaderworkerset.LeaderWorkerSetProgressing))
+// groupStartTimes returns the start times of the groups recorded by the group-start-times annotation of
+// the leader statefulset, by group index.
+func groupStartTimes(leaderSts *appsv1.StatefulSet) (map[int]metav1.Time, error) {
+	startTimes := map[int]metav1.Time{}
+	value, found := leaderSts.Annotations[leaderworkerset.GroupStartTimesAnnotationKey]
+	if !found {
+		return startTimes, nil
 	}
-
-	updateCondition := setConditions(lws, conditions)
-	// if condition changed, record events
-	if updateCondition {
-		r.Record.Eventf(lws, corev1.EventTypeNormal, conditions[0].Reason, conditions[0].Message+fmt.Sprintf(", with %d groups ready of total %d groups", readyCount, int(*lws.Spec.Replicas)))
+	if err := json.Unmarshal([]byte(value), &startTimes); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation for statefulset %s: %w", leaderworkerset.GroupStartTimesAnnotationKey, leaderSts.Name, err)
 	}
-	return updateStatus || updateCondition, updateDone, nil
+	return startTimes, nil
 }
 
-// Updates status and condition of LeaderWorkerSet and returns whether or not an update actually occurred.
-func (r *LeaderWorkerSetReconciler) updateStatus(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet, revisionKey string) (bool, error) {
-	updateStatus := false
-	log := ctrl.LoggerFrom(ctx)
-
-	// Retrieve the leader StatefulSet.
-	sts := &appsv1.StatefulSet{}
-	if err := r.Get(ctx, types.NamespacedName{Name: lws.Name, Namespace: lws.Namespace}, sts); err != nil {
-		log.Error(err, "Error retrieving leader StatefulSet")
-		return false, err
+// recordGroupDeadlines sets the group-start-times and deadline-exceeded-groups annotations of the leader
+// statefulset, they're removed when empty. The statefulset is only patched when they change.
+func (r *LeaderWorkerSetReconciler) recordGroupDeadlines(ctx context.Context, leaderSts *appsv1.StatefulSet, startTimes map[int]metav1.Time, exceededGroups []int) error {
+	annotations := map[string]string{}
+	if len(startTimes) > 0 {
+		encoded, err := json.Marshal(startTimes)
+		if err != nil {
+			return err
+		}
+		annotations[leaderworkerset.GroupStartTimesAnnotationKey] = string(encoded)
 	}
-
-	// retrieve the current number of replicas -- the number of leaders
-	replicas := int(sts.Status.Replicas)
-	if lws.Status.Replicas != int32(replicas) {
-		lws.Status.Replicas = int32(replicas)
-		updateStatus = true
+	if len(exceededGroups) > 0 {
+		encoded, err := json.Marshal(exceededGroups)
+		if err != nil {
+			return err
+		}
+		annotations[leaderworkerset.DeadlineExceededGroupsAnnotationKey] = string(encoded)
+	}
+	original := leaderSts.DeepCopy()
+	for _, key := range []string{leaderworkerset.GroupStartTimesAnnotationKey, leaderworkerset.DeadlineExceededGroupsAnnotationKey} {
+		if value, found := annotations[key]; found {
+			metav1.SetMetaDataAnnotation(&leaderSts.ObjectMeta, key, value)
+		} else {
+			delete(leaderSts.Annotations, key)
+		}
+	}
+	if maps.Equal(original.Annotations, leaderSts.Annotations) {
+		return nil
 	}
+	return r.Patch(ctx, leaderSts, client.MergeFrom(original))
+}
 
-	if lws.Status.HPAPodSelector == "" {
-		labelSelector := &metav1.LabelSelector{
-			MatchLabels: map[string]string{
-				leaderworkerset.SetNameLabelKey:     lws.Name,
-				leaderworkerset.WorkerIndexLabelKey: "0", // select leaders
-			},
+// updateDuplicateLeaderCondition deletes the leader pods of a group index beyond the first one, e.g. left
+// by a manual edit of the labels, so that every group converges to a single leader. The pod of the leader
+// statefulset is kept, otherwise the oldest pod, and the newer duplicates are deleted as long as they're
+// controlled by the leader statefulset or orphans, the others are only reported. The pods controlled by a
+// worker statefulset are workers whose worker index label is repaired by the pod controller. It sets the
+// DuplicateLeader condition while duplicates are found, with an event for every group once duplicates
+// are found, and returns whether the condition changed.
+func (r *LeaderWorkerSetReconciler) updateDuplicateLeaderCondition(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet, pods []corev1.Pod) (bool, error) {
+	log := ctrl.LoggerFrom(ctx)
+	leaderPodList := podutils.LeaderPods(pods)
+	leaderStsName := controllerutils.LeaderStatefulSetName(lws)
+	leaderPods := map[int][]*corev1.Pod{}
+	for i := range leaderPodList {
+		leaderPod := &leaderPodList[i]
+		if leaderPod.DeletionTimestamp != nil || leaderPod.Labels[leaderworkerset.GroupIndexLabelKey] == "" {
+			continue
 		}
-		selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+		if ref := metav1.GetControllerOfNoCopy(leaderPod); ref != nil && ref.Kind == "StatefulSet" && strings.HasPrefix(ref.Name, leaderStsName+"-") {
+			continue
+		}
+		index, err := strconv.Atoi(leaderPod.Labels[leaderworkerset.GroupIndexLabelKey])
 		if err != nil {
-			log.Error(err, "Converting label selector to selector")
 			return false, err
 		}
+		leaderPods[index] = append(leaderPods[index], leaderPod)
+	}
 
-		lws.Status.HPAPodSelector = selector.String()
-		updateStatus = true
+	var duplicateGroups []int
+	keptPods := map[int][]string{}
+	for index, pods := range leaderPods {
+		if len(pods) < 2 {
+			continue
+		}
+		stsPodName := fmt.Sprintf("%s-%d", leaderStsName, index)
+		slices.SortFunc(pods, func(a, b *corev1.Pod) int {
+			if (a.Name == stsPodName) != (b.Name == stsPodName) {
+				if a.Name == stsPodName {
+					return -1
+				}
+				return 1
+			}
+			if c := a.CreationTimestamp.Time.Compare(b.CreationTimestamp.Time); c != 0 {
+				return c
+			}
+			return strings.Compare(a.Name, b.Name)
+		})
+		for _, pod := range pods[1:] {
+			if ref := metav1.GetControllerOfNoCopy(pod); ref != nil && (ref.Kind != "StatefulSet" || ref.Name != leaderStsName) {
+				keptPods[index] = append(keptPods[index], pod.Name)
+				continue
+			}
+			log.V(2).Info("Deleting duplicate leader pod", "pod", klog.KObj(pod), "leader", klog.KObj(pods[0]))
+			if err := r.Delete(ctx, pod); client.IgnoreNotFound(err) != nil {
+				return false, err
+			}
+		}
+		duplicateGroups = append(duplicateGroups, index)
 	}
 
-	// check if an update is needed
-	updateConditions, updateDone, err := r.updateConditions(ctx, lws, revisionKey)
-	if err != nil {
-		return false, err
+	slices.Sort(duplicateGroups)
+	for _, index := range r.newlyAffectedGroups(lws, leaderworkerset.LeaderWorkerSetDuplicateLeader, duplicateGroups) {
+		if kept := keptPods[index]; len(kept) > 0 {
+			r.Record.Eventf(lws, corev1.EventTypeWarning, DuplicateLeader, fmt.Sprintf("Group %s-%d has more than one leader pod, %s not controlled by the leaderworkerset were kept", leaderStsName, index, strings.Join(kept, ", ")))
+			continue
+		}
+		r.Record.Eventf(lws, corev1.EventTypeWarning, DuplicateLeader, fmt.Sprintf("Group %s-%d had more than one leader pod, the newer duplicates were deleted", leaderStsName, index))
 	}
 
-	if updateStatus || updateConditions {
-		if err := r.Status().Update(ctx, lws); err != nil {
-			if !apierrors.IsConflict(err) {
-				log.Error(err, "Updating LeaderWorkerSet status and/or condition.")
-			}
-			return false, err
+	condition := makeCondition(leaderworkerset.LeaderWorkerSetDuplicateLeader)
+	if len(duplicateGroups) == 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "NoDuplicateLeader"
+		condition.Message = "Every group has a single leader pod"
+		return setCondition(lws, condition), nil
+	}
+
+	groupNames := make([]string, 0, len(duplicateGroups))
+	var kept []string
+	for _, index := range duplicateGroups {
+		groupNames = append(groupNames, fmt.Sprintf("%s-%d", leaderStsName, index))
+		kept = append(kept, keptPods[index]...)
+	}
+	condition.Message = fmt.Sprintf("Groups %s had more than one leader pod, the newer duplicates were deleted", strings.Join(groupNames, ", "))
+	if len(kept) > 0 {
+		condition.Message += fmt.Sprintf(" except %s, not controlled by the leaderworkerset", strings.Join(kept, ", "))
+	}
+	return setCondition(lws, condition), nil
+}
+
+// updateRolloutStartTime sets the rollout start time once a group running an old revision is observed,
+// and clears it once all the groups are updated, recording the completion time of the rollout. The start
+// time is kept in the status so that rollouts in progress when the controller restarts are still tracked,
+// and the completion time is only recorded on that transition. It returns whether the status was changed.
+func updateRolloutStartTime(lws *leaderworkerset.LeaderWorkerSet, revisionKey string, now time.Time) bool {
+	outdated := groupsOutdated(lws, revisionKey)
+	if lws.Status.RolloutStartTime == nil {
+		if outdated && lws.Status.UpdatedReplicas != lws.Status.Replicas {
+			lws.Status.RolloutStartTime = ptr.To(metav1.NewTime(now))
+			return true
 		}
+		return false
 	}
-	return updateDone, nil
+	if !outdated && lws.Status.UpdatedReplicas == lws.Status.Replicas {
+		lws.Status.RolloutStartTime = nil
+		lws.Status.LastRolloutCompletionTime = ptr.To(metav1.NewTime(now))
+		return true
+	}
+	return false
+}
+
+// updateRevisions sets the updateRevision to the revisionKey, and the currentRevision as well once all
+// the groups are updated, same as for StatefulSets. Returns true if any of them changed.
+func updateRevisions(lws *leaderworkerset.LeaderWorkerSet, revisionKey string) bool {
+	changed := false
+	if lws.Status.UpdateRevision != revisionKey {
+		lws.Status.UpdateRevision = revisionKey
+		changed = true
+	}
+	rolloutDone := !groupsOutdated(lws, revisionKey) && lws.Status.UpdatedReplicas == lws.Status.Replicas
+	if lws.Status.CurrentRevision != revisionKey && (lws.Status.CurrentRevision == "" || rolloutDone) {
+		lws.Status.CurrentRevision = revisionKey
+		changed = true
+	}
+	return changed
+}
+
+// groupsOutdated returns true if any of the tracked groups isn't at the revisionKey.
+func groupsOutdated(lws *leaderworkerset.LeaderWorkerSet, revisionKey string) bool {
+	return slices.ContainsFunc(lws.Status.GroupStatuses, func(status leaderworkerset.GroupStatus) bool {
+		return status.Revision != revisionKey
+	})
 }
 
 // iterateReplicas will iterate the leader pods together with corresponding worker statefulsets
@@ -516,21 +2629,14 @@ func (r *LeaderWorkerSetReconciler) updateStatus(ctx context.Context, lws *leade
 //   - The first value represents the number of continuous ready replicas ranging from the last index to 0,
 //     to help us judge whether we can update the Partition or not.
 //   - The second value represents the unready replicas whose index is smaller than leaderWorkerSet Replicas.
-func (r *LeaderWorkerSetReconciler) iterateReplicas(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet, stsReplicas int32, revisionKey string) (int32, int32, error) {
-	podSelector := client.MatchingLabels(map[string]string{
-		leaderworkerset.SetNameLabelKey:     lws.Name,
-		leaderworkerset.WorkerIndexLabelKey: "0",
-	})
-	var leaderPodList corev1.PodList
-	if err := r.List(ctx, &leaderPodList, podSelector, client.InNamespace(lws.Namespace)); err != nil {
-		return 0, 0, err
-	}
-
+//
+// Indexes are relative to start, the index of the first group.
+func (r *LeaderWorkerSetReconciler) iterateReplicas(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet, pods []corev1.Pod, start, stsReplicas int32, revisionKey string) (int32, int32, error) {
 	// Get a sorted leader pod list matches with the following sorted statefulsets one by one, which means
 	// the leader pod and the corresponding worker statefulset has the same index.
 	sortedPods := utils.SortByIndex(func(pod corev1.Pod) (int, error) {
-		return strconv.Atoi(pod.Labels[leaderworkerset.GroupIndexLabelKey])
-	}, leaderPodList.Items, int(stsReplicas))
+		return relativeGroupIndex(pod.Labels[leaderworkerset.GroupIndexLabelKey], start)
+	}, podutils.LeaderPods(pods), int(stsReplicas))
 
 	stsSelector := client.MatchingLabels(map[string]string{
 		leaderworkerset.SetNameLabelKey: lws.Name,
@@ -540,21 +2646,28 @@ func (r *LeaderWorkerSetReconciler) iterateReplicas(ctx context.Context, lws *le
 		return 0, 0, err
 	}
 	sortedSts := utils.SortByIndex(func(sts appsv1.StatefulSet) (int, error) {
-		return strconv.Atoi(sts.Labels[leaderworkerset.GroupIndexLabelKey])
+		return relativeGroupIndex(sts.Labels[leaderworkerset.GroupIndexLabelKey], start)
 	}, stsList.Items, int(stsReplicas))
 
-	// Once size==1, no worker statefulSets will be created.
-	noWorkerSts := *lws.Spec.LeaderWorkerTemplate.Size == 1
+	sizes, err := r.groupSizes(ctx, lws, pods)
+	if err != nil {
+		return 0, 0, err
+	}
 	processReplica := func(index int32) (ready bool) {
-		nominatedName := fmt.Sprintf("%s-%d", lws.Name, index)
+		nominatedName := fmt.Sprintf("%s-%d", controllerutils.LeaderStatefulSetName(lws), start+index)
+		// Once size==1, no worker statefulSets will be created. The size is the one of the revision
+		// of the group.
+		noWorkerSts := groupSize(lws, sizes, strconv.Itoa(int(start+index))) == 1
 		// It can happen that the leader pod or the worker statefulset hasn't created yet
 		// or under rebuilding, which also indicates not ready.
 		if nominatedName != sortedPods[index].Name || (!noWorkerSts && nominatedName != sortedSts[index].Name) {
 			return false
 		}
 
+		// Groups below the partition of the rollout strategy are not updated, they only need to be ready.
+		pinned := index < rolloutPartition(lws)
 		podTemplateHash := revisionutils.GetRevisionKey(&sortedPods[index])
-		if !(podTemplateHash == revisionKey && podutils.PodRunningAndReady(sortedPods[index])) {
+		if !((pinned || podTemplateHash == revisionKey) && podutils.PodRunningAndReady(sortedPods[index])) {
 			return false
 		}
 
@@ -563,7 +2676,7 @@ func (r *LeaderWorkerSetReconciler) iterateReplicas(ctx context.Context, lws *le
 		}
 
 		stsTemplateHash := revisionutils.GetRevisionKey(&sortedSts[index])
-		return stsTemplateHash == revisionKey && statefulsetutils.StatefulsetReady(sortedSts[index])
+		return (pinned || stsTemplateHash == revisionKey) && statefulsetutils.StatefulsetReady(sortedSts[index])
 	}
 
 	var skip bool
@@ -584,9 +2697,32 @@ func (r *LeaderWorkerSetReconciler) iterateReplicas(ctx context.Context, lws *le
 	return continuousReadyReplicas, lwsUnreadyReplicas, nil
 }
 
+// relativeGroupIndex parses the group index and returns it relative to start, groups below start
+// which are being deleted return an error.
+func relativeGroupIndex(groupIndex string, start int32) (int, error) {
+	index, err := strconv.Atoi(groupIndex)
+	if err != nil {
+		return 0, err
+	}
+	if index < int(start) {
+		return 0, fmt.Errorf("group index %d is lower than the start index %d", index, start)
+	}
+	return index - int(start), nil
+}
+
+// listPods lists the pods of the lws, leaders and workers. They're listed once per reconcile and
+// passed to the helpers, which filter them, e.g. with podutils.LeaderPods.
+func (r *LeaderWorkerSetReconciler) listPods(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet) ([]corev1.Pod, error) {
+	var podList corev1.PodList
+	if err := r.List(ctx, &podList, client.InNamespace(lws.Namespace), client.MatchingLabels{leaderworkerset.SetNameLabelKey: lws.Name}); err != nil {
+		return nil, err
+	}
+	return podList.Items, nil
+}
+
 func (r *LeaderWorkerSetReconciler) getLeaderStatefulSet(ctx context.Context, lws *leaderworkerset.LeaderWorkerSet) (*appsv1.StatefulSet, error) {
 	sts := &appsv1.StatefulSet{}
-	err := r.Get(ctx, types.NamespacedName{Name: lws.Name, Namespace: lws.Namespace}, sts)
+	err := r.Get(ctx, types.NamespacedName{Name: controllerutils.LeaderStatefulSetName(lws), Namespace: lws.Namespace}, sts)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			return nil, nil
@@ -639,7 +2775,8 @@ func (r *LeaderWorkerSetReconciler) getUpdatedRevision(ctx context.Context, sts
 }
 
 // constructLeaderStatefulSetApplyConfiguration constructs the applied configuration for the leader StatefulSet
-func constructLeaderStatefulSetApplyConfiguration(lws *leaderworkerset.LeaderWorkerSet, partition, replicas int32, revisionKey string) (*appsapplyv1.StatefulSetApplyConfiguration, error) {
+// start is the ordinal of the first group, and partition is relative to it.
+func constructLeaderStatefulSetApplyConfiguration(lws *leaderworkerset.LeaderWorkerSet, start, partition, replicas int32, revisionKey string) (*appsapplyv1.StatefulSetApplyConfiguration, error) {
 	var podTemplateSpec corev1.PodTemplateSpec
 	if lws.Spec.LeaderWorkerTemplate.LeaderTemplate != nil {
 		podTemplateSpec = *lws.Spec.LeaderWorkerTemplate.LeaderTemplate.DeepCopy()
@@ -657,6 +2794,7 @@ func constructLeaderStatefulSetApplyConfiguration(lws *leaderworkerset.LeaderWor
 		return nil, err
 	}
 
+	addInheritedLabels(&podTemplateApplyConfiguration, lws)
 	podTemplateApplyConfiguration.WithLabels(map[string]string{
 		leaderworkerset.WorkerIndexLabelKey: "0",
 		leaderworkerset.SetNameLabelKey:     lws.Name,
@@ -664,33 +2802,104 @@ func constructLeaderStatefulSetApplyConfiguration(lws *leaderworkerset.LeaderWor
 	})
 	podAnnotations := make(map[string]string)
 	podAnnotations[leaderworkerset.SizeAnnotationKey] = strconv.Itoa(int(*lws.Spec.LeaderWorkerTemplate.Size))
-	if lws.Annotations[leaderworkerset.ExclusiveKeyAnnotationKey] != "" {
-		podAnnotations[leaderworkerset.ExclusiveKeyAnnotationKey] = lws.Annotations[leaderworkerset.ExclusiveKeyAnnotationKey]
+	if topologyKey := controllerutils.ExclusiveTopologyKey(lws); topologyKey != "" {
+		podAnnotations[leaderworkerset.ExclusiveKeyAnnotationKey] = topologyKey
 	}
 	if lws.Spec.LeaderWorkerTemplate.SubGroupPolicy != nil {
 		podAnnotations[leaderworkerset.SubGroupPolicyTypeAnnotationKey] = (string(*lws.Spec.LeaderWorkerTemplate.SubGroupPolicy.Type))
 		podAnnotations[leaderworkerset.SubGroupSizeAnnotationKey] = strconv.Itoa(int(*lws.Spec.LeaderWorkerTemplate.SubGroupPolicy.SubGroupSize))
-		if lws.Annotations[leaderworkerset.SubGroupExclusiveKeyAnnotationKey] != "" {
-			podAnnotations[leaderworkerset.SubGroupExclusiveKeyAnnotationKey] = lws.Annotations[leaderworkerset.SubGroupExclusiveKeyAnnotationKey]
+		if subGroupTopologyKey := controllerutils.SubGroupExclusiveTopologyKey(lws); subGroupTopologyKey != "" {
+			podAnnotations[leaderworkerset.SubGroupExclusiveKeyAnnotationKey] = subGroupTopologyKey
 		}
 	}
 
-	if lws.Spec.NetworkConfig != nil && *lws.Spec.NetworkConfig.SubdomainPolicy == leaderworkerset.SubdomainUniquePerReplica {
-		podAnnotations[leaderworkerset.SubdomainPolicyAnnotationKey] = string(leaderworkerset.SubdomainUniquePerReplica)
+	if lws.Spec.NetworkConfig != nil && *lws.Spec.NetworkConfig.SubdomainPolicy != leaderworkerset.SubdomainShared {
+		podAnnotations[leaderworkerset.SubdomainPolicyAnnotationKey] = string(*lws.Spec.NetworkConfig.SubdomainPolicy)
+	}
+	if lws.Spec.LeaderWorkerTemplate.InjectPeerAddresses {
+		podAnnotations[leaderworkerset.InjectPeerAddressesAnnotationKey] = "true"
+	}
+	if len(lws.Spec.LeaderWorkerTemplate.NetworkEnvNames) > 0 {
+		networkEnvNames, err := json.Marshal(lws.Spec.LeaderWorkerTemplate.NetworkEnvNames)
+		if err != nil {
+			return nil, err
+		}
+		podAnnotations[leaderworkerset.NetworkEnvNamesAnnotationKey] = string(networkEnvNames)
+	}
+	if lws.Spec.LeaderWorkerTemplate.LeaderPodDeletionCost != nil {
+		podAnnotations[leaderworkerset.LeaderPodDeletionCostAnnotationKey] = strconv.Itoa(int(*lws.Spec.LeaderWorkerTemplate.LeaderPodDeletionCost))
+	}
+	if lws.Spec.LeaderWorkerTemplate.LeaderPriorityClassName != "" {
+		podAnnotations[leaderworkerset.LeaderPriorityClassNameAnnotationKey] = lws.Spec.LeaderWorkerTemplate.LeaderPriorityClassName
+	}
+	if lws.Spec.LeaderWorkerTemplate.LeaderTerminationGracePeriodSeconds != nil {
+		podAnnotations[leaderworkerset.LeaderTerminationGracePeriodSecondsAnnotationKey] = strconv.FormatInt(*lws.Spec.LeaderWorkerTemplate.LeaderTerminationGracePeriodSeconds, 10)
+	}
+	if lws.Spec.LeaderWorkerTemplate.LeaderServiceAccountName != "" {
+		podAnnotations[leaderworkerset.ServiceAccountNameAnnotationKey] = lws.Spec.LeaderWorkerTemplate.LeaderServiceAccountName
+	}
+	if lws.Spec.LeaderWorkerTemplate.GangSchedulingLabelKey != "" {
+		podAnnotations[leaderworkerset.GangSchedulingLabelKeyAnnotationKey] = lws.Spec.LeaderWorkerTemplate.GangSchedulingLabelKey
+	}
+	if len(lws.Spec.LeaderWorkerTemplate.GroupSpreadConstraints) > 0 {
+		groupSpreadConstraints, err := json.Marshal(lws.Spec.LeaderWorkerTemplate.GroupSpreadConstraints)
+		if err != nil {
+			return nil, err
+		}
+		podAnnotations[leaderworkerset.GroupSpreadConstraintsAnnotationKey] = string(groupSpreadConstraints)
+	}
+	if len(lws.Spec.LeaderWorkerTemplate.CommonContainers) > 0 {
+		commonContainers, err := json.Marshal(lws.Spec.LeaderWorkerTemplate.CommonContainers)
+		if err != nil {
+			return nil, err
+		}
+		podAnnotations[leaderworkerset.CommonContainersAnnotationKey] = string(commonContainers)
+	}
+	if len(lws.Spec.LeaderWorkerTemplate.LeaderGroupInitContainers) > 0 {
+		leaderGroupInitContainers, err := json.Marshal(lws.Spec.LeaderWorkerTemplate.LeaderGroupInitContainers)
+		if err != nil {
+			return nil, err
+		}
+		podAnnotations[leaderworkerset.LeaderGroupInitContainersAnnotationKey] = string(leaderGroupInitContainers)
+	}
+	if lws.Spec.LeaderWorkerTemplate.PublishMembershipConfigMap {
+		podAnnotations[leaderworkerset.MembershipConfigMapAnnotationKey] = "true"
+		// The group index of the leader pods is only known once they're created.
+		addMembershipVolume(&podTemplateApplyConfiguration, fmt.Sprintf("%s-membership", lws.Name))
 	}
 
 	podTemplateApplyConfiguration.WithAnnotations(podAnnotations)
 
+	// The leader pod is held from scheduling until all the workers in the group are ready,
+	// the gate will be removed by the pod controller.
+	if lws.Spec.StartupPolicy == leaderworkerset.WorkersFirstStartupPolicy && *lws.Spec.LeaderWorkerTemplate.Size > 1 {
+		podTemplateApplyConfiguration.Spec.WithSchedulingGates(coreapplyv1.PodSchedulingGate().WithName(leaderworkerset.WorkersReadySchedulingGate))
+	}
+
+	// The leader statefulset is always rolling updated, the Recreate rollout strategy is
+	// implemented by scaling it down and up.
+	rollingUpdate := appsapplyv1.RollingUpdateStatefulSetStrategy().WithPartition(partition)
+	if config := lws.Spec.RolloutStrategy.RollingUpdateConfiguration; config != nil {
+		maxUnavailable := config.MaxUnavailable
+		// The statefulset counts groups, so subgroups are converted to groups first.
+		if config.Granularity == leaderworkerset.SubGroupRolloutGranularity {
+			maxUnavailableGroups, err := rolloututils.MaxUnavailableGroups(lws, *lws.Spec.Replicas)
+			if err != nil {
+				return nil, err
+			}
+			maxUnavailable = intstr.FromInt32(maxUnavailableGroups)
+		}
+		rollingUpdate.WithMaxUnavailable(maxUnavailable)
+	}
+
 	// construct statefulset apply configuration
-	statefulSetConfig := appsapplyv1.StatefulSet(lws.Name, lws.Namespace).
+	statefulSetConfig := appsapplyv1.StatefulSet(controllerutils.LeaderStatefulSetName(lws), lws.Namespace).
 		WithSpec(appsapplyv1.StatefulSetSpec().
 			WithServiceName(lws.Name).
 			WithReplicas(replicas).
 			WithPodManagementPolicy(appsv1.ParallelPodManagement).
 			WithTemplate(&podTemplateApplyConfiguration).
-			WithUpdateStrategy(appsapplyv1.StatefulSetUpdateStrategy().WithType(appsv1.StatefulSetUpdateStrategyType(lws.Spec.RolloutStrategy.Type)).WithRollingUpdate(
-				appsapplyv1.RollingUpdateStatefulSetStrategy().WithMaxUnavailable(lws.Spec.RolloutStrategy.RollingUpdateConfiguration.MaxUnavailable).WithPartition(partition),
-			)).
+			WithUpdateStrategy(appsapplyv1.StatefulSetUpdateStrategy().WithType(appsv1.RollingUpdateStatefulSetStrategyType).WithRollingUpdate(rollingUpdate)).
 			WithSelector(metaapplyv1.LabelSelector().
 				WithMatchLabels(map[string]string{
 					leaderworkerset.SetNameLabelKey:     lws.Name,
@@ -703,9 +2912,55 @@ func constructLeaderStatefulSetApplyConfiguration(lws *leaderworkerset.LeaderWor
 		WithAnnotations(map[string]string{
 			leaderworkerset.ReplicasAnnotationKey: strconv.Itoa(int(*lws.Spec.Replicas)),
 		})
+	// The start ordinal only ever moves up, once set it has to be applied in every reconciliation,
+	// otherwise it would be reset to 0 by server side apply.
+	if start > 0 {
+		statefulSetConfig.Spec.WithOrdinals(appsapplyv1.StatefulSetOrdinals().WithStart(start))
+	}
+	if err := addVolumeClaimTemplates(statefulSetConfig, lws); err != nil {
+		return nil, err
+	}
 	return statefulSetConfig, nil
 }
 
+// addVolumeClaimTemplates adds the volume claim templates of the lws and their retention policy to
+// the statefulset, which creates a claim for each of its pods. The worker statefulsets are never
+// scaled down, they're deleted with their groups, so only WhenDeleted matters for them.
+func addVolumeClaimTemplates(sts *appsapplyv1.StatefulSetApplyConfiguration, lws *leaderworkerset.LeaderWorkerSet) error {
+	for i := range lws.Spec.LeaderWorkerTemplate.VolumeClaimTemplates {
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&lws.Spec.LeaderWorkerTemplate.VolumeClaimTemplates[i])
+		if err != nil {
+			return err
+		}
+		var claim coreapplyv1.PersistentVolumeClaimApplyConfiguration
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj, &claim); err != nil {
+			return err
+		}
+		sts.Spec.WithVolumeClaimTemplates(&claim)
+	}
+	if policy := lws.Spec.LeaderWorkerTemplate.PersistentVolumeClaimRetentionPolicy; policy != nil {
+		retentionPolicy := appsapplyv1.StatefulSetPersistentVolumeClaimRetentionPolicy()
+		if policy.WhenDeleted != "" {
+			retentionPolicy.WithWhenDeleted(policy.WhenDeleted)
+		}
+		if policy.WhenScaled != "" {
+			retentionPolicy.WithWhenScaled(policy.WhenScaled)
+		}
+		sts.Spec.WithPersistentVolumeClaimRetentionPolicy(retentionPolicy)
+	}
+	return nil
+}
+
+// addInheritedLabels sets the inherited labels of the lws on the pod template, unless the template
+// sets them already.
+func addInheritedLabels(podTemplateApplyConfiguration *coreapplyv1.PodTemplateSpecApplyConfiguration, lws *leaderworkerset.LeaderWorkerSet) {
+	for key, value := range controllerutils.InheritedLabels(lws) {
+		if _, found := podTemplateApplyConfiguration.Labels[key]; !found {
+			podTemplateApplyConfiguration.WithLabels(map[string]string{key: value})
+		}
+	}
+}
+
 func makeCondition(conditionType leaderworkerset.LeaderWorkerSetConditionType) metav1.Condition {
 	var condtype, reason, message string
 	switch conditionType {
@@ -717,6 +2972,46 @@ func makeCondition(conditionType leaderworkerset.LeaderWorkerSetConditionType) m
 		condtype = string(leaderworkerset.LeaderWorkerSetUpdateInProgress)
 		reason = GroupsUpdating
 		message = "Rolling Upgrade is in progress"
+	case leaderworkerset.LeaderWorkerSetPaused:
+		condtype = string(leaderworkerset.LeaderWorkerSetPaused)
+		reason = "Paused"
+		message = "Reconciliation is paused"
+	case leaderworkerset.LeaderWorkerSetSuspended:
+		condtype = string(leaderworkerset.LeaderWorkerSetSuspended)
+		reason = "Suspended"
+		message = "LeaderWorkerSet is suspended"
+	case leaderworkerset.LeaderWorkerSetGroupUnschedulable:
+		condtype = string(leaderworkerset.LeaderWorkerSetGroupUnschedulable)
+		reason = GroupUnschedulable
+		message = "Groups are unschedulable"
+	case leaderworkerset.LeaderWorkerSetInsufficientCapacity:
+		condtype = string(leaderworkerset.LeaderWorkerSetInsufficientCapacity)
+		reason = InsufficientCapacity
+		message = "Groups can't be scheduled for lack of capacity"
+	case leaderworkerset.LeaderWorkerSetGroupFailed:
+		condtype = string(leaderworkerset.LeaderWorkerSetGroupFailed)
+		reason = GroupFailed
+		message = "Groups failed"
+	case leaderworkerset.LeaderWorkerSetGroupDeadlineExceeded:
+		condtype = string(leaderworkerset.LeaderWorkerSetGroupDeadlineExceeded)
+		reason = GroupDeadlineExceeded
+		message = "Groups exceeded the active deadline"
+	case leaderworkerset.LeaderWorkerSetDuplicateLeader:
+		condtype = string(leaderworkerset.LeaderWorkerSetDuplicateLeader)
+		reason = DuplicateLeader
+		message = "Groups have more than one leader pod"
+	case leaderworkerset.LeaderWorkerSetPendingApproval:
+		condtype = string(leaderworkerset.LeaderWorkerSetPendingApproval)
+		reason = "AwaitingApproval"
+		message = "Rollout is waiting for approval"
+	case leaderworkerset.LeaderWorkerSetRolloutStalled:
+		condtype = string(leaderworkerset.LeaderWorkerSetRolloutStalled)
+		reason = ProgressDeadlineExceeded
+		message = "Rollout is stalled"
+	case leaderworkerset.LeaderWorkerSetUpdateComplete:
+		condtype = string(leaderworkerset.LeaderWorkerSetUpdateComplete)
+		reason = "AllGroupsUpdated"
+		message = "All replicas are at the latest revision"
 	default:
 		condtype = string(leaderworkerset.LeaderWorkerSetProgressing)
 		reason = GroupsProgressing
@@ -736,7 +3031,7 @@ func makeCondition(conditionType leaderworkerset.LeaderWorkerSetConditionType) m
 func setConditions(lws *leaderworkerset.LeaderWorkerSet, conditions []metav1.Condition) bool {
 	shouldUpdate := false
 	for _, condition := range conditions {
-		shouldUpdate = shouldUpdate || setCondition(lws, condition)
+		shouldUpdate = setCondition(lws, condition) || shouldUpdate
 	}
 
 	return shouldUpdate
@@ -755,8 +3050,14 @@ func setCondition(lws *leaderworkerset.LeaderWorkerSet, newCondition metav1.Cond
 				// with the new condition.
 				lws.Status.Conditions[i] = newCondition
 				shouldUpdate = true
+			} else if newCondition.Reason != curCondition.Reason || newCondition.Message != curCondition.Message {
+				// the status is the same but the details changed, e.g. the groups named by the message.
+				// Keep the transition time of the stored condition.
+				lws.Status.Conditions[i].Reason = newCondition.Reason
+				lws.Status.Conditions[i].Message = newCondition.Message
+				shouldUpdate = true
 			}
-			// if both are true or both are false, do nothing.
+			// if both are true or both are false with the same details, do nothing.
 			found = true
 		} else {
 			// if the conditions are not of the same type, do nothing unless one is Progressing and one is
@@ -18,21 +18,42 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-logr/logr/funcr"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/prometheus/client_golang/prometheus"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	appsapplyv1 "k8s.io/client-go/applyconfigurations/apps/v1"
 	coreapplyv1 "k8s.io/client-go/applyconfigurations/core/v1"
 	metaapplyv1 "k8s.io/client-go/applyconfigurations/meta/v1"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
 
 	leaderworkerset "sigs.k8s.io/lws/api/leaderworkerset/v1"
 
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/lws/pkg/metrics"
 	revisionutils "sigs.k8s.io/lws/pkg/utils/revision"
 	"sigs.k8s.io/lws/test/wrappers"
 )
@@ -402,10 +423,80 @@ func TestLeaderStatefulSetApplyConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:        "1 replica, size 2, startupPolicy WorkersFirst",
+			revisionKey: revisionKey1,
+			lws: wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").
+				Replica(1).
+				RolloutStrategy(leaderworkerset.RolloutStrategy{
+					Type: leaderworkerset.RollingUpdateStrategyType,
+					RollingUpdateConfiguration: &leaderworkerset.RollingUpdateConfiguration{
+						MaxUnavailable: intstr.FromInt32(1),
+					},
+				}).
+				LeaderTemplateSpec(wrappers.MakeLeaderPodSpec()).
+				WorkerTemplateSpec(wrappers.MakeWorkerPodSpec()).
+				Size(2).
+				StartupPolicy(leaderworkerset.WorkersFirstStartupPolicy).
+				RestartPolicy(leaderworkerset.RecreateGroupOnPodRestart).Obj(),
+			wantApplyConfig: &appsapplyv1.StatefulSetApplyConfiguration{
+				TypeMetaApplyConfiguration: metaapplyv1.TypeMetaApplyConfiguration{
+					Kind:       ptr.To[string]("StatefulSet"),
+					APIVersion: ptr.To[string]("apps/v1"),
+				},
+				ObjectMetaApplyConfiguration: &metaapplyv1.ObjectMetaApplyConfiguration{
+					Name:      ptr.To[string]("test-sample"),
+					Namespace: ptr.To[string]("default"),
+					Labels: map[string]string{
+						"leaderworkerset.sigs.k8s.io/name":                   "test-sample",
+						"leaderworkerset.sigs.k8s.io/template-revision-hash": revisionKey1,
+					},
+					Annotations: map[string]string{"leaderworkerset.sigs.k8s.io/replicas": "1"},
+				},
+				Spec: &appsapplyv1.StatefulSetSpecApplyConfiguration{
+					Replicas: ptr.To[int32](1),
+					Selector: &metaapplyv1.LabelSelectorApplyConfiguration{
+						MatchLabels: map[string]string{
+							"leaderworkerset.sigs.k8s.io/name":         "test-sample",
+							"leaderworkerset.sigs.k8s.io/worker-index": "0",
+						},
+					},
+					Template: &coreapplyv1.PodTemplateSpecApplyConfiguration{
+						ObjectMetaApplyConfiguration: &metaapplyv1.ObjectMetaApplyConfiguration{
+							Labels: map[string]string{
+								"leaderworkerset.sigs.k8s.io/name":                   "test-sample",
+								"leaderworkerset.sigs.k8s.io/worker-index":           "0",
+								"leaderworkerset.sigs.k8s.io/template-revision-hash": revisionKey1,
+							},
+							Annotations: map[string]string{
+								"leaderworkerset.sigs.k8s.io/size": "2",
+							},
+						},
+						Spec: &coreapplyv1.PodSpecApplyConfiguration{
+							Containers: []coreapplyv1.ContainerApplyConfiguration{
+								{
+									Name:      ptr.To[string]("worker"),
+									Image:     ptr.To[string]("nginxinc/nginx-unprivileged:1.27"),
+									Resources: &coreapplyv1.ResourceRequirementsApplyConfiguration{},
+								},
+							},
+							SchedulingGates: []coreapplyv1.PodSchedulingGateApplyConfiguration{
+								{Name: ptr.To[string](leaderworkerset.WorkersReadySchedulingGate)},
+							},
+						},
+					},
+					ServiceName:         ptr.To[string]("test-sample"),
+					PodManagementPolicy: ptr.To[appsv1.PodManagementPolicyType](appsv1.ParallelPodManagement),
+					UpdateStrategy: appsapplyv1.StatefulSetUpdateStrategy().
+						WithType(appsv1.RollingUpdateStatefulSetStrategyType).
+						WithRollingUpdate(appsapplyv1.RollingUpdateStatefulSetStrategy().WithPartition(0).WithMaxUnavailable(intstr.FromInt32(1))),
+				},
+			},
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			stsApplyConfig, err := constructLeaderStatefulSetApplyConfiguration(tc.lws, 0, *tc.lws.Spec.Replicas, tc.revisionKey)
+			stsApplyConfig, err := constructLeaderStatefulSetApplyConfiguration(tc.lws, 0, 0, *tc.lws.Spec.Replicas, tc.revisionKey)
 			if err != nil {
 				t.Errorf("failed with error: %s", err.Error())
 			}
@@ -525,6 +616,22 @@ func TestSetCondition(t *testing.T) {
 				Conditions([]metav1.Condition{{Type: "Progressing", Status: "False"}}).
 				Obj(),
 		},
+		{
+			name:      "Same condition type, Same condition status, different message",
+			condition: metav1.Condition{Type: "GroupUnschedulable", Status: "True", Message: "Groups test-sample-0, test-sample-1 are unschedulable"},
+			lws: wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").
+				Conditions([]metav1.Condition{{Type: "GroupUnschedulable", Status: "True", Message: "Groups test-sample-1 are unschedulable"}}).
+				Obj(),
+			expectedShouldUpdate: true,
+		},
+		{
+			name:      "Same condition type, Same condition status, different reason",
+			condition: metav1.Condition{Type: "Available", Status: "False", Reason: "MinReadySeconds"},
+			lws: wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").
+				Conditions([]metav1.Condition{{Type: "Available", Status: "False", Reason: "AllGroupsReady"}}).
+				Obj(),
+			expectedShouldUpdate: true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -533,6 +640,5212 @@ func TestSetCondition(t *testing.T) {
 			if shouldUpdate != tc.expectedShouldUpdate {
 				t.Errorf("Expected value %t, got %t", tc.expectedShouldUpdate, shouldUpdate)
 			}
+			if condition := meta.FindStatusCondition(tc.lws.Status.Conditions, tc.condition.Type); condition != nil && condition.Status == tc.condition.Status {
+				if condition.Reason != tc.condition.Reason || condition.Message != tc.condition.Message {
+					t.Errorf("Expected reason %q and message %q, got %q and %q", tc.condition.Reason, tc.condition.Message, condition.Reason, condition.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestGroupProgressing(t *testing.T) {
+	readyLeader := corev1.Pod{
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	workerSts := func(readyReplicas int32) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{Status: appsv1.StatefulSetStatus{ReadyReplicas: readyReplicas}}
+	}
+
+	tests := []struct {
+		name                string
+		leaderPod           corev1.Pod
+		workerSts           *appsv1.StatefulSet
+		size                int32
+		expectedProgressing bool
+	}{
+		{
+			name:      "no pods ready",
+			leaderPod: corev1.Pod{},
+			workerSts: workerSts(0),
+			size:      3,
+		},
+		{
+			name:                "only leader ready",
+			leaderPod:           readyLeader,
+			workerSts:           workerSts(0),
+			size:                3,
+			expectedProgressing: true,
+		},
+		{
+			name:                "only leader ready, worker statefulset not created yet",
+			leaderPod:           readyLeader,
+			size:                3,
+			expectedProgressing: true,
+		},
+		{
+			name:                "only some workers ready",
+			leaderPod:           corev1.Pod{},
+			workerSts:           workerSts(1),
+			size:                3,
+			expectedProgressing: true,
+		},
+		{
+			name:      "all pods ready",
+			leaderPod: readyLeader,
+			workerSts: workerSts(2),
+			size:      3,
+		},
+		{
+			name:      "size 1 with leader ready",
+			leaderPod: readyLeader,
+			size:      1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := groupProgressing(tc.leaderPod, tc.workerSts, tc.size); got != tc.expectedProgressing {
+				t.Errorf("Expected value %t, got %t", tc.expectedProgressing, got)
+			}
+		})
+	}
+}
+
+func TestDrainOldGroups(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	leaderPod := func(revisionKey string, annotations map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "test-sample-1",
+				Namespace:   "default",
+				Labels:      map[string]string{leaderworkerset.RevisionKey: revisionKey},
+				Annotations: annotations,
+			},
+		}
+	}
+
+	tests := []struct {
+		name                    string
+		drainGracePeriodSeconds *int32
+		leaderPod               *corev1.Pod
+		// advance moves the clock forward before the groups are drained.
+		advance          time.Duration
+		wantRequeueAfter time.Duration
+		wantDeadlineSet  bool
+	}{
+		{
+			name:      "drainGracePeriodSeconds not set",
+			leaderPod: leaderPod("old", nil),
+		},
+		{
+			name:                    "old group starts draining",
+			drainGracePeriodSeconds: ptr.To[int32](30),
+			leaderPod:               leaderPod("old", nil),
+			wantRequeueAfter:        30 * time.Second,
+			wantDeadlineSet:         true,
+		},
+		{
+			name:                    "old group is still draining",
+			drainGracePeriodSeconds: ptr.To[int32](30),
+			leaderPod: leaderPod("old", map[string]string{
+				leaderworkerset.DrainDeadlineAnnotationKey: now.Add(20 * time.Second).Format(time.RFC3339),
+			}),
+			advance:          5 * time.Second,
+			wantRequeueAfter: 15 * time.Second,
+			wantDeadlineSet:  true,
+		},
+		{
+			name:                    "drain deadline passed",
+			drainGracePeriodSeconds: ptr.To[int32](30),
+			leaderPod: leaderPod("old", map[string]string{
+				leaderworkerset.DrainDeadlineAnnotationKey: now.Add(20 * time.Second).Format(time.RFC3339),
+			}),
+			advance:         20 * time.Second,
+			wantDeadlineSet: true,
+		},
+		{
+			name:                    "old group has been drained",
+			drainGracePeriodSeconds: ptr.To[int32](30),
+			leaderPod: leaderPod("old", map[string]string{
+				leaderworkerset.DrainDeadlineAnnotationKey: now.Add(-time.Second).Format(time.RFC3339),
+			}),
+			wantDeadlineSet: true,
+		},
+		{
+			name:                    "group already updated",
+			drainGracePeriodSeconds: ptr.To[int32](30),
+			leaderPod:               leaderPod("new", nil),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(2).Obj()
+			lws.Spec.RolloutStrategy.RollingUpdateConfiguration = &leaderworkerset.RollingUpdateConfiguration{
+				DrainGracePeriodSeconds: tc.drainGracePeriodSeconds,
+			}
+			client := fake.NewClientBuilder().WithObjects(tc.leaderPod).Build()
+			r := NewLeaderWorkerSetReconciler(client, nil, nil)
+			fakeClock := clocktesting.NewFakeClock(now)
+			fakeClock.Step(tc.advance)
+			r.Clock = fakeClock
+
+			requeueAfter, err := r.drainOldGroups(context.TODO(), lws, 1, 2, "new")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if requeueAfter != tc.wantRequeueAfter {
+				t.Errorf("unexpected requeue, want: %v, got: %v", tc.wantRequeueAfter, requeueAfter)
+			}
+			var pod corev1.Pod
+			if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample-1"}, &pod); err != nil {
+				t.Fatal(err)
+			}
+			if _, gotDeadlineSet := pod.Annotations[leaderworkerset.DrainDeadlineAnnotationKey]; gotDeadlineSet != tc.wantDeadlineSet {
+				t.Errorf("unexpected drain deadline annotation, want: %t, got: %t", tc.wantDeadlineSet, gotDeadlineSet)
+			}
+		})
+	}
+}
+
+func TestDeletedGroups(t *testing.T) {
+	tests := []struct {
+		name           string
+		stsStart       int32
+		stsReplicas    int32
+		stsPartition   int32
+		start          int32
+		partition      int32
+		replicas       int32
+		wantScaledDown []int32
+		wantUpdated    []int32
+	}{
+		{
+			name:        "no change",
+			stsReplicas: 3,
+			replicas:    3,
+		},
+		{
+			name:           "highest indexes scaled down",
+			stsReplicas:    4,
+			replicas:       2,
+			wantScaledDown: []int32{2, 3},
+		},
+		{
+			name:           "lowest indexes scaled down",
+			stsStart:       1,
+			stsReplicas:    3,
+			start:          3,
+			replicas:       1,
+			wantScaledDown: []int32{1, 2},
+		},
+		{
+			name:         "partition lowered",
+			stsReplicas:  4,
+			stsPartition: 3,
+			partition:    1,
+			replicas:     4,
+			wantUpdated:  []int32{1, 2},
+		},
+		{
+			name:           "partition lowered while scaling down",
+			stsReplicas:    4,
+			stsPartition:   4,
+			partition:      2,
+			replicas:       3,
+			wantScaledDown: []int32{3},
+			wantUpdated:    []int32{2},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sts := &appsv1.StatefulSet{
+				Spec: appsv1.StatefulSetSpec{
+					Replicas: ptr.To(tc.stsReplicas),
+					Ordinals: &appsv1.StatefulSetOrdinals{Start: tc.stsStart},
+					UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+						RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: ptr.To(tc.stsPartition)},
+					},
+				},
+			}
+			scaledDown, updated := deletedGroups(sts, tc.start, tc.partition, tc.replicas)
+			if diff := cmp.Diff(tc.wantScaledDown, scaledDown); diff != "" {
+				t.Errorf("unexpected scaled down groups (-want +got): %s", diff)
+			}
+			if diff := cmp.Diff(tc.wantUpdated, updated); diff != "" {
+				t.Errorf("unexpected updated groups (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestTerminateWorkersFirst(t *testing.T) {
+	fakeClock := clocktesting.NewFakeClock(time.Now().Truncate(time.Second))
+	leaderPod := func(index int, revisionKey string, terminationStart *time.Time) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("test-sample-%d", index),
+				Namespace: "default",
+				Labels: map[string]string{
+					leaderworkerset.SetNameLabelKey:     "test-sample",
+					leaderworkerset.WorkerIndexLabelKey: "0",
+					leaderworkerset.GroupIndexLabelKey:  strconv.Itoa(index),
+					leaderworkerset.RevisionKey:         revisionKey,
+				},
+			},
+		}
+		if terminationStart != nil {
+			pod.Annotations = map[string]string{leaderworkerset.WorkersTerminationStartAnnotationKey: terminationStart.Format(time.RFC3339)}
+		}
+		return pod
+	}
+	workerSts := func(index int) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("test-sample-%d", index), Namespace: "default"}}
+	}
+	groups := func(revisionKey string, terminating ...int) []client.Object {
+		var objects []client.Object
+		for i := range 3 {
+			var terminationStart *time.Time
+			if slices.Contains(terminating, i) {
+				terminationStart = ptr.To(fakeClock.Now().Add(-10 * time.Second))
+			}
+			objects = append(objects, leaderPod(i, revisionKey, terminationStart), workerSts(i))
+		}
+		return objects
+	}
+
+	tests := []struct {
+		name               string
+		orderedTermination bool
+		gracePeriodSeconds *int64
+		objects            []client.Object
+		partition          int32
+		replicas           int32
+		wantRequeueAfter   time.Duration
+		wantTerminating    []string
+		wantWorkerSets     []string
+	}{
+		{
+			name:           "orderedTermination not set",
+			objects:        groups("old"),
+			partition:      0,
+			replicas:       2,
+			wantWorkerSets: []string{"test-sample-0", "test-sample-1", "test-sample-2"},
+		},
+		{
+			name:               "workers of scaled down groups are deleted first",
+			orderedTermination: true,
+			objects:            groups("new"),
+			partition:          0,
+			replicas:           1,
+			wantRequeueAfter:   30 * time.Second,
+			wantTerminating:    []string{"test-sample-1", "test-sample-2"},
+			wantWorkerSets:     []string{"test-sample-0"},
+		},
+		{
+			name:               "workers of old groups are deleted first during rolling update",
+			orderedTermination: true,
+			objects:            append(groups("old")[:4], leaderPod(2, "new", nil), workerSts(2)),
+			partition:          1,
+			replicas:           3,
+			gracePeriodSeconds: ptr.To[int64](60),
+			wantRequeueAfter:   time.Minute,
+			wantTerminating:    []string{"test-sample-1"},
+			wantWorkerSets:     []string{"test-sample-0", "test-sample-2"},
+		},
+		{
+			name:               "workers already gone",
+			orderedTermination: true,
+			objects:            []client.Object{leaderPod(0, "new", nil), workerSts(0), leaderPod(1, "new", ptr.To(fakeClock.Now().Add(-10*time.Second)))},
+			partition:          0,
+			replicas:           1,
+			wantTerminating:    []string{"test-sample-1"},
+			wantWorkerSets:     []string{"test-sample-0"},
+		},
+		{
+			name:               "grace period of the workers elapsed",
+			orderedTermination: true,
+			objects:            groups("new", 2),
+			partition:          0,
+			replicas:           2,
+			gracePeriodSeconds: ptr.To[int64](5),
+			wantTerminating:    []string{"test-sample-2"},
+			wantWorkerSets:     []string{"test-sample-0", "test-sample-1"},
+		},
+		{
+			name:               "group scaled back up is released",
+			orderedTermination: true,
+			objects:            groups("new", 2),
+			partition:          0,
+			replicas:           3,
+			wantWorkerSets:     []string{"test-sample-0", "test-sample-1", "test-sample-2"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(int(tc.replicas)).Size(2).OrderedTermination(tc.orderedTermination).Obj()
+			lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec.TerminationGracePeriodSeconds = tc.gracePeriodSeconds
+			leaderSts := &appsv1.StatefulSet{
+				Spec: appsv1.StatefulSetSpec{
+					Replicas: ptr.To[int32](3),
+					UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+						RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: ptr.To[int32](2)},
+					},
+				},
+			}
+			client := fake.NewClientBuilder().WithObjects(tc.objects...).Build()
+			r := NewLeaderWorkerSetReconciler(client, nil, nil)
+			r.Clock = fakeClock
+
+			requeueAfter, err := r.terminateWorkersFirst(context.TODO(), lws, leaderSts, lwsPods(t, r, lws), 0, tc.partition, tc.replicas, "new")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if requeueAfter != tc.wantRequeueAfter {
+				t.Errorf("unexpected requeue after, want: %v, got: %v", tc.wantRequeueAfter, requeueAfter)
+			}
+
+			var pods corev1.PodList
+			if err := client.List(context.TODO(), &pods); err != nil {
+				t.Fatal(err)
+			}
+			var gotTerminating []string
+			for _, pod := range pods.Items {
+				if _, ok := pod.Annotations[leaderworkerset.WorkersTerminationStartAnnotationKey]; ok {
+					gotTerminating = append(gotTerminating, pod.Name)
+				}
+			}
+			if diff := cmp.Diff(tc.wantTerminating, gotTerminating); diff != "" {
+				t.Errorf("unexpected terminating groups (-want +got): %s", diff)
+			}
+			var statefulSets appsv1.StatefulSetList
+			if err := client.List(context.TODO(), &statefulSets); err != nil {
+				t.Fatal(err)
+			}
+			var gotWorkerSets []string
+			for _, sts := range statefulSets.Items {
+				gotWorkerSets = append(gotWorkerSets, sts.Name)
+			}
+			if diff := cmp.Diff(tc.wantWorkerSets, gotWorkerSets); diff != "" {
+				t.Errorf("unexpected worker statefulsets (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestTerminateWorkersFirstRevisionSize(t *testing.T) {
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(1).Size(2).OrderedTermination(true).Obj()
+	lws.UID = "lws-uid"
+	k8sClient := fake.NewClientBuilder().Build()
+	revision, err := revisionutils.NewRevision(context.TODO(), k8sClient, lws, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	objects := []client.Object{revision}
+	for i := range 2 {
+		leader := wrappers.MakePodWithLabels("test-sample", strconv.Itoa(i), "0", "default", 2)
+		leader.Labels[leaderworkerset.RevisionKey] = revisionutils.GetRevisionKey(revision)
+		objects = append(objects, leader, &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: leader.Name, Namespace: "default"}})
+	}
+	for _, obj := range objects {
+		if err := k8sClient.Create(context.TODO(), obj); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The groups of size 2 keep their workers on their revision while the size is changed to 1.
+	lws.Spec.LeaderWorkerTemplate.Size = ptr.To[int32](1)
+	leaderSts := &appsv1.StatefulSet{
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: ptr.To[int32](2),
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: ptr.To[int32](0)},
+			},
+		},
+	}
+	r := NewLeaderWorkerSetReconciler(k8sClient, nil, nil)
+
+	requeueAfter, err := r.terminateWorkersFirst(context.TODO(), lws, leaderSts, lwsPods(t, r, lws), 0, 0, 1, "new")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requeueAfter == 0 {
+		t.Errorf("expected the leader statefulset to be held until the workers of the scaled down group are gone")
+	}
+	var workerSts appsv1.StatefulSet
+	if err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: "test-sample-1", Namespace: "default"}, &workerSts); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the worker statefulset of the scaled down group to be deleted, got: %v", err)
+	}
+}
+
+func TestRecreateParameters(t *testing.T) {
+	leaderSts := func(replicas int32) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-sample", Namespace: "default"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To[int32](replicas)},
+		}
+	}
+	leaderPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-sample-0",
+			Namespace: "default",
+			Labels: map[string]string{
+				leaderworkerset.SetNameLabelKey:     "test-sample",
+				leaderworkerset.WorkerIndexLabelKey: "0",
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		sts          *appsv1.StatefulSet
+		lwsUpdated   bool
+		leaderPods   []client.Object
+		wantReplicas int32
+	}{
+		{
+			name:         "leader statefulset not created yet",
+			wantReplicas: 3,
+		},
+		{
+			name:         "leaderWorkerSet updated, all groups are deleted",
+			sts:          leaderSts(3),
+			lwsUpdated:   true,
+			leaderPods:   []client.Object{leaderPod},
+			wantReplicas: 0,
+		},
+		{
+			name:         "old leader pods are still being deleted",
+			sts:          leaderSts(0),
+			leaderPods:   []client.Object{leaderPod},
+			wantReplicas: 0,
+		},
+		{
+			name:         "all old groups deleted, groups are recreated",
+			sts:          leaderSts(0),
+			wantReplicas: 3,
+		},
+		{
+			name:         "groups recreated",
+			sts:          leaderSts(3),
+			leaderPods:   []client.Object{leaderPod},
+			wantReplicas: 3,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(3).Obj()
+			lws.Spec.RolloutStrategy = leaderworkerset.RolloutStrategy{Type: leaderworkerset.RecreateStrategyType}
+			client := fake.NewClientBuilder().WithObjects(tc.leaderPods...).Build()
+			r := NewLeaderWorkerSetReconciler(client, nil, record.NewFakeRecorder(10))
+
+			partition, replicas, err := r.recreateParameters(context.TODO(), lws, tc.sts, lwsPods(t, r, lws), tc.lwsUpdated)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if partition != 0 {
+				t.Errorf("unexpected partition, want: 0, got: %d", partition)
+			}
+			if replicas != tc.wantReplicas {
+				t.Errorf("unexpected replicas, want: %d, got: %d", tc.wantReplicas, replicas)
+			}
+		})
+	}
+}
+
+func TestLeaderStatefulSetApplyConfigRecreate(t *testing.T) {
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(3).Size(2).Obj()
+	lws.Spec.RolloutStrategy = leaderworkerset.RolloutStrategy{Type: leaderworkerset.RecreateStrategyType}
+
+	stsApplyConfig, err := constructLeaderStatefulSetApplyConfiguration(lws, 0, 0, 0, "test-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := appsapplyv1.StatefulSetUpdateStrategy().
+		WithType(appsv1.RollingUpdateStatefulSetStrategyType).
+		WithRollingUpdate(appsapplyv1.RollingUpdateStatefulSetStrategy().WithPartition(0))
+	if diff := cmp.Diff(want, stsApplyConfig.Spec.UpdateStrategy); diff != "" {
+		t.Errorf("unexpected update strategy: (-want, +got) %s", diff)
+	}
+}
+
+func TestLeaderStatefulSetApplyConfigPerGroupEnv(t *testing.T) {
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(3).Size(2).Obj()
+	lws.Spec.NetworkConfig = &leaderworkerset.NetworkConfig{SubdomainPolicy: ptr.To(leaderworkerset.SubdomainShared)}
+	lws.Spec.LeaderWorkerTemplate.PerGroupEnv = []leaderworkerset.PerGroupEnv{
+		{GroupIndex: 0, Env: []corev1.EnvVar{{Name: "MODEL_SHARD", Value: "a"}}},
+		{GroupIndex: 1, Env: []corev1.EnvVar{{Name: "MODEL_SHARD", Value: "b"}}},
+	}
+	before, err := constructLeaderStatefulSetApplyConfiguration(lws, 0, 0, 3, "test-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, found := before.Spec.Template.Annotations[leaderworkerset.PerGroupEnvAnnotationKey]; found {
+		t.Errorf("unexpected %s annotation on the leader template shared by all the groups", leaderworkerset.PerGroupEnvAnnotationKey)
+	}
+
+	// Editing the variables of group 1 doesn't change the template of the leader pods of the other groups.
+	lws.Spec.LeaderWorkerTemplate.PerGroupEnv[1].Env = []corev1.EnvVar{{Name: "MODEL_SHARD", Value: "c"}}
+	after, err := constructLeaderStatefulSetApplyConfiguration(lws, 0, 0, 3, "test-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(before.Spec.Template, after.Spec.Template); diff != "" {
+		t.Errorf("unexpected change of the leader template (-before +after): %s", diff)
+	}
+}
+
+func TestLeaderStatefulSetApplyConfigExclusiveTopology(t *testing.T) {
+	tests := []struct {
+		name              string
+		annotationKey     string
+		exclusiveTopology *leaderworkerset.ExclusiveTopology
+		wantTopologyKey   string
+	}{
+		{
+			name: "exclusive placement disabled",
+		},
+		{
+			name:            "exclusive-topology annotation",
+			annotationKey:   "topology.kubernetes.io/zone",
+			wantTopologyKey: "topology.kubernetes.io/zone",
+		},
+		{
+			name:              "exclusiveTopology",
+			exclusiveTopology: &leaderworkerset.ExclusiveTopology{TopologyKey: "example.com/rack"},
+			wantTopologyKey:   "example.com/rack",
+		},
+		{
+			name:              "exclusiveTopology takes precedence over the annotation",
+			annotationKey:     "topology.kubernetes.io/zone",
+			exclusiveTopology: &leaderworkerset.ExclusiveTopology{TopologyKey: "kubernetes.io/hostname"},
+			wantTopologyKey:   "kubernetes.io/hostname",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(1).Size(2).Obj()
+			if tc.annotationKey != "" {
+				lws.Annotations = map[string]string{leaderworkerset.ExclusiveKeyAnnotationKey: tc.annotationKey}
+			}
+			lws.Spec.LeaderWorkerTemplate.ExclusiveTopology = tc.exclusiveTopology
+
+			stsApplyConfig, err := constructLeaderStatefulSetApplyConfiguration(lws, 0, 0, 1, "test-key")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := stsApplyConfig.Spec.Template.Annotations[leaderworkerset.ExclusiveKeyAnnotationKey]; got != tc.wantTopologyKey {
+				t.Errorf("unexpected exclusive topology key, want: %q, got: %q", tc.wantTopologyKey, got)
+			}
+		})
+	}
+}
+
+func TestLeaderStatefulSetApplyConfigHostnamePrefix(t *testing.T) {
+	tests := []struct {
+		name           string
+		hostnamePrefix string
+		wantName       string
+	}{
+		{
+			name:     "defaults to the lws name",
+			wantName: "test-sample",
+		},
+		{
+			name:           "hostnamePrefix",
+			hostnamePrefix: "short",
+			wantName:       "short",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(1).Size(2).Obj()
+			lws.Spec.NetworkConfig = &leaderworkerset.NetworkConfig{
+				SubdomainPolicy: ptr.To(leaderworkerset.SubdomainShared),
+				HostnamePrefix:  tc.hostnamePrefix,
+			}
+
+			stsApplyConfig, err := constructLeaderStatefulSetApplyConfiguration(lws, 0, 0, 1, "test-key")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := ptr.Deref(stsApplyConfig.Name, ""); got != tc.wantName {
+				t.Errorf("unexpected statefulset name, want: %q, got: %q", tc.wantName, got)
+			}
+			// The headless service is still named after the lws.
+			if got := ptr.Deref(stsApplyConfig.Spec.ServiceName, ""); got != "test-sample" {
+				t.Errorf("unexpected service name, want: %q, got: %q", "test-sample", got)
+			}
+		})
+	}
+}
+
+func TestLeaderStatefulSetApplyConfigInjectPeerAddresses(t *testing.T) {
+	for _, inject := range []bool{false, true} {
+		t.Run(fmt.Sprintf("injectPeerAddresses=%t", inject), func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(1).Size(4).Obj()
+			lws.Spec.LeaderWorkerTemplate.InjectPeerAddresses = inject
+
+			stsApplyConfig, err := constructLeaderStatefulSetApplyConfiguration(lws, 0, 0, 1, "test-key")
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, got := stsApplyConfig.Spec.Template.Annotations[leaderworkerset.InjectPeerAddressesAnnotationKey]
+			if got != inject {
+				t.Errorf("unexpected %s annotation, want: %t, got: %t", leaderworkerset.InjectPeerAddressesAnnotationKey, inject, got)
+			}
+		})
+	}
+}
+
+func TestLeaderStatefulSetApplyConfigLeaderPodDeletionCost(t *testing.T) {
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(1).Size(2).Obj()
+	lws.Spec.LeaderWorkerTemplate.LeaderPodDeletionCost = ptr.To[int32](100)
+
+	stsApplyConfig, err := constructLeaderStatefulSetApplyConfiguration(lws, 0, 0, 1, "test-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := stsApplyConfig.Spec.Template.Annotations[leaderworkerset.LeaderPodDeletionCostAnnotationKey]; got != "100" {
+		t.Errorf("unexpected %s annotation, want: %q, got: %q", leaderworkerset.LeaderPodDeletionCostAnnotationKey, "100", got)
+	}
+}
+
+func TestLeaderStatefulSetApplyConfigGroupSpreadConstraints(t *testing.T) {
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(1).Size(2).Obj()
+	lws.Spec.LeaderWorkerTemplate.GroupSpreadConstraints = []corev1.TopologySpreadConstraint{
+		{MaxSkew: 1, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: corev1.DoNotSchedule},
+	}
+
+	stsApplyConfig, err := constructLeaderStatefulSetApplyConfiguration(lws, 0, 0, 1, "test-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"maxSkew":1,"topologyKey":"topology.kubernetes.io/zone","whenUnsatisfiable":"DoNotSchedule"}]`
+	if got := stsApplyConfig.Spec.Template.Annotations[leaderworkerset.GroupSpreadConstraintsAnnotationKey]; got != want {
+		t.Errorf("unexpected %s annotation, want: %q, got: %q", leaderworkerset.GroupSpreadConstraintsAnnotationKey, want, got)
+	}
+}
+
+func TestLeaderStatefulSetApplyConfigInheritLabels(t *testing.T) {
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(1).Size(2).Obj()
+	lws.Labels = map[string]string{"cost-center": "ml", "team": "inference"}
+	lws.Spec.LeaderWorkerTemplate.InheritLabels = []string{"cost-center", "team", "missing"}
+	lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Labels = map[string]string{"team": "serving"}
+
+	stsApplyConfig, err := constructLeaderStatefulSetApplyConfiguration(lws, 0, 0, 1, "test-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	labels := stsApplyConfig.Spec.Template.Labels
+	if got := labels["cost-center"]; got != "ml" {
+		t.Errorf("unexpected cost-center label, want: %q, got: %q", "ml", got)
+	}
+	if got := labels["team"]; got != "serving" {
+		t.Errorf("template label should take precedence, want: %q, got: %q", "serving", got)
+	}
+	if _, found := labels["missing"]; found {
+		t.Errorf("unexpected label for a key missing on the LeaderWorkerSet")
+	}
+}
+
+func TestLeaderStatefulSetApplyConfigVolumeClaimTemplates(t *testing.T) {
+	tests := []struct {
+		name            string
+		retentionPolicy *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy
+		wantPolicy      *appsapplyv1.StatefulSetPersistentVolumeClaimRetentionPolicyApplyConfiguration
+	}{
+		{
+			name: "no retention policy",
+		},
+		{
+			name: "claims retained on scale down and deletion",
+			retentionPolicy: &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+				WhenDeleted: appsv1.RetainPersistentVolumeClaimRetentionPolicyType,
+				WhenScaled:  appsv1.RetainPersistentVolumeClaimRetentionPolicyType,
+			},
+			wantPolicy: appsapplyv1.StatefulSetPersistentVolumeClaimRetentionPolicy().
+				WithWhenDeleted(appsv1.RetainPersistentVolumeClaimRetentionPolicyType).
+				WithWhenScaled(appsv1.RetainPersistentVolumeClaimRetentionPolicyType),
+		},
+		{
+			name: "claims deleted on scale down and deletion",
+			retentionPolicy: &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+				WhenDeleted: appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+				WhenScaled:  appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+			},
+			wantPolicy: appsapplyv1.StatefulSetPersistentVolumeClaimRetentionPolicy().
+				WithWhenDeleted(appsv1.DeletePersistentVolumeClaimRetentionPolicyType).
+				WithWhenScaled(appsv1.DeletePersistentVolumeClaimRetentionPolicyType),
+		},
+		{
+			name: "only whenScaled set",
+			retentionPolicy: &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+				WhenScaled: appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+			},
+			wantPolicy: appsapplyv1.StatefulSetPersistentVolumeClaimRetentionPolicy().
+				WithWhenScaled(appsv1.DeletePersistentVolumeClaimRetentionPolicyType),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(2).Size(2).Obj()
+			lws.Spec.LeaderWorkerTemplate.VolumeClaimTemplates = []corev1.PersistentVolumeClaim{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "model-cache"},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+						Resources: corev1.VolumeResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+						},
+					},
+				},
+			}
+			lws.Spec.LeaderWorkerTemplate.PersistentVolumeClaimRetentionPolicy = tc.retentionPolicy
+
+			stsApplyConfig, err := constructLeaderStatefulSetApplyConfiguration(lws, 0, 0, 2, "test-key")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(stsApplyConfig.Spec.VolumeClaimTemplates) != 1 {
+				t.Fatalf("unexpected number of volumeClaimTemplates, want: 1, got: %d", len(stsApplyConfig.Spec.VolumeClaimTemplates))
+			}
+			claim := stsApplyConfig.Spec.VolumeClaimTemplates[0]
+			if claim.Name == nil || *claim.Name != "model-cache" {
+				t.Errorf("unexpected claim name, want: %q, got: %v", "model-cache", claim.Name)
+			}
+			if diff := cmp.Diff(resource.MustParse("10Gi"), (*claim.Spec.Resources.Requests)[corev1.ResourceStorage]); diff != "" {
+				t.Errorf("unexpected storage request: %s", diff)
+			}
+			if diff := cmp.Diff(tc.wantPolicy, stsApplyConfig.Spec.PersistentVolumeClaimRetentionPolicy); diff != "" {
+				t.Errorf("unexpected retention policy: %s", diff)
+			}
+		})
+	}
+}
+
+func TestScaleDownPolicy(t *testing.T) {
+	// groupIndexes returns the indexes of the groups in [start, start+replicas).
+	groupIndexes := func(start, replicas int32) []int32 {
+		indexes := []int32{}
+		for i := start; i < start+replicas; i++ {
+			indexes = append(indexes, i)
+		}
+		return indexes
+	}
+	tests := []struct {
+		name             string
+		policy           leaderworkerset.ScaleDownPolicyType
+		start            int32
+		stsReplicas      int32
+		replicas         int32
+		wantStart        int32
+		wantDeletedIndex []int32
+	}{
+		{
+			name:             "HighestIndexFirst scale down by one",
+			policy:           leaderworkerset.HighestIndexFirstScaleDownPolicy,
+			stsReplicas:      4,
+			replicas:         3,
+			wantDeletedIndex: []int32{3},
+		},
+		{
+			name:             "HighestIndexFirst scale down to 0",
+			policy:           leaderworkerset.HighestIndexFirstScaleDownPolicy,
+			stsReplicas:      3,
+			replicas:         0,
+			wantDeletedIndex: []int32{0, 1, 2},
+		},
+		{
+			name:             "LowestIndexFirst scale down by one",
+			policy:           leaderworkerset.LowestIndexFirstScaleDownPolicy,
+			stsReplicas:      4,
+			replicas:         3,
+			wantStart:        1,
+			wantDeletedIndex: []int32{0},
+		},
+		{
+			name:             "LowestIndexFirst scale down by several",
+			policy:           leaderworkerset.LowestIndexFirstScaleDownPolicy,
+			stsReplicas:      5,
+			replicas:         2,
+			wantStart:        3,
+			wantDeletedIndex: []int32{0, 1, 2},
+		},
+		{
+			name:             "LowestIndexFirst scale down after a previous scale down",
+			policy:           leaderworkerset.LowestIndexFirstScaleDownPolicy,
+			start:            2,
+			stsReplicas:      3,
+			replicas:         1,
+			wantStart:        4,
+			wantDeletedIndex: []int32{2, 3},
+		},
+		{
+			name:             "LowestIndexFirst scale up keeps the start index",
+			policy:           leaderworkerset.LowestIndexFirstScaleDownPolicy,
+			start:            2,
+			stsReplicas:      2,
+			replicas:         3,
+			wantStart:        2,
+			wantDeletedIndex: []int32{},
+		},
+		{
+			name:             "HighestIndexFirst after LowestIndexFirst keeps the start index",
+			policy:           leaderworkerset.HighestIndexFirstScaleDownPolicy,
+			start:            2,
+			stsReplicas:      3,
+			replicas:         2,
+			wantStart:        2,
+			wantDeletedIndex: []int32{4},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(int(tc.replicas)).Size(2).ScaleDownPolicy(tc.policy).Obj()
+			sts := &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{leaderworkerset.ReplicasAnnotationKey: strconv.Itoa(int(tc.stsReplicas))},
+				},
+				Spec: appsv1.StatefulSetSpec{
+					Replicas: ptr.To(tc.stsReplicas),
+					Ordinals: &appsv1.StatefulSetOrdinals{Start: tc.start},
+				},
+			}
+
+			start := desiredStartOrdinal(lws, sts)
+			if start != tc.wantStart {
+				t.Errorf("unexpected start index, want: %d, got: %d", tc.wantStart, start)
+			}
+			remaining := groupIndexes(start, tc.replicas)
+			deleted := []int32{}
+			for _, index := range groupIndexes(tc.start, tc.stsReplicas) {
+				if !slices.Contains(remaining, index) {
+					deleted = append(deleted, index)
+				}
+			}
+			if diff := cmp.Diff(tc.wantDeletedIndex, deleted); diff != "" {
+				t.Errorf("unexpected deleted group indexes: (-want, +got) %s", diff)
+			}
+
+			stsApplyConfig, err := constructLeaderStatefulSetApplyConfiguration(lws, start, 0, tc.replicas, "test-key")
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gotStart int32
+			if stsApplyConfig.Spec.Ordinals != nil {
+				gotStart = *stsApplyConfig.Spec.Ordinals.Start
+			}
+			if gotStart != tc.wantStart {
+				t.Errorf("unexpected statefulset start ordinal, want: %d, got: %d", tc.wantStart, gotStart)
+			}
+		})
+	}
+}
+
+func TestStartIndex(t *testing.T) {
+	tests := []struct {
+		name            string
+		startIndex      int32
+		policy          leaderworkerset.ScaleDownPolicyType
+		sts             *appsv1.StatefulSet
+		replicas        int32
+		wantLeaderNames []string
+	}{
+		{
+			name:            "new statefulset without startIndex",
+			replicas:        2,
+			wantLeaderNames: []string{"test-sample-0", "test-sample-1"},
+		},
+		{
+			name:            "new statefulset with startIndex",
+			startIndex:      1,
+			replicas:        2,
+			wantLeaderNames: []string{"test-sample-1", "test-sample-2"},
+		},
+		{
+			name:       "scale up keeps the offset",
+			startIndex: 1,
+			sts: &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{leaderworkerset.ReplicasAnnotationKey: "2"}},
+				Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To[int32](2), Ordinals: &appsv1.StatefulSetOrdinals{Start: 1}},
+			},
+			replicas:        3,
+			wantLeaderNames: []string{"test-sample-1", "test-sample-2", "test-sample-3"},
+		},
+		{
+			name:       "HighestIndexFirst scale down keeps the offset",
+			startIndex: 1,
+			policy:     leaderworkerset.HighestIndexFirstScaleDownPolicy,
+			sts: &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{leaderworkerset.ReplicasAnnotationKey: "3"}},
+				Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To[int32](3), Ordinals: &appsv1.StatefulSetOrdinals{Start: 1}},
+			},
+			replicas:        2,
+			wantLeaderNames: []string{"test-sample-1", "test-sample-2"},
+		},
+		{
+			name:       "LowestIndexFirst scale down moves up from the offset",
+			startIndex: 1,
+			policy:     leaderworkerset.LowestIndexFirstScaleDownPolicy,
+			sts: &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{leaderworkerset.ReplicasAnnotationKey: "3"}},
+				Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To[int32](3), Ordinals: &appsv1.StatefulSetOrdinals{Start: 1}},
+			},
+			replicas:        2,
+			wantLeaderNames: []string{"test-sample-2", "test-sample-3"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(int(tc.replicas)).Size(2).StartIndex(tc.startIndex).Obj()
+			if tc.policy != "" {
+				lws.Spec.ScaleDownPolicy = tc.policy
+			}
+			start := desiredStartOrdinal(lws, tc.sts)
+			stsApplyConfig, err := constructLeaderStatefulSetApplyConfiguration(lws, start, 0, tc.replicas, "test-key")
+			if err != nil {
+				t.Fatal(err)
+			}
+			var stsStart int32
+			if stsApplyConfig.Spec.Ordinals != nil {
+				stsStart = *stsApplyConfig.Spec.Ordinals.Start
+			}
+			// The statefulset names its pods <name>-<ordinal> for the ordinals [start, start+replicas).
+			leaderNames := []string{}
+			for i := stsStart; i < stsStart+*stsApplyConfig.Spec.Replicas; i++ {
+				leaderNames = append(leaderNames, fmt.Sprintf("%s-%d", *stsApplyConfig.Name, i))
+			}
+			if diff := cmp.Diff(tc.wantLeaderNames, leaderNames); diff != "" {
+				t.Errorf("unexpected leader pod names: (-want, +got) %s", diff)
+			}
+		})
+	}
+}
+
+func TestCurrentPartition(t *testing.T) {
+	tests := []struct {
+		name          string
+		stsStart      int32
+		stsPartition  int32
+		start         int32
+		wantPartition int32
+	}{
+		{
+			name:          "start not changed",
+			stsStart:      1,
+			stsPartition:  3,
+			start:         1,
+			wantPartition: 3,
+		},
+		{
+			name:          "start moved up keeps the same groups updated",
+			stsPartition:  3,
+			start:         2,
+			wantPartition: 1,
+		},
+		{
+			name:          "start moved past the partition",
+			stsPartition:  1,
+			start:         2,
+			wantPartition: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sts := &appsv1.StatefulSet{
+				Spec: appsv1.StatefulSetSpec{
+					Ordinals: &appsv1.StatefulSetOrdinals{Start: tc.stsStart},
+					UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+						RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: ptr.To(tc.stsPartition)},
+					},
+				},
+			}
+			if got := currentPartition(sts, tc.start); got != tc.wantPartition {
+				t.Errorf("unexpected partition, want: %d, got: %d", tc.wantPartition, got)
+			}
+		})
+	}
+}
+
+func TestUpdateConditionsGroupStatuses(t *testing.T) {
+	leaderPod := func(index int, revisionKey, nodeName string, ready bool) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("test-sample-%d", index),
+				Namespace: "default",
+				Labels: map[string]string{
+					leaderworkerset.SetNameLabelKey:     "test-sample",
+					leaderworkerset.WorkerIndexLabelKey: "0",
+					leaderworkerset.GroupIndexLabelKey:  strconv.Itoa(index),
+					leaderworkerset.RevisionKey:         revisionKey,
+				},
+			},
+			Spec: corev1.PodSpec{NodeName: nodeName},
+		}
+		if ready {
+			pod.Status.Phase = corev1.PodRunning
+			pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		}
+		return pod
+	}
+	workerSts := func(index int, revisionKey string, ready bool) *appsv1.StatefulSet {
+		sts := &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("test-sample-%d", index),
+				Namespace: "default",
+				Labels:    map[string]string{leaderworkerset.RevisionKey: revisionKey},
+			},
+			Spec: appsv1.StatefulSetSpec{Replicas: ptr.To[int32](1)},
+		}
+		if ready {
+			sts.Status.Replicas = 1
+			sts.Status.ReadyReplicas = 1
+		}
+		return sts
+	}
+
+	tests := []struct {
+		name              string
+		objects           []client.Object
+		wantGroupStatuses []leaderworkerset.GroupStatus
+	}{
+		{
+			name: "no groups",
+		},
+		{
+			name: "groups in every phase",
+			objects: []client.Object{
+				leaderPod(3, "old", "node", true), workerSts(3, "old", true),
+				leaderPod(0, "new", "node", true), workerSts(0, "new", true),
+				leaderPod(1, "new", "", false),
+				leaderPod(2, "new", "node", true), workerSts(2, "new", false),
+			},
+			wantGroupStatuses: []leaderworkerset.GroupStatus{
+				{Index: 0, Phase: leaderworkerset.GroupReady, Revision: "new"},
+				{Index: 1, Phase: leaderworkerset.GroupPending, Revision: "new"},
+				{Index: 2, Phase: leaderworkerset.GroupCreating, Revision: "new"},
+				{Index: 3, Phase: leaderworkerset.GroupUpdating, Revision: "old"},
+			},
+		},
+		{
+			name: "leader pod updated but worker statefulset not yet",
+			objects: []client.Object{
+				leaderPod(0, "new", "node", true), workerSts(0, "old", true),
+			},
+			wantGroupStatuses: []leaderworkerset.GroupStatus{
+				{Index: 0, Phase: leaderworkerset.GroupUpdating, Revision: "new"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(4).Size(2).Obj()
+			client := fake.NewClientBuilder().WithObjects(tc.objects...).Build()
+			r := NewLeaderWorkerSetReconciler(client, nil, record.NewFakeRecorder(10))
+
+			if _, _, _, err := r.updateConditions(context.TODO(), lws, lwsPods(t, r, lws), "new", false, 0); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.wantGroupStatuses, lws.Status.GroupStatuses); diff != "" {
+				t.Errorf("unexpected group statuses: (-want, +got) %s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdateConditionsUpdateComplete(t *testing.T) {
+	leaderPod := func(index int, revisionKey string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("test-sample-%d", index),
+				Namespace: "default",
+				Labels: map[string]string{
+					leaderworkerset.SetNameLabelKey:     "test-sample",
+					leaderworkerset.WorkerIndexLabelKey: "0",
+					leaderworkerset.GroupIndexLabelKey:  strconv.Itoa(index),
+					leaderworkerset.RevisionKey:         revisionKey,
+				},
+			},
+		}
+	}
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(2).Size(1).Obj()
+	lws.Status.Replicas = 2
+	client := fake.NewClientBuilder().WithObjects(leaderPod(0, "old"), leaderPod(1, "old")).Build()
+	r := NewLeaderWorkerSetReconciler(client, nil, record.NewFakeRecorder(10))
+
+	expectCondition := func(revisionKey string, wantStatus metav1.ConditionStatus, wantChanged bool) {
+		t.Helper()
+		lastTransitionTime := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+		if condition := meta.FindStatusCondition(lws.Status.Conditions, string(leaderworkerset.LeaderWorkerSetUpdateComplete)); condition != nil {
+			condition.LastTransitionTime = lastTransitionTime
+		}
+		if _, _, _, err := r.updateConditions(context.TODO(), lws, lwsPods(t, r, lws), revisionKey, false, 0); err != nil {
+			t.Fatal(err)
+		}
+		condition := meta.FindStatusCondition(lws.Status.Conditions, string(leaderworkerset.LeaderWorkerSetUpdateComplete))
+		if condition == nil {
+			t.Fatalf("condition %s not found", leaderworkerset.LeaderWorkerSetUpdateComplete)
+		}
+		if condition.Status != wantStatus {
+			t.Errorf("unexpected condition status with revision %s, want: %s, got: %s", revisionKey, wantStatus, condition.Status)
+		}
+		if changed := !condition.LastTransitionTime.Equal(&lastTransitionTime); changed != wantChanged {
+			t.Errorf("unexpected lastTransitionTime change with revision %s, want changed: %t, got: %s", revisionKey, wantChanged, condition.LastTransitionTime)
+		}
+	}
+
+	// All the groups are at the current revision.
+	expectCondition("old", metav1.ConditionTrue, true)
+	// No transition, so lastTransitionTime is preserved.
+	expectCondition("old", metav1.ConditionTrue, false)
+	// A new revision is detected.
+	expectCondition("new", metav1.ConditionFalse, true)
+	expectCondition("new", metav1.ConditionFalse, false)
+
+	// The groups are updated to the new revision.
+	for i := range 2 {
+		pod := leaderPod(i, "new")
+		if err := client.Update(context.TODO(), pod); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expectCondition("new", metav1.ConditionTrue, true)
+}
+
+func TestUpdateConditionsMinReplicas(t *testing.T) {
+	fakeClock := clocktesting.NewFakeClock(time.Now().Truncate(time.Second))
+	readyLeaderPod := func(index int) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("test-sample-%d", index),
+				Namespace: "default",
+				Labels: map[string]string{
+					leaderworkerset.SetNameLabelKey:     "test-sample",
+					leaderworkerset.WorkerIndexLabelKey: "0",
+					leaderworkerset.GroupIndexLabelKey:  strconv.Itoa(index),
+					leaderworkerset.RevisionKey:         "new",
+				},
+			},
+			Spec: corev1.PodSpec{NodeName: "node"},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		}
+	}
+	pendingLeaderPod := func(index int, reason string, pendingFor time.Duration) *corev1.Pod {
+		pod := readyLeaderPod(index)
+		pod.Spec.NodeName = ""
+		pod.Status = corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{{
+				Type:               corev1.PodScheduled,
+				Status:             corev1.ConditionFalse,
+				Reason:             reason,
+				LastTransitionTime: metav1.NewTime(fakeClock.Now().Add(-pendingFor)),
+			}},
+		}
+		return pod
+	}
+
+	tests := []struct {
+		name                   string
+		minReplicas            *int32
+		objects                []client.Object
+		wantAvailable          bool
+		wantInsufficientStatus metav1.ConditionStatus
+	}{
+		{
+			name:    "groups lacking capacity without minReplicas",
+			objects: []client.Object{readyLeaderPod(0), readyLeaderPod(1), pendingLeaderPod(2, corev1.PodReasonUnschedulable, 5*time.Minute)},
+		},
+		{
+			name:                   "groups lacking capacity with minReplicas met",
+			minReplicas:            ptr.To[int32](2),
+			objects:                []client.Object{readyLeaderPod(0), readyLeaderPod(1), pendingLeaderPod(2, corev1.PodReasonUnschedulable, 5*time.Minute)},
+			wantAvailable:          true,
+			wantInsufficientStatus: metav1.ConditionTrue,
+		},
+		{
+			name:                   "groups lacking capacity with minReplicas not met",
+			minReplicas:            ptr.To[int32](3),
+			objects:                []client.Object{readyLeaderPod(0), readyLeaderPod(1), pendingLeaderPod(2, corev1.PodReasonUnschedulable, 5*time.Minute)},
+			wantInsufficientStatus: metav1.ConditionTrue,
+		},
+		{
+			name:                   "groups lacking capacity while another group isn't ready",
+			minReplicas:            ptr.To[int32](1),
+			objects:                []client.Object{readyLeaderPod(0), pendingLeaderPod(1, corev1.PodReasonSchedulingGated, 5*time.Minute), pendingLeaderPod(2, corev1.PodReasonUnschedulable, 5*time.Minute)},
+			wantInsufficientStatus: metav1.ConditionTrue,
+		},
+		{
+			name:        "group transiently unschedulable",
+			minReplicas: ptr.To[int32](2),
+			objects:     []client.Object{readyLeaderPod(0), readyLeaderPod(1), pendingLeaderPod(2, corev1.PodReasonUnschedulable, 10*time.Second)},
+		},
+		{
+			name:        "group pending for another reason",
+			minReplicas: ptr.To[int32](2),
+			objects:     []client.Object{readyLeaderPod(0), readyLeaderPod(1), pendingLeaderPod(2, corev1.PodReasonSchedulingGated, 5*time.Minute)},
+		},
+		{
+			name:          "all groups ready",
+			minReplicas:   ptr.To[int32](2),
+			objects:       []client.Object{readyLeaderPod(0), readyLeaderPod(1), readyLeaderPod(2)},
+			wantAvailable: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(3).Size(1).Obj()
+			lws.Spec.MinReplicas = tc.minReplicas
+			client := fake.NewClientBuilder().WithObjects(tc.objects...).Build()
+			r := NewLeaderWorkerSetReconciler(client, nil, record.NewFakeRecorder(10))
+			r.UnschedulableTimeout = time.Minute
+			r.Clock = fakeClock
+
+			if _, _, _, err := r.updateConditions(context.TODO(), lws, lwsPods(t, r, lws), "new", false, 0); err != nil {
+				t.Fatal(err)
+			}
+			if available := meta.IsStatusConditionTrue(lws.Status.Conditions, string(leaderworkerset.LeaderWorkerSetAvailable)); available != tc.wantAvailable {
+				t.Errorf("unexpected Available condition, want: %t, got: %t", tc.wantAvailable, available)
+			}
+			var insufficientStatus metav1.ConditionStatus
+			if condition := meta.FindStatusCondition(lws.Status.Conditions, string(leaderworkerset.LeaderWorkerSetInsufficientCapacity)); condition != nil {
+				insufficientStatus = condition.Status
+			}
+			if insufficientStatus != tc.wantInsufficientStatus {
+				t.Errorf("unexpected InsufficientCapacity condition status, want: %q, got: %q", tc.wantInsufficientStatus, insufficientStatus)
+			}
+		})
+	}
+}
+
+func TestUpdateConditionsRevisionSize(t *testing.T) {
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(1).Size(2).Obj()
+	lws.UID = "lws-uid"
+	k8sClient := fake.NewClientBuilder().Build()
+	revision, err := revisionutils.NewRevision(context.TODO(), k8sClient, lws, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The group of size 2 is held on its revision while the size is changed to 1, its leader is ready
+	// but not its worker.
+	leader := wrappers.MakePodWithLabels("test-sample", "0", "0", "default", 2)
+	leader.Labels[leaderworkerset.RevisionKey] = revisionutils.GetRevisionKey(revision)
+	leader.Status.Phase = corev1.PodRunning
+	leader.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	workerSts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-sample-0",
+			Namespace: "default",
+			Labels:    map[string]string{leaderworkerset.RevisionKey: revisionutils.GetRevisionKey(revision)},
+		},
+		Spec: appsv1.StatefulSetSpec{Replicas: ptr.To[int32](1)},
+	}
+	for _, obj := range []client.Object{revision, leader, workerSts} {
+		if err := k8sClient.Create(context.TODO(), obj); err != nil {
+			t.Fatal(err)
+		}
+	}
+	lws.Spec.LeaderWorkerTemplate.Size = ptr.To[int32](1)
+	r := NewLeaderWorkerSetReconciler(k8sClient, nil, record.NewFakeRecorder(10))
+
+	if _, _, _, err := r.updateConditions(context.TODO(), lws, lwsPods(t, r, lws), "new", false, 0); err != nil {
+		t.Fatal(err)
+	}
+	if lws.Status.ReadyReplicas != 0 {
+		t.Errorf("unexpected ready replicas, want: 0, got: %d", lws.Status.ReadyReplicas)
+	}
+	if lws.Status.ProgressingReplicas != 1 {
+		t.Errorf("unexpected progressing replicas, want: 1, got: %d", lws.Status.ProgressingReplicas)
+	}
+}
+
+func TestRolloutPartition(t *testing.T) {
+	// group returns the ready leader pod and worker statefulset of the group with the given revision.
+	group := func(index int, revisionKey string) []client.Object {
+		labels := map[string]string{
+			leaderworkerset.SetNameLabelKey:    "test-sample",
+			leaderworkerset.GroupIndexLabelKey: strconv.Itoa(index),
+			leaderworkerset.RevisionKey:        revisionKey,
+		}
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("test-sample-%d", index),
+				Namespace: "default",
+				Labels:    map[string]string{leaderworkerset.WorkerIndexLabelKey: "0"},
+			},
+			Spec: corev1.PodSpec{NodeName: "node"},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		}
+		maps.Copy(pod.Labels, labels)
+		sts := &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("test-sample-%d", index),
+				Namespace: "default",
+				Labels:    labels,
+			},
+			Spec:   appsv1.StatefulSetSpec{Replicas: ptr.To[int32](1)},
+			Status: appsv1.StatefulSetStatus{Replicas: 1, ReadyReplicas: 1},
+		}
+		return []client.Object{pod, sts}
+	}
+	groups := func(oldGroups, newGroups int) []client.Object {
+		var objects []client.Object
+		for i := 0; i < oldGroups+newGroups; i++ {
+			revisionKey := "new"
+			if i < oldGroups {
+				revisionKey = "old"
+			}
+			objects = append(objects, group(i, revisionKey)...)
+		}
+		return objects
+	}
+
+	tests := []struct {
+		name                string
+		objects             []client.Object
+		partition           *int32
+		stsPartition        int32
+		wantPartition       int32
+		wantAvailable       bool
+		wantUpdateDone      bool
+		wantUpdatingIndexes []int32
+	}{
+		{
+			name:                "rollout started, the partition moves down by maxUnavailable",
+			objects:             groups(4, 0),
+			partition:           ptr.To[int32](2),
+			stsPartition:        4,
+			wantPartition:       3,
+			wantUpdatingIndexes: []int32{2, 3},
+		},
+		{
+			name:           "groups above the partition updated, the partition is held",
+			objects:        groups(2, 2),
+			partition:      ptr.To[int32](2),
+			stsPartition:   2,
+			wantPartition:  2,
+			wantAvailable:  true,
+			wantUpdateDone: false,
+		},
+		{
+			name:                "partition lowered, the rollout continues",
+			objects:             groups(2, 2),
+			partition:           ptr.To[int32](0),
+			stsPartition:        2,
+			wantPartition:       1,
+			wantUpdatingIndexes: []int32{0, 1},
+		},
+		{
+			name:                "partition unset, the rollout continues",
+			objects:             groups(2, 2),
+			stsPartition:        2,
+			wantPartition:       1,
+			wantUpdatingIndexes: []int32{0, 1},
+		},
+		{
+			name:           "partition equal to replicas, no group is updated",
+			objects:        groups(4, 0),
+			partition:      ptr.To[int32](4),
+			stsPartition:   4,
+			wantPartition:  4,
+			wantAvailable:  true,
+			wantUpdateDone: false,
+		},
+		{
+			name:           "all groups updated",
+			objects:        groups(0, 4),
+			partition:      ptr.To[int32](2),
+			stsPartition:   2,
+			wantPartition:  2,
+			wantAvailable:  true,
+			wantUpdateDone: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(4).Size(2).Obj()
+			lws.Spec.RolloutStrategy = leaderworkerset.RolloutStrategy{
+				Type: leaderworkerset.RollingUpdateStrategyType,
+				RollingUpdateConfiguration: &leaderworkerset.RollingUpdateConfiguration{
+					MaxUnavailable: intstr.FromInt32(1),
+					Partition:      tc.partition,
+				},
+			}
+			sts := &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{leaderworkerset.ReplicasAnnotationKey: "4"},
+				},
+				Spec: appsv1.StatefulSetSpec{
+					Replicas: ptr.To[int32](4),
+					UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+						RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: ptr.To(tc.stsPartition)},
+					},
+				},
+			}
+			client := fake.NewClientBuilder().WithObjects(tc.objects...).Build()
+			r := NewLeaderWorkerSetReconciler(client, nil, record.NewFakeRecorder(10))
+
+			partition, replicas, err := r.rollingUpdateParameters(context.TODO(), lws, sts, lwsPods(t, r, lws), "new", false, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if partition != tc.wantPartition || replicas != 4 {
+				t.Errorf("unexpected partition and replicas, want: (%d, 4), got: (%d, %d)", tc.wantPartition, partition, replicas)
+			}
+
+			_, updateDone, _, err := r.updateConditions(context.TODO(), lws, lwsPods(t, r, lws), "new", false, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if updateDone != tc.wantUpdateDone {
+				t.Errorf("unexpected updateDone, want: %t, got: %t", tc.wantUpdateDone, updateDone)
+			}
+			if available := meta.IsStatusConditionTrue(lws.Status.Conditions, string(leaderworkerset.LeaderWorkerSetAvailable)); available != tc.wantAvailable {
+				t.Errorf("unexpected Available condition, want: %t, got: %t", tc.wantAvailable, available)
+			}
+			var updatingIndexes []int32
+			for _, status := range lws.Status.GroupStatuses {
+				if status.Phase == leaderworkerset.GroupUpdating {
+					updatingIndexes = append(updatingIndexes, status.Index)
+				}
+			}
+			if diff := cmp.Diff(tc.wantUpdatingIndexes, updatingIndexes); diff != "" {
+				t.Errorf("unexpected updating groups: (-want, +got) %s", diff)
+			}
+		})
+	}
+}
+
+func TestIterateReplicasRevisionSize(t *testing.T) {
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(2).Size(1).Obj()
+	lws.UID = "lws-uid"
+	k8sClient := fake.NewClientBuilder().Build()
+	revision, err := revisionutils.NewRevision(context.TODO(), k8sClient, lws, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Group 0 is pinned below the partition on its revision of size 1, without workers, while the
+	// size is changed to 2.
+	leader := wrappers.MakePodWithLabels("test-sample", "0", "0", "default", 1)
+	leader.Labels[leaderworkerset.RevisionKey] = revisionutils.GetRevisionKey(revision)
+	leader.Status.Phase = corev1.PodRunning
+	leader.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	for _, obj := range []client.Object{revision, leader} {
+		if err := k8sClient.Create(context.TODO(), obj); err != nil {
+			t.Fatal(err)
+		}
+	}
+	lws.Spec.LeaderWorkerTemplate.Size = ptr.To[int32](2)
+	lws.Spec.RolloutStrategy.RollingUpdateConfiguration = &leaderworkerset.RollingUpdateConfiguration{Partition: ptr.To[int32](1)}
+	r := NewLeaderWorkerSetReconciler(k8sClient, nil, record.NewFakeRecorder(10))
+
+	continuousReadyReplicas, unreadyReplicas, err := r.iterateReplicas(context.TODO(), lws, lwsPods(t, r, lws), 0, 1, "new")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if continuousReadyReplicas != 1 || unreadyReplicas != 0 {
+		t.Errorf("unexpected replicas, want: 1 continuous ready and 0 unready, got: %d continuous ready and %d unready", continuousReadyReplicas, unreadyReplicas)
+	}
+}
+
+func TestRolloutRequireApproval(t *testing.T) {
+	var objects []client.Object
+	for i := 0; i < 4; i++ {
+		labels := map[string]string{
+			leaderworkerset.SetNameLabelKey:    "test-sample",
+			leaderworkerset.GroupIndexLabelKey: strconv.Itoa(i),
+			leaderworkerset.RevisionKey:        "old",
+		}
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("test-sample-%d", i),
+				Namespace: "default",
+				Labels:    map[string]string{leaderworkerset.WorkerIndexLabelKey: "0"},
+			},
+			Spec: corev1.PodSpec{NodeName: "node"},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		}
+		maps.Copy(pod.Labels, labels)
+		sts := &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("test-sample-%d", i), Namespace: "default", Labels: labels},
+			Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To[int32](1)},
+			Status:     appsv1.StatefulSetStatus{Replicas: 1, ReadyReplicas: 1},
+		}
+		objects = append(objects, pod, sts)
+	}
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(4).Size(2).Obj()
+	lws.Spec.RolloutStrategy = leaderworkerset.RolloutStrategy{
+		Type: leaderworkerset.RollingUpdateStrategyType,
+		RollingUpdateConfiguration: &leaderworkerset.RollingUpdateConfiguration{
+			MaxUnavailable:  intstr.FromInt32(1),
+			MaxSurge:        intstr.FromInt32(1),
+			RequireApproval: true,
+		},
+	}
+	// The leaderWorkerSet was just updated to the "new" revision, no group is updated yet.
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{leaderworkerset.ReplicasAnnotationKey: "4"},
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: ptr.To[int32](4),
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: ptr.To[int32](4)},
+			},
+		},
+	}
+	client := fake.NewClientBuilder().WithObjects(objects...).Build()
+	r := NewLeaderWorkerSetReconciler(client, nil, record.NewFakeRecorder(10))
+	// reconcile mirrors the reconciliation of the rollout, returning the partition and replicas the
+	// leader statefulset is applied with.
+	reconcile := func() (int32, int32) {
+		t.Helper()
+		partition, replicas, err := r.rollingUpdateParameters(context.TODO(), lws, sts, lwsPods(t, r, lws), "new", false, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if awaitingApproval(lws, "new") {
+			partition, replicas = holdRollout(lws, sts, 0, partition, replicas)
+		}
+		if _, _, _, err := r.updateConditions(context.TODO(), lws, lwsPods(t, r, lws), "new", false, 0); err != nil {
+			t.Fatal(err)
+		}
+		return partition, replicas
+	}
+	expect := func(wantPartition, wantReplicas int32, wantPendingApproval bool) {
+		t.Helper()
+		partition, replicas := reconcile()
+		if partition != wantPartition || replicas != wantReplicas {
+			t.Errorf("unexpected partition and replicas, want: (%d, %d), got: (%d, %d)", wantPartition, wantReplicas, partition, replicas)
+		}
+		if pending := meta.IsStatusConditionTrue(lws.Status.Conditions, string(leaderworkerset.LeaderWorkerSetPendingApproval)); pending != wantPendingApproval {
+			t.Errorf("unexpected PendingApproval condition, want: %t, got: %t", wantPendingApproval, pending)
+		}
+	}
+
+	// The group statuses are first reported with the old revision.
+	reconcile()
+
+	// The rollout is halted, neither the partition moves nor surge groups are created.
+	expect(4, 4, true)
+
+	// An approval of another revision doesn't resume the rollout.
+	lws.Annotations = map[string]string{leaderworkerset.ApproveRevisionAnnotationKey: "old"}
+	expect(4, 4, true)
+
+	// Once the update revision is approved, the rollout proceeds.
+	lws.Annotations[leaderworkerset.ApproveRevisionAnnotationKey] = "new"
+	expect(3, 5, false)
+}
+
+func TestRollingUpdateParametersMaxSurge(t *testing.T) {
+	// group returns the leader pod and worker statefulset of the group with the given revision.
+	group := func(index int, revisionKey string, ready bool) []client.Object {
+		labels := map[string]string{
+			leaderworkerset.SetNameLabelKey:    "test-sample",
+			leaderworkerset.GroupIndexLabelKey: strconv.Itoa(index),
+			leaderworkerset.RevisionKey:        revisionKey,
+		}
+		readyStatus := corev1.ConditionFalse
+		var readyReplicas int32
+		if ready {
+			readyStatus = corev1.ConditionTrue
+			readyReplicas = 1
+		}
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("test-sample-%d", index),
+				Namespace: "default",
+				Labels:    map[string]string{leaderworkerset.WorkerIndexLabelKey: "0"},
+			},
+			Spec: corev1.PodSpec{NodeName: "node"},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: readyStatus}},
+			},
+		}
+		maps.Copy(pod.Labels, labels)
+		sts := &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("test-sample-%d", index),
+				Namespace: "default",
+				Labels:    labels,
+			},
+			Spec:   appsv1.StatefulSetSpec{Replicas: ptr.To[int32](1)},
+			Status: appsv1.StatefulSetStatus{Replicas: 1, ReadyReplicas: readyReplicas},
+		}
+		return []client.Object{pod, sts}
+	}
+	// groups returns ready old groups followed by new groups, of which only the first readyNewGroups are ready.
+	groups := func(oldGroups, newGroups, readyNewGroups int) []client.Object {
+		var objects []client.Object
+		for i := 0; i < oldGroups; i++ {
+			objects = append(objects, group(i, "old", true)...)
+		}
+		for i := 0; i < newGroups; i++ {
+			objects = append(objects, group(oldGroups+i, "new", i < readyNewGroups)...)
+		}
+		return objects
+	}
+
+	tests := []struct {
+		name          string
+		maxSurge      intstr.IntOrString
+		objects       []client.Object
+		updated       bool
+		stsReplicas   int32
+		stsPartition  int32
+		wantPartition int32
+		wantReplicas  int32
+	}{
+		{
+			name:          "surge 1, rollout started, a surge group is created",
+			maxSurge:      intstr.FromInt32(1),
+			objects:       groups(4, 0, 0),
+			updated:       true,
+			stsReplicas:   4,
+			wantPartition: 4,
+			wantReplicas:  5,
+		},
+		{
+			name:          "surge 1, the surge group is not ready, the partition is held",
+			maxSurge:      intstr.FromInt32(1),
+			objects:       groups(4, 1, 0),
+			stsReplicas:   5,
+			stsPartition:  4,
+			wantPartition: 4,
+			wantReplicas:  5,
+		},
+		{
+			name:          "surge 1, the surge group is ready, an old group is replaced",
+			maxSurge:      intstr.FromInt32(1),
+			objects:       groups(4, 1, 1),
+			stsReplicas:   5,
+			stsPartition:  4,
+			wantPartition: 3,
+			wantReplicas:  5,
+		},
+		{
+			name:          "surge 1, the last old group is replaced, the surge group is deleted",
+			maxSurge:      intstr.FromInt32(1),
+			objects:       groups(1, 4, 4),
+			stsReplicas:   5,
+			stsPartition:  1,
+			wantPartition: 0,
+			wantReplicas:  4,
+		},
+		{
+			name:          "surge 2, rollout started, two surge groups are created",
+			maxSurge:      intstr.FromInt32(2),
+			objects:       groups(4, 0, 0),
+			updated:       true,
+			stsReplicas:   4,
+			wantPartition: 4,
+			wantReplicas:  6,
+		},
+		{
+			name:          "surge 2, one surge group is ready, one old group is replaced",
+			maxSurge:      intstr.FromInt32(2),
+			objects:       append(groups(4, 0, 0), append(group(4, "new", false), group(5, "new", true)...)...),
+			stsReplicas:   6,
+			stsPartition:  4,
+			wantPartition: 3,
+			wantReplicas:  6,
+		},
+		{
+			name:          "surge 2, the surge groups are ready, two old groups are replaced",
+			maxSurge:      intstr.FromInt32(2),
+			objects:       groups(4, 2, 2),
+			stsReplicas:   6,
+			stsPartition:  4,
+			wantPartition: 2,
+			wantReplicas:  6,
+		},
+		{
+			name:          "surge 2, the last old groups are replaced, a surge group is deleted",
+			maxSurge:      intstr.FromInt32(2),
+			objects:       groups(2, 4, 4),
+			stsReplicas:   6,
+			stsPartition:  2,
+			wantPartition: 0,
+			wantReplicas:  5,
+		},
+		{
+			name:          "surge 2, rollout aborted, the surge groups are kept while the groups are rolled back",
+			maxSurge:      intstr.FromInt32(2),
+			objects:       groups(2, 4, 4),
+			updated:       true,
+			stsReplicas:   6,
+			stsPartition:  2,
+			wantPartition: 4,
+			wantReplicas:  6,
+		},
+		{
+			name:          "surge 2, rollout aborted and rolled back, the surge groups are deleted",
+			maxSurge:      intstr.FromInt32(2),
+			objects:       groups(0, 6, 6),
+			stsReplicas:   6,
+			wantPartition: 0,
+			wantReplicas:  4,
+		},
+		{
+			name:          "surge 50%, rollout started, two surge groups are created",
+			maxSurge:      intstr.FromString("50%"),
+			objects:       groups(4, 0, 0),
+			updated:       true,
+			stsReplicas:   4,
+			wantPartition: 4,
+			wantReplicas:  6,
+		},
+		{
+			name:          "surge 50%, the surge groups are ready, two old groups are replaced",
+			maxSurge:      intstr.FromString("50%"),
+			objects:       groups(4, 2, 2),
+			stsReplicas:   6,
+			stsPartition:  4,
+			wantPartition: 2,
+			wantReplicas:  6,
+		},
+		{
+			name:          "surge 30% is rounded up to two groups",
+			maxSurge:      intstr.FromString("30%"),
+			objects:       groups(4, 0, 0),
+			updated:       true,
+			stsReplicas:   4,
+			wantPartition: 4,
+			wantReplicas:  6,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(4).Size(2).Obj()
+			lws.Spec.RolloutStrategy = leaderworkerset.RolloutStrategy{
+				Type: leaderworkerset.RollingUpdateStrategyType,
+				RollingUpdateConfiguration: &leaderworkerset.RollingUpdateConfiguration{
+					MaxUnavailable: intstr.FromInt32(0),
+					MaxSurge:       tc.maxSurge,
+				},
+			}
+			sts := &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{leaderworkerset.ReplicasAnnotationKey: "4"},
+				},
+				Spec: appsv1.StatefulSetSpec{
+					Replicas: ptr.To(tc.stsReplicas),
+					UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+						RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: ptr.To(tc.stsPartition)},
+					},
+				},
+			}
+			client := fake.NewClientBuilder().WithObjects(tc.objects...).Build()
+			r := NewLeaderWorkerSetReconciler(client, nil, record.NewFakeRecorder(10))
+
+			partition, replicas, err := r.rollingUpdateParameters(context.TODO(), lws, sts, lwsPods(t, r, lws), "new", tc.updated, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if partition != tc.wantPartition || replicas != tc.wantReplicas {
+				t.Errorf("unexpected partition and replicas, want: (%d, %d), got: (%d, %d)", tc.wantPartition, tc.wantReplicas, partition, replicas)
+			}
+		})
+	}
+}
+
+func TestRollingUpdateParametersSubGroupGranularity(t *testing.T) {
+	// oldGroups returns the ready leader pods and worker statefulsets of groups on the old revision.
+	oldGroups := func(count int) []client.Object {
+		var objects []client.Object
+		for i := 0; i < count; i++ {
+			labels := map[string]string{
+				leaderworkerset.SetNameLabelKey:    "test-sample",
+				leaderworkerset.GroupIndexLabelKey: strconv.Itoa(i),
+				leaderworkerset.RevisionKey:        "old",
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("test-sample-%d", i),
+					Namespace: "default",
+					Labels:    map[string]string{leaderworkerset.WorkerIndexLabelKey: "0"},
+				},
+				Status: corev1.PodStatus{
+					Phase:      corev1.PodRunning,
+					Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+				},
+			}
+			maps.Copy(pod.Labels, labels)
+			sts := &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("test-sample-%d", i), Namespace: "default", Labels: labels},
+				Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To[int32](7)},
+				Status:     appsv1.StatefulSetStatus{Replicas: 7, ReadyReplicas: 7},
+			}
+			objects = append(objects, pod, sts)
+		}
+		return objects
+	}
+
+	// Groups of size 8 with subgroups of size 4 have 2 subgroups, so 4 replicas have 8 subgroups.
+	tests := []struct {
+		name           string
+		granularity    leaderworkerset.RolloutGranularity
+		maxUnavailable intstr.IntOrString
+		wantPartition  int32
+	}{
+		{
+			name:           "group granularity, 25% of the groups is one group",
+			granularity:    leaderworkerset.GroupRolloutGranularity,
+			maxUnavailable: intstr.FromString("25%"),
+			wantPartition:  3,
+		},
+		{
+			name:           "group granularity, 20% of the groups is rounded down to no group",
+			granularity:    leaderworkerset.GroupRolloutGranularity,
+			maxUnavailable: intstr.FromString("20%"),
+			wantPartition:  4,
+		},
+		{
+			name:           "subgroup granularity, 20% of the subgroups is one subgroup, rounded up to one group",
+			granularity:    leaderworkerset.SubGroupRolloutGranularity,
+			maxUnavailable: intstr.FromString("20%"),
+			wantPartition:  3,
+		},
+		{
+			name:           "subgroup granularity, 50% of the subgroups is two groups",
+			granularity:    leaderworkerset.SubGroupRolloutGranularity,
+			maxUnavailable: intstr.FromString("50%"),
+			wantPartition:  2,
+		},
+		{
+			name:           "subgroup granularity, 3 subgroups are rounded down to one group",
+			granularity:    leaderworkerset.SubGroupRolloutGranularity,
+			maxUnavailable: intstr.FromInt32(3),
+			wantPartition:  3,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(4).Size(8).Obj()
+			lws.Spec.LeaderWorkerTemplate.SubGroupPolicy = &leaderworkerset.SubGroupPolicy{SubGroupSize: ptr.To[int32](4)}
+			lws.Spec.RolloutStrategy = leaderworkerset.RolloutStrategy{
+				Type: leaderworkerset.RollingUpdateStrategyType,
+				RollingUpdateConfiguration: &leaderworkerset.RollingUpdateConfiguration{
+					MaxUnavailable: tc.maxUnavailable,
+					Granularity:    tc.granularity,
+				},
+			}
+			sts := &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{leaderworkerset.ReplicasAnnotationKey: "4"},
+				},
+				Spec: appsv1.StatefulSetSpec{
+					Replicas: ptr.To[int32](4),
+					UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+						RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: ptr.To[int32](4)},
+					},
+				},
+			}
+			client := fake.NewClientBuilder().WithObjects(oldGroups(4)...).Build()
+			r := NewLeaderWorkerSetReconciler(client, nil, record.NewFakeRecorder(10))
+
+			partition, replicas, err := r.rollingUpdateParameters(context.TODO(), lws, sts, lwsPods(t, r, lws), "new", false, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if partition != tc.wantPartition || replicas != 4 {
+				t.Errorf("unexpected partition and replicas, want: (%d, %d), got: (%d, %d)", tc.wantPartition, 4, partition, replicas)
+			}
+		})
+	}
+}
+
+func TestUpdateConditionsUpdatedSubGroups(t *testing.T) {
+	// groupPods returns the pods of a group of size 8 with subgroups of size 4, where the pods of
+	// the subgroups in updatedSubGroups are on the new revision.
+	groupPods := func(index int, updatedSubGroups ...string) []client.Object {
+		var objects []client.Object
+		for worker := 0; worker < 8; worker++ {
+			name := fmt.Sprintf("test-sample-%d", index)
+			if worker > 0 {
+				name = fmt.Sprintf("test-sample-%d-%d", index, worker)
+			}
+			subGroup := strconv.Itoa(worker / 4)
+			revisionKey := "old"
+			if slices.Contains(updatedSubGroups, subGroup) {
+				revisionKey = "new"
+			}
+			objects = append(objects, &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "default",
+					Labels: map[string]string{
+						leaderworkerset.SetNameLabelKey:       "test-sample",
+						leaderworkerset.WorkerIndexLabelKey:   strconv.Itoa(worker),
+						leaderworkerset.GroupIndexLabelKey:    strconv.Itoa(index),
+						leaderworkerset.SubGroupIndexLabelKey: subGroup,
+						leaderworkerset.RevisionKey:           revisionKey,
+					},
+				},
+			})
+		}
+		return objects
+	}
+
+	tests := []struct {
+		name                 string
+		granularity          leaderworkerset.RolloutGranularity
+		objects              []client.Object
+		wantUpdatedSubGroups []int32
+	}{
+		{
+			name:                 "subgroup granularity",
+			granularity:          leaderworkerset.SubGroupRolloutGranularity,
+			objects:              slices.Concat(groupPods(0, "0", "1"), groupPods(1, "1"), groupPods(2)),
+			wantUpdatedSubGroups: []int32{2, 1, 0},
+		},
+		{
+			name:                 "group granularity doesn't report subgroups",
+			granularity:          leaderworkerset.GroupRolloutGranularity,
+			objects:              slices.Concat(groupPods(0, "0", "1"), groupPods(1, "1"), groupPods(2)),
+			wantUpdatedSubGroups: []int32{0, 0, 0},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(3).Size(8).Obj()
+			lws.Spec.LeaderWorkerTemplate.SubGroupPolicy = &leaderworkerset.SubGroupPolicy{SubGroupSize: ptr.To[int32](4)}
+			lws.Spec.RolloutStrategy.RollingUpdateConfiguration = &leaderworkerset.RollingUpdateConfiguration{
+				MaxUnavailable: intstr.FromInt32(1),
+				Granularity:    tc.granularity,
+			}
+			client := fake.NewClientBuilder().WithObjects(tc.objects...).Build()
+			r := NewLeaderWorkerSetReconciler(client, nil, record.NewFakeRecorder(10))
+
+			if _, _, _, err := r.updateConditions(context.TODO(), lws, lwsPods(t, r, lws), "new", false, 0); err != nil {
+				t.Fatal(err)
+			}
+			var gotUpdatedSubGroups []int32
+			for _, status := range lws.Status.GroupStatuses {
+				gotUpdatedSubGroups = append(gotUpdatedSubGroups, status.UpdatedSubGroups)
+			}
+			if diff := cmp.Diff(tc.wantUpdatedSubGroups, gotUpdatedSubGroups); diff != "" {
+				t.Errorf("unexpected updated subgroups: (-want, +got) %s", diff)
+			}
+		})
+	}
+}
+
+func TestReconcileHeadlessServices(t *testing.T) {
+	tests := []struct {
+		name                   string
+		subdomainPolicy        leaderworkerset.SubdomainPolicy
+		disableHeadlessService bool
+		wantService            bool
+	}{
+		{
+			name:            "shared subdomain",
+			subdomainPolicy: leaderworkerset.SubdomainShared,
+			wantService:     true,
+		},
+		{
+			name:            "unique subdomain per replica",
+			subdomainPolicy: leaderworkerset.SubdomainUniquePerReplica,
+		},
+		{
+			name:            "subdomainPolicy None",
+			subdomainPolicy: leaderworkerset.SubdomainNone,
+		},
+		{
+			name:                   "shared subdomain with headless service disabled",
+			subdomainPolicy:        leaderworkerset.SubdomainShared,
+			disableHeadlessService: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Obj()
+			lws.Spec.NetworkConfig = &leaderworkerset.NetworkConfig{SubdomainPolicy: ptr.To(tc.subdomainPolicy)}
+			client := newFakeClientBuilder().Build()
+			r := NewLeaderWorkerSetReconciler(client, testScheme, record.NewFakeRecorder(10))
+			r.DisableHeadlessService = tc.disableHeadlessService
+
+			disableHeadlessService(lws, r.DisableHeadlessService)
+			if err := r.reconcileHeadlessServices(context.TODO(), lws); err != nil {
+				t.Fatal(err)
+			}
+			var service corev1.Service
+			err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample"}, &service)
+			if gotService := err == nil; gotService != tc.wantService {
+				t.Errorf("unexpected headless service, want: %t, got: %t (err: %v)", tc.wantService, gotService, err)
+			}
+		})
+	}
+}
+
+func TestReconcilePerGroupServices(t *testing.T) {
+	lws := wrappers.BuildLeaderWorkerSet("default").Obj()
+	lws.UID = "lws-uid"
+	lws.Spec.LeaderWorkerTemplate.LeaderTemplate = nil
+	lws.Spec.NetworkConfig = &leaderworkerset.NetworkConfig{SubdomainPolicy: ptr.To(leaderworkerset.SubdomainShared), PerGroupService: true}
+	client := newFakeClientBuilder().Build()
+	r := NewLeaderWorkerSetReconciler(client, testScheme, record.NewFakeRecorder(10))
+
+	servicePorts := func(port int32) []corev1.ServicePort {
+		return []corev1.ServicePort{{Name: fmt.Sprintf("tcp-%d", port), Protocol: corev1.ProtocolTCP, Port: port, TargetPort: intstr.FromInt32(port)}}
+	}
+	steps := []struct {
+		name         string
+		update       func(lws *leaderworkerset.LeaderWorkerSet)
+		start        int32
+		replicas     int32
+		wantServices []string
+		wantPorts    []corev1.ServicePort
+	}{
+		{
+			name:         "services created for each group",
+			replicas:     3,
+			wantServices: []string{"test-sample-0", "test-sample-1", "test-sample-2"},
+			wantPorts:    servicePorts(8080),
+		},
+		{
+			name: "services updated with the new leader ports",
+			update: func(lws *leaderworkerset.LeaderWorkerSet) {
+				lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec.Containers[0].Ports[0].ContainerPort = 9090
+			},
+			replicas:     3,
+			wantServices: []string{"test-sample-0", "test-sample-1", "test-sample-2"},
+			wantPorts:    servicePorts(9090),
+		},
+		{
+			name:         "services of the scaled down groups deleted",
+			replicas:     1,
+			wantServices: []string{"test-sample-0"},
+			wantPorts:    servicePorts(9090),
+		},
+		{
+			name:         "services follow the start ordinal",
+			start:        2,
+			replicas:     2,
+			wantServices: []string{"test-sample-2", "test-sample-3"},
+			wantPorts:    servicePorts(9090),
+		},
+		{
+			name: "services deleted once disabled",
+			update: func(lws *leaderworkerset.LeaderWorkerSet) {
+				lws.Spec.NetworkConfig.PerGroupService = false
+			},
+			start:    2,
+			replicas: 2,
+		},
+	}
+
+	for _, step := range steps {
+		if step.update != nil {
+			step.update(lws)
+		}
+		if err := r.reconcilePerGroupServices(context.TODO(), lws, step.start, step.replicas); err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		var services corev1.ServiceList
+		if err := client.List(context.TODO(), &services); err != nil {
+			t.Fatal(err)
+		}
+		var gotServices []string
+		for _, service := range services.Items {
+			gotServices = append(gotServices, service.Name)
+			groupIndex := service.Labels[leaderworkerset.GroupIndexLabelKey]
+			wantSelector := map[string]string{
+				leaderworkerset.SetNameLabelKey:     "test-sample",
+				leaderworkerset.GroupIndexLabelKey:  groupIndex,
+				leaderworkerset.WorkerIndexLabelKey: "0",
+			}
+			if service.Name != "test-sample-"+groupIndex {
+				t.Errorf("%s: unexpected group index label %q on service %s", step.name, groupIndex, service.Name)
+			}
+			if diff := cmp.Diff(wantSelector, service.Spec.Selector); diff != "" {
+				t.Errorf("%s: unexpected selector of service %s (-want +got): %s", step.name, service.Name, diff)
+			}
+			if diff := cmp.Diff(step.wantPorts, service.Spec.Ports); diff != "" {
+				t.Errorf("%s: unexpected ports of service %s (-want +got): %s", step.name, service.Name, diff)
+			}
+			if !metav1.IsControlledBy(&service, lws) {
+				t.Errorf("%s: service %s is not controlled by the leaderworkerset", step.name, service.Name)
+			}
+		}
+		if diff := cmp.Diff(step.wantServices, gotServices, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+			t.Errorf("%s: unexpected services (-want +got): %s", step.name, diff)
+		}
+	}
+}
+
+func TestReconcileMembershipConfigMaps(t *testing.T) {
+	lws := wrappers.BuildLeaderWorkerSet("default").Size(3).Obj()
+	lws.UID = "lws-uid"
+	lws.Spec.LeaderWorkerTemplate.PublishMembershipConfigMap = true
+	lws.Spec.NetworkConfig = &leaderworkerset.NetworkConfig{SubdomainPolicy: ptr.To(leaderworkerset.SubdomainShared)}
+	client := newFakeClientBuilder().Build()
+	r := NewLeaderWorkerSetReconciler(client, testScheme, record.NewFakeRecorder(10))
+
+	membership := func(groupIndex string) map[string]string {
+		return map[string]string{
+			leaderworkerset.MembershipHostsKey: fmt.Sprintf("test-sample-%[1]s.test-sample.default\ntest-sample-%[1]s-1.test-sample.default\ntest-sample-%[1]s-2.test-sample.default\n", groupIndex),
+			leaderworkerset.MembershipSizeKey:  "3",
+		}
+	}
+	steps := []struct {
+		name           string
+		update         func()
+		start          int32
+		replicas       int32
+		wantConfigMaps []string
+	}{
+		{
+			name:           "configmaps created for each group",
+			replicas:       2,
+			wantConfigMaps: []string{"test-sample-0-membership", "test-sample-1-membership"},
+		},
+		{
+			name: "deleted configmap recreated",
+			update: func() {
+				if err := client.Delete(context.TODO(), &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-sample-1-membership", Namespace: "default"}}); err != nil {
+					t.Fatal(err)
+				}
+			},
+			replicas:       2,
+			wantConfigMaps: []string{"test-sample-0-membership", "test-sample-1-membership"},
+		},
+		{
+			name: "modified configmap restored",
+			update: func() {
+				var configMap corev1.ConfigMap
+				if err := client.Get(context.TODO(), types.NamespacedName{Name: "test-sample-0-membership", Namespace: "default"}, &configMap); err != nil {
+					t.Fatal(err)
+				}
+				configMap.Data[leaderworkerset.MembershipSizeKey] = "1"
+				if err := client.Update(context.TODO(), &configMap); err != nil {
+					t.Fatal(err)
+				}
+			},
+			replicas:       2,
+			wantConfigMaps: []string{"test-sample-0-membership", "test-sample-1-membership"},
+		},
+		{
+			name:           "configmaps of the scaled down groups deleted",
+			replicas:       1,
+			wantConfigMaps: []string{"test-sample-0-membership"},
+		},
+		{
+			name:           "configmaps follow the start ordinal",
+			start:          2,
+			replicas:       2,
+			wantConfigMaps: []string{"test-sample-2-membership", "test-sample-3-membership"},
+		},
+		{
+			name: "configmaps deleted once disabled",
+			update: func() {
+				lws.Spec.LeaderWorkerTemplate.PublishMembershipConfigMap = false
+			},
+			start:    2,
+			replicas: 2,
+		},
+	}
+
+	for _, step := range steps {
+		if step.update != nil {
+			step.update()
+		}
+		if err := r.reconcileMembershipConfigMaps(context.TODO(), lws, lwsPods(t, r, lws), step.start, step.replicas); err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		var configMaps corev1.ConfigMapList
+		if err := client.List(context.TODO(), &configMaps); err != nil {
+			t.Fatal(err)
+		}
+		var gotConfigMaps []string
+		for _, configMap := range configMaps.Items {
+			gotConfigMaps = append(gotConfigMaps, configMap.Name)
+			groupIndex := configMap.Labels[leaderworkerset.GroupIndexLabelKey]
+			if configMap.Name != fmt.Sprintf("test-sample-%s-membership", groupIndex) {
+				t.Errorf("%s: unexpected group index label %q on configmap %s", step.name, groupIndex, configMap.Name)
+			}
+			if diff := cmp.Diff(membership(groupIndex), configMap.Data); diff != "" {
+				t.Errorf("%s: unexpected data of configmap %s (-want +got): %s", step.name, configMap.Name, diff)
+			}
+			if !metav1.IsControlledBy(&configMap, lws) {
+				t.Errorf("%s: configmap %s is not controlled by the leaderworkerset", step.name, configMap.Name)
+			}
+		}
+		if diff := cmp.Diff(step.wantConfigMaps, gotConfigMaps, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+			t.Errorf("%s: unexpected configmaps (-want +got): %s", step.name, diff)
+		}
+	}
+}
+
+func TestReconcileMembershipConfigMapsGroupSizes(t *testing.T) {
+	lws := wrappers.BuildLeaderWorkerSet("default").Size(2).Obj()
+	lws.UID = "lws-uid"
+	lws.Spec.LeaderWorkerTemplate.PublishMembershipConfigMap = true
+	lws.Spec.NetworkConfig = &leaderworkerset.NetworkConfig{SubdomainPolicy: ptr.To(leaderworkerset.SubdomainShared)}
+	client := newFakeClientBuilder().Build()
+	revision, err := revisionutils.NewRevision(context.TODO(), client, lws, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Create(context.TODO(), revision); err != nil {
+		t.Fatal(err)
+	}
+	// Group 0 stays on the revision of size 2 while the size is changed to 3.
+	leader := wrappers.MakePodWithLabels("test-sample", "0", "0", "default", 2)
+	leader.Labels[leaderworkerset.RevisionKey] = revisionutils.GetRevisionKey(revision)
+	if err := client.Create(context.TODO(), leader); err != nil {
+		t.Fatal(err)
+	}
+	lws.Spec.LeaderWorkerTemplate.Size = ptr.To[int32](3)
+	r := NewLeaderWorkerSetReconciler(client, testScheme, record.NewFakeRecorder(10))
+
+	if err := r.reconcileMembershipConfigMaps(context.TODO(), lws, lwsPods(t, r, lws), 0, 2); err != nil {
+		t.Fatal(err)
+	}
+	for name, wantSize := range map[string]string{"test-sample-0-membership": "2", "test-sample-1-membership": "3"} {
+		var configMap corev1.ConfigMap
+		if err := client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "default"}, &configMap); err != nil {
+			t.Fatal(err)
+		}
+		if got := configMap.Data[leaderworkerset.MembershipSizeKey]; got != wantSize {
+			t.Errorf("unexpected size of configmap %s, want: %s, got: %s", name, wantSize, got)
+		}
+	}
+}
+
+func TestMembershipConfigMapHosts(t *testing.T) {
+	tests := []struct {
+		name          string
+		networkConfig *leaderworkerset.NetworkConfig
+		wantHosts     string
+	}{
+		{
+			name:          "shared subdomain",
+			networkConfig: &leaderworkerset.NetworkConfig{SubdomainPolicy: ptr.To(leaderworkerset.SubdomainShared)},
+			wantHosts:     "test-sample-1.test-sample.default\ntest-sample-1-1.test-sample.default\n",
+		},
+		{
+			name:          "unique subdomain per replica",
+			networkConfig: &leaderworkerset.NetworkConfig{SubdomainPolicy: ptr.To(leaderworkerset.SubdomainUniquePerReplica)},
+			wantHosts:     "test-sample-1.test-sample-1.default\ntest-sample-1-1.test-sample-1.default\n",
+		},
+		{
+			name:          "no subdomain",
+			networkConfig: &leaderworkerset.NetworkConfig{SubdomainPolicy: ptr.To(leaderworkerset.SubdomainNone)},
+			wantHosts:     "test-sample-1\ntest-sample-1-1\n",
+		},
+		{
+			name:          "hostname prefix",
+			networkConfig: &leaderworkerset.NetworkConfig{SubdomainPolicy: ptr.To(leaderworkerset.SubdomainShared), HostnamePrefix: "worker"},
+			wantHosts:     "worker-1.test-sample.default\nworker-1-1.test-sample.default\n",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildLeaderWorkerSet("default").Size(2).Obj()
+			lws.Spec.NetworkConfig = tc.networkConfig
+			r := NewLeaderWorkerSetReconciler(newFakeClientBuilder().Build(), testScheme, record.NewFakeRecorder(10))
+			configMap, err := r.constructMembershipConfigMap(lws, "1", 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.wantHosts, configMap.Data[leaderworkerset.MembershipHostsKey]); diff != "" {
+				t.Errorf("unexpected hosts (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestReconcilePaused(t *testing.T) {
+	leaderPod := func(index int) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("test-sample-%d", index),
+				Namespace: "default",
+				Labels: map[string]string{
+					leaderworkerset.SetNameLabelKey:     "test-sample",
+					leaderworkerset.WorkerIndexLabelKey: "0",
+					leaderworkerset.GroupIndexLabelKey:  strconv.Itoa(index),
+					leaderworkerset.RevisionKey:         "old",
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		leaderSts *appsv1.StatefulSet
+	}{
+		{
+			name: "leader statefulset not created yet",
+		},
+		{
+			name: "leader statefulset created",
+			leaderSts: &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-sample",
+					Namespace: "default",
+					Labels:    map[string]string{leaderworkerset.RevisionKey: "old"},
+				},
+				Spec:   appsv1.StatefulSetSpec{Replicas: ptr.To[int32](2)},
+				Status: appsv1.StatefulSetStatus{Replicas: 2},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// The lws is scaled up while paused.
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(4).Size(1).
+				Annotation(map[string]string{leaderworkerset.PausedAnnotationKey: "true"}).Obj()
+			objects := []client.Object{lws, leaderPod(0), leaderPod(1)}
+			if tc.leaderSts != nil {
+				objects = append(objects, tc.leaderSts)
+			}
+			client := newFakeClientBuilder().WithStatusSubresource(lws).WithObjects(objects...).Build()
+			r := NewLeaderWorkerSetReconciler(client, testScheme, record.NewFakeRecorder(10))
+
+			var oldPods corev1.PodList
+			if err := client.List(context.TODO(), &oldPods); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "test-sample"}}); err != nil {
+				t.Fatal(err)
+			}
+
+			var pods corev1.PodList
+			if err := client.List(context.TODO(), &pods); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(oldPods.Items, pods.Items); diff != "" {
+				t.Errorf("unexpected pod mutations (-want, +got): %s", diff)
+			}
+			var statefulSets appsv1.StatefulSetList
+			if err := client.List(context.TODO(), &statefulSets); err != nil {
+				t.Fatal(err)
+			}
+			wantStatefulSets := 0
+			if tc.leaderSts != nil {
+				wantStatefulSets = 1
+				if replicas := *statefulSets.Items[0].Spec.Replicas; replicas != 2 {
+					t.Errorf("unexpected leader statefulset replicas, want: 2, got: %d", replicas)
+				}
+			}
+			if len(statefulSets.Items) != wantStatefulSets {
+				t.Errorf("unexpected number of statefulsets, want: %d, got: %d", wantStatefulSets, len(statefulSets.Items))
+			}
+			var revisions appsv1.ControllerRevisionList
+			if err := client.List(context.TODO(), &revisions); err != nil {
+				t.Fatal(err)
+			}
+			if len(revisions.Items) != 0 {
+				t.Errorf("unexpected controller revisions created while paused: %d", len(revisions.Items))
+			}
+
+			var gotLws leaderworkerset.LeaderWorkerSet
+			if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample"}, &gotLws); err != nil {
+				t.Fatal(err)
+			}
+			if !meta.IsStatusConditionTrue(gotLws.Status.Conditions, string(leaderworkerset.LeaderWorkerSetPaused)) {
+				t.Errorf("expected condition %s to be true, got conditions: %v", leaderworkerset.LeaderWorkerSetPaused, gotLws.Status.Conditions)
+			}
+			if gotLws.Status.ObservedGeneration != 0 {
+				t.Errorf("unexpected observedGeneration while paused: %d", gotLws.Status.ObservedGeneration)
+			}
+		})
+	}
+}
+
+func TestHealth(t *testing.T) {
+	tests := []struct {
+		name            string
+		replicas        int
+		standbyReplicas int32
+		readyReplicas   int32
+		crashingPods    int32
+		want            string
+	}{
+		{
+			name:          "all groups ready",
+			replicas:      3,
+			readyReplicas: 3,
+			want:          leaderworkerset.HealthHealthy,
+		},
+		{
+			name:     "no groups wanted",
+			replicas: 0,
+			want:     leaderworkerset.HealthHealthy,
+		},
+		{
+			name:            "all groups ready with standby groups",
+			replicas:        3,
+			standbyReplicas: 1,
+			readyReplicas:   3,
+			want:            leaderworkerset.HealthHealthy,
+		},
+		{
+			name:          "some groups ready",
+			replicas:      3,
+			readyReplicas: 2,
+			want:          leaderworkerset.HealthDegraded,
+		},
+		{
+			name:          "all groups ready with crashing pods",
+			replicas:      3,
+			readyReplicas: 3,
+			crashingPods:  1,
+			want:          leaderworkerset.HealthDegraded,
+		},
+		{
+			name:         "no groups ready",
+			replicas:     3,
+			crashingPods: 2,
+			want:         leaderworkerset.HealthUnhealthy,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(tc.replicas).Obj()
+			lws.Spec.StandbyReplicas = ptr.To(tc.standbyReplicas)
+			addStandbyReplicas(lws)
+			lws.Status.ReadyReplicas = tc.readyReplicas
+			lws.Status.CrashingPods = tc.crashingPods
+			if got := health(lws); got != tc.want {
+				t.Errorf("unexpected health, want: %s, got: %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestClampReplicas(t *testing.T) {
+	tests := []struct {
+		name            string
+		replicas        int
+		maxReplicas     *int32
+		standbyReplicas int32
+		wantReplicas    int32
+		wantEvent       string
+	}{
+		{
+			name:         "no maxReplicas",
+			replicas:     5,
+			wantReplicas: 5,
+		},
+		{
+			name:         "at maxReplicas",
+			replicas:     4,
+			maxReplicas:  ptr.To[int32](4),
+			wantReplicas: 4,
+		},
+		{
+			name:         "over maxReplicas",
+			replicas:     6,
+			maxReplicas:  ptr.To[int32](4),
+			wantReplicas: 4,
+			wantEvent:    "Warning ReplicasClamped Replicas 6 exceeds maxReplicas 4, clamping to 4",
+		},
+		{
+			name:            "at maxReplicas with standby replicas",
+			replicas:        3,
+			maxReplicas:     ptr.To[int32](4),
+			standbyReplicas: 1,
+			wantReplicas:    3,
+		},
+		{
+			name:            "over maxReplicas with standby replicas",
+			replicas:        4,
+			maxReplicas:     ptr.To[int32](4),
+			standbyReplicas: 1,
+			wantReplicas:    3,
+			wantEvent:       "Warning ReplicasClamped Replicas 4 plus standbyReplicas 1 exceeds maxReplicas 4, clamping to 3",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(tc.replicas).Obj()
+			lws.Spec.MaxReplicas = tc.maxReplicas
+			lws.Spec.StandbyReplicas = ptr.To(tc.standbyReplicas)
+			recorder := record.NewFakeRecorder(10)
+			clampReplicas(lws, recorder)
+			if got := *lws.Spec.Replicas; got != tc.wantReplicas {
+				t.Errorf("unexpected replicas, want: %d, got: %d", tc.wantReplicas, got)
+			}
+			var gotEvent string
+			select {
+			case gotEvent = <-recorder.Events:
+			default:
+			}
+			if gotEvent != tc.wantEvent {
+				t.Errorf("unexpected event, want: %q, got: %q", tc.wantEvent, gotEvent)
+			}
+		})
+	}
+}
+
+func TestUpdateHealthAnnotation(t *testing.T) {
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(2).Obj()
+	client := newFakeClientBuilder().WithObjects(lws).Build()
+	r := NewLeaderWorkerSetReconciler(client, testScheme, record.NewFakeRecorder(10))
+	getLws := func() *leaderworkerset.LeaderWorkerSet {
+		t.Helper()
+		var lws leaderworkerset.LeaderWorkerSet
+		if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample"}, &lws); err != nil {
+			t.Fatal(err)
+		}
+		return &lws
+	}
+	expectHealth := func(readyReplicas int32, want string, wantPatched bool) {
+		t.Helper()
+		lws := getLws()
+		resourceVersion := lws.ResourceVersion
+		lws.Status.ReadyReplicas = readyReplicas
+		if err := r.updateHealthAnnotation(context.TODO(), lws); err != nil {
+			t.Fatal(err)
+		}
+		lws = getLws()
+		if got := lws.Annotations[leaderworkerset.HealthAnnotationKey]; got != want {
+			t.Errorf("unexpected health annotation, want: %s, got: %s", want, got)
+		}
+		if patched := lws.ResourceVersion != resourceVersion; patched != wantPatched {
+			t.Errorf("unexpected patch of the lws, want patched: %t, got: %t", wantPatched, patched)
+		}
+	}
+
+	expectHealth(0, leaderworkerset.HealthUnhealthy, true)
+	// The annotation is not patched again when the health doesn't change.
+	expectHealth(0, leaderworkerset.HealthUnhealthy, false)
+	expectHealth(1, leaderworkerset.HealthDegraded, true)
+	expectHealth(2, leaderworkerset.HealthHealthy, true)
+	expectHealth(2, leaderworkerset.HealthHealthy, false)
+}
+
+func TestWriteStatusKeepsInMemorySpec(t *testing.T) {
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(3).Obj()
+	lws.Spec.StandbyReplicas = ptr.To[int32](1)
+	client := newFakeClientBuilder().WithObjects(lws).WithStatusSubresource(lws).Build()
+	r := NewLeaderWorkerSetReconciler(client, testScheme, record.NewFakeRecorder(10))
+
+	addStandbyReplicas(lws)
+	lws.Status.ReadyReplicas = 3
+	if err := r.writeStatus(context.TODO(), lws); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.updateHealthAnnotation(context.TODO(), lws); err != nil {
+		t.Fatal(err)
+	}
+	if got := *lws.Spec.Replicas; got != 4 {
+		t.Errorf("unexpected in-memory replicas, want: 4, got: %d", got)
+	}
+	if got := lws.Annotations[leaderworkerset.HealthAnnotationKey]; got != leaderworkerset.HealthHealthy {
+		t.Errorf("unexpected health annotation, want: %s, got: %s", leaderworkerset.HealthHealthy, got)
+	}
+
+	var stored leaderworkerset.LeaderWorkerSet
+	if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample"}, &stored); err != nil {
+		t.Fatal(err)
+	}
+	if got := *stored.Spec.Replicas; got != 3 {
+		t.Errorf("unexpected stored replicas, want: 3, got: %d", got)
+	}
+	if stored.Status.ReadyReplicas != 3 {
+		t.Errorf("unexpected stored ready replicas, want: 3, got: %d", stored.Status.ReadyReplicas)
+	}
+	if stored.ResourceVersion != lws.ResourceVersion {
+		t.Errorf("unexpected in-memory resource version, want: %s, got: %s", stored.ResourceVersion, lws.ResourceVersion)
+	}
+}
+
+func TestReconcileSuspended(t *testing.T) {
+	leaderPod := func(index int) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("test-sample-%d", index),
+				Namespace: "default",
+				Labels: map[string]string{
+					leaderworkerset.SetNameLabelKey:     "test-sample",
+					leaderworkerset.WorkerIndexLabelKey: "0",
+					leaderworkerset.GroupIndexLabelKey:  strconv.Itoa(index),
+					leaderworkerset.RevisionKey:         "old",
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		leaderSts *appsv1.StatefulSet
+	}{
+		{
+			name: "leader statefulset not created yet",
+		},
+		{
+			name: "leader statefulset created",
+			leaderSts: &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-sample",
+					Namespace: "default",
+					Labels:    map[string]string{leaderworkerset.RevisionKey: "old"},
+				},
+				Spec:   appsv1.StatefulSetSpec{Replicas: ptr.To[int32](2)},
+				Status: appsv1.StatefulSetStatus{Replicas: 2},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// The lws is scaled up while suspended.
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(4).Size(1).Suspend(true).Obj()
+			objects := []client.Object{lws, leaderPod(0), leaderPod(1)}
+			if tc.leaderSts != nil {
+				objects = append(objects, tc.leaderSts)
+			}
+			client := newFakeClientBuilder().WithStatusSubresource(lws).WithObjects(objects...).Build()
+			r := NewLeaderWorkerSetReconciler(client, testScheme, record.NewFakeRecorder(10))
+			getLws := func() *leaderworkerset.LeaderWorkerSet {
+				t.Helper()
+				var lws leaderworkerset.LeaderWorkerSet
+				if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample"}, &lws); err != nil {
+					t.Fatal(err)
+				}
+				return &lws
+			}
+
+			var oldPods corev1.PodList
+			if err := client.List(context.TODO(), &oldPods); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "test-sample"}}); err != nil {
+				t.Fatal(err)
+			}
+
+			var pods corev1.PodList
+			if err := client.List(context.TODO(), &pods); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(oldPods.Items, pods.Items); diff != "" {
+				t.Errorf("unexpected pod mutations while suspended (-want, +got): %s", diff)
+			}
+			var statefulSets appsv1.StatefulSetList
+			if err := client.List(context.TODO(), &statefulSets); err != nil {
+				t.Fatal(err)
+			}
+			wantStatefulSets := 0
+			if tc.leaderSts != nil {
+				wantStatefulSets = 1
+				if replicas := *statefulSets.Items[0].Spec.Replicas; replicas != 2 {
+					t.Errorf("unexpected leader statefulset replicas, want: 2, got: %d", replicas)
+				}
+			}
+			if len(statefulSets.Items) != wantStatefulSets {
+				t.Errorf("unexpected number of statefulsets, want: %d, got: %d", wantStatefulSets, len(statefulSets.Items))
+			}
+			gotLws := getLws()
+			if !meta.IsStatusConditionTrue(gotLws.Status.Conditions, string(leaderworkerset.LeaderWorkerSetSuspended)) {
+				t.Errorf("expected condition %s to be true, got conditions: %v", leaderworkerset.LeaderWorkerSetSuspended, gotLws.Status.Conditions)
+			}
+			if meta.IsStatusConditionTrue(gotLws.Status.Conditions, string(leaderworkerset.LeaderWorkerSetPaused)) {
+				t.Errorf("unexpected condition %s while suspended, got conditions: %v", leaderworkerset.LeaderWorkerSetPaused, gotLws.Status.Conditions)
+			}
+
+			// The lws is resumed.
+			if tc.leaderSts == nil {
+				return
+			}
+			gotLws.Spec.Suspend = ptr.To(false)
+			if err := client.Update(context.TODO(), gotLws); err != nil {
+				t.Fatal(err)
+			}
+			if _, _, err := updateStatusWithPods(r, getLws(), "old", true); err != nil {
+				t.Fatal(err)
+			}
+			if !meta.IsStatusConditionFalse(getLws().Status.Conditions, string(leaderworkerset.LeaderWorkerSetSuspended)) {
+				t.Errorf("expected condition %s to be false once resumed, got conditions: %v", leaderworkerset.LeaderWorkerSetSuspended, getLws().Status.Conditions)
+			}
+		})
+	}
+}
+
+func TestUpdateStatusObservedGeneration(t *testing.T) {
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(2).Size(1).Obj()
+	lws.Generation = 1
+	leaderSts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-sample", Namespace: "default"},
+		Status:     appsv1.StatefulSetStatus{Replicas: 2},
+	}
+	client := newFakeClientBuilder().WithStatusSubresource(lws).WithObjects(lws, leaderSts).Build()
+	r := NewLeaderWorkerSetReconciler(client, testScheme, record.NewFakeRecorder(10))
+	getLws := func() *leaderworkerset.LeaderWorkerSet {
+		t.Helper()
+		var lws leaderworkerset.LeaderWorkerSet
+		if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample"}, &lws); err != nil {
+			t.Fatal(err)
+		}
+		return &lws
+	}
+	// setGeneration simulates a spec update, the fake client doesn't bump the generation.
+	setGeneration := func(generation int64) {
+		t.Helper()
+		lws := getLws()
+		lws.Generation = generation
+		if err := client.Update(context.TODO(), lws); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	steps := []struct {
+		name                   string
+		generation             int64
+		specApplied            bool
+		wantObservedGeneration int64
+	}{
+		{
+			name:                   "first generation applied",
+			generation:             1,
+			specApplied:            true,
+			wantObservedGeneration: 1,
+		},
+		{
+			name:                   "spec update not applied yet",
+			generation:             2,
+			wantObservedGeneration: 1,
+		},
+		{
+			name:                   "spec update applied",
+			generation:             2,
+			specApplied:            true,
+			wantObservedGeneration: 2,
+		},
+		{
+			name:                   "status only update",
+			generation:             2,
+			specApplied:            true,
+			wantObservedGeneration: 2,
+		},
+	}
+	for _, step := range steps {
+		setGeneration(step.generation)
+		if _, _, err := updateStatusWithPods(r, getLws(), "revision", step.specApplied); err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if got := getLws().Status.ObservedGeneration; got != step.wantObservedGeneration {
+			t.Errorf("%s: unexpected observedGeneration, want: %d, got: %d", step.name, step.wantObservedGeneration, got)
+		}
+	}
+}
+
+func TestDesiredStandbyGroups(t *testing.T) {
+	tests := []struct {
+		name            string
+		current         []int32
+		start           int32
+		groups          int32
+		standbyReplicas int32
+		want            []int32
+	}{
+		{
+			name:   "no standby replicas",
+			groups: 3,
+		},
+		{
+			name:    "standby replicas removed",
+			current: []int32{2},
+			groups:  2,
+		},
+		{
+			name:            "highest indexes by default",
+			groups:          4,
+			standbyReplicas: 2,
+			want:            []int32{2, 3},
+		},
+		{
+			name:            "current standby groups are kept",
+			current:         []int32{0, 3},
+			groups:          4,
+			standbyReplicas: 2,
+			want:            []int32{0, 3},
+		},
+		{
+			name:            "topped up after increasing the standby replicas",
+			current:         []int32{1},
+			groups:          4,
+			standbyReplicas: 2,
+			want:            []int32{1, 3},
+		},
+		{
+			name:            "trimmed after decreasing the standby replicas",
+			current:         []int32{1, 3},
+			groups:          3,
+			standbyReplicas: 1,
+			want:            []int32{1},
+		},
+		{
+			name:            "deleted groups are replaced",
+			current:         []int32{0, 5},
+			groups:          4,
+			standbyReplicas: 2,
+			want:            []int32{0, 3},
+		},
+		{
+			name:            "groups below the start are replaced",
+			current:         []int32{1, 4},
+			start:           2,
+			groups:          3,
+			standbyReplicas: 2,
+			want:            []int32{3, 4},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := desiredStandbyGroups(tc.current, tc.start, tc.groups, tc.standbyReplicas)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected standby groups (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestPromoteStandbyGroups(t *testing.T) {
+	tests := []struct {
+		name           string
+		standby        []int32
+		groups         int32
+		ready          []int32
+		wantStandby    []int32
+		wantPromotions []standbyPromotion
+	}{
+		{
+			name:        "all groups ready",
+			standby:     []int32{3},
+			groups:      4,
+			ready:       []int32{0, 1, 2, 3},
+			wantStandby: []int32{3},
+		},
+		{
+			name:        "standby group not ready",
+			standby:     []int32{3},
+			groups:      4,
+			ready:       []int32{0, 2},
+			wantStandby: []int32{3},
+		},
+		{
+			name:           "failed group replaced by the standby group",
+			standby:        []int32{3},
+			groups:         4,
+			ready:          []int32{0, 2, 3},
+			wantStandby:    []int32{1},
+			wantPromotions: []standbyPromotion{{promoted: 3, demoted: 1}},
+		},
+		{
+			name:           "only ready standby groups are promoted",
+			standby:        []int32{3, 4},
+			groups:         5,
+			ready:          []int32{1, 4},
+			wantStandby:    []int32{0, 3},
+			wantPromotions: []standbyPromotion{{promoted: 4, demoted: 0}},
+		},
+		{
+			name:        "no groups ready",
+			standby:     []int32{2},
+			groups:      3,
+			wantStandby: []int32{2},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotStandby, gotPromotions := promoteStandbyGroups(tc.standby, 0, tc.groups, sets.New(tc.ready...))
+			if diff := cmp.Diff(tc.wantStandby, gotStandby); diff != "" {
+				t.Errorf("unexpected standby groups (-want +got): %s", diff)
+			}
+			if diff := cmp.Diff(tc.wantPromotions, gotPromotions, cmp.AllowUnexported(standbyPromotion{})); diff != "" {
+				t.Errorf("unexpected promotions (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdateStatusStandbyGroups(t *testing.T) {
+	leaderPod := func(index int) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("test-standby-%d", index),
+				Namespace: "default",
+				Labels: map[string]string{
+					leaderworkerset.SetNameLabelKey:     "test-standby",
+					leaderworkerset.WorkerIndexLabelKey: "0",
+					leaderworkerset.GroupIndexLabelKey:  strconv.Itoa(index),
+					leaderworkerset.RevisionKey:         "revision",
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		}
+	}
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-standby", "default").Replica(2).Size(1).Obj()
+	lws.Spec.StandbyReplicas = ptr.To[int32](1)
+	leaderSts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-standby", Namespace: "default"},
+		Status:     appsv1.StatefulSetStatus{Replicas: 3},
+	}
+	k8sClient := newFakeClientBuilder().WithStatusSubresource(lws).
+		WithObjects(lws, leaderSts, leaderPod(0), leaderPod(1), leaderPod(2)).Build()
+	r := NewLeaderWorkerSetReconciler(k8sClient, testScheme, record.NewFakeRecorder(10))
+	getLws := func() *leaderworkerset.LeaderWorkerSet {
+		t.Helper()
+		var lws leaderworkerset.LeaderWorkerSet
+		if err := k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-standby"}, &lws); err != nil {
+			t.Fatal(err)
+		}
+		// Same as the reconciliation, the standby groups are part of the replicas.
+		addStandbyReplicas(&lws)
+		return &lws
+	}
+	standbyPods := func() []string {
+		t.Helper()
+		var podList corev1.PodList
+		if err := k8sClient.List(context.TODO(), &podList, client.MatchingLabels{leaderworkerset.StandbyLabelKey: "true"}); err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, pod := range podList.Items {
+			names = append(names, pod.Name)
+		}
+		return names
+	}
+
+	// The highest group is kept as the standby, and isn't counted as ready.
+	if _, _, err := updateStatusWithPods(r, getLws(), "revision", true); err != nil {
+		t.Fatal(err)
+	}
+	current := getLws()
+	if diff := cmp.Diff([]int32{2}, current.Status.StandbyGroups); diff != "" {
+		t.Errorf("unexpected standby groups (-want +got): %s", diff)
+	}
+	if current.Status.ReadyReplicas != 2 {
+		t.Errorf("unexpected readyReplicas, want: 2, got: %d", current.Status.ReadyReplicas)
+	}
+	if diff := cmp.Diff([]string{"test-standby-2"}, standbyPods()); diff != "" {
+		t.Errorf("unexpected standby pods (-want +got): %s", diff)
+	}
+
+	// The group 1 fails, the standby is promoted in its place.
+	failed := leaderPod(1)
+	if err := k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: failed.Name}, failed); err != nil {
+		t.Fatal(err)
+	}
+	failed.Status.Phase = corev1.PodFailed
+	if err := k8sClient.Status().Update(context.TODO(), failed); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := updateStatusWithPods(r, getLws(), "revision", true); err != nil {
+		t.Fatal(err)
+	}
+	current = getLws()
+	if diff := cmp.Diff([]int32{1}, current.Status.StandbyGroups); diff != "" {
+		t.Errorf("unexpected standby groups after the failure (-want +got): %s", diff)
+	}
+	if current.Status.ReadyReplicas != 2 {
+		t.Errorf("unexpected readyReplicas after the failure, want: 2, got: %d", current.Status.ReadyReplicas)
+	}
+	if diff := cmp.Diff([]string{"test-standby-1"}, standbyPods()); diff != "" {
+		t.Errorf("unexpected standby pods after the failure (-want +got): %s", diff)
+	}
+
+	// No standby is promoted while paused.
+	failed = leaderPod(0)
+	if err := k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: failed.Name}, failed); err != nil {
+		t.Fatal(err)
+	}
+	failed.Status.Phase = corev1.PodFailed
+	if err := k8sClient.Status().Update(context.TODO(), failed); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := updateStatusWithPods(r, getLws(), "revision", false); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]int32{1}, getLws().Status.StandbyGroups); diff != "" {
+		t.Errorf("unexpected standby groups while paused (-want +got): %s", diff)
+	}
+}
+
+func TestRolloutDurationMetric(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(metrics.RolloutDuration)
+	rolloutDuration := func() (uint64, float64) {
+		t.Helper()
+		families, err := registry.Gather()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, family := range families {
+			for _, metric := range family.GetMetric() {
+				for _, label := range metric.GetLabel() {
+					if label.GetName() == "name" && label.GetValue() == "test-rollout-duration" {
+						return metric.GetHistogram().GetSampleCount(), metric.GetHistogram().GetSampleSum()
+					}
+				}
+			}
+		}
+		return 0, 0
+	}
+	leaderPod := func(index int, revisionKey string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("test-rollout-duration-%d", index),
+				Namespace: "default",
+				Labels: map[string]string{
+					leaderworkerset.SetNameLabelKey:     "test-rollout-duration",
+					leaderworkerset.WorkerIndexLabelKey: "0",
+					leaderworkerset.GroupIndexLabelKey:  strconv.Itoa(index),
+					leaderworkerset.RevisionKey:         revisionKey,
+				},
+			},
+		}
+	}
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-rollout-duration", "default").Replica(2).Size(1).Obj()
+	leaderSts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-rollout-duration", Namespace: "default"},
+		Status:     appsv1.StatefulSetStatus{Replicas: 2},
+	}
+	client := newFakeClientBuilder().WithStatusSubresource(lws).
+		WithObjects(lws, leaderSts, leaderPod(0, "old"), leaderPod(1, "old")).Build()
+	fakeClock := clocktesting.NewFakeClock(time.Now().Truncate(time.Second))
+	r := NewLeaderWorkerSetReconciler(client, testScheme, record.NewFakeRecorder(10))
+	r.Clock = fakeClock
+	getLws := func() *leaderworkerset.LeaderWorkerSet {
+		t.Helper()
+		var lws leaderworkerset.LeaderWorkerSet
+		if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-rollout-duration"}, &lws); err != nil {
+			t.Fatal(err)
+		}
+		return &lws
+	}
+
+	// A new revision is observed.
+	if _, _, err := updateStatusWithPods(r, getLws(), "new", true); err != nil {
+		t.Fatal(err)
+	}
+	current := getLws()
+	if current.Status.RolloutStartTime == nil {
+		t.Fatal("expected the rollout start time to be set")
+	}
+	if count, _ := rolloutDuration(); count != 0 {
+		t.Errorf("unexpected rollout duration samples before the rollout completed: %d", count)
+	}
+
+	if !current.Status.RolloutStartTime.Time.Equal(fakeClock.Now()) {
+		t.Errorf("unexpected rollout start time, want: %v, got: %v", fakeClock.Now(), current.Status.RolloutStartTime)
+	}
+
+	// The controller restarts mid-rollout, the start time is reconstructed from the status.
+	current.Status.RolloutStartTime = ptr.To(metav1.NewTime(fakeClock.Now().Add(-time.Hour)))
+	if err := client.Status().Update(context.TODO(), current); err != nil {
+		t.Fatal(err)
+	}
+	r = NewLeaderWorkerSetReconciler(client, testScheme, record.NewFakeRecorder(10))
+	r.Clock = fakeClock
+	if _, _, err := updateStatusWithPods(r, getLws(), "new", true); err != nil {
+		t.Fatal(err)
+	}
+	if count, _ := rolloutDuration(); count != 0 {
+		t.Errorf("unexpected rollout duration samples before the rollout completed: %d", count)
+	}
+
+	// All the groups are updated.
+	for i := range 2 {
+		if err := client.Update(context.TODO(), leaderPod(i, "new")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fakeClock.Step(time.Minute)
+	if _, _, err := updateStatusWithPods(r, getLws(), "new", true); err != nil {
+		t.Fatal(err)
+	}
+	if rolloutStartTime := getLws().Status.RolloutStartTime; rolloutStartTime != nil {
+		t.Errorf("expected the rollout start time to be cleared, got: %v", rolloutStartTime)
+	}
+	count, sum := rolloutDuration()
+	if count != 1 {
+		t.Fatalf("unexpected rollout duration samples, want: 1, got: %d", count)
+	}
+	if want := (time.Hour + time.Minute).Seconds(); sum != want {
+		t.Errorf("unexpected rollout duration, want: %vs, got: %vs", want, sum)
+	}
+
+	// Reconciling again doesn't record the rollout twice.
+	if _, _, err := updateStatusWithPods(r, getLws(), "new", true); err != nil {
+		t.Fatal(err)
+	}
+	if count, _ := rolloutDuration(); count != 1 {
+		t.Errorf("unexpected rollout duration samples, want: 1, got: %d", count)
+	}
+}
+
+func TestUpdateStatusGroupUnschedulable(t *testing.T) {
+	// Condition times are serialized with a precision of seconds.
+	fakeClock := clocktesting.NewFakeClock(time.Now().Truncate(time.Second))
+	pendingSince := metav1.NewTime(fakeClock.Now())
+	pod := func(name string, groupIndex, workerIndex int, unschedulable bool) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels: map[string]string{
+					leaderworkerset.SetNameLabelKey:     "test-sample",
+					leaderworkerset.GroupIndexLabelKey:  strconv.Itoa(groupIndex),
+					leaderworkerset.WorkerIndexLabelKey: strconv.Itoa(workerIndex),
+				},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		if unschedulable {
+			pod.Status = corev1.PodStatus{
+				Phase: corev1.PodPending,
+				Conditions: []corev1.PodCondition{{
+					Type:               corev1.PodScheduled,
+					Status:             corev1.ConditionFalse,
+					Reason:             corev1.PodReasonUnschedulable,
+					LastTransitionTime: pendingSince,
+				}},
+			}
+		}
+		return pod
+	}
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(2).Size(2).Obj()
+	leaderSts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-sample", Namespace: "default"},
+		Status:     appsv1.StatefulSetStatus{Replicas: 2},
+	}
+	unschedulableWorker := pod("test-sample-1-1", 1, 1, true)
+	client := newFakeClientBuilder().WithStatusSubresource(lws).
+		WithObjects(lws, leaderSts, pod("test-sample-0", 0, 0, false), pod("test-sample-0-1", 0, 1, false), pod("test-sample-1", 1, 0, false), unschedulableWorker).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := NewLeaderWorkerSetReconciler(client, testScheme, recorder)
+	r.Clock = fakeClock
+	getLws := func() *leaderworkerset.LeaderWorkerSet {
+		t.Helper()
+		var lws leaderworkerset.LeaderWorkerSet
+		if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample"}, &lws); err != nil {
+			t.Fatal(err)
+		}
+		return &lws
+	}
+	unschedulableCondition := func() *metav1.Condition {
+		return meta.FindStatusCondition(getLws().Status.Conditions, string(leaderworkerset.LeaderWorkerSetGroupUnschedulable))
+	}
+	unschedulableEvents := func() []string {
+		var events []string
+		for len(recorder.Events) > 0 {
+			if event := <-recorder.Events; strings.Contains(event, GroupUnschedulable) {
+				events = append(events, event)
+			}
+		}
+		return events
+	}
+
+	// The worker has been unschedulable for less than the timeout.
+	fakeClock.Step(2 * time.Minute)
+	_, requeueAfter, err := updateStatusWithPods(r, getLws(), "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requeueAfter != 3*time.Minute {
+		t.Errorf("unexpected requeueAfter, want: %s, got: %s", 3*time.Minute, requeueAfter)
+	}
+	if condition := unschedulableCondition(); condition != nil {
+		t.Errorf("unexpected GroupUnschedulable condition: %v", condition)
+	}
+
+	// The worker has been unschedulable for longer than the timeout.
+	fakeClock.Step(3 * time.Minute)
+	if _, requeueAfter, err = updateStatusWithPods(r, getLws(), "", true); err != nil {
+		t.Fatal(err)
+	}
+	if requeueAfter != 0 {
+		t.Errorf("unexpected requeueAfter, want: 0, got: %s", requeueAfter)
+	}
+	condition := unschedulableCondition()
+	if condition == nil || condition.Status != metav1.ConditionTrue {
+		t.Fatalf("expected GroupUnschedulable condition to be true, got: %v", condition)
+	}
+	if !strings.Contains(condition.Message, "test-sample-1") {
+		t.Errorf("expected the condition message to name the group, got: %q", condition.Message)
+	}
+	if events := unschedulableEvents(); len(events) != 1 || !strings.Contains(events[0], "test-sample-1") {
+		t.Errorf("expected a GroupUnschedulable event naming the group, got: %v", events)
+	}
+
+	// No new event while the group stays unschedulable.
+	fakeClock.Step(time.Minute)
+	if _, _, err = updateStatusWithPods(r, getLws(), "", true); err != nil {
+		t.Fatal(err)
+	}
+	if events := unschedulableEvents(); len(events) != 0 {
+		t.Errorf("unexpected GroupUnschedulable events: %v", events)
+	}
+
+	// Another group becomes unschedulable, the condition names both and only the new one is reported.
+	otherWorker := pod("test-sample-0-1", 0, 1, true)
+	if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: otherWorker.Name}, &corev1.Pod{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Status().Update(context.TODO(), otherWorker); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = updateStatusWithPods(r, getLws(), "", true); err != nil {
+		t.Fatal(err)
+	}
+	if condition := unschedulableCondition(); condition == nil || !strings.Contains(condition.Message, "test-sample-0, test-sample-1") {
+		t.Errorf("expected the condition message to name both groups, got: %v", condition)
+	}
+	if events := unschedulableEvents(); len(events) != 1 || !strings.Contains(events[0], "test-sample-0 ") {
+		t.Errorf("expected a single GroupUnschedulable event naming the new group, got: %v", events)
+	}
+
+	// The workers are scheduled.
+	for _, worker := range []*corev1.Pod{unschedulableWorker, otherWorker} {
+		worker.Status = corev1.PodStatus{Phase: corev1.PodRunning}
+		if err := client.Status().Update(context.TODO(), worker); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, _, err = updateStatusWithPods(r, getLws(), "", true); err != nil {
+		t.Fatal(err)
+	}
+	if condition := unschedulableCondition(); condition == nil || condition.Status != metav1.ConditionFalse {
+		t.Errorf("expected GroupUnschedulable condition to be false, got: %v", condition)
+	}
+}
+
+func TestUpdateStatusRolloutStalled(t *testing.T) {
+	// Condition times are serialized with a precision of seconds.
+	fakeClock := clocktesting.NewFakeClock(time.Now().Truncate(time.Second))
+	leaderPod := func(index int, revisionKey string, ready bool) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              fmt.Sprintf("test-sample-%d", index),
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(fakeClock.Now()),
+				Labels: map[string]string{
+					leaderworkerset.SetNameLabelKey:     "test-sample",
+					leaderworkerset.WorkerIndexLabelKey: "0",
+					leaderworkerset.GroupIndexLabelKey:  strconv.Itoa(index),
+					leaderworkerset.RevisionKey:         revisionKey,
+				},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		if ready {
+			pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		}
+		return pod
+	}
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(3).Size(1).Obj()
+	lws.Spec.RolloutStrategy = leaderworkerset.RolloutStrategy{
+		Type: leaderworkerset.RollingUpdateStrategyType,
+		RollingUpdateConfiguration: &leaderworkerset.RollingUpdateConfiguration{
+			MaxUnavailable:          intstr.FromInt32(1),
+			ProgressDeadlineSeconds: ptr.To[int32](600),
+		},
+	}
+	leaderSts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-sample", Namespace: "default"},
+		Status:     appsv1.StatefulSetStatus{Replicas: 3},
+	}
+	// The group 1 was recreated with the new revision, and doesn't become ready.
+	newGroup := leaderPod(1, "new", false)
+	client := newFakeClientBuilder().WithStatusSubresource(lws).
+		WithObjects(lws, leaderSts, leaderPod(0, "old", true), newGroup, leaderPod(2, "old", true)).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := NewLeaderWorkerSetReconciler(client, testScheme, recorder)
+	r.Clock = fakeClock
+	getLws := func() *leaderworkerset.LeaderWorkerSet {
+		t.Helper()
+		var lws leaderworkerset.LeaderWorkerSet
+		if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample"}, &lws); err != nil {
+			t.Fatal(err)
+		}
+		return &lws
+	}
+	stalledCondition := func() *metav1.Condition {
+		return meta.FindStatusCondition(getLws().Status.Conditions, string(leaderworkerset.LeaderWorkerSetRolloutStalled))
+	}
+	stalledEvents := func() []string {
+		var events []string
+		for len(recorder.Events) > 0 {
+			if event := <-recorder.Events; strings.Contains(event, RolloutStalled) {
+				events = append(events, event)
+			}
+		}
+		return events
+	}
+
+	// The new group is within the progress deadline.
+	fakeClock.Step(4 * time.Minute)
+	_, requeueAfter, err := updateStatusWithPods(r, getLws(), "new", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requeueAfter != 6*time.Minute {
+		t.Errorf("unexpected requeueAfter, want: %s, got: %s", 6*time.Minute, requeueAfter)
+	}
+	if condition := stalledCondition(); condition != nil {
+		t.Errorf("unexpected RolloutStalled condition: %v", condition)
+	}
+
+	// The new group exceeded the progress deadline, the rollout is stalled.
+	fakeClock.Step(6 * time.Minute)
+	if _, _, err = updateStatusWithPods(r, getLws(), "new", true); err != nil {
+		t.Fatal(err)
+	}
+	condition := stalledCondition()
+	if condition == nil || condition.Status != metav1.ConditionTrue {
+		t.Fatalf("expected RolloutStalled condition to be true, got: %v", condition)
+	}
+	if !strings.Contains(condition.Message, "test-sample-1") {
+		t.Errorf("expected the condition message to name the group, got: %q", condition.Message)
+	}
+	if events := stalledEvents(); len(events) != 1 || !strings.Contains(events[0], "test-sample-1 ") {
+		t.Errorf("expected a RolloutStalled event naming the group, got: %v", events)
+	}
+
+	// No new event while the group stays stalled.
+	fakeClock.Step(time.Minute)
+	if _, _, err = updateStatusWithPods(r, getLws(), "new", true); err != nil {
+		t.Fatal(err)
+	}
+	if events := stalledEvents(); len(events) != 0 {
+		t.Errorf("unexpected RolloutStalled events: %v", events)
+	}
+
+	// The group 0 is recreated with the new revision and doesn't become ready either, the condition
+	// names both groups and only the new one is reported.
+	otherGroup := leaderPod(0, "new", false)
+	otherGroup.CreationTimestamp = metav1.NewTime(fakeClock.Now().Add(-11 * time.Minute))
+	if err := client.Delete(context.TODO(), leaderPod(0, "old", true)); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Create(context.TODO(), otherGroup); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = updateStatusWithPods(r, getLws(), "new", true); err != nil {
+		t.Fatal(err)
+	}
+	if condition := stalledCondition(); condition == nil || !strings.Contains(condition.Message, "test-sample-0, test-sample-1") {
+		t.Errorf("expected the condition message to name both groups, got: %v", condition)
+	}
+	if events := stalledEvents(); len(events) != 1 || !strings.Contains(events[0], "test-sample-0 ") {
+		t.Errorf("expected a single RolloutStalled event naming the new group, got: %v", events)
+	}
+
+	// The new groups eventually become ready, the rollout resumes.
+	for _, group := range []*corev1.Pod{newGroup, otherGroup} {
+		group.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		if err := client.Status().Update(context.TODO(), group); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, _, err = updateStatusWithPods(r, getLws(), "new", true); err != nil {
+		t.Fatal(err)
+	}
+	if condition := stalledCondition(); condition == nil || condition.Status != metav1.ConditionFalse {
+		t.Errorf("expected RolloutStalled condition to be false, got: %v", condition)
+	}
+}
+
+func TestHoldRollout(t *testing.T) {
+	tests := []struct {
+		name          string
+		lwsReplicas   int32
+		stsReplicas   int32
+		stsPartition  int32
+		partition     int32
+		replicas      int32
+		wantPartition int32
+		wantReplicas  int32
+	}{
+		{
+			name:          "partition moving down is held",
+			lwsReplicas:   4,
+			stsReplicas:   4,
+			stsPartition:  3,
+			partition:     2,
+			replicas:      4,
+			wantPartition: 3,
+			wantReplicas:  4,
+		},
+		{
+			name:          "no surge replica is created",
+			lwsReplicas:   4,
+			stsReplicas:   4,
+			stsPartition:  4,
+			partition:     3,
+			replicas:      5,
+			wantPartition: 4,
+			wantReplicas:  4,
+		},
+		{
+			name:          "existing surge replicas are kept",
+			lwsReplicas:   4,
+			stsReplicas:   5,
+			stsPartition:  3,
+			partition:     2,
+			replicas:      5,
+			wantPartition: 3,
+			wantReplicas:  5,
+		},
+		{
+			name:          "scaling up is carried out",
+			lwsReplicas:   6,
+			stsReplicas:   4,
+			stsPartition:  4,
+			partition:     4,
+			replicas:      6,
+			wantPartition: 4,
+			wantReplicas:  6,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(int(tc.lwsReplicas)).Obj()
+			sts := &appsv1.StatefulSet{
+				Spec: appsv1.StatefulSetSpec{
+					Replicas: ptr.To(tc.stsReplicas),
+					UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+						RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: ptr.To(tc.stsPartition)},
+					},
+				},
+			}
+			partition, replicas := holdRollout(lws, sts, 0, tc.partition, tc.replicas)
+			if partition != tc.wantPartition || replicas != tc.wantReplicas {
+				t.Errorf("unexpected partition and replicas, want: (%d, %d), got: (%d, %d)", tc.wantPartition, tc.wantReplicas, partition, replicas)
+			}
+		})
+	}
+}
+
+func TestUpdateStatusMinReadySeconds(t *testing.T) {
+	// Condition times are serialized with a precision of seconds.
+	fakeClock := clocktesting.NewFakeClock(time.Now().Truncate(time.Second))
+	pod := func(name string, groupIndex, workerIndex int, readyFor time.Duration) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels: map[string]string{
+					leaderworkerset.SetNameLabelKey:     "test-sample",
+					leaderworkerset.GroupIndexLabelKey:  strconv.Itoa(groupIndex),
+					leaderworkerset.WorkerIndexLabelKey: strconv.Itoa(workerIndex),
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{
+					Type:               corev1.PodReady,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(fakeClock.Now().Add(-readyFor)),
+				}},
+			},
+		}
+	}
+	workerSts := func(name string) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To[int32](1)},
+			Status:     appsv1.StatefulSetStatus{Replicas: 1},
+		}
+	}
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(2).Size(2).MinReadySeconds(60).Obj()
+	leaderSts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-sample", Namespace: "default"},
+		Status:     appsv1.StatefulSetStatus{Replicas: 2},
+	}
+	// The first group has been ready for longer than minReadySeconds, the leader of the second
+	// group only became ready 10 seconds ago.
+	worker := pod("test-sample-1-1", 1, 1, 30*time.Second)
+	client := newFakeClientBuilder().WithStatusSubresource(lws).
+		WithObjects(lws, leaderSts, workerSts("test-sample-0"), workerSts("test-sample-1"),
+			pod("test-sample-0", 0, 0, 90*time.Second), pod("test-sample-0-1", 0, 1, 90*time.Second),
+			pod("test-sample-1", 1, 0, 10*time.Second), worker).Build()
+	r := NewLeaderWorkerSetReconciler(client, testScheme, record.NewFakeRecorder(10))
+	r.Clock = fakeClock
+	getLws := func() *leaderworkerset.LeaderWorkerSet {
+		t.Helper()
+		var lws leaderworkerset.LeaderWorkerSet
+		if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample"}, &lws); err != nil {
+			t.Fatal(err)
+		}
+		return &lws
+	}
+
+	_, requeueAfter, err := updateStatusWithPods(r, getLws(), "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := getLws().Status.ReadyReplicas; got != 1 {
+		t.Errorf("unexpected readyReplicas, want: 1, got: %d", got)
+	}
+	if requeueAfter != 50*time.Second {
+		t.Errorf("unexpected requeueAfter, want: %s, got: %s", 50*time.Second, requeueAfter)
+	}
+
+	// Both groups have been ready for longer than minReadySeconds.
+	fakeClock.Step(50 * time.Second)
+	if _, requeueAfter, err = updateStatusWithPods(r, getLws(), "", true); err != nil {
+		t.Fatal(err)
+	}
+	if got := getLws().Status.ReadyReplicas; got != 2 {
+		t.Errorf("unexpected readyReplicas, want: 2, got: %d", got)
+	}
+	if requeueAfter != 0 {
+		t.Errorf("unexpected requeueAfter, want: 0, got: %s", requeueAfter)
+	}
+
+	// A worker of the second group becomes unready.
+	worker.Status.Conditions[0].Status = corev1.ConditionFalse
+	if err := client.Status().Update(context.TODO(), worker); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = updateStatusWithPods(r, getLws(), "", true); err != nil {
+		t.Fatal(err)
+	}
+	if got := getLws().Status.ReadyReplicas; got != 1 {
+		t.Errorf("unexpected readyReplicas, want: 1, got: %d", got)
+	}
+}
+
+func TestBoundedStatusRequeueAfter(t *testing.T) {
+	tests := []struct {
+		name         string
+		requeueAfter time.Duration
+		resyncPeriod time.Duration
+		want         time.Duration
+	}{
+		{
+			name:         "nothing pending",
+			resyncPeriod: time.Minute,
+			want:         0,
+		},
+		{
+			name:         "pending before the resync period",
+			requeueAfter: 10 * time.Second,
+			resyncPeriod: time.Minute,
+			want:         10 * time.Second,
+		},
+		{
+			name:         "pending after the resync period",
+			requeueAfter: 5 * time.Minute,
+			resyncPeriod: time.Minute,
+			want:         time.Minute,
+		},
+		{
+			name:         "resync period disabled",
+			requeueAfter: 5 * time.Minute,
+			want:         5 * time.Minute,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := boundedStatusRequeueAfter(tc.requeueAfter, tc.resyncPeriod); got != tc.want {
+				t.Errorf("unexpected requeueAfter, want: %s, got: %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestPinnedPartition(t *testing.T) {
+	revision := func(key string) *appsv1.ControllerRevision {
+		return &appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-sample-" + key,
+				Namespace: "default",
+				Labels: map[string]string{
+					leaderworkerset.SetNameLabelKey: "test-sample",
+					leaderworkerset.RevisionKey:     key,
+				},
+			},
+		}
+	}
+	leaderPod := func(index int, revisionKey string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("test-sample-%d", index),
+				Namespace: "default",
+				Labels: map[string]string{
+					leaderworkerset.SetNameLabelKey:     "test-sample",
+					leaderworkerset.WorkerIndexLabelKey: "0",
+					leaderworkerset.GroupIndexLabelKey:  strconv.Itoa(index),
+					leaderworkerset.RevisionKey:         revisionKey,
+				},
+			},
+		}
+	}
+	pin := func(index int) string {
+		return leaderworkerset.PinGroupAnnotationKeyPrefix + strconv.Itoa(index)
+	}
+
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		start         int32
+		wantPartition int32
+		wantEvents    int
+	}{
+		{
+			name: "no pinned group",
+		},
+		{
+			name:          "group pinned to its revision",
+			annotations:   map[string]string{pin(1): "old"},
+			wantPartition: 2,
+		},
+		{
+			name:          "highest pinned group holds the rollout",
+			annotations:   map[string]string{pin(0): "old", pin(1): "old"},
+			wantPartition: 2,
+		},
+		{
+			name:          "partition relative to the start ordinal",
+			annotations:   map[string]string{pin(1): "old"},
+			start:         1,
+			wantPartition: 1,
+		},
+		{
+			name:        "group below the start ordinal",
+			annotations: map[string]string{pin(0): "old"},
+			start:       1,
+		},
+		{
+			name:        "group already updated",
+			annotations: map[string]string{pin(3): "old"},
+		},
+		{
+			name:        "group pinned to the update revision",
+			annotations: map[string]string{pin(3): "new"},
+		},
+		{
+			name:        "group not created",
+			annotations: map[string]string{pin(5): "old"},
+		},
+		{
+			name:        "pinned revision not found",
+			annotations: map[string]string{pin(1): "missing"},
+			wantEvents:  1,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(4).Size(1).Obj()
+			lws.Annotations = tc.annotations
+			client := newFakeClientBuilder().WithObjects(revision("old"), revision("new"),
+				leaderPod(0, "old"), leaderPod(1, "old"), leaderPod(2, "new"), leaderPod(3, "new")).Build()
+			recorder := record.NewFakeRecorder(10)
+			r := NewLeaderWorkerSetReconciler(client, testScheme, recorder)
+
+			partition, err := r.pinnedPartition(context.TODO(), lws, tc.start, "new")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if partition != tc.wantPartition {
+				t.Errorf("unexpected partition, want: %d, got: %d", tc.wantPartition, partition)
+			}
+			if len(recorder.Events) != tc.wantEvents {
+				t.Errorf("unexpected number of events, want: %d, got: %d", tc.wantEvents, len(recorder.Events))
+			}
+
+			// Unpinning the groups releases the rollout.
+			lws.Annotations = nil
+			partition, err = r.pinnedPartition(context.TODO(), lws, tc.start, "new")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if partition != 0 {
+				t.Errorf("unexpected partition once unpinned, want: 0, got: %d", partition)
+			}
+		})
+	}
+}
+
+func TestCapGroupCreates(t *testing.T) {
+	tests := []struct {
+		name            string
+		currentReplicas *int32
+		replicas        int32
+		maxCreates      int32
+		// wantReplicas are the replicas applied by the consecutive reconciles, each one starting
+		// from the replicas applied by the previous one.
+		wantReplicas []int32
+	}{
+		{
+			name:         "no cap",
+			replicas:     10,
+			wantReplicas: []int32{10},
+		},
+		{
+			name:         "leader statefulset created in batches",
+			replicas:     10,
+			maxCreates:   4,
+			wantReplicas: []int32{4, 8, 10},
+		},
+		{
+			name:            "scale up in batches",
+			currentReplicas: ptr.To[int32](3),
+			replicas:        8,
+			maxCreates:      2,
+			wantReplicas:    []int32{5, 7, 8},
+		},
+		{
+			name:            "scale up within the cap",
+			currentReplicas: ptr.To[int32](3),
+			replicas:        5,
+			maxCreates:      2,
+			wantReplicas:    []int32{5},
+		},
+		{
+			name:            "scale down is not capped",
+			currentReplicas: ptr.To[int32](8),
+			replicas:        2,
+			maxCreates:      2,
+			wantReplicas:    []int32{2},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var leaderSts *appsv1.StatefulSet
+			if tc.currentReplicas != nil {
+				leaderSts = &appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{Replicas: tc.currentReplicas}}
+			}
+			var gotReplicas []int32
+			for len(gotReplicas) <= len(tc.wantReplicas) {
+				replicas := capGroupCreates(leaderSts, tc.replicas, tc.maxCreates)
+				gotReplicas = append(gotReplicas, replicas)
+				if replicas == tc.replicas {
+					break
+				}
+				leaderSts = &appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{Replicas: ptr.To(replicas)}}
+			}
+			if diff := cmp.Diff(tc.wantReplicas, gotReplicas); diff != "" {
+				t.Errorf("unexpected replicas of the consecutive reconciles (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdateStatusRevisions(t *testing.T) {
+	leaderPod := func(index int, revisionKey string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("test-sample-%d", index),
+				Namespace: "default",
+				Labels: map[string]string{
+					leaderworkerset.SetNameLabelKey:     "test-sample",
+					leaderworkerset.WorkerIndexLabelKey: "0",
+					leaderworkerset.GroupIndexLabelKey:  strconv.Itoa(index),
+					leaderworkerset.RevisionKey:         revisionKey,
+				},
+			},
+		}
+	}
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(2).Size(1).Obj()
+	leaderSts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-sample", Namespace: "default"},
+		Status:     appsv1.StatefulSetStatus{Replicas: 2},
+	}
+	client := newFakeClientBuilder().WithStatusSubresource(lws).
+		WithObjects(lws, leaderSts, leaderPod(0, "old"), leaderPod(1, "old")).Build()
+	r := NewLeaderWorkerSetReconciler(client, testScheme, record.NewFakeRecorder(10))
+	updateStatus := func(revisionKey string) leaderworkerset.LeaderWorkerSetStatus {
+		t.Helper()
+		var lws leaderworkerset.LeaderWorkerSet
+		if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample"}, &lws); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := updateStatusWithPods(r, &lws, revisionKey, true); err != nil {
+			t.Fatal(err)
+		}
+		if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample"}, &lws); err != nil {
+			t.Fatal(err)
+		}
+		return lws.Status
+	}
+	expectRevisions := func(status leaderworkerset.LeaderWorkerSetStatus, wantCurrent, wantUpdate string) {
+		t.Helper()
+		if status.CurrentRevision != wantCurrent || status.UpdateRevision != wantUpdate {
+			t.Errorf("unexpected revisions, want current: %q, update: %q, got current: %q, update: %q",
+				wantCurrent, wantUpdate, status.CurrentRevision, status.UpdateRevision)
+		}
+	}
+
+	// The revisions are initialized.
+	expectRevisions(updateStatus("old"), "old", "old")
+
+	// A rollout starts, the groups still serve the old revision.
+	expectRevisions(updateStatus("new"), "old", "new")
+
+	// Only one of the groups is updated.
+	if err := client.Update(context.TODO(), leaderPod(1, "new")); err != nil {
+		t.Fatal(err)
+	}
+	expectRevisions(updateStatus("new"), "old", "new")
+
+	// All the groups are updated.
+	if err := client.Update(context.TODO(), leaderPod(0, "new")); err != nil {
+		t.Fatal(err)
+	}
+	expectRevisions(updateStatus("new"), "new", "new")
+}
+
+func TestUpdateStatusLastRolloutCompletionTime(t *testing.T) {
+	leaderPod := func(index int, revisionKey string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("test-sample-%d", index),
+				Namespace: "default",
+				Labels: map[string]string{
+					leaderworkerset.SetNameLabelKey:     "test-sample",
+					leaderworkerset.WorkerIndexLabelKey: "0",
+					leaderworkerset.GroupIndexLabelKey:  strconv.Itoa(index),
+					leaderworkerset.RevisionKey:         revisionKey,
+				},
+			},
+		}
+	}
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(2).Size(1).Obj()
+	leaderSts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-sample", Namespace: "default"},
+		Status:     appsv1.StatefulSetStatus{Replicas: 2},
+	}
+	client := newFakeClientBuilder().WithStatusSubresource(lws).
+		WithObjects(lws, leaderSts, leaderPod(0, "old"), leaderPod(1, "old")).Build()
+	fakeClock := clocktesting.NewFakeClock(time.Now().Truncate(time.Second))
+	r := NewLeaderWorkerSetReconciler(client, testScheme, record.NewFakeRecorder(10))
+	r.Clock = fakeClock
+	getLws := func() *leaderworkerset.LeaderWorkerSet {
+		t.Helper()
+		var lws leaderworkerset.LeaderWorkerSet
+		if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample"}, &lws); err != nil {
+			t.Fatal(err)
+		}
+		return &lws
+	}
+	updateStatus := func(revisionKey string) *metav1.Time {
+		t.Helper()
+		if _, _, err := updateStatusWithPods(r, getLws(), revisionKey, true); err != nil {
+			t.Fatal(err)
+		}
+		return getLws().Status.LastRolloutCompletionTime
+	}
+	updatePods := func(revisionKey string) {
+		t.Helper()
+		for i := range 2 {
+			if err := client.Update(context.TODO(), leaderPod(i, revisionKey)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	// No rollout has completed yet.
+	if completionTime := updateStatus("old"); completionTime != nil {
+		t.Errorf("unexpected completion time without a rollout: %v", completionTime)
+	}
+	if completionTime := updateStatus("new"); completionTime != nil {
+		t.Errorf("unexpected completion time while the rollout is in progress: %v", completionTime)
+	}
+
+	// The completion time is set once the rollout completes.
+	updatePods("new")
+	fakeClock.Step(time.Minute)
+	if completionTime := updateStatus("new"); completionTime == nil || !completionTime.Time.Equal(fakeClock.Now()) {
+		t.Fatalf("expected the completion time to be set to %v once the rollout completed, got: %v", fakeClock.Now(), completionTime)
+	}
+
+	// It isn't overwritten by the following reconciles.
+	current := getLws()
+	previous := metav1.NewTime(fakeClock.Now().Add(-time.Hour))
+	current.Status.LastRolloutCompletionTime = &previous
+	if err := client.Status().Update(context.TODO(), current); err != nil {
+		t.Fatal(err)
+	}
+	if completionTime := updateStatus("new"); completionTime == nil || !completionTime.Equal(&previous) {
+		t.Errorf("unexpected completion time once the rollout completed, want: %v, got: %v", previous, completionTime)
+	}
+
+	// Nor by the next rollout until it completes.
+	if completionTime := updateStatus("newer"); completionTime == nil || !completionTime.Equal(&previous) {
+		t.Errorf("unexpected completion time while the next rollout is in progress, want: %v, got: %v", previous, completionTime)
+	}
+	updatePods("newer")
+	fakeClock.Step(time.Minute)
+	if completionTime := updateStatus("newer"); completionTime == nil || !completionTime.Time.Equal(fakeClock.Now()) {
+		t.Errorf("expected the completion time to be updated to %v once the next rollout completed, got: %v", fakeClock.Now(), completionTime)
+	}
+}
+
+func TestUpdateStatusCrashingPods(t *testing.T) {
+	waiting := func(reason string) corev1.ContainerStatus {
+		return corev1.ContainerStatus{Name: "main", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason}}}
+	}
+	running := corev1.ContainerStatus{Name: "main", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}}
+	pod := func(name, setName, workerIndex string, initStatuses, statuses []corev1.ContainerStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels: map[string]string{
+					leaderworkerset.SetNameLabelKey:     setName,
+					leaderworkerset.WorkerIndexLabelKey: workerIndex,
+					leaderworkerset.GroupIndexLabelKey:  "0",
+				},
+			},
+			Status: corev1.PodStatus{InitContainerStatuses: initStatuses, ContainerStatuses: statuses},
+		}
+	}
+	tests := []struct {
+		name             string
+		pods             []client.Object
+		wantCrashingPods int32
+	}{
+		{
+			name: "no crashing pods",
+			pods: []client.Object{
+				pod("test-sample-0", "test-sample", "0", nil, []corev1.ContainerStatus{running}),
+				pod("test-sample-0-1", "test-sample", "1", nil, []corev1.ContainerStatus{waiting("ContainerCreating")}),
+			},
+		},
+		{
+			name: "crashing leader and worker",
+			pods: []client.Object{
+				pod("test-sample-0", "test-sample", "0", nil, []corev1.ContainerStatus{running, waiting("CrashLoopBackOff")}),
+				pod("test-sample-0-1", "test-sample", "1", []corev1.ContainerStatus{waiting("CrashLoopBackOff")}, nil),
+				pod("test-sample-0-2", "test-sample", "2", nil, []corev1.ContainerStatus{waiting("ImagePullBackOff")}),
+			},
+			wantCrashingPods: 2,
+		},
+		{
+			name: "crashing pod of another leaderworkerset",
+			pods: []client.Object{
+				pod("test-sample-0", "test-sample", "0", nil, []corev1.ContainerStatus{running}),
+				pod("other-0", "other", "0", nil, []corev1.ContainerStatus{waiting("CrashLoopBackOff")}),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(1).Size(3).Obj()
+			lws.Status.CrashingPods = 1
+			leaderSts := &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-sample", Namespace: "default"},
+				Status:     appsv1.StatefulSetStatus{Replicas: 1},
+			}
+			client := newFakeClientBuilder().WithStatusSubresource(lws).
+				WithObjects(append(tc.pods, lws, leaderSts)...).Build()
+			r := NewLeaderWorkerSetReconciler(client, testScheme, record.NewFakeRecorder(10))
+
+			if _, _, err := updateStatusWithPods(r, lws, "revision", true); err != nil {
+				t.Fatal(err)
+			}
+			var got leaderworkerset.LeaderWorkerSet
+			if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample"}, &got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Status.CrashingPods != tc.wantCrashingPods {
+				t.Errorf("unexpected crashing pods, want: %d, got: %d", tc.wantCrashingPods, got.Status.CrashingPods)
+			}
+		})
+	}
+}
+
+func TestUpdateStatusGroupFailed(t *testing.T) {
+	terminated := func(name string, exitCode int32) corev1.ContainerStatus {
+		return corev1.ContainerStatus{Name: name, State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode}}}
+	}
+	restarted := func(name string, exitCode int32) corev1.ContainerStatus {
+		return corev1.ContainerStatus{
+			Name:                 name,
+			RestartCount:         1,
+			State:                corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode}},
+		}
+	}
+	pod := func(name, groupIndex, workerIndex string, statuses ...corev1.ContainerStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels: map[string]string{
+					leaderworkerset.SetNameLabelKey:     "test-sample",
+					leaderworkerset.WorkerIndexLabelKey: workerIndex,
+					leaderworkerset.GroupIndexLabelKey:  groupIndex,
+				},
+			},
+			Status: corev1.PodStatus{ContainerStatuses: statuses},
+		}
+	}
+	retained := func(pod *corev1.Pod, failedPod string) *corev1.Pod {
+		pod.Annotations = map[string]string{leaderworkerset.GroupFailedAnnotationKey: failedPod}
+		return pod
+	}
+	policy := &leaderworkerset.PodFailurePolicy{
+		Rules: []leaderworkerset.PodFailurePolicyRule{
+			{OnExitCodes: leaderworkerset.PodFailurePolicyOnExitCodesRequirement{Operator: leaderworkerset.PodFailurePolicyOnExitCodesOpIn, Values: []int32{42}}},
+			{OnExitCodes: leaderworkerset.PodFailurePolicyOnExitCodesRequirement{ContainerName: ptr.To("sidecar"), Operator: leaderworkerset.PodFailurePolicyOnExitCodesOpNotIn, Values: []int32{143}}},
+		},
+	}
+	tests := []struct {
+		name   string
+		policy *leaderworkerset.PodFailurePolicy
+		pods   []client.Object
+		// previouslyFailed sets the GroupFailed condition before the status is updated.
+		previouslyFailed bool
+		wantStatus       metav1.ConditionStatus
+		wantMessage      string
+		wantEvent        bool
+	}{
+		{
+			name:             "no policy",
+			policy:           nil,
+			previouslyFailed: true,
+			pods:             []client.Object{pod("test-sample-0", "0", "0", terminated("main", 42))},
+			wantStatus:       metav1.ConditionFalse,
+			wantMessage:      "No group matched the pod failure policy",
+		},
+		{
+			name:             "non-matching exit codes",
+			policy:           policy,
+			previouslyFailed: true,
+			pods: []client.Object{
+				pod("test-sample-0", "0", "0", terminated("main", 1), terminated("sidecar", 143)),
+				pod("test-sample-0-1", "0", "1", restarted("main", 0)),
+				pod("test-sample-1", "1", "0", terminated("main", 0), terminated("sidecar", 0)),
+			},
+			wantStatus:  metav1.ConditionFalse,
+			wantMessage: "No group matched the pod failure policy",
+		},
+		{
+			name:   "matching exit codes",
+			policy: policy,
+			pods: []client.Object{
+				pod("test-sample-0", "0", "0", terminated("main", 1)),
+				pod("test-sample-1", "1", "0", terminated("main", 1)),
+				pod("test-sample-1-1", "1", "1", restarted("main", 42)),
+				pod("test-sample-2", "2", "0", terminated("sidecar", 1)),
+			},
+			wantStatus:  metav1.ConditionTrue,
+			wantMessage: "Groups test-sample-1, test-sample-2 failed with an exit code matching the pod failure policy",
+			wantEvent:   true,
+		},
+		{
+			name:   "groups retained as failed",
+			policy: policy,
+			pods: []client.Object{
+				retained(pod("test-sample-0", "0", "0", restarted("main", 1)), "test-sample-0"),
+				pod("test-sample-1", "1", "0", terminated("main", 1)),
+				pod("test-sample-1-1", "1", "1", restarted("main", 42)),
+				retained(pod("test-sample-2", "2", "0"), "test-sample-2-1"),
+				pod("test-sample-2-1", "2", "1", restarted("main", 1)),
+			},
+			wantStatus:  metav1.ConditionTrue,
+			wantMessage: "Groups test-sample-1 failed with an exit code matching the pod failure policy; Groups test-sample-0, test-sample-2 failed and are retained for inspection",
+			wantEvent:   true,
+		},
+		{
+			name:             "retained groups restarted",
+			policy:           nil,
+			previouslyFailed: true,
+			pods: []client.Object{
+				pod("test-sample-0", "0", "0"),
+				pod("test-sample-2", "2", "0"),
+			},
+			wantStatus:  metav1.ConditionFalse,
+			wantMessage: "No group matched the pod failure policy",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(3).Size(2).Obj()
+			lws.Spec.LeaderWorkerTemplate.PodFailurePolicy = tc.policy
+			if tc.previouslyFailed {
+				lws.Status.Conditions = []metav1.Condition{{Type: string(leaderworkerset.LeaderWorkerSetGroupFailed), Status: metav1.ConditionTrue}}
+			}
+			leaderSts := &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-sample", Namespace: "default"},
+				Status:     appsv1.StatefulSetStatus{Replicas: 3},
+			}
+			client := newFakeClientBuilder().WithStatusSubresource(lws).
+				WithObjects(append(tc.pods, lws, leaderSts)...).Build()
+			recorder := record.NewFakeRecorder(10)
+			r := NewLeaderWorkerSetReconciler(client, testScheme, recorder)
+
+			if _, _, err := updateStatusWithPods(r, lws, "revision", true); err != nil {
+				t.Fatal(err)
+			}
+			var got leaderworkerset.LeaderWorkerSet
+			if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample"}, &got); err != nil {
+				t.Fatal(err)
+			}
+			condition := meta.FindStatusCondition(got.Status.Conditions, string(leaderworkerset.LeaderWorkerSetGroupFailed))
+			if condition == nil {
+				t.Fatal("expected the GroupFailed condition to be set")
+			}
+			if condition.Status != tc.wantStatus || condition.Message != tc.wantMessage {
+				t.Errorf("unexpected GroupFailed condition, want: %s %q, got: %s %q", tc.wantStatus, tc.wantMessage, condition.Status, condition.Message)
+			}
+			gotEvent := false
+			for len(recorder.Events) > 0 {
+				if strings.Contains(<-recorder.Events, GroupFailed) {
+					gotEvent = true
+				}
+			}
+			if gotEvent != tc.wantEvent {
+				t.Errorf("unexpected GroupFailed event, want: %t, got: %t", tc.wantEvent, gotEvent)
+			}
+		})
+	}
+}
+
+func TestUpdateStatusGroupDeadlineExceeded(t *testing.T) {
+	fakeClock := clocktesting.NewFakeClock(time.Now().Truncate(time.Second))
+	leaderPod := func(index int, age time.Duration) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              fmt.Sprintf("test-sample-%d", index),
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(fakeClock.Now().Add(-age)),
+				Labels: map[string]string{
+					leaderworkerset.SetNameLabelKey:     "test-sample",
+					leaderworkerset.WorkerIndexLabelKey: "0",
+					leaderworkerset.GroupIndexLabelKey:  strconv.Itoa(index),
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name                  string
+		activeDeadlineSeconds *int64
+		// ages are the ages of the leader pods of the groups, by group index.
+		ages []time.Duration
+		// previouslyExceeded sets the GroupDeadlineExceeded condition before the status is updated.
+		previouslyExceeded bool
+		// steps are the durations the clock is stepped by before each status update.
+		steps            []time.Duration
+		wantRequeueAfter []time.Duration
+		wantStatus       metav1.ConditionStatus
+		wantMessage      string
+		wantDeleted      []string
+	}{
+		{
+			name:               "no active deadline",
+			ages:               []time.Duration{time.Hour},
+			previouslyExceeded: true,
+			steps:              []time.Duration{0},
+			wantRequeueAfter:   []time.Duration{0},
+			wantStatus:         metav1.ConditionFalse,
+			wantMessage:        "No active deadline is set",
+		},
+		{
+			name:                  "deadline not exceeded",
+			activeDeadlineSeconds: ptr.To[int64](60),
+			ages:                  []time.Duration{10 * time.Second, 30 * time.Second},
+			steps:                 []time.Duration{0, 20 * time.Second},
+			wantRequeueAfter:      []time.Duration{30 * time.Second, 10 * time.Second},
+		},
+		{
+			name:                  "deadline exceeded",
+			activeDeadlineSeconds: ptr.To[int64](60),
+			ages:                  []time.Duration{2 * time.Minute, 30 * time.Second, time.Minute},
+			steps:                 []time.Duration{0},
+			wantRequeueAfter:      []time.Duration{30 * time.Second},
+			wantStatus:            metav1.ConditionTrue,
+			wantMessage:           "Groups test-sample-0, test-sample-2 exceeded the active deadline of 1m0s and are kept down",
+			wantDeleted:           []string{"test-sample-0", "test-sample-2"},
+		},
+		{
+			name:                  "deadline expires as the clock moves",
+			activeDeadlineSeconds: ptr.To[int64](60),
+			ages:                  []time.Duration{10 * time.Second, 30 * time.Second},
+			steps:                 []time.Duration{0, 30 * time.Second},
+			wantRequeueAfter:      []time.Duration{30 * time.Second, 20 * time.Second},
+			wantStatus:            metav1.ConditionTrue,
+			wantMessage:           "Groups test-sample-1 exceeded the active deadline of 1m0s and are kept down",
+			wantDeleted:           []string{"test-sample-1"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClock.SetTime(time.Now().Truncate(time.Second))
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(len(tc.ages)).Size(2).Obj()
+			lws.Spec.LeaderWorkerTemplate.ActiveDeadlineSeconds = tc.activeDeadlineSeconds
+			if tc.previouslyExceeded {
+				lws.Status.Conditions = []metav1.Condition{{Type: string(leaderworkerset.LeaderWorkerSetGroupDeadlineExceeded), Status: metav1.ConditionTrue}}
+			}
+			leaderSts := &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-sample", Namespace: "default"},
+				Status:     appsv1.StatefulSetStatus{Replicas: int32(len(tc.ages))},
+			}
+			objects := []client.Object{lws, leaderSts}
+			for i, age := range tc.ages {
+				objects = append(objects, leaderPod(i, age))
+			}
+			client := newFakeClientBuilder().WithStatusSubresource(lws).WithObjects(objects...).Build()
+			recorder := record.NewFakeRecorder(10)
+			r := NewLeaderWorkerSetReconciler(client, testScheme, recorder)
+			r.Clock = fakeClock
+
+			for i, step := range tc.steps {
+				fakeClock.Step(step)
+				if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample"}, lws); err != nil {
+					t.Fatal(err)
+				}
+				_, requeueAfter, err := updateStatusWithPods(r, lws, "revision", true)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if requeueAfter != tc.wantRequeueAfter[i] {
+					t.Errorf("unexpected requeue after status update %d, want: %v, got: %v", i, tc.wantRequeueAfter[i], requeueAfter)
+				}
+			}
+
+			var got leaderworkerset.LeaderWorkerSet
+			if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample"}, &got); err != nil {
+				t.Fatal(err)
+			}
+			condition := meta.FindStatusCondition(got.Status.Conditions, string(leaderworkerset.LeaderWorkerSetGroupDeadlineExceeded))
+			if tc.wantStatus == "" {
+				if condition != nil {
+					t.Errorf("unexpected GroupDeadlineExceeded condition: %v", condition)
+				}
+			} else if condition == nil || condition.Status != tc.wantStatus || condition.Message != tc.wantMessage {
+				t.Errorf("unexpected GroupDeadlineExceeded condition, want: %s %q, got: %v", tc.wantStatus, tc.wantMessage, condition)
+			}
+
+			var pods corev1.PodList
+			if err := client.List(context.TODO(), &pods); err != nil {
+				t.Fatal(err)
+			}
+			var gotDeleted []string
+			for i := range tc.ages {
+				name := fmt.Sprintf("test-sample-%d", i)
+				if !slices.ContainsFunc(pods.Items, func(pod corev1.Pod) bool { return pod.Name == name }) {
+					gotDeleted = append(gotDeleted, name)
+				}
+			}
+			if diff := cmp.Diff(tc.wantDeleted, gotDeleted); diff != "" {
+				t.Errorf("unexpected deleted groups (-want +got): %s", diff)
+			}
+			var gotEventGroups []string
+			for len(recorder.Events) > 0 {
+				if event := <-recorder.Events; strings.Contains(event, GroupDeadlineExceeded) {
+					gotEventGroups = append(gotEventGroups, strings.Fields(event)[3])
+				}
+			}
+			if diff := cmp.Diff(tc.wantDeleted, gotEventGroups); diff != "" {
+				t.Errorf("unexpected groups of the GroupDeadlineExceeded events (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdateStatusGroupDeadlineExceededKeepsGroupsDown(t *testing.T) {
+	fakeClock := clocktesting.NewFakeClock(time.Now().Truncate(time.Second))
+	leaderPod := func(index int, gated bool) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              fmt.Sprintf("test-sample-%d", index),
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(fakeClock.Now()),
+				Labels: map[string]string{
+					leaderworkerset.SetNameLabelKey:     "test-sample",
+					leaderworkerset.WorkerIndexLabelKey: "0",
+					leaderworkerset.GroupIndexLabelKey:  strconv.Itoa(index),
+				},
+			},
+		}
+		if gated {
+			pod.Spec.SchedulingGates = []corev1.PodSchedulingGate{{Name: leaderworkerset.DeadlineExceededSchedulingGate}}
+		}
+		return pod
+	}
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(2).Size(2).ActiveDeadlineSeconds(60).Obj()
+	leaderSts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-sample", Namespace: "default"},
+		Status:     appsv1.StatefulSetStatus{Replicas: 2},
+	}
+	startTime := metav1.NewTime(fakeClock.Now())
+	client := newFakeClientBuilder().WithStatusSubresource(lws).WithObjects(lws, leaderSts, leaderPod(0, false)).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := NewLeaderWorkerSetReconciler(client, testScheme, recorder)
+	r.Clock = fakeClock
+
+	updateStatus := func() {
+		t.Helper()
+		var lws leaderworkerset.LeaderWorkerSet
+		if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample"}, &lws); err != nil {
+			t.Fatal(err)
+		}
+		addStandbyReplicas(&lws)
+		if _, _, err := updateStatusWithPods(r, &lws, "revision", true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// createLeaderPod stands for the leader statefulset recreating the leader pod, gated by the pod webhook.
+	createLeaderPod := func(index int, gated bool) {
+		t.Helper()
+		if err := client.Create(context.TODO(), leaderPod(index, gated)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expect := func(wantStatus metav1.ConditionStatus, wantMessage string, wantAnnotations map[string]string, wantPods []string, wantEventGroups []string) {
+		t.Helper()
+		var got leaderworkerset.LeaderWorkerSet
+		if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample"}, &got); err != nil {
+			t.Fatal(err)
+		}
+		condition := meta.FindStatusCondition(got.Status.Conditions, string(leaderworkerset.LeaderWorkerSetGroupDeadlineExceeded))
+		if wantStatus == "" {
+			if condition != nil {
+				t.Errorf("unexpected GroupDeadlineExceeded condition: %v", condition)
+			}
+		} else if condition == nil || condition.Status != wantStatus || condition.Message != wantMessage {
+			t.Errorf("unexpected GroupDeadlineExceeded condition, want: %s %q, got: %v", wantStatus, wantMessage, condition)
+		}
+		var sts appsv1.StatefulSet
+		if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample"}, &sts); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(wantAnnotations, sts.Annotations, cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("unexpected leader statefulset annotations (-want +got): %s", diff)
+		}
+		var pods corev1.PodList
+		if err := client.List(context.TODO(), &pods); err != nil {
+			t.Fatal(err)
+		}
+		var gotPods []string
+		for _, pod := range pods.Items {
+			gotPods = append(gotPods, pod.Name)
+		}
+		if diff := cmp.Diff(wantPods, gotPods, cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("unexpected leader pods (-want +got): %s", diff)
+		}
+		var gotEventGroups []string
+		for len(recorder.Events) > 0 {
+			if event := <-recorder.Events; strings.Contains(event, GroupDeadlineExceeded) {
+				gotEventGroups = append(gotEventGroups, strings.Fields(event)[3])
+			}
+		}
+		if diff := cmp.Diff(wantEventGroups, gotEventGroups); diff != "" {
+			t.Errorf("unexpected groups of the GroupDeadlineExceeded events (-want +got): %s", diff)
+		}
+	}
+	encodedStartTimes, err := json.Marshal(map[int]metav1.Time{0: startTime, 1: metav1.NewTime(startTime.Add(30 * time.Second))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	startTimes := string(encodedStartTimes)
+
+	// The start of the group 1 is recorded once its leader pod is created.
+	updateStatus()
+	fakeClock.Step(30 * time.Second)
+	createLeaderPod(1, false)
+	updateStatus()
+	expect("", "", map[string]string{leaderworkerset.GroupStartTimesAnnotationKey: startTimes},
+		[]string{"test-sample-0", "test-sample-1"}, nil)
+
+	// The group 0 exceeds the deadline, it's recorded and its leader pod is deleted.
+	fakeClock.Step(30 * time.Second)
+	updateStatus()
+	expect(metav1.ConditionTrue, "Groups test-sample-0 exceeded the active deadline of 1m0s and are kept down",
+		map[string]string{leaderworkerset.GroupStartTimesAnnotationKey: startTimes, leaderworkerset.DeadlineExceededGroupsAnnotationKey: "[0]"},
+		[]string{"test-sample-1"}, []string{"test-sample-0"})
+
+	// The leader pod recreated without the scheduling gate is deleted again, the gated one is kept, and the
+	// deadline isn't restarted by the recreated leader pod.
+	createLeaderPod(0, false)
+	updateStatus()
+	expect(metav1.ConditionTrue, "Groups test-sample-0 exceeded the active deadline of 1m0s and are kept down",
+		map[string]string{leaderworkerset.GroupStartTimesAnnotationKey: startTimes, leaderworkerset.DeadlineExceededGroupsAnnotationKey: "[0]"},
+		[]string{"test-sample-1"}, nil)
+	createLeaderPod(0, true)
+	updateStatus()
+	expect(metav1.ConditionTrue, "Groups test-sample-0 exceeded the active deadline of 1m0s and are kept down",
+		map[string]string{leaderworkerset.GroupStartTimesAnnotationKey: startTimes, leaderworkerset.DeadlineExceededGroupsAnnotationKey: "[0]"},
+		[]string{"test-sample-0", "test-sample-1"}, nil)
+
+	// The group 1 exceeds the deadline as well, the condition names both and only the new one is reported.
+	fakeClock.Step(30 * time.Second)
+	updateStatus()
+	expect(metav1.ConditionTrue, "Groups test-sample-0, test-sample-1 exceeded the active deadline of 1m0s and are kept down",
+		map[string]string{leaderworkerset.GroupStartTimesAnnotationKey: startTimes, leaderworkerset.DeadlineExceededGroupsAnnotationKey: "[0,1]"},
+		[]string{"test-sample-0"}, []string{"test-sample-1"})
+
+	// The deadline is raised, the groups are released and their gated leader pods deleted to be recreated.
+	createLeaderPod(1, true)
+	if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample"}, lws); err != nil {
+		t.Fatal(err)
+	}
+	lws.Spec.LeaderWorkerTemplate.ActiveDeadlineSeconds = ptr.To[int64](3600)
+	if err := client.Update(context.TODO(), lws); err != nil {
+		t.Fatal(err)
+	}
+	updateStatus()
+	expect(metav1.ConditionFalse, "No group exceeded the active deadline",
+		map[string]string{leaderworkerset.GroupStartTimesAnnotationKey: startTimes},
+		nil, nil)
+
+	// The deadline is unset, the start of the groups is forgotten.
+	createLeaderPod(0, false)
+	if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample"}, lws); err != nil {
+		t.Fatal(err)
+	}
+	lws.Spec.LeaderWorkerTemplate.ActiveDeadlineSeconds = nil
+	if err := client.Update(context.TODO(), lws); err != nil {
+		t.Fatal(err)
+	}
+	updateStatus()
+	expect(metav1.ConditionFalse, "No active deadline is set", nil, []string{"test-sample-0"}, nil)
+}
+
+func TestRestartRequestedGroups(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	leaderPod := func(name string, created time.Time) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(created),
+			},
+		}
+	}
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(2).Size(2).Obj()
+	lws.Status.GroupStatuses = []leaderworkerset.GroupStatus{
+		{Index: 0, Phase: leaderworkerset.GroupReady},
+		{Index: 1, Phase: leaderworkerset.GroupReady},
+	}
+	client := newFakeClientBuilder().WithStatusSubresource(lws).
+		WithObjects(lws, leaderPod("test-sample-0", now.Add(-time.Hour)), leaderPod("test-sample-1", now.Add(-time.Hour))).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := NewLeaderWorkerSetReconciler(client, testScheme, recorder)
+
+	// Each step sets the restart-group annotation of group 1, then reconciles twice.
+	steps := []struct {
+		name string
+		// recreateLeader recreates the leader pod of group 1 at the given time before the step,
+		// as done by the leader statefulset after a restart.
+		recreateLeader *time.Time
+		requestedAt    time.Time
+		wantRestarts   int
+		wantProcessed  time.Time
+	}{
+		{
+			name:          "new timestamp",
+			requestedAt:   now,
+			wantRestarts:  1,
+			wantProcessed: now,
+		},
+		{
+			name:           "newer timestamp",
+			recreateLeader: ptr.To(now.Add(time.Second)),
+			requestedAt:    now.Add(time.Minute),
+			wantRestarts:   1,
+			wantProcessed:  now.Add(time.Minute),
+		},
+		{
+			name:           "older timestamp",
+			recreateLeader: ptr.To(now.Add(2 * time.Minute)),
+			requestedAt:    now.Add(-time.Minute),
+			wantProcessed:  now.Add(time.Minute),
+		},
+		{
+			name:          "newer timestamp than the processed one, but older than the leader pod",
+			requestedAt:   now.Add(90 * time.Second),
+			wantProcessed: now.Add(90 * time.Second),
+		},
+	}
+	for _, step := range steps {
+		if step.recreateLeader != nil {
+			if err := client.Create(context.TODO(), leaderPod("test-sample-1", *step.recreateLeader)); err != nil {
+				t.Fatalf("%s: %v", step.name, err)
+			}
+		}
+		var current leaderworkerset.LeaderWorkerSet
+		if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample"}, &current); err != nil {
+			t.Fatal(err)
+		}
+		current.Annotations = map[string]string{leaderworkerset.RestartGroupAnnotationKeyPrefix + "1": step.requestedAt.Format(time.RFC3339)}
+		if err := client.Update(context.TODO(), &current); err != nil {
+			t.Fatal(err)
+		}
+
+		for range 2 {
+			if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample"}, &current); err != nil {
+				t.Fatal(err)
+			}
+			if err := r.restartRequestedGroups(context.TODO(), &current); err != nil {
+				t.Fatalf("%s: unexpected error: %v", step.name, err)
+			}
+		}
+
+		gotRestarts := 0
+		for len(recorder.Events) > 0 {
+			if event := <-recorder.Events; strings.Contains(event, GroupRestarted) {
+				gotRestarts++
+			}
+		}
+		if gotRestarts != step.wantRestarts {
+			t.Errorf("%s: unexpected restarts, want: %d, got: %d", step.name, step.wantRestarts, gotRestarts)
+		}
+		var leader corev1.Pod
+		err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample-1"}, &leader)
+		if gotDeleted := apierrors.IsNotFound(err); gotDeleted != (step.wantRestarts > 0) {
+			t.Errorf("%s: unexpected leader pod deletion, want: %t, got: %t", step.name, step.wantRestarts > 0, gotDeleted)
+		}
+		if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample"}, &current); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := lastRestartRequest(&current, 1), step.wantProcessed.Format(time.RFC3339); got != want {
+			t.Errorf("%s: unexpected processed timestamp, want: %s, got: %s", step.name, want, got)
+		}
+		if got := lastRestartRequest(&current, 0); got != "" {
+			t.Errorf("%s: unexpected processed timestamp of group 0: %s", step.name, got)
+		}
+	}
+}
+
+func TestGroupMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(metrics.GroupTotal, metrics.GroupReady)
+	gauges := func() map[string]float64 {
+		t.Helper()
+		families, err := registry.Gather()
+		if err != nil {
+			t.Fatal(err)
+		}
+		values := map[string]float64{}
+		for _, family := range families {
+			for _, metric := range family.GetMetric() {
+				for _, label := range metric.GetLabel() {
+					if label.GetName() == "name" && label.GetValue() == "test-group-metrics" {
+						values[family.GetName()] = metric.GetGauge().GetValue()
+					}
+				}
+			}
+		}
+		return values
+	}
+	leaderPod := func(index int, ready bool) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("test-group-metrics-%d", index),
+				Namespace: "default",
+				Labels: map[string]string{
+					leaderworkerset.SetNameLabelKey:     "test-group-metrics",
+					leaderworkerset.WorkerIndexLabelKey: "0",
+					leaderworkerset.GroupIndexLabelKey:  strconv.Itoa(index),
+					leaderworkerset.RevisionKey:         "revision",
+				},
+			},
+		}
+		if ready {
+			pod.Status = corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			}
+		}
+		return pod
+	}
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-group-metrics", "default").Replica(3).Size(1).Obj()
+	leaderSts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-group-metrics", Namespace: "default"},
+		Status:     appsv1.StatefulSetStatus{Replicas: 3},
+	}
+	k8sClient := newFakeClientBuilder().WithStatusSubresource(lws).
+		WithObjects(lws, leaderSts, leaderPod(0, true), leaderPod(1, true), leaderPod(2, false)).Build()
+	r := NewLeaderWorkerSetReconciler(k8sClient, testScheme, record.NewFakeRecorder(10))
+
+	var current leaderworkerset.LeaderWorkerSet
+	if err := k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-group-metrics"}, &current); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := updateStatusWithPods(r, &current, "revision", true); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]float64{"lws_group_total": 3, "lws_group_ready": 2}
+	if diff := cmp.Diff(want, gauges()); diff != "" {
+		t.Errorf("unexpected group gauges (-want +got): %s", diff)
+	}
+
+	// The gauges are deleted along with the leaderworkerset.
+	if err := k8sClient.Delete(context.TODO(), &current); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "test-group-metrics"}}); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string]float64{}, gauges()); diff != "" {
+		t.Errorf("unexpected group gauges after the deletion (-want +got): %s", diff)
+	}
+}
+
+func TestMakeReconcileDecision(t *testing.T) {
+	leaderSts := func(replicas, partition int32) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: ptr.To(replicas),
+				UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+					RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: ptr.To(partition)},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name         string
+		recreate     bool
+		leaderSts    *appsv1.StatefulSet
+		partition    int32
+		replicas     int32
+		lwsUpdated   bool
+		wantDecision reconcileDecision
+	}{
+		{
+			name:         "leader statefulset created",
+			replicas:     3,
+			wantDecision: reconcileDecision{Created: 3, Reason: decisionScale},
+		},
+		{
+			name:         "no change",
+			leaderSts:    leaderSts(3, 0),
+			replicas:     3,
+			wantDecision: reconcileDecision{Reason: decisionNoChange},
+		},
+		{
+			name:         "scale up",
+			leaderSts:    leaderSts(3, 0),
+			replicas:     5,
+			wantDecision: reconcileDecision{Created: 2, Reason: decisionScale},
+		},
+		{
+			name:         "scale down",
+			leaderSts:    leaderSts(3, 0),
+			replicas:     1,
+			wantDecision: reconcileDecision{Deleted: 2, Reason: decisionScale},
+		},
+		{
+			name:         "rollout started with a surge",
+			leaderSts:    leaderSts(3, 0),
+			partition:    3,
+			replicas:     4,
+			lwsUpdated:   true,
+			wantDecision: reconcileDecision{Created: 1, Reason: decisionRollout},
+		},
+		{
+			name:         "rollout progressing",
+			leaderSts:    leaderSts(4, 3),
+			partition:    1,
+			replicas:     4,
+			wantDecision: reconcileDecision{Updated: 2, Reason: decisionRollout},
+		},
+		{
+			name:         "recreate deletes all the groups",
+			recreate:     true,
+			leaderSts:    leaderSts(3, 0),
+			lwsUpdated:   true,
+			wantDecision: reconcileDecision{Deleted: 3, Reason: decisionRecreate},
+		},
+		{
+			name:         "recreate creates the groups back",
+			recreate:     true,
+			leaderSts:    leaderSts(0, 0),
+			replicas:     3,
+			wantDecision: reconcileDecision{Created: 3, Reason: decisionRecreate},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(3).Obj()
+			if tc.recreate {
+				lws.Spec.RolloutStrategy.Type = leaderworkerset.RecreateStrategyType
+			}
+			got := makeReconcileDecision(lws, tc.leaderSts, 0, tc.partition, tc.replicas, tc.lwsUpdated)
+			if diff := cmp.Diff(tc.wantDecision, got); diff != "" {
+				t.Errorf("unexpected decision (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestLogDecision(t *testing.T) {
+	var lines []string
+	logger := funcr.New(func(prefix, args string) {
+		lines = append(lines, args)
+	}, funcr.Options{Verbosity: 3})
+	ctx := ctrl.LoggerInto(context.TODO(), logger)
+	r := &LeaderWorkerSetReconciler{DecisionLogVerbosity: 3}
+	r.logDecision(ctx, reconcileDecision{Created: 1, Deleted: 2, Updated: 3, Reason: decisionRollout})
+
+	want := []string{`"level"=3 "msg"="Reconcile decision" "reason"="Rollout" "created"=1 "deleted"=2 "updated"=3`}
+	if diff := cmp.Diff(want, lines); diff != "" {
+		t.Errorf("unexpected log lines (-want +got): %s", diff)
+	}
+
+	// The decision isn't logged beyond the verbosity of the logger.
+	lines = nil
+	r.DecisionLogVerbosity = 4
+	r.logDecision(ctx, reconcileDecision{Reason: decisionNoChange})
+	if len(lines) != 0 {
+		t.Errorf("unexpected log lines: %v", lines)
+	}
+}
+
+func TestSteady(t *testing.T) {
+	tests := []struct {
+		name            string
+		standbyReplicas int32
+		status          leaderworkerset.LeaderWorkerSetStatus
+		want            bool
+	}{
+		{
+			name: "all groups ready at the current revision",
+			status: leaderworkerset.LeaderWorkerSetStatus{
+				ObservedGeneration: 2, CurrentRevision: "new", UpdateRevision: "new",
+				Replicas: 3, ReadyReplicas: 3, UpdatedReplicas: 3,
+			},
+			want: true,
+		},
+		{
+			name: "spec not observed yet",
+			status: leaderworkerset.LeaderWorkerSetStatus{
+				ObservedGeneration: 1, CurrentRevision: "new", UpdateRevision: "new",
+				Replicas: 3, ReadyReplicas: 3, UpdatedReplicas: 3,
+			},
+		},
+		{
+			name: "rollout in progress",
+			status: leaderworkerset.LeaderWorkerSetStatus{
+				ObservedGeneration: 2, CurrentRevision: "old", UpdateRevision: "new",
+				Replicas: 3, ReadyReplicas: 3, UpdatedReplicas: 1,
+			},
+		},
+		{
+			name: "a group isn't ready",
+			status: leaderworkerset.LeaderWorkerSetStatus{
+				ObservedGeneration: 2, CurrentRevision: "new", UpdateRevision: "new",
+				Replicas: 3, ReadyReplicas: 2, UpdatedReplicas: 3,
+			},
+		},
+		{
+			name: "scaling up",
+			status: leaderworkerset.LeaderWorkerSetStatus{
+				ObservedGeneration: 2, CurrentRevision: "new", UpdateRevision: "new",
+				Replicas: 2, ReadyReplicas: 2, UpdatedReplicas: 2,
+			},
+		},
+		{
+			name:            "all groups ready with standby groups",
+			standbyReplicas: 1,
+			status: leaderworkerset.LeaderWorkerSetStatus{
+				ObservedGeneration: 2, CurrentRevision: "new", UpdateRevision: "new",
+				Replicas: 4, ReadyReplicas: 3, UpdatedReplicas: 4,
+			},
+			want: true,
+		},
+		{
+			name:            "standby group not created yet",
+			standbyReplicas: 1,
+			status: leaderworkerset.LeaderWorkerSetStatus{
+				ObservedGeneration: 2, CurrentRevision: "new", UpdateRevision: "new",
+				Replicas: 3, ReadyReplicas: 3, UpdatedReplicas: 3,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-steady", "default").Replica(3).Obj()
+			lws.Spec.StandbyReplicas = ptr.To(tc.standbyReplicas)
+			addStandbyReplicas(lws)
+			lws.Generation = 2
+			lws.Status = tc.status
+			if got := steady(lws, "new"); got != tc.want {
+				t.Errorf("unexpected steady, want: %t, got: %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestUpdateStatusDuplicateLeader(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	leaderPod := func(name string, index int, age time.Duration) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+				Labels: map[string]string{
+					leaderworkerset.SetNameLabelKey:     "test-sample",
+					leaderworkerset.WorkerIndexLabelKey: "0",
+					leaderworkerset.GroupIndexLabelKey:  strconv.Itoa(index),
+				},
+			},
+		}
+	}
+	controlledBy := func(pod *corev1.Pod, kind, name string) *corev1.Pod {
+		pod.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: kind, Name: name, Controller: ptr.To(true)}}
+		return pod
+	}
+	tests := []struct {
+		name       string
+		leaderPods []*corev1.Pod
+		// previouslyDuplicated sets the DuplicateLeader condition before the status is updated.
+		previouslyDuplicated bool
+		wantStatus           metav1.ConditionStatus
+		wantMessage          string
+		wantDeleted          []string
+		wantEventGroups      []string
+	}{
+		{
+			name: "single leader per group",
+			leaderPods: []*corev1.Pod{
+				leaderPod("test-sample-0", 0, time.Hour),
+				leaderPod("test-sample-1", 1, time.Hour),
+			},
+		},
+		{
+			name: "newer duplicate of the statefulset pod",
+			leaderPods: []*corev1.Pod{
+				leaderPod("test-sample-0", 0, time.Hour),
+				leaderPod("test-sample-1", 1, time.Hour),
+				leaderPod("test-sample-1-copy", 1, time.Minute),
+			},
+			wantStatus:      metav1.ConditionTrue,
+			wantMessage:     "Groups test-sample-1 had more than one leader pod, the newer duplicates were deleted",
+			wantDeleted:     []string{"test-sample-1-copy"},
+			wantEventGroups: []string{"test-sample-1"},
+		},
+		{
+			name: "statefulset pod recreated after an older duplicate",
+			leaderPods: []*corev1.Pod{
+				leaderPod("test-sample-0", 0, time.Minute),
+				leaderPod("test-sample-0-copy", 0, time.Hour),
+				leaderPod("test-sample-1", 1, time.Hour),
+			},
+			wantStatus:      metav1.ConditionTrue,
+			wantMessage:     "Groups test-sample-0 had more than one leader pod, the newer duplicates were deleted",
+			wantDeleted:     []string{"test-sample-0-copy"},
+			wantEventGroups: []string{"test-sample-0"},
+		},
+		{
+			name: "duplicates without the statefulset pod",
+			leaderPods: []*corev1.Pod{
+				leaderPod("test-sample-0", 0, time.Hour),
+				leaderPod("test-sample-1-b", 1, time.Minute),
+				leaderPod("test-sample-1-a", 1, time.Hour),
+			},
+			wantStatus:      metav1.ConditionTrue,
+			wantMessage:     "Groups test-sample-1 had more than one leader pod, the newer duplicates were deleted",
+			wantDeleted:     []string{"test-sample-1-b"},
+			wantEventGroups: []string{"test-sample-1"},
+		},
+		{
+			name: "duplicates of several groups",
+			leaderPods: []*corev1.Pod{
+				leaderPod("test-sample-0", 0, time.Hour),
+				leaderPod("test-sample-0-copy", 0, time.Minute),
+				leaderPod("test-sample-1", 1, time.Hour),
+				leaderPod("test-sample-1-copy", 1, time.Minute),
+			},
+			wantStatus:      metav1.ConditionTrue,
+			wantMessage:     "Groups test-sample-0, test-sample-1 had more than one leader pod, the newer duplicates were deleted",
+			wantDeleted:     []string{"test-sample-0-copy", "test-sample-1-copy"},
+			wantEventGroups: []string{"test-sample-0", "test-sample-1"},
+		},
+		{
+			name: "worker pod with an edited worker index label",
+			leaderPods: []*corev1.Pod{
+				controlledBy(leaderPod("test-sample-0", 0, time.Hour), "StatefulSet", "test-sample"),
+				controlledBy(leaderPod("test-sample-1", 1, time.Hour), "StatefulSet", "test-sample"),
+				controlledBy(leaderPod("test-sample-1-1", 1, time.Minute), "StatefulSet", "test-sample-1"),
+			},
+		},
+		{
+			name: "duplicates controlled by the leader statefulset, another controller or none",
+			leaderPods: []*corev1.Pod{
+				controlledBy(leaderPod("test-sample-0", 0, time.Hour), "StatefulSet", "test-sample"),
+				controlledBy(leaderPod("test-sample-0-copy", 0, 2*time.Minute), "ReplicaSet", "other"),
+				leaderPod("test-sample-0-orphan", 0, time.Minute),
+				controlledBy(leaderPod("test-sample-1", 1, time.Hour), "StatefulSet", "test-sample"),
+				controlledBy(leaderPod("test-sample-1-copy", 1, time.Minute), "ReplicaSet", "other"),
+			},
+			wantStatus:      metav1.ConditionTrue,
+			wantMessage:     "Groups test-sample-0, test-sample-1 had more than one leader pod, the newer duplicates were deleted except test-sample-0-copy, test-sample-1-copy, not controlled by the leaderworkerset",
+			wantDeleted:     []string{"test-sample-0-orphan"},
+			wantEventGroups: []string{"test-sample-0", "test-sample-1"},
+		},
+		{
+			name: "duplicates resolved",
+			leaderPods: []*corev1.Pod{
+				leaderPod("test-sample-0", 0, time.Hour),
+				leaderPod("test-sample-1", 1, time.Hour),
+			},
+			previouslyDuplicated: true,
+			wantStatus:           metav1.ConditionFalse,
+			wantMessage:          "Every group has a single leader pod",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(2).Size(2).Obj()
+			if tc.previouslyDuplicated {
+				lws.Status.Conditions = []metav1.Condition{{Type: string(leaderworkerset.LeaderWorkerSetDuplicateLeader), Status: metav1.ConditionTrue}}
+			}
+			leaderSts := &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-sample", Namespace: "default"},
+				Status:     appsv1.StatefulSetStatus{Replicas: 2},
+			}
+			objects := []client.Object{lws, leaderSts}
+			for _, pod := range tc.leaderPods {
+				objects = append(objects, pod)
+			}
+			client := newFakeClientBuilder().WithStatusSubresource(lws).WithObjects(objects...).Build()
+			recorder := record.NewFakeRecorder(10)
+			r := NewLeaderWorkerSetReconciler(client, testScheme, recorder)
+
+			if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample"}, lws); err != nil {
+				t.Fatal(err)
+			}
+			if _, _, err := updateStatusWithPods(r, lws, "revision", true); err != nil {
+				t.Fatal(err)
+			}
+
+			var got leaderworkerset.LeaderWorkerSet
+			if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample"}, &got); err != nil {
+				t.Fatal(err)
+			}
+			condition := meta.FindStatusCondition(got.Status.Conditions, string(leaderworkerset.LeaderWorkerSetDuplicateLeader))
+			if tc.wantStatus == "" {
+				if condition != nil {
+					t.Errorf("unexpected DuplicateLeader condition: %v", condition)
+				}
+			} else if condition == nil || condition.Status != tc.wantStatus || condition.Message != tc.wantMessage {
+				t.Errorf("unexpected DuplicateLeader condition, want: %s %q, got: %v", tc.wantStatus, tc.wantMessage, condition)
+			}
+
+			var pods corev1.PodList
+			if err := client.List(context.TODO(), &pods); err != nil {
+				t.Fatal(err)
+			}
+			var gotDeleted []string
+			for _, pod := range tc.leaderPods {
+				if !slices.ContainsFunc(pods.Items, func(p corev1.Pod) bool { return p.Name == pod.Name }) {
+					gotDeleted = append(gotDeleted, pod.Name)
+				}
+			}
+			if diff := cmp.Diff(tc.wantDeleted, gotDeleted); diff != "" {
+				t.Errorf("unexpected deleted leader pods (-want +got): %s", diff)
+			}
+			var gotEventGroups []string
+			for len(recorder.Events) > 0 {
+				if event := <-recorder.Events; strings.Contains(event, DuplicateLeader) {
+					gotEventGroups = append(gotEventGroups, strings.Fields(event)[3])
+				}
+			}
+			if diff := cmp.Diff(tc.wantEventGroups, gotEventGroups); diff != "" {
+				t.Errorf("unexpected groups of the DuplicateLeader events (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestReconcileSteadyLeaderWorkerSet(t *testing.T) {
+	const groups = 50
+	key := types.NamespacedName{Namespace: "default", Name: "test-steady"}
+	statefulSet := func(name string) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    map[string]string{leaderworkerset.SetNameLabelKey: "test-steady"},
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: ptr.To[int32](groups),
+				UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+					RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: ptr.To[int32](0)},
+				},
+			},
+			Status: appsv1.StatefulSetStatus{Replicas: groups, ReadyReplicas: groups},
+		}
+	}
+	tests := []struct {
+		name        string
+		mutate      func(context.Context, client.Client) error
+		wantSkipped bool
+	}{
+		{
+			name:        "nothing changed",
+			mutate:      func(context.Context, client.Client) error { return nil },
+			wantSkipped: true,
+		},
+		{
+			name: "a worker pod is deleted",
+			mutate: func(ctx context.Context, c client.Client) error {
+				sts := &appsv1.StatefulSet{}
+				if err := c.Get(ctx, types.NamespacedName{Namespace: "default", Name: "test-steady-7"}, sts); err != nil {
+					return err
+				}
+				sts.Status.ReadyReplicas--
+				return c.Status().Update(ctx, sts)
+			},
+		},
+		{
+			name: "a worker statefulset is deleted",
+			mutate: func(ctx context.Context, c client.Client) error {
+				return c.Delete(ctx, statefulSet("test-steady-7"))
+			},
+		},
+		{
+			name: "the headless service is deleted",
+			mutate: func(ctx context.Context, c client.Client) error {
+				return c.Delete(ctx, &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "test-steady", Namespace: "default"}})
+			},
+		},
+		{
+			name: "the leaderworkerset is annotated",
+			mutate: func(ctx context.Context, c client.Client) error {
+				lws := &leaderworkerset.LeaderWorkerSet{}
+				if err := c.Get(ctx, key, lws); err != nil {
+					return err
+				}
+				lws.Annotations = map[string]string{leaderworkerset.RestartGroupAnnotationKeyPrefix + "0": "2024-01-01T00:00:00Z"}
+				return c.Update(ctx, lws)
+			},
+		},
+		{
+			name: "a duplicate leader pod shows up",
+			mutate: func(ctx context.Context, c client.Client) error {
+				return c.Create(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-steady-7-copy",
+					Namespace: "default",
+					Labels: map[string]string{
+						leaderworkerset.SetNameLabelKey:     "test-steady",
+						leaderworkerset.WorkerIndexLabelKey: "0",
+						leaderworkerset.GroupIndexLabelKey:  "7",
+					},
+				}})
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-steady", "default").Replica(groups).Size(2).Obj()
+			objects := []client.Object{lws, statefulSet("test-steady"), &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-steady",
+					Namespace: "default",
+					Labels:    map[string]string{leaderworkerset.SetNameLabelKey: "test-steady"},
+				},
+			}}
+			for i := range groups {
+				objects = append(objects, statefulSet(fmt.Sprintf("test-steady-%d", i)))
+			}
+			reads := map[string]int{}
+			k8sClient := newFakeClientBuilder().WithStatusSubresource(&appsv1.StatefulSet{}).
+				WithObjects(objects...).WithInterceptorFuncs(interceptor.Funcs{
+				Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					reads[fmt.Sprintf("get %T", obj)]++
+					return c.Get(ctx, key, obj, opts...)
+				},
+				List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+					reads[fmt.Sprintf("list %T", list)]++
+					return c.List(ctx, list, opts...)
+				},
+			}).Build()
+			r := NewLeaderWorkerSetReconciler(k8sClient, testScheme, record.NewFakeRecorder(100))
+
+			// The last reconcile found all the groups ready at the current revision.
+			current := &leaderworkerset.LeaderWorkerSet{}
+			if err := k8sClient.Get(context.TODO(), key, current); err != nil {
+				t.Fatal(err)
+			}
+			fingerprint, err := r.steadyFingerprint(context.TODO(), current, lwsPods(t, r, current))
+			if err != nil {
+				t.Fatal(err)
+			}
+			r.steadyFingerprints.Store(key, fingerprint)
+
+			if err := tc.mutate(context.TODO(), k8sClient); err != nil {
+				t.Fatal(err)
+			}
+			clear(reads)
+			result, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: key})
+			if !tc.wantSkipped {
+				// The full reconcile reads the leader statefulset, whatever its outcome.
+				if reads["get *v1.StatefulSet"] == 0 {
+					t.Errorf("expected the reconcile not to be skipped, reads: %v", reads)
+				}
+				// The pods are listed once, and passed to the helpers of the reconcile.
+				if reads["list *v1.PodList"] != 1 {
+					t.Errorf("expected the pods to be listed once, reads: %v", reads)
+				}
+				if _, found := r.steadyFingerprints.Load(key); found && err != nil {
+					t.Errorf("expected the fingerprint to be forgotten after a failed reconcile")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(ctrl.Result{}, result); diff != "" {
+				t.Errorf("unexpected result (-want +got): %s", diff)
+			}
+			// The skipped reconcile reads a constant number of lists, regardless of the number of groups and pods.
+			want := map[string]int{
+				"get *v1.LeaderWorkerSet":  1,
+				"list *v1.StatefulSetList": 1,
+				"list *v1.ServiceList":     1,
+				"list *v1.ConfigMapList":   1,
+				"list *v1.PodList":         1,
+			}
+			if diff := cmp.Diff(want, reads); diff != "" {
+				t.Errorf("unexpected reads (-want +got): %s", diff)
+			}
 		})
 	}
 }
@@ -18,15 +18,18 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	appsapplyv1 "k8s.io/client-go/applyconfigurations/apps/v1"
@@ -34,10 +37,17 @@ import (
 	metaapplyv1 "k8s.io/client-go/applyconfigurations/meta/v1"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	leaderworkerset "sigs.k8s.io/lws/api/leaderworkerset/v1"
 	acceleratorutils "sigs.k8s.io/lws/pkg/utils/accelerators"
@@ -52,10 +62,95 @@ type PodReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
 	Record record.EventRecorder
+	// DisableHeadlessService handles all the leaderWorkerSets as if their subdomainPolicy was None.
+	DisableHeadlessService bool
+	// MaxGroupRecreateBackoff is the ceiling of the exponential backoff between the recreations of
+	// a group, 0 disables the backoff.
+	MaxGroupRecreateBackoff time.Duration
+	Clock                   clock.Clock
+
+	recreateBackoff *groupRecreateBackoff
 }
 
+const (
+	// DefaultMaxGroupRecreateBackoff is the default of MaxGroupRecreateBackoff, same as the
+	// maximum back-off of crash-looping containers.
+	DefaultMaxGroupRecreateBackoff = 5 * time.Minute
+	// groupRecreateBaseBackoff is the backoff after the first recreation of a group, doubled
+	// on every following recreation.
+	groupRecreateBaseBackoff = 10 * time.Second
+)
+
 func NewPodReconciler(client client.Client, schema *runtime.Scheme, record record.EventRecorder) *PodReconciler {
-	return &PodReconciler{Client: client, Scheme: schema, Record: record}
+	return &PodReconciler{
+		Client:                  client,
+		Scheme:                  schema,
+		Record:                  record,
+		MaxGroupRecreateBackoff: DefaultMaxGroupRecreateBackoff,
+		Clock:                   clock.RealClock{},
+		recreateBackoff:         &groupRecreateBackoff{attempts: map[string]*groupRecreateAttempts{}},
+	}
+}
+
+// groupRecreateBackoff tracks the recreations of the groups in memory, to back off exponentially
+// when a group is recreated over and over, e.g. because of a crash-looping pod.
+type groupRecreateBackoff struct {
+	mu       sync.Mutex
+	attempts map[string]*groupRecreateAttempts
+}
+
+type groupRecreateAttempts struct {
+	count          int
+	lastRecreation time.Time
+}
+
+// remaining returns how long the recreation of the group has to wait for, or 0 if it can be
+// recreated. A group that wasn't recreated for twice the maxBackoff, i.e. stayed healthy for
+// longer than any backoff, starts over from the base backoff.
+func (b *groupRecreateBackoff) remaining(key string, now time.Time, maxBackoff time.Duration) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	attempts, ok := b.attempts[key]
+	if !ok || maxBackoff <= 0 {
+		return 0
+	}
+	since := now.Sub(attempts.lastRecreation)
+	if since >= 2*maxBackoff {
+		delete(b.attempts, key)
+		return 0
+	}
+	return max(recreateBackoffDuration(attempts.count, maxBackoff)-since, 0)
+}
+
+// recordRecreation records a recreation of the group, and forgets the groups that stayed healthy.
+func (b *groupRecreateBackoff) recordRecreation(key string, now time.Time, maxBackoff time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if maxBackoff <= 0 {
+		return
+	}
+	for k, attempts := range b.attempts {
+		if now.Sub(attempts.lastRecreation) >= 2*maxBackoff {
+			delete(b.attempts, k)
+		}
+	}
+	attempts, ok := b.attempts[key]
+	if !ok {
+		attempts = &groupRecreateAttempts{}
+		b.attempts[key] = attempts
+	}
+	attempts.count++
+	attempts.lastRecreation = now
+}
+
+// recreateBackoffDuration returns the backoff after count recreations, doubling the base backoff
+// on every recreation up to maxBackoff.
+func recreateBackoffDuration(count int, maxBackoff time.Duration) time.Duration {
+	backoff := groupRecreateBaseBackoff
+	for i := 1; i < count && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxBackoff)
 }
 
 //+kubebuilder:rbac:groups="",resources=events,verbs=create;watch;update;patch
@@ -76,26 +171,42 @@ func (r *PodReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 	if lwsName == "" {
 		return ctrl.Result{}, errors.New("leaderworkerset.sigs.k8s.io/name label is unexpected missing")
 	}
-	if _, exist := pod.Labels[leaderworkerset.WorkerIndexLabelKey]; !exist {
-		return ctrl.Result{}, errors.New("leaderworkerset.sigs.k8s.io/worker-index label is unexpected missing")
-	}
 	// get the leaderWorkerSet object
 	var leaderWorkerSet leaderworkerset.LeaderWorkerSet
 	if err := r.Get(ctx, types.NamespacedName{Name: lwsName, Namespace: pod.Namespace}, &leaderWorkerSet); err != nil {
 		// If lws not found, it's mostly because deleted, ignore the error as Pods will be GCed finally.
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
-	leaderDeleted, err := r.handleRestartPolicy(ctx, pod, leaderWorkerSet)
+	if paused(&leaderWorkerSet) || suspended(&leaderWorkerSet) {
+		log.V(2).Info("Skipping reconciliation of pod for paused or suspended leaderworkerset")
+		return ctrl.Result{}, nil
+	}
+	// Orphan pods, e.g. created manually to recover a group, are adopted before anything else.
+	// The pod is reconciled again once its controller reference is observed.
+	if adopted, err := r.adoptOrphanPod(ctx, &pod, &leaderWorkerSet); err != nil || adopted {
+		return ctrl.Result{}, err
+	}
+	// The worker index label tells leaders and workers apart, so it's repaired before anything else.
+	// The pod is reconciled again once the patched label is observed.
+	if repaired, err := r.repairWorkerIndexLabel(ctx, &pod, &leaderWorkerSet); err != nil || repaired {
+		return ctrl.Result{}, err
+	}
+	disableHeadlessService(&leaderWorkerSet, r.DisableHeadlessService)
+	leaderDeleted, backoff, err := r.handleRestartPolicy(ctx, pod, leaderWorkerSet)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
-	if leaderDeleted {
-		return ctrl.Result{}, nil
+	if leaderDeleted || backoff > 0 {
+		return ctrl.Result{RequeueAfter: backoff}, nil
 	}
 
-	// worker pods' reconciliation is only done to handle restart policy
+	// worker pods' reconciliation is only done to handle restart policy, the leader readiness gate
+	// and the nodes of the group
 	if !podutils.LeaderPod(pod) {
-		return ctrl.Result{}, nil
+		if err := r.syncWorkerLeaderReadyCondition(ctx, &pod); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, r.syncGroupNodesAnnotation(ctx, &pod)
 	}
 
 	// validate leader's annotations to prevent infinite StatefulSet creation loops
@@ -110,6 +221,13 @@ func (r *PodReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 		return ctrl.Result{}, nil
 	}
 
+	if err := r.syncGroupLeaderReadyConditions(ctx, &pod); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.syncGroupNodesAnnotation(ctx, &pod); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if leaderWorkerSet.Spec.NetworkConfig != nil && *leaderWorkerSet.Spec.NetworkConfig.SubdomainPolicy == leaderworkerset.SubdomainUniquePerReplica {
 		if err := controllerutils.CreateHeadlessServiceIfNotExists(ctx, r.Client, r.Scheme, &leaderWorkerSet, pod.Name, map[string]string{leaderworkerset.SetNameLabelKey: leaderWorkerSet.Name, leaderworkerset.GroupIndexLabelKey: pod.Labels[leaderworkerset.GroupIndexLabelKey]}, &pod); err != nil {
 			return ctrl.Result{}, err
@@ -123,17 +241,17 @@ func (r *PodReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 		log.V(2).Info("skip creating the worker sts since the leader pod is being deleted")
 		return ctrl.Result{}, nil
 	}
-
-	// Once size = 1, no need to create worker statefulSets.
-	if *leaderWorkerSet.Spec.LeaderWorkerTemplate.Size == 1 {
+	// The workers of a group deleted with orderedTermination are terminated before the leader pod.
+	if _, ok := pod.Annotations[leaderworkerset.WorkersTerminationStartAnnotationKey]; ok {
+		log.V(2).Info("skip creating the worker sts since the workers are terminating before the leader pod")
 		return ctrl.Result{}, nil
 	}
-
-	// logic for handling leader pod
-	if leaderWorkerSet.Spec.StartupPolicy == leaderworkerset.LeaderReadyStartupPolicy && !podutils.IsPodReady(&pod) {
-		log.V(2).Info("defer the creation of the worker statefulset because leader pod is not ready.")
+	// The groups past their activeDeadlineSeconds are kept down.
+	if podutils.HasSchedulingGate(pod, leaderworkerset.DeadlineExceededSchedulingGate) {
+		log.V(2).Info("skip creating the worker sts since the group exceeded its active deadline")
 		return ctrl.Result{}, nil
 	}
+
 	revision, err := revisionutils.GetRevision(ctx, r.Client, &leaderWorkerSet, revisionutils.GetRevisionKey(&pod))
 	if err != nil {
 		log.Error(err, "Getting lws revisions")
@@ -143,13 +261,33 @@ func (r *PodReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 		log.V(2).Info(fmt.Sprintf("Revision has not been created yet, requeing reconciler for pod %s", pod.Name))
 		return ctrl.Result{Requeue: true, RequeueAfter: time.Second}, nil
 	}
+	currentLws, err := revisionutils.ApplyRevision(&leaderWorkerSet, revision)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Once size = 1, no need to create worker statefulSets. The size is the one of the revision of
+	// the group, which isn't updated yet e.g. when held by a partition.
+	if *currentLws.Spec.LeaderWorkerTemplate.Size == 1 {
+		return ctrl.Result{}, nil
+	}
+
+	// logic for handling leader pod
+	if leaderWorkerSet.Spec.StartupPolicy == leaderworkerset.LeaderReadyStartupPolicy && !leaderReady(&pod, &leaderWorkerSet) {
+		log.V(2).Info("defer the creation of the worker statefulset because leader pod is not ready.")
+		return ctrl.Result{}, nil
+	}
+	if remaining := leaderStartupDelayRemaining(&pod, &leaderWorkerSet, r.Clock.Now()); remaining > 0 {
+		log.V(2).Info("defer the creation of the worker statefulset until the leader startup delay has elapsed", "remaining", remaining)
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
 	statefulSet, err := constructWorkerStatefulSetApplyConfiguration(pod, leaderWorkerSet, revision)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
 	// if exclusive placement is enabled but leader pod is not scheduled, don't create the worker sts
-	if topologyKey, found := leaderWorkerSet.Annotations[leaderworkerset.ExclusiveKeyAnnotationKey]; found {
+	if topologyKey := controllerutils.ExclusiveTopologyKey(&leaderWorkerSet); topologyKey != "" {
 		// check if the leader pod is scheduled.
 		if pod.Spec.NodeName == "" {
 			log.V(2).Info(fmt.Sprintf("Pod %q is not scheduled yet", pod.Name))
@@ -184,49 +322,329 @@ func (r *PodReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 			return ctrl.Result{}, client.IgnoreAlreadyExists(err)
 		}
 		r.Record.Eventf(&leaderWorkerSet, corev1.EventTypeNormal, GroupsProgressing, fmt.Sprintf("Created worker statefulset for leader pod %s", pod.Name))
+	} else if leaderWorkerSet.Spec.StartupPolicy == leaderworkerset.WorkersFirstStartupPolicy {
+		if err := r.releaseLeaderIfWorkersReady(ctx, &pod, &workerSts); err != nil {
+			return ctrl.Result{}, err
+		}
 	}
 	log.V(2).Info("Worker Reconcile completed.")
 	return ctrl.Result{}, nil
 }
 
-func (r *PodReconciler) handleRestartPolicy(ctx context.Context, pod corev1.Pod, leaderWorkerSet leaderworkerset.LeaderWorkerSet) (bool, error) {
-	if leaderWorkerSet.Spec.LeaderWorkerTemplate.RestartPolicy != leaderworkerset.RecreateGroupOnPodRestart {
+// repairWorkerIndexLabel patches the worker index label of the pod when it's missing or doesn't match
+// the index derived from the pod name, e.g. after it was edited by a user. Returns true if the label
+// was patched.
+func (r *PodReconciler) repairWorkerIndexLabel(ctx context.Context, pod *corev1.Pod, lws *leaderworkerset.LeaderWorkerSet) (bool, error) {
+	workerIndex, err := workerIndexFromName(pod, lws)
+	if err != nil {
+		return false, err
+	}
+	currentIndex, found := pod.Labels[leaderworkerset.WorkerIndexLabelKey]
+	if found && currentIndex == workerIndex {
+		return false, nil
+	}
+	patch := client.MergeFrom(pod.DeepCopy())
+	pod.Labels[leaderworkerset.WorkerIndexLabelKey] = workerIndex
+	if err := r.Patch(ctx, pod, patch); err != nil {
+		return false, err
+	}
+	r.Record.Eventf(lws, corev1.EventTypeWarning, WorkerIndexLabelRepaired,
+		fmt.Sprintf("Repaired the %s label of pod %s from %q to %q", leaderworkerset.WorkerIndexLabelKey, pod.Name, currentIndex, workerIndex))
+	return true, nil
+}
+
+// adoptOrphanPod sets the statefulset the pod is named after as the controller of the pod when it has
+// none, so that a pod created manually with the right labels, e.g. in disaster recovery, is kept rather
+// than recreated. The revision label of the statefulset controller is set as well, otherwise it would
+// recreate the pod as outdated. Returns true if the pod was adopted.
+func (r *PodReconciler) adoptOrphanPod(ctx context.Context, pod *corev1.Pod, lws *leaderworkerset.LeaderWorkerSet) (bool, error) {
+	if metav1.GetControllerOf(pod) != nil || pod.DeletionTimestamp != nil {
+		return false, nil
+	}
+	stsName, _ := statefulsetutils.GetParentNameAndOrdinal(pod.Name)
+	if stsName == "" {
 		return false, nil
 	}
+	var sts appsv1.StatefulSet
+	if err := r.Get(ctx, types.NamespacedName{Name: stsName, Namespace: pod.Namespace}, &sts); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	if adoptable, reason := orphanAdoptable(pod, &sts); !adoptable {
+		ctrl.LoggerFrom(ctx).V(2).Info("Not adopting orphan pod", "statefulset", klog.KObj(&sts), "reason", reason)
+		return false, nil
+	}
+	patch := client.MergeFrom(pod.DeepCopy())
+	if err := controllerutil.SetControllerReference(&sts, pod, r.Scheme); err != nil {
+		return false, err
+	}
+	if sts.Status.UpdateRevision != "" {
+		pod.Labels[appsv1.ControllerRevisionHashLabelKey] = sts.Status.UpdateRevision
+	}
+	if err := r.Patch(ctx, pod, patch); err != nil {
+		return false, err
+	}
+	r.Record.Eventf(lws, corev1.EventTypeNormal, PodAdopted, fmt.Sprintf("Adopted orphan pod %s into statefulset %s", pod.Name, sts.Name))
+	return true, nil
+}
+
+// orphanAdoptable returns whether the orphan pod can be adopted by the statefulset, i.e. the pod is
+// selected by the statefulset, its ordinal is within the replicas, and it's at the revision of the
+// template of the statefulset. Otherwise, it returns the reason why not.
+func orphanAdoptable(pod *corev1.Pod, sts *appsv1.StatefulSet) (bool, string) {
+	selector, err := metav1.LabelSelectorAsSelector(sts.Spec.Selector)
+	if err != nil || !selector.Matches(labels.Set(pod.Labels)) {
+		return false, "pod is not selected by the statefulset"
+	}
+	_, ordinal := statefulsetutils.GetParentNameAndOrdinal(pod.Name)
+	start := startOrdinal(sts)
+	if ordinal < int(start) || ordinal >= int(start+ptr.Deref(sts.Spec.Replicas, 1)) {
+		return false, "pod ordinal is out of the replicas of the statefulset"
+	}
+	revisionKey := revisionutils.GetRevisionKey(pod)
+	if revisionKey == "" || revisionKey != sts.Spec.Template.Labels[leaderworkerset.RevisionKey] {
+		return false, "pod revision doesn't match the revision of the statefulset"
+	}
+	return true, ""
+}
+
+// workerIndexFromName returns the worker index of the pod derived from its name. Leader pods are
+// named after the leader statefulset and always have index 0, while worker pods are named after
+// their leader pod, suffixed by their ordinal.
+func workerIndexFromName(pod *corev1.Pod, lws *leaderworkerset.LeaderWorkerSet) (string, error) {
+	parent, ordinal := statefulsetutils.GetParentNameAndOrdinal(pod.Name)
+	if ordinal == -1 {
+		return "", fmt.Errorf("parsing pod ordinal for pod %s", pod.Name)
+	}
+	if parent == controllerutils.LeaderStatefulSetName(lws) {
+		return "0", nil
+	}
+	return strconv.Itoa(ordinal), nil
+}
+
+// handleRestartPolicy deletes the leader pod to recreate the group according to the restart policy.
+// It returns whether the leader pod is deleted, or how long until the group can be recreated again
+// when its recreations are backed off.
+func (r *PodReconciler) handleRestartPolicy(ctx context.Context, pod corev1.Pod, leaderWorkerSet leaderworkerset.LeaderWorkerSet) (bool, time.Duration, error) {
+	switch leaderWorkerSet.Spec.LeaderWorkerTemplate.RestartPolicy {
+	case leaderworkerset.RecreateGroupOnPodRestart:
+	case leaderworkerset.RecreateGroupOnLeaderRestart:
+		// Worker pods are restarted on their own, and a deleted leader pod takes down its
+		// worker statefulset anyway, so only the container restarts of the leader matter.
+		if !podutils.LeaderPod(pod) || !podutils.ContainerRestarted(pod) {
+			return false, 0, nil
+		}
+	default:
+		return false, 0, nil
+	}
 	// the leader pod will be deleted if the worker pod is deleted or any containes were restarted
 	if !podutils.ContainerRestarted(pod) && !podutils.PodDeleted(pod) {
-		return false, nil
+		return false, 0, nil
+	}
+	// Groups failed by the podFailurePolicy are kept as is rather than recreated, the failure is
+	// reported by the GroupFailed condition of the lws.
+	podFailurePolicy := leaderWorkerSet.Spec.LeaderWorkerTemplate.PodFailurePolicy
+	if podutils.MatchesPodFailurePolicy(pod, podFailurePolicy) {
+		return false, 0, nil
 	}
 	var leader corev1.Pod
 	if !podutils.LeaderPod(pod) {
 		leaderPodName, ordinal := statefulsetutils.GetParentNameAndOrdinal(pod.Name)
 		if ordinal == -1 {
-			return false, fmt.Errorf("parsing pod name for pod %s", pod.Name)
+			return false, 0, fmt.Errorf("parsing pod name for pod %s", pod.Name)
 		}
 		if err := r.Get(ctx, types.NamespacedName{Name: leaderPodName, Namespace: pod.Namespace}, &leader); err != nil {
 			// If the error is not found, it is likely caused by the fact that the leader was deleted but the worker statefulset
 			// deletion hasn't deleted all the worker pods
-			return false, client.IgnoreNotFound(err)
+			return false, 0, client.IgnoreNotFound(err)
 		}
 		// Different revision key means that this pod will be deleted soon and alternative will be created with the matching key
 		if revisionutils.GetRevisionKey(&leader) != revisionutils.GetRevisionKey(&pod) {
-			return false, nil
+			return false, 0, nil
+		}
+		if podutils.MatchesPodFailurePolicy(leader, podFailurePolicy) {
+			return false, 0, nil
 		}
 	} else {
 		leader = pod
 	}
 	// if the leader pod is being deleted, we don't need to send deletion requests
 	if leader.DeletionTimestamp != nil {
-		return true, nil
+		return true, 0, nil
+	}
+	if leaderWorkerSet.Spec.LeaderWorkerTemplate.FailedGroupRetention == leaderworkerset.RetainFailedGroupRetention {
+		return false, 0, r.retainFailedGroup(ctx, &leader, pod, &leaderWorkerSet)
+	}
+	backoffKey := fmt.Sprintf("%s/%s", leaderWorkerSet.UID, leader.Labels[leaderworkerset.GroupIndexLabelKey])
+	if remaining := r.recreateBackoff.remaining(backoffKey, r.Clock.Now(), r.MaxGroupRecreateBackoff); remaining > 0 {
+		ctrl.LoggerFrom(ctx).V(2).Info("Backing off the recreation of the group", "leader", klog.KObj(&leader), "remaining", remaining)
+		return false, remaining, nil
 	}
 	deletionOpt := metav1.DeletePropagationForeground
 	if err := r.Delete(ctx, &leader, &client.DeleteOptions{
 		PropagationPolicy: &deletionOpt,
 	}); err != nil {
-		return false, err
+		return false, 0, err
 	}
-	r.Record.Eventf(&leaderWorkerSet, corev1.EventTypeNormal, "RecreateGroupOnPodRestart", fmt.Sprintf("Worker pod %s failed, deleted leader pod %s to recreate group %s", pod.Name, leader.Name, leader.Labels[leaderworkerset.GroupIndexLabelKey]))
-	return true, nil
+	r.recreateBackoff.recordRecreation(backoffKey, r.Clock.Now(), r.MaxGroupRecreateBackoff)
+	r.Record.Eventf(&leaderWorkerSet, corev1.EventTypeNormal, GroupRecreated, fmt.Sprintf("Worker pod %s failed, deleted leader pod %s to recreate group %s", pod.Name, leader.Name, leader.Labels[leaderworkerset.GroupIndexLabelKey]))
+	return true, 0, nil
+}
+
+// retainFailedGroup marks the group of the failed pod as failed with an annotation on its leader pod
+// instead of recreating it, so that its pods are kept for inspection until the group is restarted
+// with the restart-group annotation.
+func (r *PodReconciler) retainFailedGroup(ctx context.Context, leader *corev1.Pod, failedPod corev1.Pod, lws *leaderworkerset.LeaderWorkerSet) error {
+	if _, ok := leader.Annotations[leaderworkerset.GroupFailedAnnotationKey]; ok {
+		return nil
+	}
+	patch := client.MergeFrom(leader.DeepCopy())
+	if leader.Annotations == nil {
+		leader.Annotations = map[string]string{}
+	}
+	leader.Annotations[leaderworkerset.GroupFailedAnnotationKey] = failedPod.Name
+	if err := r.Patch(ctx, leader, patch); err != nil {
+		return err
+	}
+	r.Record.Eventf(lws, corev1.EventTypeWarning, GroupFailed, fmt.Sprintf("Pod %s failed, retained group %s for inspection", failedPod.Name, leader.Labels[leaderworkerset.GroupIndexLabelKey]))
+	return nil
+}
+
+// releaseLeaderIfWorkersReady removes the WorkersReady scheduling gate from the leader pod
+// once all the pods of the worker statefulset are ready.
+func (r *PodReconciler) releaseLeaderIfWorkersReady(ctx context.Context, leaderPod *corev1.Pod, workerSts *appsv1.StatefulSet) error {
+	log := ctrl.LoggerFrom(ctx)
+	gateIndex := -1
+	for i, gate := range leaderPod.Spec.SchedulingGates {
+		if gate.Name == leaderworkerset.WorkersReadySchedulingGate {
+			gateIndex = i
+			break
+		}
+	}
+	if gateIndex == -1 {
+		return nil
+	}
+	if workerSts.Spec.Replicas == nil || workerSts.Status.ReadyReplicas < *workerSts.Spec.Replicas {
+		log.V(2).Info("defer the scheduling of the leader pod because worker pods are not ready.")
+		return nil
+	}
+	patch := client.MergeFrom(leaderPod.DeepCopy())
+	leaderPod.Spec.SchedulingGates = append(leaderPod.Spec.SchedulingGates[:gateIndex], leaderPod.Spec.SchedulingGates[gateIndex+1:]...)
+	return r.Patch(ctx, leaderPod, patch)
+}
+
+// syncGroupLeaderReadyConditions sets the leader ready condition of the worker pods in the group
+// to match the readiness of the leader pod.
+func (r *PodReconciler) syncGroupLeaderReadyConditions(ctx context.Context, leaderPod *corev1.Pod) error {
+	var workerPods corev1.PodList
+	if err := r.List(ctx, &workerPods, client.InNamespace(leaderPod.Namespace), client.MatchingLabels{
+		leaderworkerset.SetNameLabelKey:    leaderPod.Labels[leaderworkerset.SetNameLabelKey],
+		leaderworkerset.GroupIndexLabelKey: leaderPod.Labels[leaderworkerset.GroupIndexLabelKey],
+	}); err != nil {
+		return err
+	}
+	leaderReady := podutils.IsPodReady(leaderPod)
+	for i := range workerPods.Items {
+		if podutils.LeaderPod(workerPods.Items[i]) {
+			continue
+		}
+		if err := r.setLeaderReadyCondition(ctx, &workerPods.Items[i], leaderReady); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncWorkerLeaderReadyCondition sets the leader ready condition of the worker pod to match the
+// readiness of its leader pod.
+func (r *PodReconciler) syncWorkerLeaderReadyCondition(ctx context.Context, workerPod *corev1.Pod) error {
+	if !podutils.HasReadinessGate(*workerPod, leaderworkerset.LeaderReadyPodConditionType) {
+		return nil
+	}
+	var leaderPod corev1.Pod
+	if err := r.Get(ctx, types.NamespacedName{Namespace: workerPod.Namespace, Name: workerPod.Annotations[leaderworkerset.LeaderPodNameAnnotationKey]}, &leaderPod); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		return r.setLeaderReadyCondition(ctx, workerPod, false)
+	}
+	return r.setLeaderReadyCondition(ctx, workerPod, podutils.IsPodReady(&leaderPod))
+}
+
+// setLeaderReadyCondition updates the leader ready condition of the worker pod if it has the
+// corresponding readiness gate.
+func (r *PodReconciler) setLeaderReadyCondition(ctx context.Context, workerPod *corev1.Pod, leaderReady bool) error {
+	if !podutils.HasReadinessGate(*workerPod, leaderworkerset.LeaderReadyPodConditionType) {
+		return nil
+	}
+	status := corev1.ConditionFalse
+	if leaderReady {
+		status = corev1.ConditionTrue
+	}
+	index, condition := podutils.GetPodCondition(&workerPod.Status, leaderworkerset.LeaderReadyPodConditionType)
+	if condition != nil && condition.Status == status {
+		return nil
+	}
+	newCondition := corev1.PodCondition{
+		Type:               leaderworkerset.LeaderReadyPodConditionType,
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+	}
+	if condition == nil {
+		workerPod.Status.Conditions = append(workerPod.Status.Conditions, newCondition)
+	} else {
+		workerPod.Status.Conditions[index] = newCondition
+	}
+	return r.Status().Update(ctx, workerPod)
+}
+
+// syncGroupNodesAnnotation sets the nodes the pods of the group of pod are scheduled on as the
+// group-nodes annotation of the leader pod. A recreated leader pod starts without the annotation.
+func (r *PodReconciler) syncGroupNodesAnnotation(ctx context.Context, pod *corev1.Pod) error {
+	leaderPod := pod
+	if !podutils.LeaderPod(*pod) {
+		leaderPod = &corev1.Pod{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: pod.Annotations[leaderworkerset.LeaderPodNameAnnotationKey]}, leaderPod); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+	}
+	if leaderPod.DeletionTimestamp != nil {
+		return nil
+	}
+	var groupPods corev1.PodList
+	if err := r.List(ctx, &groupPods, client.InNamespace(leaderPod.Namespace), client.MatchingLabels{
+		leaderworkerset.SetNameLabelKey:    leaderPod.Labels[leaderworkerset.SetNameLabelKey],
+		leaderworkerset.GroupIndexLabelKey: leaderPod.Labels[leaderworkerset.GroupIndexLabelKey],
+	}); err != nil {
+		return err
+	}
+	nodes := map[string]string{}
+	for _, groupPod := range groupPods.Items {
+		if groupPod.Spec.NodeName != "" {
+			nodes[groupPod.Name] = groupPod.Spec.NodeName
+		}
+	}
+	value := ""
+	if len(nodes) > 0 {
+		// The keys of a map are marshaled sorted, so the value is stable.
+		encoded, err := json.Marshal(nodes)
+		if err != nil {
+			return err
+		}
+		value = string(encoded)
+	}
+	if leaderPod.Annotations[leaderworkerset.GroupNodesAnnotationKey] == value {
+		return nil
+	}
+	patch := client.MergeFrom(leaderPod.DeepCopy())
+	if value == "" {
+		delete(leaderPod.Annotations, leaderworkerset.GroupNodesAnnotationKey)
+	} else {
+		if leaderPod.Annotations == nil {
+			leaderPod.Annotations = map[string]string{}
+		}
+		leaderPod.Annotations[leaderworkerset.GroupNodesAnnotationKey] = value
+	}
+	return r.Patch(ctx, leaderPod, patch)
 }
 
 func (r *PodReconciler) setNodeSelectorForWorkerPods(ctx context.Context, pod *corev1.Pod, sts *appsapplyv1.StatefulSetApplyConfiguration, topologyKey string) error {
@@ -320,19 +738,64 @@ func constructWorkerStatefulSetApplyConfiguration(leaderPod corev1.Pod, lws lead
 		leaderworkerset.RevisionKey:             revisionutils.GetRevisionKey(&leaderPod),
 	}
 
+	addInheritedLabels(&podTemplateApplyConfiguration, currentLws)
 	podTemplateApplyConfiguration.WithLabels(labelMap)
 	podAnnotations := make(map[string]string)
-	podAnnotations[leaderworkerset.SizeAnnotationKey] = strconv.Itoa(int(*lws.Spec.LeaderWorkerTemplate.Size))
+	podAnnotations[leaderworkerset.SizeAnnotationKey] = strconv.Itoa(int(*currentLws.Spec.LeaderWorkerTemplate.Size))
 	podAnnotations[leaderworkerset.LeaderPodNameAnnotationKey] = leaderPod.Name
-	if lws.Annotations[leaderworkerset.ExclusiveKeyAnnotationKey] != "" {
-		podAnnotations[leaderworkerset.ExclusiveKeyAnnotationKey] = lws.Annotations[leaderworkerset.ExclusiveKeyAnnotationKey]
+	if currentLws.Spec.LeaderWorkerTemplate.WorkerReadinessFollowsLeader {
+		podAnnotations[leaderworkerset.WorkerReadinessFollowsLeaderAnnotationKey] = "true"
+	}
+	if currentLws.Spec.LeaderWorkerTemplate.InjectPeerAddresses {
+		podAnnotations[leaderworkerset.InjectPeerAddressesAnnotationKey] = "true"
+	}
+	if len(currentLws.Spec.LeaderWorkerTemplate.NetworkEnvNames) > 0 {
+		networkEnvNames, err := json.Marshal(currentLws.Spec.LeaderWorkerTemplate.NetworkEnvNames)
+		if err != nil {
+			return nil, err
+		}
+		podAnnotations[leaderworkerset.NetworkEnvNamesAnnotationKey] = string(networkEnvNames)
+	}
+	if currentLws.Spec.LeaderWorkerTemplate.WorkerServiceAccountName != "" {
+		podAnnotations[leaderworkerset.ServiceAccountNameAnnotationKey] = currentLws.Spec.LeaderWorkerTemplate.WorkerServiceAccountName
+	}
+	if currentLws.Spec.LeaderWorkerTemplate.GangSchedulingLabelKey != "" {
+		podAnnotations[leaderworkerset.GangSchedulingLabelKeyAnnotationKey] = currentLws.Spec.LeaderWorkerTemplate.GangSchedulingLabelKey
+	}
+	if len(currentLws.Spec.LeaderWorkerTemplate.CommonContainers) > 0 {
+		commonContainers, err := json.Marshal(currentLws.Spec.LeaderWorkerTemplate.CommonContainers)
+		if err != nil {
+			return nil, err
+		}
+		podAnnotations[leaderworkerset.CommonContainersAnnotationKey] = string(commonContainers)
+	}
+	// The workers only carry the variables of their group, so that the overrides of the other
+	// groups don't change their template. perGroupEnv isn't part of the revisions, it's read from
+	// the lws when the worker statefulset is created.
+	groupIndex := leaderPod.Labels[leaderworkerset.GroupIndexLabelKey]
+	if env, found := controllerutils.PerGroupEnv(&lws)[groupIndex]; found {
+		perGroupEnv, err := json.Marshal(map[string][]corev1.EnvVar{groupIndex: env})
+		if err != nil {
+			return nil, err
+		}
+		podAnnotations[leaderworkerset.PerGroupEnvAnnotationKey] = string(perGroupEnv)
+	}
+	if currentLws.Spec.LeaderWorkerTemplate.PublishMembershipConfigMap {
+		podAnnotations[leaderworkerset.MembershipConfigMapAnnotationKey] = "true"
+		addMembershipVolume(&podTemplateApplyConfiguration, controllerutils.MembershipConfigMapName(lws.Name, leaderPod.Labels[leaderworkerset.GroupIndexLabelKey]))
+	}
+	if topologyKey := controllerutils.ExclusiveTopologyKey(&lws); topologyKey != "" {
+		podAnnotations[leaderworkerset.ExclusiveKeyAnnotationKey] = topologyKey
 	}
 	if lws.Spec.LeaderWorkerTemplate.SubGroupPolicy != nil {
 		podAnnotations[leaderworkerset.SubGroupSizeAnnotationKey] = strconv.Itoa(int(*lws.Spec.LeaderWorkerTemplate.SubGroupPolicy.SubGroupSize))
-		if lws.Annotations[leaderworkerset.SubGroupExclusiveKeyAnnotationKey] != "" {
-			podAnnotations[leaderworkerset.SubGroupExclusiveKeyAnnotationKey] = lws.Annotations[leaderworkerset.SubGroupExclusiveKeyAnnotationKey]
+		if subGroupTopologyKey := controllerutils.SubGroupExclusiveTopologyKey(&lws); subGroupTopologyKey != "" {
+			podAnnotations[leaderworkerset.SubGroupExclusiveKeyAnnotationKey] = subGroupTopologyKey
 		}
 	}
+	if lws.Spec.NetworkConfig != nil && *lws.Spec.NetworkConfig.SubdomainPolicy == leaderworkerset.SubdomainNone {
+		podAnnotations[leaderworkerset.SubdomainPolicyAnnotationKey] = string(leaderworkerset.SubdomainNone)
+	}
 	acceleratorutils.AddTPUAnnotations(leaderPod, podAnnotations)
 	podTemplateApplyConfiguration.WithAnnotations(podAnnotations)
 	serviceName := leaderPod.Name
@@ -343,16 +806,44 @@ func constructWorkerStatefulSetApplyConfiguration(leaderPod corev1.Pod, lws lead
 	statefulSetConfig := appsapplyv1.StatefulSet(leaderPod.Name, leaderPod.Namespace).
 		WithSpec(appsapplyv1.StatefulSetSpec().
 			WithServiceName(serviceName).
-			WithReplicas(*lws.Spec.LeaderWorkerTemplate.Size - 1).
+			WithReplicas(*currentLws.Spec.LeaderWorkerTemplate.Size - 1).
 			WithPodManagementPolicy(appsv1.ParallelPodManagement).
 			WithTemplate(&podTemplateApplyConfiguration).
 			WithOrdinals(appsapplyv1.StatefulSetOrdinals().WithStart(1)).
 			WithSelector(metaapplyv1.LabelSelector().
 				WithMatchLabels(selectorMap))).
 		WithLabels(labelMap)
+	if err := addVolumeClaimTemplates(statefulSetConfig, currentLws); err != nil {
+		return nil, err
+	}
 	return statefulSetConfig, nil
 }
 
+// leaderStartupDelayRemaining returns how long the creation of the worker statefulset is still delayed
+// by leaderStartupDelaySeconds, which is measured from the creation of the leader pod.
+func leaderStartupDelayRemaining(pod *corev1.Pod, lws *leaderworkerset.LeaderWorkerSet, now time.Time) time.Duration {
+	if lws.Spec.LeaderStartupDelaySeconds == nil {
+		return 0
+	}
+	delay := time.Duration(*lws.Spec.LeaderStartupDelaySeconds) * time.Second
+	return max(pod.CreationTimestamp.Add(delay).Sub(now), 0)
+}
+
+// leaderReady returns whether the leader pod has signaled that the worker statefulset can be created
+// with the LeaderReady startup policy, which is the Ready condition unless leaderReadyConfiguration is set.
+func leaderReady(pod *corev1.Pod, lws *leaderworkerset.LeaderWorkerSet) bool {
+	config := lws.Spec.LeaderReadyConfiguration
+	switch {
+	case config != nil && config.ConditionType != nil:
+		_, condition := podutils.GetPodCondition(&pod.Status, *config.ConditionType)
+		return condition != nil && condition.Status == corev1.ConditionTrue
+	case config != nil && config.Annotation != nil:
+		return pod.Annotations[*config.Annotation] == "true"
+	default:
+		return podutils.IsPodReady(pod)
+	}
+}
+
 func (r *PodReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&corev1.Pod{}).
@@ -365,6 +856,40 @@ func (r *PodReconciler) SetupWithManager(mgr ctrl.Manager) error {
 				_, exist := statefulSet.Labels[leaderworkerset.SetNameLabelKey]
 				return exist
 			}
+			if _, ok := object.(*leaderworkerset.LeaderWorkerSet); ok {
+				return true
+			}
 			return false
-		})).Owns(&appsv1.StatefulSet{}).Complete(r)
+		})).Owns(&appsv1.StatefulSet{}).
+		Watches(&leaderworkerset.LeaderWorkerSet{},
+			handler.EnqueueRequestsFromMapFunc(r.leaderPodsForLeaderWorkerSet),
+			builder.WithPredicates(predicate.Funcs{
+				CreateFunc:  func(event.CreateEvent) bool { return false },
+				DeleteFunc:  func(event.DeleteEvent) bool { return false },
+				GenericFunc: func(event.GenericEvent) bool { return false },
+				// Pods are not reconciled while the lws is paused or suspended, so reconcile all of them once it's resumed.
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					oldLws, okOld := e.ObjectOld.(*leaderworkerset.LeaderWorkerSet)
+					newLws, okNew := e.ObjectNew.(*leaderworkerset.LeaderWorkerSet)
+					return okOld && okNew && (paused(oldLws) || suspended(oldLws)) && !paused(newLws) && !suspended(newLws)
+				},
+			})).
+		Complete(r)
+}
+
+// leaderPodsForLeaderWorkerSet returns the reconcile requests of all the leader pods of the lws.
+func (r *PodReconciler) leaderPodsForLeaderWorkerSet(ctx context.Context, obj client.Object) []reconcile.Request {
+	var leaderPods corev1.PodList
+	if err := r.List(ctx, &leaderPods, client.InNamespace(obj.GetNamespace()), client.MatchingLabels{
+		leaderworkerset.SetNameLabelKey:     obj.GetName(),
+		leaderworkerset.WorkerIndexLabelKey: "0",
+	}); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Listing leader pods", "leaderworkerset", klog.KObj(obj))
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(leaderPods.Items))
+	for _, pod := range leaderPods.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}})
+	}
+	return requests
 }
@@ -19,17 +19,23 @@ package controllers
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	appsapplyv1 "k8s.io/client-go/applyconfigurations/apps/v1"
 	coreapplyv1 "k8s.io/client-go/applyconfigurations/core/v1"
 	metaapplyv1 "k8s.io/client-go/applyconfigurations/meta/v1"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	leaderworkerset "sigs.k8s.io/lws/api/leaderworkerset/v1"
+	podutils "sigs.k8s.io/lws/pkg/utils/pod"
 	revisionutils "sigs.k8s.io/lws/pkg/utils/revision"
 	"sigs.k8s.io/lws/test/wrappers"
 )
@@ -43,6 +49,10 @@ func TestConstructWorkerStatefulSetApplyConfiguration(t *testing.T) {
 		t.Fatal(err)
 	}
 	updateRevisionKey := revisionutils.GetRevisionKey(updateRevision)
+	size2Revision, err := revisionutils.NewRevision(context.TODO(), client, wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(1).WorkerTemplateSpec(wrappers.MakeWorkerPodSpec()).Size(2).Obj(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	tests := []struct {
 		name                  string
@@ -127,7 +137,7 @@ func TestConstructWorkerStatefulSetApplyConfiguration(t *testing.T) {
 		},
 		{
 			name:     "1 replica, size 2, exclusive placement enabled",
-			revision: updateRevision,
+			revision: size2Revision,
 			pod: &corev1.Pod{
 				ObjectMeta: v1.ObjectMeta{
 					Name:      "test-sample",
@@ -204,7 +214,7 @@ func TestConstructWorkerStatefulSetApplyConfiguration(t *testing.T) {
 		},
 		{
 			name:     "1 replica, size 2, subgroupsize 2, exclusive placement enabled",
-			revision: updateRevision,
+			revision: size2Revision,
 			pod: &corev1.Pod{
 				ObjectMeta: v1.ObjectMeta{
 					Name:      "test-sample",
@@ -294,3 +304,1356 @@ func TestConstructWorkerStatefulSetApplyConfiguration(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleRestartPolicy(t *testing.T) {
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").
+		Replica(1).
+		Size(2).
+		WorkerTemplateSpec(wrappers.MakeWorkerPodSpec()).
+		RestartPolicy(leaderworkerset.RecreateGroupOnPodRestart).Obj()
+
+	tests := []struct {
+		name                 string
+		restartPolicy        leaderworkerset.RestartPolicyType
+		failedGroupRetention leaderworkerset.FailedGroupRetentionType
+		// retained marks the group as failed before the restart is handled.
+		retained      bool
+		restartLeader bool
+		restartCount  int32
+		exitCode      int32
+		wantDeleted   bool
+		wantRetained  string
+		wantEvents    []string
+	}{
+		{
+			name:          "worker restarted with RecreateGroupOnPodRestart",
+			restartPolicy: leaderworkerset.RecreateGroupOnPodRestart,
+			restartCount:  1,
+			wantDeleted:   true,
+			wantEvents:    []string{"Normal GroupRecreated Worker pod test-sample-0-1 failed, deleted leader pod test-sample-0 to recreate group 0"},
+		},
+		{
+			name:          "worker not restarted with RecreateGroupOnPodRestart",
+			restartPolicy: leaderworkerset.RecreateGroupOnPodRestart,
+		},
+		{
+			name:          "worker restarted with None restart policy",
+			restartPolicy: leaderworkerset.NoneRestartPolicy,
+			restartCount:  1,
+		},
+		{
+			name:          "leader restarted with RecreateGroupOnPodRestart",
+			restartPolicy: leaderworkerset.RecreateGroupOnPodRestart,
+			restartLeader: true,
+			restartCount:  1,
+			wantDeleted:   true,
+			wantEvents:    []string{"Normal GroupRecreated Worker pod test-sample-0 failed, deleted leader pod test-sample-0 to recreate group 0"},
+		},
+		{
+			name:          "worker restarted with RecreateGroupOnLeaderRestart",
+			restartPolicy: leaderworkerset.RecreateGroupOnLeaderRestart,
+			restartCount:  1,
+		},
+		{
+			name:          "leader restarted with RecreateGroupOnLeaderRestart",
+			restartPolicy: leaderworkerset.RecreateGroupOnLeaderRestart,
+			restartLeader: true,
+			restartCount:  1,
+			wantDeleted:   true,
+			wantEvents:    []string{"Normal GroupRecreated Worker pod test-sample-0 failed, deleted leader pod test-sample-0 to recreate group 0"},
+		},
+		{
+			name:          "leader not restarted with RecreateGroupOnLeaderRestart",
+			restartPolicy: leaderworkerset.RecreateGroupOnLeaderRestart,
+			restartLeader: true,
+		},
+		{
+			name:          "worker restarted with an exit code matching the pod failure policy",
+			restartPolicy: leaderworkerset.RecreateGroupOnPodRestart,
+			restartCount:  1,
+			exitCode:      42,
+		},
+		{
+			name:          "worker restarted with an exit code not matching the pod failure policy",
+			restartPolicy: leaderworkerset.RecreateGroupOnPodRestart,
+			restartCount:  1,
+			exitCode:      1,
+			wantDeleted:   true,
+			wantEvents:    []string{"Normal GroupRecreated Worker pod test-sample-0-1 failed, deleted leader pod test-sample-0 to recreate group 0"},
+		},
+		{
+			name:          "leader restarted with None restart policy",
+			restartPolicy: leaderworkerset.NoneRestartPolicy,
+			restartLeader: true,
+			restartCount:  1,
+		},
+		{
+			name:                 "worker restarted with Recreate failed group retention",
+			restartPolicy:        leaderworkerset.RecreateGroupOnPodRestart,
+			failedGroupRetention: leaderworkerset.RecreateFailedGroupRetention,
+			restartCount:         1,
+			wantDeleted:          true,
+			wantEvents:           []string{"Normal GroupRecreated Worker pod test-sample-0-1 failed, deleted leader pod test-sample-0 to recreate group 0"},
+		},
+		{
+			name:                 "worker restarted with Retain failed group retention",
+			restartPolicy:        leaderworkerset.RecreateGroupOnPodRestart,
+			failedGroupRetention: leaderworkerset.RetainFailedGroupRetention,
+			restartCount:         1,
+			wantRetained:         "test-sample-0-1",
+			wantEvents:           []string{"Warning GroupFailed Pod test-sample-0-1 failed, retained group 0 for inspection"},
+		},
+		{
+			name:                 "leader restarted with Retain failed group retention",
+			restartPolicy:        leaderworkerset.RecreateGroupOnLeaderRestart,
+			failedGroupRetention: leaderworkerset.RetainFailedGroupRetention,
+			restartLeader:        true,
+			restartCount:         1,
+			wantRetained:         "test-sample-0",
+			wantEvents:           []string{"Warning GroupFailed Pod test-sample-0 failed, retained group 0 for inspection"},
+		},
+		{
+			name:                 "worker restarted in a group already retained",
+			restartPolicy:        leaderworkerset.RecreateGroupOnPodRestart,
+			failedGroupRetention: leaderworkerset.RetainFailedGroupRetention,
+			retained:             true,
+			restartCount:         1,
+			wantRetained:         "test-sample-0-2",
+		},
+		{
+			name:                 "worker not restarted with Retain failed group retention",
+			restartPolicy:        leaderworkerset.RecreateGroupOnPodRestart,
+			failedGroupRetention: leaderworkerset.RetainFailedGroupRetention,
+		},
+		{
+			name:                 "worker restarted in a group retained before Recreate was set",
+			restartPolicy:        leaderworkerset.RecreateGroupOnPodRestart,
+			failedGroupRetention: leaderworkerset.RecreateFailedGroupRetention,
+			retained:             true,
+			restartCount:         1,
+			wantDeleted:          true,
+			wantEvents:           []string{"Normal GroupRecreated Worker pod test-sample-0-1 failed, deleted leader pod test-sample-0 to recreate group 0"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			leader := wrappers.MakePodWithLabels("test-sample", "0", "0", "default", 2)
+			worker := wrappers.MakePodWithLabels("test-sample", "0", "1", "default", 2)
+			restartedPod := worker
+			if tc.restartLeader {
+				restartedPod = leader
+			}
+			restartedPod.Status.Phase = corev1.PodRunning
+			restartedPod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "worker", RestartCount: tc.restartCount}}
+			if tc.exitCode != 0 {
+				restartedPod.Status.ContainerStatuses[0].LastTerminationState.Terminated = &corev1.ContainerStateTerminated{ExitCode: tc.exitCode}
+			}
+			if tc.retained {
+				leader.Annotations = map[string]string{leaderworkerset.GroupFailedAnnotationKey: "test-sample-0-2"}
+			}
+
+			client := fake.NewClientBuilder().WithObjects(leader).Build()
+			recorder := record.NewFakeRecorder(10)
+			r := NewPodReconciler(client, nil, recorder)
+			currentLws := lws.DeepCopy()
+			currentLws.Spec.LeaderWorkerTemplate.RestartPolicy = tc.restartPolicy
+			currentLws.Spec.LeaderWorkerTemplate.FailedGroupRetention = tc.failedGroupRetention
+			currentLws.Spec.LeaderWorkerTemplate.PodFailurePolicy = &leaderworkerset.PodFailurePolicy{
+				Rules: []leaderworkerset.PodFailurePolicyRule{{OnExitCodes: leaderworkerset.PodFailurePolicyOnExitCodesRequirement{Operator: leaderworkerset.PodFailurePolicyOnExitCodesOpIn, Values: []int32{42}}}},
+			}
+
+			deleted, _, err := r.handleRestartPolicy(context.TODO(), *restartedPod, *currentLws)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if deleted != tc.wantDeleted {
+				t.Errorf("unexpected leader deletion, want: %t, got: %t", tc.wantDeleted, deleted)
+			}
+			if !tc.wantDeleted {
+				var gotLeader corev1.Pod
+				if err := client.Get(context.TODO(), types.NamespacedName{Name: leader.Name, Namespace: leader.Namespace}, &gotLeader); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if got := gotLeader.Annotations[leaderworkerset.GroupFailedAnnotationKey]; got != tc.wantRetained {
+					t.Errorf("unexpected %s annotation, want: %q, got: %q", leaderworkerset.GroupFailedAnnotationKey, tc.wantRetained, got)
+				}
+			}
+			close(recorder.Events)
+			var gotEvents []string
+			for event := range recorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			if diff := cmp.Diff(tc.wantEvents, gotEvents); diff != "" {
+				t.Errorf("unexpected events: (-want, +got) %s", diff)
+			}
+		})
+	}
+}
+
+func TestHandleRestartPolicyBackoff(t *testing.T) {
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").
+		Replica(1).
+		Size(2).
+		WorkerTemplateSpec(wrappers.MakeWorkerPodSpec()).
+		RestartPolicy(leaderworkerset.RecreateGroupOnPodRestart).Obj()
+	lws.UID = "lws-uid"
+
+	fakeClock := clocktesting.NewFakeClock(time.Now().Truncate(time.Second))
+	r := NewPodReconciler(nil, nil, record.NewFakeRecorder(100))
+	r.Clock = fakeClock
+	r.MaxGroupRecreateBackoff = time.Minute
+
+	// Each step advances the clock, then restarts a container of the leader pod.
+	steps := []struct {
+		name        string
+		advance     time.Duration
+		wantDeleted bool
+		wantBackoff time.Duration
+	}{
+		{name: "first recreation", wantDeleted: true},
+		{name: "backed off after one recreation", advance: time.Second, wantBackoff: 9 * time.Second},
+		{name: "base backoff elapsed", advance: 9 * time.Second, wantDeleted: true},
+		{name: "backoff doubled", advance: 5 * time.Second, wantBackoff: 15 * time.Second},
+		{name: "doubled backoff elapsed", advance: 15 * time.Second, wantDeleted: true},
+		{name: "backoff doubled again", wantBackoff: 40 * time.Second},
+		{name: "quadrupled backoff elapsed", advance: 40 * time.Second, wantDeleted: true},
+		{name: "backoff capped", wantBackoff: time.Minute},
+		{name: "capped backoff elapsed", advance: time.Minute, wantDeleted: true},
+		{name: "capped backoff elapsed again", advance: time.Minute, wantDeleted: true},
+		{name: "backoff reset after staying healthy", advance: 2 * time.Minute, wantDeleted: true},
+		{name: "base backoff after reset", wantBackoff: 10 * time.Second},
+	}
+	for _, step := range steps {
+		fakeClock.Step(step.advance)
+		leader := wrappers.MakePodWithLabels("test-sample", "0", "0", "default", 2)
+		leader.Status.Phase = corev1.PodRunning
+		leader.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "worker", RestartCount: 1}}
+		r.Client = fake.NewClientBuilder().WithObjects(leader).Build()
+
+		deleted, backoff, err := r.handleRestartPolicy(context.TODO(), *leader, *lws)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", step.name, err)
+		}
+		if deleted != step.wantDeleted {
+			t.Errorf("%s: unexpected leader deletion, want: %t, got: %t", step.name, step.wantDeleted, deleted)
+		}
+		if backoff != step.wantBackoff {
+			t.Errorf("%s: unexpected backoff, want: %s, got: %s", step.name, step.wantBackoff, backoff)
+		}
+	}
+}
+
+func TestRecreateBackoffDuration(t *testing.T) {
+	tests := []struct {
+		count      int
+		maxBackoff time.Duration
+		want       time.Duration
+	}{
+		{count: 1, maxBackoff: 5 * time.Minute, want: 10 * time.Second},
+		{count: 2, maxBackoff: 5 * time.Minute, want: 20 * time.Second},
+		{count: 5, maxBackoff: 5 * time.Minute, want: 160 * time.Second},
+		{count: 6, maxBackoff: 5 * time.Minute, want: 5 * time.Minute},
+		{count: 100, maxBackoff: 5 * time.Minute, want: 5 * time.Minute},
+		{count: 1, maxBackoff: 5 * time.Second, want: 5 * time.Second},
+	}
+	for _, tc := range tests {
+		if got := recreateBackoffDuration(tc.count, tc.maxBackoff); got != tc.want {
+			t.Errorf("unexpected backoff after %d recreations with max %s, want: %s, got: %s", tc.count, tc.maxBackoff, tc.want, got)
+		}
+	}
+}
+
+func TestPodReconcilePaused(t *testing.T) {
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").
+		Replica(1).
+		Size(2).
+		WorkerTemplateSpec(wrappers.MakeWorkerPodSpec()).
+		RestartPolicy(leaderworkerset.RecreateGroupOnPodRestart).
+		Annotation(map[string]string{leaderworkerset.PausedAnnotationKey: "true"}).Obj()
+	leader := wrappers.MakePodWithLabels("test-sample", "0", "0", "default", 2)
+	worker := wrappers.MakePodWithLabels("test-sample", "0", "1", "default", 2)
+	worker.Status.Phase = corev1.PodRunning
+	worker.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "worker", RestartCount: 1}}
+
+	client := newFakeClientBuilder().WithObjects(lws, leader, worker).Build()
+	r := NewPodReconciler(client, testScheme, record.NewFakeRecorder(10))
+
+	for _, pod := range []*corev1.Pod{worker, leader} {
+		if _, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}}); err != nil {
+			t.Fatalf("unexpected error reconciling pod %s: %v", pod.Name, err)
+		}
+	}
+
+	// The group is neither recreated nor is the worker statefulset created.
+	var pods corev1.PodList
+	if err := client.List(context.TODO(), &pods); err != nil {
+		t.Fatal(err)
+	}
+	if len(pods.Items) != 2 {
+		t.Errorf("unexpected number of pods, want: 2, got: %d", len(pods.Items))
+	}
+	var statefulSets appsv1.StatefulSetList
+	if err := client.List(context.TODO(), &statefulSets); err != nil {
+		t.Fatal(err)
+	}
+	if len(statefulSets.Items) != 0 {
+		t.Errorf("unexpected worker statefulsets created while paused: %d", len(statefulSets.Items))
+	}
+}
+
+func TestPodReconcileSuspended(t *testing.T) {
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").
+		Replica(1).
+		Size(2).
+		WorkerTemplateSpec(wrappers.MakeWorkerPodSpec()).
+		RestartPolicy(leaderworkerset.RecreateGroupOnPodRestart).
+		Suspend(true).Obj()
+	leader := wrappers.MakePodWithLabels("test-sample", "0", "0", "default", 2)
+	worker := wrappers.MakePodWithLabels("test-sample", "0", "1", "default", 2)
+	worker.Status.Phase = corev1.PodRunning
+	worker.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "worker", RestartCount: 1}}
+
+	client := newFakeClientBuilder().WithObjects(lws, leader, worker).Build()
+	r := NewPodReconciler(client, testScheme, record.NewFakeRecorder(10))
+
+	for _, pod := range []*corev1.Pod{worker, leader} {
+		if _, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}}); err != nil {
+			t.Fatalf("unexpected error reconciling pod %s: %v", pod.Name, err)
+		}
+	}
+
+	// No pod is deleted to recreate the group, nor is the worker statefulset created.
+	var pods corev1.PodList
+	if err := client.List(context.TODO(), &pods); err != nil {
+		t.Fatal(err)
+	}
+	if len(pods.Items) != 2 {
+		t.Errorf("unexpected number of pods, want: 2, got: %d", len(pods.Items))
+	}
+	var statefulSets appsv1.StatefulSetList
+	if err := client.List(context.TODO(), &statefulSets); err != nil {
+		t.Fatal(err)
+	}
+	if len(statefulSets.Items) != 0 {
+		t.Errorf("unexpected worker statefulsets created while suspended: %d", len(statefulSets.Items))
+	}
+}
+
+func TestPodReconcileLeaderReadyConfiguration(t *testing.T) {
+	startedCondition := corev1.PodConditionType("example.com/started")
+
+	tests := []struct {
+		name                string
+		config              *leaderworkerset.LeaderReadyConfiguration
+		conditions          []corev1.PodCondition
+		annotations         map[string]string
+		wantWorkerSetCreate bool
+	}{
+		{
+			name:       "leader not ready",
+			conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionFalse}},
+		},
+		{
+			name:                "leader ready",
+			conditions:          []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			wantWorkerSetCreate: true,
+		},
+		{
+			name:       "ready leader without the custom condition",
+			config:     &leaderworkerset.LeaderReadyConfiguration{ConditionType: &startedCondition},
+			conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+		{
+			name:       "custom condition false",
+			config:     &leaderworkerset.LeaderReadyConfiguration{ConditionType: &startedCondition},
+			conditions: []corev1.PodCondition{{Type: startedCondition, Status: corev1.ConditionFalse}},
+		},
+		{
+			name:                "custom condition true on a not ready leader",
+			config:              &leaderworkerset.LeaderReadyConfiguration{ConditionType: &startedCondition},
+			conditions:          []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionFalse}, {Type: startedCondition, Status: corev1.ConditionTrue}},
+			wantWorkerSetCreate: true,
+		},
+		{
+			name:        "annotation not true",
+			config:      &leaderworkerset.LeaderReadyConfiguration{Annotation: ptr.To("example.com/started")},
+			annotations: map[string]string{"example.com/started": "false"},
+		},
+		{
+			name:                "annotation true",
+			config:              &leaderworkerset.LeaderReadyConfiguration{Annotation: ptr.To("example.com/started")},
+			annotations:         map[string]string{"example.com/started": "true"},
+			wantWorkerSetCreate: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").
+				Replica(1).
+				Size(2).
+				WorkerTemplateSpec(wrappers.MakeWorkerPodSpec()).
+				StartupPolicy(leaderworkerset.LeaderReadyStartupPolicy).
+				LeaderReadyConfiguration(tc.config).Obj()
+			client := newFakeClientBuilder().WithObjects(lws).Build()
+			revision, err := revisionutils.NewRevision(context.TODO(), client, lws, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := revisionutils.CreateRevision(context.TODO(), client, revision, lws); err != nil {
+				t.Fatal(err)
+			}
+			leader := wrappers.MakePodWithLabels("test-sample", "0", "0", "default", 2)
+			leader.Labels[leaderworkerset.RevisionKey] = revisionutils.GetRevisionKey(revision)
+			for k, v := range tc.annotations {
+				leader.Annotations[k] = v
+			}
+			leader.Status.Conditions = tc.conditions
+			if err := client.Create(context.TODO(), leader); err != nil {
+				t.Fatal(err)
+			}
+
+			r := NewPodReconciler(client, testScheme, record.NewFakeRecorder(10))
+			if _, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: leader.Namespace, Name: leader.Name}}); err != nil {
+				t.Fatalf("unexpected error reconciling the leader pod: %v", err)
+			}
+
+			var statefulSets appsv1.StatefulSetList
+			if err := client.List(context.TODO(), &statefulSets); err != nil {
+				t.Fatal(err)
+			}
+			if gotWorkerSetCreate := len(statefulSets.Items) == 1; gotWorkerSetCreate != tc.wantWorkerSetCreate {
+				t.Errorf("unexpected worker statefulset creation, want: %t, got: %t", tc.wantWorkerSetCreate, gotWorkerSetCreate)
+			}
+		})
+	}
+}
+
+func TestPodReconcileLeaderStartupDelay(t *testing.T) {
+	tests := []struct {
+		name          string
+		startupPolicy leaderworkerset.StartupPolicyType
+		leaderReady   bool
+		delay         *int32
+		// steps are the durations the clock is stepped by before each reconciliation.
+		steps            []time.Duration
+		wantRequeueAfter []time.Duration
+		wantWorkerSet    bool
+	}{
+		{
+			name:             "no delay",
+			startupPolicy:    leaderworkerset.LeaderCreatedStartupPolicy,
+			steps:            []time.Duration{0},
+			wantRequeueAfter: []time.Duration{0},
+			wantWorkerSet:    true,
+		},
+		{
+			name:             "delay not elapsed",
+			startupPolicy:    leaderworkerset.LeaderCreatedStartupPolicy,
+			delay:            ptr.To[int32](30),
+			steps:            []time.Duration{0, 10 * time.Second},
+			wantRequeueAfter: []time.Duration{30 * time.Second, 20 * time.Second},
+		},
+		{
+			name:             "delay elapsed",
+			startupPolicy:    leaderworkerset.LeaderCreatedStartupPolicy,
+			delay:            ptr.To[int32](30),
+			steps:            []time.Duration{0, 30 * time.Second},
+			wantRequeueAfter: []time.Duration{30 * time.Second, 0},
+			wantWorkerSet:    true,
+		},
+		{
+			name:             "delay elapsed but leader not ready",
+			startupPolicy:    leaderworkerset.LeaderReadyStartupPolicy,
+			delay:            ptr.To[int32](30),
+			steps:            []time.Duration{30 * time.Second},
+			wantRequeueAfter: []time.Duration{0},
+		},
+		{
+			name:             "leader ready but delay not elapsed",
+			startupPolicy:    leaderworkerset.LeaderReadyStartupPolicy,
+			leaderReady:      true,
+			delay:            ptr.To[int32](30),
+			steps:            []time.Duration{20 * time.Second, 10 * time.Second},
+			wantRequeueAfter: []time.Duration{10 * time.Second, 0},
+			wantWorkerSet:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").
+				Replica(1).
+				Size(2).
+				WorkerTemplateSpec(wrappers.MakeWorkerPodSpec()).
+				StartupPolicy(tc.startupPolicy).Obj()
+			lws.Spec.LeaderStartupDelaySeconds = tc.delay
+			client := newFakeClientBuilder().WithObjects(lws).Build()
+			revision, err := revisionutils.NewRevision(context.TODO(), client, lws, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := revisionutils.CreateRevision(context.TODO(), client, revision, lws); err != nil {
+				t.Fatal(err)
+			}
+			fakeClock := clocktesting.NewFakeClock(time.Now().Truncate(time.Second))
+			leader := wrappers.MakePodWithLabels("test-sample", "0", "0", "default", 2)
+			leader.Labels[leaderworkerset.RevisionKey] = revisionutils.GetRevisionKey(revision)
+			leader.CreationTimestamp = v1.NewTime(fakeClock.Now())
+			if tc.leaderReady {
+				leader.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+			}
+			if err := client.Create(context.TODO(), leader); err != nil {
+				t.Fatal(err)
+			}
+
+			r := NewPodReconciler(client, testScheme, record.NewFakeRecorder(10))
+			r.Clock = fakeClock
+			for i, step := range tc.steps {
+				fakeClock.Step(step)
+				result, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: leader.Namespace, Name: leader.Name}})
+				if err != nil {
+					t.Fatalf("unexpected error reconciling the leader pod: %v", err)
+				}
+				if result.RequeueAfter != tc.wantRequeueAfter[i] {
+					t.Errorf("unexpected requeue after reconciliation %d, want: %v, got: %v", i, tc.wantRequeueAfter[i], result.RequeueAfter)
+				}
+			}
+
+			var statefulSets appsv1.StatefulSetList
+			if err := client.List(context.TODO(), &statefulSets); err != nil {
+				t.Fatal(err)
+			}
+			if gotWorkerSet := len(statefulSets.Items) == 1; gotWorkerSet != tc.wantWorkerSet {
+				t.Errorf("unexpected worker statefulset creation, want: %t, got: %t", tc.wantWorkerSet, gotWorkerSet)
+			}
+		})
+	}
+}
+
+func TestPodReconcileWorkersTerminating(t *testing.T) {
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		wantWorkerSet bool
+	}{
+		{
+			name:          "workers not terminating",
+			wantWorkerSet: true,
+		},
+		{
+			name:        "workers terminating before the leader",
+			annotations: map[string]string{leaderworkerset.WorkersTerminationStartAnnotationKey: time.Now().Format(time.RFC3339)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").
+				Replica(1).
+				Size(2).
+				WorkerTemplateSpec(wrappers.MakeWorkerPodSpec()).
+				OrderedTermination(true).Obj()
+			client := newFakeClientBuilder().WithObjects(lws).Build()
+			revision, err := revisionutils.NewRevision(context.TODO(), client, lws, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := revisionutils.CreateRevision(context.TODO(), client, revision, lws); err != nil {
+				t.Fatal(err)
+			}
+			leader := wrappers.MakePodWithLabels("test-sample", "0", "0", "default", 2)
+			leader.Labels[leaderworkerset.RevisionKey] = revisionutils.GetRevisionKey(revision)
+			leader.Annotations = tc.annotations
+			if err := client.Create(context.TODO(), leader); err != nil {
+				t.Fatal(err)
+			}
+
+			r := NewPodReconciler(client, testScheme, record.NewFakeRecorder(10))
+			if _, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: leader.Namespace, Name: leader.Name}}); err != nil {
+				t.Fatalf("unexpected error reconciling the leader pod: %v", err)
+			}
+
+			var statefulSets appsv1.StatefulSetList
+			if err := client.List(context.TODO(), &statefulSets); err != nil {
+				t.Fatal(err)
+			}
+			if gotWorkerSet := len(statefulSets.Items) == 1; gotWorkerSet != tc.wantWorkerSet {
+				t.Errorf("unexpected worker statefulset creation, want: %t, got: %t", tc.wantWorkerSet, gotWorkerSet)
+			}
+		})
+	}
+}
+
+func TestPodReconcileRevisionSize(t *testing.T) {
+	tests := []struct {
+		name          string
+		revisionSize  int
+		size          int
+		wantWorkerSet bool
+	}{
+		{
+			name:          "group of size 2 held on its revision after the size is changed to 1",
+			revisionSize:  2,
+			size:          1,
+			wantWorkerSet: true,
+		},
+		{
+			name:         "group of size 1 held on its revision after the size is changed to 2",
+			revisionSize: 1,
+			size:         2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").
+				Replica(1).
+				Size(tc.revisionSize).
+				WorkerTemplateSpec(wrappers.MakeWorkerPodSpec()).Obj()
+			client := newFakeClientBuilder().WithObjects(lws).Build()
+			revision, err := revisionutils.NewRevision(context.TODO(), client, lws, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := revisionutils.CreateRevision(context.TODO(), client, revision, lws); err != nil {
+				t.Fatal(err)
+			}
+			leader := wrappers.MakePodWithLabels("test-sample", "0", "0", "default", tc.revisionSize)
+			leader.Labels[leaderworkerset.RevisionKey] = revisionutils.GetRevisionKey(revision)
+			if err := client.Create(context.TODO(), leader); err != nil {
+				t.Fatal(err)
+			}
+			lws.Spec.LeaderWorkerTemplate.Size = ptr.To(int32(tc.size))
+			if err := client.Update(context.TODO(), lws); err != nil {
+				t.Fatal(err)
+			}
+
+			r := NewPodReconciler(client, testScheme, record.NewFakeRecorder(10))
+			if _, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: leader.Namespace, Name: leader.Name}}); err != nil {
+				t.Fatalf("unexpected error reconciling the leader pod: %v", err)
+			}
+
+			var statefulSets appsv1.StatefulSetList
+			if err := client.List(context.TODO(), &statefulSets); err != nil {
+				t.Fatal(err)
+			}
+			if gotWorkerSet := len(statefulSets.Items) == 1; gotWorkerSet != tc.wantWorkerSet {
+				t.Errorf("unexpected worker statefulset creation, want: %t, got: %t", tc.wantWorkerSet, gotWorkerSet)
+			}
+		})
+	}
+}
+
+func TestPodReconcileDeadlineExceeded(t *testing.T) {
+	tests := []struct {
+		name          string
+		gates         []corev1.PodSchedulingGate
+		wantWorkerSet bool
+	}{
+		{
+			name:          "group within its deadline",
+			wantWorkerSet: true,
+		},
+		{
+			name:  "group kept down past its deadline",
+			gates: []corev1.PodSchedulingGate{{Name: leaderworkerset.DeadlineExceededSchedulingGate}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").
+				Replica(1).
+				Size(2).
+				WorkerTemplateSpec(wrappers.MakeWorkerPodSpec()).
+				ActiveDeadlineSeconds(60).Obj()
+			client := newFakeClientBuilder().WithObjects(lws).Build()
+			revision, err := revisionutils.NewRevision(context.TODO(), client, lws, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := revisionutils.CreateRevision(context.TODO(), client, revision, lws); err != nil {
+				t.Fatal(err)
+			}
+			leader := wrappers.MakePodWithLabels("test-sample", "0", "0", "default", 2)
+			leader.Labels[leaderworkerset.RevisionKey] = revisionutils.GetRevisionKey(revision)
+			leader.Spec.SchedulingGates = tc.gates
+			if err := client.Create(context.TODO(), leader); err != nil {
+				t.Fatal(err)
+			}
+
+			r := NewPodReconciler(client, testScheme, record.NewFakeRecorder(10))
+			if _, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: leader.Namespace, Name: leader.Name}}); err != nil {
+				t.Fatalf("unexpected error reconciling the leader pod: %v", err)
+			}
+
+			var statefulSets appsv1.StatefulSetList
+			if err := client.List(context.TODO(), &statefulSets); err != nil {
+				t.Fatal(err)
+			}
+			if gotWorkerSet := len(statefulSets.Items) == 1; gotWorkerSet != tc.wantWorkerSet {
+				t.Errorf("unexpected worker statefulset creation, want: %t, got: %t", tc.wantWorkerSet, gotWorkerSet)
+			}
+		})
+	}
+}
+
+func TestPodReconcileGroupNodes(t *testing.T) {
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(1).Size(3).Obj()
+	pod := func(workerIndex, nodeName string) *corev1.Pod {
+		pod := wrappers.MakePodWithLabels("test-sample", "0", workerIndex, "default", 3)
+		if workerIndex != "0" {
+			pod.Annotations[leaderworkerset.LeaderPodNameAnnotationKey] = "test-sample-0"
+		}
+		pod.Spec.NodeName = nodeName
+		return pod
+	}
+	client := newFakeClientBuilder().
+		WithObjects(lws, pod("0", "node-a"), pod("1", "node-b"), pod("2", "")).Build()
+	r := NewPodReconciler(client, testScheme, record.NewFakeRecorder(10))
+	reconcile := func(name string) {
+		t.Helper()
+		if _, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: name}}); err != nil {
+			t.Fatalf("unexpected error reconciling pod %s: %v", name, err)
+		}
+	}
+	expect := func(want string) {
+		t.Helper()
+		var leader corev1.Pod
+		if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample-0"}, &leader); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(want, leader.Annotations[leaderworkerset.GroupNodesAnnotationKey]); diff != "" {
+			t.Errorf("unexpected group nodes (-want +got): %s", diff)
+		}
+	}
+
+	// The unscheduled pods are left out.
+	reconcile("test-sample-0-1")
+	expect(`{"test-sample-0":"node-a","test-sample-0-1":"node-b"}`)
+
+	// The annotation is updated once the remaining worker gets scheduled.
+	worker := pod("2", "")
+	if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample-0-2"}, worker); err != nil {
+		t.Fatal(err)
+	}
+	worker.Spec.NodeName = "node-c"
+	if err := client.Update(context.TODO(), worker); err != nil {
+		t.Fatal(err)
+	}
+	reconcile("test-sample-0-2")
+	expect(`{"test-sample-0":"node-a","test-sample-0-1":"node-b","test-sample-0-2":"node-c"}`)
+
+	// The group is recreated, the new pods start without the annotation until they're scheduled.
+	for _, name := range []string{"test-sample-0", "test-sample-0-1", "test-sample-0-2"} {
+		if err := client.Delete(context.TODO(), &corev1.Pod{ObjectMeta: v1.ObjectMeta{Namespace: "default", Name: name}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, recreated := range []*corev1.Pod{pod("0", ""), pod("1", ""), pod("2", "")} {
+		if err := client.Create(context.TODO(), recreated); err != nil {
+			t.Fatal(err)
+		}
+	}
+	reconcile("test-sample-0")
+	expect("")
+
+	leader := pod("0", "")
+	if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "test-sample-0"}, leader); err != nil {
+		t.Fatal(err)
+	}
+	leader.Spec.NodeName = "node-d"
+	if err := client.Update(context.TODO(), leader); err != nil {
+		t.Fatal(err)
+	}
+	reconcile("test-sample-0")
+	expect(`{"test-sample-0":"node-d"}`)
+}
+
+func TestRepairWorkerIndexLabel(t *testing.T) {
+	tests := []struct {
+		name            string
+		podName         string
+		workerIndex     *string
+		hostnamePrefix  string
+		wantWorkerIndex string
+		wantRepaired    bool
+	}{
+		{
+			name:            "leader with the correct label",
+			podName:         "test-sample-1",
+			workerIndex:     ptr.To("0"),
+			wantWorkerIndex: "0",
+		},
+		{
+			name:            "leader missing the label",
+			podName:         "test-sample-1",
+			wantWorkerIndex: "0",
+			wantRepaired:    true,
+		},
+		{
+			name:            "leader with a worker index",
+			podName:         "test-sample-1",
+			workerIndex:     ptr.To("2"),
+			wantWorkerIndex: "0",
+			wantRepaired:    true,
+		},
+		{
+			name:            "leader named after the hostname prefix",
+			podName:         "prefix-1",
+			hostnamePrefix:  "prefix",
+			workerIndex:     ptr.To("3"),
+			wantWorkerIndex: "0",
+			wantRepaired:    true,
+		},
+		{
+			name:            "worker with the correct label",
+			podName:         "test-sample-1-2",
+			workerIndex:     ptr.To("2"),
+			wantWorkerIndex: "2",
+		},
+		{
+			name:            "worker missing the label",
+			podName:         "test-sample-1-2",
+			wantWorkerIndex: "2",
+			wantRepaired:    true,
+		},
+		{
+			name:            "worker with an out of range index",
+			podName:         "test-sample-1-2",
+			workerIndex:     ptr.To("9"),
+			wantWorkerIndex: "2",
+			wantRepaired:    true,
+		},
+		{
+			name:            "worker labeled as the leader",
+			podName:         "test-sample-1-3",
+			workerIndex:     ptr.To("0"),
+			wantWorkerIndex: "3",
+			wantRepaired:    true,
+		},
+		{
+			name:            "worker with a non numeric index",
+			podName:         "test-sample-1-3",
+			workerIndex:     ptr.To("three"),
+			wantWorkerIndex: "3",
+			wantRepaired:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Size(4).Obj()
+			if tc.hostnamePrefix != "" {
+				lws.Spec.NetworkConfig = &leaderworkerset.NetworkConfig{HostnamePrefix: tc.hostnamePrefix}
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      tc.podName,
+					Namespace: "default",
+					Labels:    map[string]string{leaderworkerset.SetNameLabelKey: "test-sample"},
+				},
+			}
+			if tc.workerIndex != nil {
+				pod.Labels[leaderworkerset.WorkerIndexLabelKey] = *tc.workerIndex
+			}
+			client := fake.NewClientBuilder().WithObjects(pod).Build()
+			recorder := record.NewFakeRecorder(10)
+			r := NewPodReconciler(client, nil, recorder)
+
+			repaired, err := r.repairWorkerIndexLabel(context.TODO(), pod, lws)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if repaired != tc.wantRepaired {
+				t.Errorf("unexpected repaired, want: %t, got: %t", tc.wantRepaired, repaired)
+			}
+			var gotPod corev1.Pod
+			if err := client.Get(context.TODO(), types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}, &gotPod); err != nil {
+				t.Fatal(err)
+			}
+			if got := gotPod.Labels[leaderworkerset.WorkerIndexLabelKey]; got != tc.wantWorkerIndex {
+				t.Errorf("unexpected worker index label, want: %s, got: %s", tc.wantWorkerIndex, got)
+			}
+			wantEvents := 0
+			if tc.wantRepaired {
+				wantEvents = 1
+			}
+			if gotEvents := len(recorder.Events); gotEvents != wantEvents {
+				t.Errorf("unexpected number of events, want: %d, got: %d", wantEvents, gotEvents)
+			}
+		})
+	}
+}
+
+func TestAdoptOrphanPod(t *testing.T) {
+	tests := []struct {
+		name            string
+		podName         string
+		podRevision     string
+		podLabels       map[string]string
+		ownedBy         *v1.OwnerReference
+		wantAdopted     bool
+		wantOwnerUID    types.UID
+		wantPodRevision string
+	}{
+		{
+			name:            "adoptable orphan",
+			podName:         "test-sample-1",
+			podRevision:     "revision",
+			wantAdopted:     true,
+			wantOwnerUID:    "sts-uid",
+			wantPodRevision: "sts-revision",
+		},
+		{
+			name:        "orphan at another revision",
+			podName:     "test-sample-1",
+			podRevision: "old-revision",
+		},
+		{
+			name:    "orphan without a revision",
+			podName: "test-sample-1",
+		},
+		{
+			name:        "orphan not selected by the statefulset",
+			podName:     "test-sample-1",
+			podRevision: "revision",
+			podLabels:   map[string]string{leaderworkerset.SetNameLabelKey: "another-sample"},
+		},
+		{
+			name:        "orphan out of the replicas",
+			podName:     "test-sample-3",
+			podRevision: "revision",
+		},
+		{
+			name:        "orphan without a statefulset",
+			podName:     "another-sample-1",
+			podRevision: "revision",
+		},
+		{
+			name:         "pod with a controller",
+			podName:      "test-sample-1",
+			podRevision:  "revision",
+			ownedBy:      &v1.OwnerReference{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "test-sample", UID: "other-uid", Controller: ptr.To(true)},
+			wantOwnerUID: "other-uid",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Obj()
+			sts := &appsv1.StatefulSet{
+				ObjectMeta: v1.ObjectMeta{Name: "test-sample", Namespace: "default", UID: "sts-uid"},
+				Spec: appsv1.StatefulSetSpec{
+					Replicas: ptr.To[int32](2),
+					Selector: &v1.LabelSelector{MatchLabels: map[string]string{
+						leaderworkerset.SetNameLabelKey:     "test-sample",
+						leaderworkerset.WorkerIndexLabelKey: "0",
+					}},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: v1.ObjectMeta{Labels: map[string]string{leaderworkerset.RevisionKey: "revision"}},
+					},
+				},
+				Status: appsv1.StatefulSetStatus{UpdateRevision: "sts-revision"},
+			}
+			podLabels := tc.podLabels
+			if podLabels == nil {
+				podLabels = map[string]string{leaderworkerset.SetNameLabelKey: "test-sample"}
+			}
+			podLabels[leaderworkerset.WorkerIndexLabelKey] = "0"
+			if tc.podRevision != "" {
+				podLabels[leaderworkerset.RevisionKey] = tc.podRevision
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      tc.podName,
+					Namespace: "default",
+					Labels:    podLabels,
+				},
+			}
+			if tc.ownedBy != nil {
+				pod.OwnerReferences = []v1.OwnerReference{*tc.ownedBy}
+			}
+			client := newFakeClientBuilder().WithObjects(sts, pod).Build()
+			recorder := record.NewFakeRecorder(10)
+			r := NewPodReconciler(client, testScheme, recorder)
+
+			adopted, err := r.adoptOrphanPod(context.TODO(), pod, lws)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if adopted != tc.wantAdopted {
+				t.Errorf("unexpected adopted, want: %t, got: %t", tc.wantAdopted, adopted)
+			}
+			var gotPod corev1.Pod
+			if err := client.Get(context.TODO(), types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}, &gotPod); err != nil {
+				t.Fatal(err)
+			}
+			var gotOwnerUID types.UID
+			if owner := v1.GetControllerOf(&gotPod); owner != nil {
+				gotOwnerUID = owner.UID
+			}
+			if gotOwnerUID != tc.wantOwnerUID {
+				t.Errorf("unexpected controller uid, want: %q, got: %q", tc.wantOwnerUID, gotOwnerUID)
+			}
+			if got := gotPod.Labels[appsv1.ControllerRevisionHashLabelKey]; got != tc.wantPodRevision {
+				t.Errorf("unexpected controller revision hash label, want: %q, got: %q", tc.wantPodRevision, got)
+			}
+			wantEvents := 0
+			if tc.wantAdopted {
+				wantEvents = 1
+			}
+			if gotEvents := len(recorder.Events); gotEvents != wantEvents {
+				t.Errorf("unexpected number of events, want: %d, got: %d", wantEvents, gotEvents)
+			}
+		})
+	}
+}
+
+func TestSyncGroupLeaderReadyConditions(t *testing.T) {
+	readyLeader := func() *corev1.Pod {
+		leader := wrappers.MakePodWithLabels("test-sample", "0", "0", "default", 3)
+		leader.Status.Phase = corev1.PodRunning
+		leader.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		return leader
+	}
+	gatedWorker := func(workerIndex string, conditions ...corev1.PodCondition) *corev1.Pod {
+		worker := wrappers.MakePodWithLabels("test-sample", "0", workerIndex, "default", 3)
+		worker.Spec.ReadinessGates = []corev1.PodReadinessGate{{ConditionType: leaderworkerset.LeaderReadyPodConditionType}}
+		worker.Status.Conditions = conditions
+		return worker
+	}
+
+	tests := []struct {
+		name            string
+		leader          *corev1.Pod
+		workers         []*corev1.Pod
+		wantConditions  map[string]corev1.ConditionStatus
+		wantNoCondition []string
+	}{
+		{
+			name:    "leader ready flips the condition to true",
+			leader:  readyLeader(),
+			workers: []*corev1.Pod{gatedWorker("1"), gatedWorker("2", corev1.PodCondition{Type: leaderworkerset.LeaderReadyPodConditionType, Status: corev1.ConditionFalse})},
+			wantConditions: map[string]corev1.ConditionStatus{
+				"test-sample-0-1": corev1.ConditionTrue,
+				"test-sample-0-2": corev1.ConditionTrue,
+			},
+		},
+		{
+			name:    "leader not ready flips the condition to false",
+			leader:  wrappers.MakePodWithLabels("test-sample", "0", "0", "default", 3),
+			workers: []*corev1.Pod{gatedWorker("1"), gatedWorker("2", corev1.PodCondition{Type: leaderworkerset.LeaderReadyPodConditionType, Status: corev1.ConditionTrue})},
+			wantConditions: map[string]corev1.ConditionStatus{
+				"test-sample-0-1": corev1.ConditionFalse,
+				"test-sample-0-2": corev1.ConditionFalse,
+			},
+		},
+		{
+			name:            "worker without the readiness gate is not changed",
+			leader:          readyLeader(),
+			workers:         []*corev1.Pod{wrappers.MakePodWithLabels("test-sample", "0", "1", "default", 3)},
+			wantNoCondition: []string{"test-sample-0-1"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithStatusSubresource(&corev1.Pod{}).WithObjects(tc.leader)
+			for _, worker := range tc.workers {
+				builder = builder.WithObjects(worker)
+			}
+			client := builder.Build()
+			r := NewPodReconciler(client, nil, record.NewFakeRecorder(10))
+
+			if err := r.syncGroupLeaderReadyConditions(context.TODO(), tc.leader); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for name, wantStatus := range tc.wantConditions {
+				var pod corev1.Pod
+				if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: name}, &pod); err != nil {
+					t.Fatal(err)
+				}
+				_, condition := podutils.GetPodCondition(&pod.Status, leaderworkerset.LeaderReadyPodConditionType)
+				if condition == nil || condition.Status != wantStatus {
+					t.Errorf("unexpected leader ready condition for pod %s, want: %s, got: %v", name, wantStatus, condition)
+				}
+			}
+			for _, name := range tc.wantNoCondition {
+				var pod corev1.Pod
+				if err := client.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: name}, &pod); err != nil {
+					t.Fatal(err)
+				}
+				if _, condition := podutils.GetPodCondition(&pod.Status, leaderworkerset.LeaderReadyPodConditionType); condition != nil {
+					t.Errorf("unexpected leader ready condition for pod %s: %v", name, condition)
+				}
+			}
+		})
+	}
+}
+
+func TestConstructWorkerStatefulSetWorkerReadinessFollowsLeader(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		client := fake.NewClientBuilder().Build()
+		lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(1).WorkerTemplateSpec(wrappers.MakeWorkerPodSpec()).Size(2).Obj()
+		lws.Spec.LeaderWorkerTemplate.WorkerReadinessFollowsLeader = enabled
+		revision, err := revisionutils.NewRevision(context.TODO(), client, lws, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		leader := wrappers.MakePodWithLabels("test-sample", "0", "0", "default", 2)
+		leader.Labels[leaderworkerset.RevisionKey] = revisionutils.GetRevisionKey(revision)
+
+		sts, err := constructWorkerStatefulSetApplyConfiguration(*leader, *lws, revision)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, found := sts.Spec.Template.Annotations[leaderworkerset.WorkerReadinessFollowsLeaderAnnotationKey]
+		if found != enabled {
+			t.Errorf("unexpected %s annotation with workerReadinessFollowsLeader %t, found: %t", leaderworkerset.WorkerReadinessFollowsLeaderAnnotationKey, enabled, found)
+		}
+	}
+}
+
+func TestConstructWorkerStatefulSetRevisionSize(t *testing.T) {
+	client := fake.NewClientBuilder().Build()
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(1).WorkerTemplateSpec(wrappers.MakeWorkerPodSpec()).Size(2).Obj()
+	revision, err := revisionutils.NewRevision(context.TODO(), client, lws, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	leader := wrappers.MakePodWithLabels("test-sample", "0", "0", "default", 2)
+	leader.Labels[leaderworkerset.RevisionKey] = revisionutils.GetRevisionKey(revision)
+	// The size is changed once the group runs, e.g. held on the old revision by a partition.
+	lws.Spec.LeaderWorkerTemplate.Size = ptr.To[int32](3)
+
+	sts, err := constructWorkerStatefulSetApplyConfiguration(*leader, *lws, revision)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := *sts.Spec.Replicas; got != 1 {
+		t.Errorf("unexpected worker replicas, want: 1, got: %d", got)
+	}
+	if got := sts.Spec.Template.Annotations[leaderworkerset.SizeAnnotationKey]; got != "2" {
+		t.Errorf("unexpected %s annotation, want: %q, got: %q", leaderworkerset.SizeAnnotationKey, "2", got)
+	}
+}
+
+func TestConstructWorkerStatefulSetInjectPeerAddresses(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		client := fake.NewClientBuilder().Build()
+		lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(1).WorkerTemplateSpec(wrappers.MakeWorkerPodSpec()).Size(2).Obj()
+		lws.Spec.LeaderWorkerTemplate.InjectPeerAddresses = enabled
+		revision, err := revisionutils.NewRevision(context.TODO(), client, lws, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		leader := wrappers.MakePodWithLabels("test-sample", "0", "0", "default", 2)
+		leader.Labels[leaderworkerset.RevisionKey] = revisionutils.GetRevisionKey(revision)
+		// Toggled after the group was created, the group keeps the value of its revision.
+		lws.Spec.LeaderWorkerTemplate.InjectPeerAddresses = !enabled
+
+		sts, err := constructWorkerStatefulSetApplyConfiguration(*leader, *lws, revision)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, found := sts.Spec.Template.Annotations[leaderworkerset.InjectPeerAddressesAnnotationKey]
+		if found != enabled {
+			t.Errorf("unexpected %s annotation with injectPeerAddresses %t in the revision, found: %t", leaderworkerset.InjectPeerAddressesAnnotationKey, enabled, found)
+		}
+	}
+}
+
+func TestConstructWorkerStatefulSetNetworkEnvNames(t *testing.T) {
+	client := fake.NewClientBuilder().Build()
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(1).WorkerTemplateSpec(wrappers.MakeWorkerPodSpec()).Size(2).Obj()
+	lws.Spec.LeaderWorkerTemplate.NetworkEnvNames = map[string]string{leaderworkerset.LwsGroupSize: "WORLD_SIZE"}
+	revision, err := revisionutils.NewRevision(context.TODO(), client, lws, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	leader := wrappers.MakePodWithLabels("test-sample", "0", "0", "default", 2)
+	leader.Labels[leaderworkerset.RevisionKey] = revisionutils.GetRevisionKey(revision)
+	// Changed after the group was created, the group keeps the names of its revision.
+	lws.Spec.LeaderWorkerTemplate.NetworkEnvNames = map[string]string{leaderworkerset.LwsGroupSize: "NUM_PROCESSES"}
+
+	sts, err := constructWorkerStatefulSetApplyConfiguration(*leader, *lws, revision)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"LWS_GROUP_SIZE":"WORLD_SIZE"}`
+	if got := sts.Spec.Template.Annotations[leaderworkerset.NetworkEnvNamesAnnotationKey]; got != want {
+		t.Errorf("unexpected %s annotation, want: %q, got: %q", leaderworkerset.NetworkEnvNamesAnnotationKey, want, got)
+	}
+}
+
+func TestConstructWorkerStatefulSetPerGroupEnv(t *testing.T) {
+	tests := []struct {
+		name           string
+		groupIndex     string
+		wantAnnotation string
+	}{
+		{
+			name:           "group with overrides",
+			groupIndex:     "1",
+			wantAnnotation: `{"1":[{"name":"MODEL_SHARD","value":"b"}]}`,
+		},
+		{
+			name:       "group without overrides",
+			groupIndex: "2",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fake.NewClientBuilder().Build()
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(3).WorkerTemplateSpec(wrappers.MakeWorkerPodSpec()).Size(2).Obj()
+			revision, err := revisionutils.NewRevision(context.TODO(), client, lws, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			// perGroupEnv isn't part of the revision, the variables set since apply to the new pods.
+			lws.Spec.LeaderWorkerTemplate.PerGroupEnv = []leaderworkerset.PerGroupEnv{
+				{GroupIndex: 0, Env: []corev1.EnvVar{{Name: "MODEL_SHARD", Value: "a"}}},
+				{GroupIndex: 1, Env: []corev1.EnvVar{{Name: "MODEL_SHARD", Value: "b"}}},
+			}
+			leader := wrappers.MakePodWithLabels("test-sample", tc.groupIndex, "0", "default", 2)
+			leader.Labels[leaderworkerset.RevisionKey] = revisionutils.GetRevisionKey(revision)
+
+			sts, err := constructWorkerStatefulSetApplyConfiguration(*leader, *lws, revision)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := sts.Spec.Template.Annotations[leaderworkerset.PerGroupEnvAnnotationKey]; got != tc.wantAnnotation {
+				t.Errorf("unexpected %s annotation, want: %s, got: %s", leaderworkerset.PerGroupEnvAnnotationKey, tc.wantAnnotation, got)
+			}
+		})
+	}
+}
+
+func TestConstructWorkerStatefulSetInheritLabels(t *testing.T) {
+	client := fake.NewClientBuilder().Build()
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(1).WorkerTemplateSpec(wrappers.MakeWorkerPodSpec()).Size(2).Obj()
+	lws.Labels = map[string]string{"cost-center": "ml"}
+	lws.Spec.LeaderWorkerTemplate.InheritLabels = []string{"cost-center", "missing"}
+	revision, err := revisionutils.NewRevision(context.TODO(), client, lws, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	leader := wrappers.MakePodWithLabels("test-sample", "0", "0", "default", 2)
+	leader.Labels[leaderworkerset.RevisionKey] = revisionutils.GetRevisionKey(revision)
+
+	sts, err := constructWorkerStatefulSetApplyConfiguration(*leader, *lws, revision)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := sts.Spec.Template.Labels["cost-center"]; got != "ml" {
+		t.Errorf("unexpected cost-center label, want: %q, got: %q", "ml", got)
+	}
+	if _, found := sts.Spec.Template.Labels["missing"]; found {
+		t.Errorf("unexpected label for a key missing on the LeaderWorkerSet")
+	}
+}
+
+func TestConstructWorkerStatefulSetVolumeClaimTemplates(t *testing.T) {
+	tests := []struct {
+		name        string
+		whenDeleted appsv1.PersistentVolumeClaimRetentionPolicyType
+	}{
+		{
+			name:        "worker claims retained on group recreation",
+			whenDeleted: appsv1.RetainPersistentVolumeClaimRetentionPolicyType,
+		},
+		{
+			name:        "worker claims deleted on group recreation",
+			whenDeleted: appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fake.NewClientBuilder().Build()
+			lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(1).WorkerTemplateSpec(wrappers.MakeWorkerPodSpec()).Size(2).Obj()
+			lws.Spec.LeaderWorkerTemplate.VolumeClaimTemplates = []corev1.PersistentVolumeClaim{
+				{ObjectMeta: v1.ObjectMeta{Name: "model-cache"}},
+			}
+			lws.Spec.LeaderWorkerTemplate.PersistentVolumeClaimRetentionPolicy = &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+				WhenDeleted: tc.whenDeleted,
+			}
+			revision, err := revisionutils.NewRevision(context.TODO(), client, lws, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			leader := wrappers.MakePodWithLabels("test-sample", "0", "0", "default", 2)
+			leader.Labels[leaderworkerset.RevisionKey] = revisionutils.GetRevisionKey(revision)
+
+			sts, err := constructWorkerStatefulSetApplyConfiguration(*leader, *lws, revision)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(sts.Spec.VolumeClaimTemplates) != 1 || *sts.Spec.VolumeClaimTemplates[0].Name != "model-cache" {
+				t.Errorf("unexpected volumeClaimTemplates: %v", sts.Spec.VolumeClaimTemplates)
+			}
+			policy := sts.Spec.PersistentVolumeClaimRetentionPolicy
+			if policy == nil || policy.WhenDeleted == nil || *policy.WhenDeleted != tc.whenDeleted {
+				t.Errorf("unexpected retention policy, want whenDeleted: %q, got: %v", tc.whenDeleted, policy)
+			}
+		})
+	}
+}
+
+func TestConstructWorkerStatefulSetMembershipConfigMap(t *testing.T) {
+	client := fake.NewClientBuilder().Build()
+	lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(1).WorkerTemplateSpec(wrappers.MakeWorkerPodSpec()).Size(2).Obj()
+	lws.Spec.LeaderWorkerTemplate.PublishMembershipConfigMap = true
+	revision, err := revisionutils.NewRevision(context.TODO(), client, lws, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	leader := wrappers.MakePodWithLabels("test-sample", "0", "0", "default", 2)
+	leader.Labels[leaderworkerset.RevisionKey] = revisionutils.GetRevisionKey(revision)
+
+	sts, err := constructWorkerStatefulSetApplyConfiguration(*leader, *lws, revision)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := sts.Spec.Template.Annotations[leaderworkerset.MembershipConfigMapAnnotationKey]; got != "true" {
+		t.Errorf("unexpected membership configmap annotation, want: %q, got: %q", "true", got)
+	}
+	want := coreapplyv1.Volume().
+		WithName(leaderworkerset.MembershipVolumeName).
+		WithConfigMap(coreapplyv1.ConfigMapVolumeSource().WithName("test-sample-0-membership"))
+	var got *coreapplyv1.VolumeApplyConfiguration
+	for i := range sts.Spec.Template.Spec.Volumes {
+		if *sts.Spec.Template.Spec.Volumes[i].Name == leaderworkerset.MembershipVolumeName {
+			got = &sts.Spec.Template.Spec.Volumes[i]
+		}
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected membership volume (-want +got): %s", diff)
+	}
+}
+
+func TestConstructWorkerStatefulSetSubdomainPolicyNone(t *testing.T) {
+	for _, subdomainPolicy := range []leaderworkerset.SubdomainPolicy{leaderworkerset.SubdomainShared, leaderworkerset.SubdomainUniquePerReplica, leaderworkerset.SubdomainNone} {
+		client := fake.NewClientBuilder().Build()
+		lws := wrappers.BuildBasicLeaderWorkerSet("test-sample", "default").Replica(1).WorkerTemplateSpec(wrappers.MakeWorkerPodSpec()).Size(2).Obj()
+		lws.Spec.NetworkConfig = &leaderworkerset.NetworkConfig{SubdomainPolicy: ptr.To(subdomainPolicy)}
+		revision, err := revisionutils.NewRevision(context.TODO(), client, lws, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		leader := wrappers.MakePodWithLabels("test-sample", "0", "0", "default", 2)
+		leader.Labels[leaderworkerset.RevisionKey] = revisionutils.GetRevisionKey(revision)
+
+		sts, err := constructWorkerStatefulSetApplyConfiguration(*leader, *lws, revision)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := sts.Spec.Template.Annotations[leaderworkerset.SubdomainPolicyAnnotationKey] == string(leaderworkerset.SubdomainNone)
+		if want := subdomainPolicy == leaderworkerset.SubdomainNone; got != want {
+			t.Errorf("unexpected %s annotation with subdomainPolicy %s, want None: %t, got None: %t", leaderworkerset.SubdomainPolicyAnnotationKey, subdomainPolicy, want, got)
+		}
+	}
+}
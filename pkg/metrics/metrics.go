@@ -0,0 +1,78 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// RolloutDuration tracks how long the rollouts take, from when a new revision is first
+	// observed until all the groups are updated.
+	RolloutDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "lws_rollout_duration_seconds",
+			Help:    "Duration of the rollouts of a LeaderWorkerSet, from when a new revision is observed until all the groups are updated.",
+			Buckets: prometheus.ExponentialBuckets(10, 2, 12),
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// GroupTotal tracks the number of groups created for each leaderworkerset.
+	GroupTotal = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "lws_group_total",
+			Help: "Number of groups of a LeaderWorkerSet that have been created, ready or not.",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// GroupReady tracks the number of ready groups of each leaderworkerset.
+	GroupReady = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "lws_group_ready",
+			Help: "Number of groups of a LeaderWorkerSet in ready state.",
+		},
+		[]string{"namespace", "name"},
+	)
+)
+
+// Register registers the leaderworkerset metrics with the controller-runtime metrics registry.
+func Register() {
+	metrics.Registry.MustRegister(RolloutDuration, GroupTotal, GroupReady)
+}
+
+// RolloutCompleted records the duration of a completed rollout of the leaderworkerset.
+func RolloutCompleted(namespace, name string, duration time.Duration) {
+	RolloutDuration.WithLabelValues(namespace, name).Observe(duration.Seconds())
+}
+
+// GroupsObserved records the number of groups and ready groups of the leaderworkerset.
+func GroupsObserved(namespace, name string, total, ready int32) {
+	GroupTotal.WithLabelValues(namespace, name).Set(float64(total))
+	GroupReady.WithLabelValues(namespace, name).Set(float64(ready))
+}
+
+// LeaderWorkerSetDeleted deletes the group gauges of a deleted leaderworkerset, so that they
+// don't keep reporting its last observed groups.
+func LeaderWorkerSetDeleted(namespace, name string) {
+	GroupTotal.DeleteLabelValues(namespace, name)
+	GroupReady.DeleteLabelValues(namespace, name)
+}
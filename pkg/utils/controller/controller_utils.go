@@ -18,7 +18,10 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -62,3 +65,82 @@ func CreateHeadlessServiceIfNotExists(ctx context.Context, k8sClient client.Clie
 	}
 	return nil
 }
+
+// ExclusiveTopologyKey returns the topology key used for exclusive placement of the groups,
+// exclusiveTopology takes precedence over the exclusive-topology annotation. It returns an
+// empty string when exclusive placement is not enabled.
+func ExclusiveTopologyKey(lws *leaderworkerset.LeaderWorkerSet) string {
+	if lws.Spec.LeaderWorkerTemplate.ExclusiveTopology != nil {
+		return lws.Spec.LeaderWorkerTemplate.ExclusiveTopology.TopologyKey
+	}
+	return lws.Annotations[leaderworkerset.ExclusiveKeyAnnotationKey]
+}
+
+// SubGroupExclusiveTopologyKey returns the topology key used for exclusive placement of the subgroups,
+// subGroupTopologyKey takes precedence over the subgroup-exclusive-topology annotation. It returns an
+// empty string when the lws has no subgroups or their exclusive placement is not enabled.
+func SubGroupExclusiveTopologyKey(lws *leaderworkerset.LeaderWorkerSet) string {
+	subGroupPolicy := lws.Spec.LeaderWorkerTemplate.SubGroupPolicy
+	if subGroupPolicy == nil {
+		return ""
+	}
+	if subGroupPolicy.SubGroupTopologyKey != nil {
+		return *subGroupPolicy.SubGroupTopologyKey
+	}
+	return lws.Annotations[leaderworkerset.SubGroupExclusiveKeyAnnotationKey]
+}
+
+// PerGroupEnv returns the perGroupEnv of the lws keyed by the group index label of the groups, i.e.
+// shifted by the networkConfig.startIndex.
+func PerGroupEnv(lws *leaderworkerset.LeaderWorkerSet) map[string][]corev1.EnvVar {
+	var start int32
+	if lws.Spec.NetworkConfig != nil {
+		start = lws.Spec.NetworkConfig.StartIndex
+	}
+	perGroupEnv := make(map[string][]corev1.EnvVar, len(lws.Spec.LeaderWorkerTemplate.PerGroupEnv))
+	for _, groupEnv := range lws.Spec.LeaderWorkerTemplate.PerGroupEnv {
+		perGroupEnv[fmt.Sprint(start+groupEnv.GroupIndex)] = groupEnv.Env
+	}
+	return perGroupEnv
+}
+
+// LeaderStatefulSetName returns the name of the leader statefulset, which is also the prefix of
+// the leader pod names. It's the hostnamePrefix when set, otherwise the lws name.
+func LeaderStatefulSetName(lws *leaderworkerset.LeaderWorkerSet) string {
+	if lws.Spec.NetworkConfig != nil && lws.Spec.NetworkConfig.HostnamePrefix != "" {
+		return lws.Spec.NetworkConfig.HostnamePrefix
+	}
+	return lws.Name
+}
+
+// InheritedLabels returns the labels of the lws whose keys are listed in inheritLabels, to be set
+// on all the pods. Keys missing on the lws are ignored.
+func InheritedLabels(lws *leaderworkerset.LeaderWorkerSet) map[string]string {
+	labels := map[string]string{}
+	for _, key := range lws.Spec.LeaderWorkerTemplate.InheritLabels {
+		if value, found := lws.Labels[key]; found {
+			labels[key] = value
+		}
+	}
+	return labels
+}
+
+// MembershipConfigMapName returns the name of the membership ConfigMap of the group, published
+// when publishMembershipConfigMap is set.
+func MembershipConfigMapName(lwsName, groupIndex string) string {
+	return fmt.Sprintf("%s-%s-membership", lwsName, groupIndex)
+}
+
+// DeadlineExceededGroups returns the indexes of the groups recorded as past their activeDeadlineSeconds
+// by the deadline-exceeded-groups annotation of the leader statefulset.
+func DeadlineExceededGroups(leaderSts *appsv1.StatefulSet) ([]int, error) {
+	value, found := leaderSts.Annotations[leaderworkerset.DeadlineExceededGroupsAnnotationKey]
+	if !found {
+		return nil, nil
+	}
+	var groups []int
+	if err := json.Unmarshal([]byte(value), &groups); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation for statefulset %s: %w", leaderworkerset.DeadlineExceededGroupsAnnotationKey, leaderSts.Name, err)
+	}
+	return groups, nil
+}
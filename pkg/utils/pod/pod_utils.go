@@ -17,7 +17,13 @@ limitations under the License.
 package pod
 
 import (
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
@@ -25,6 +31,9 @@ import (
 	leaderworkerset "sigs.k8s.io/lws/api/leaderworkerset/v1"
 )
 
+// crashLoopBackOffReason is the waiting reason of a container restarting after a back-off.
+const crashLoopBackOffReason = "CrashLoopBackOff"
+
 // ContainerRestarted return true when there is any container in the pod that gets restarted
 func ContainerRestarted(pod corev1.Pod) bool {
 	if pod.Status.Phase == corev1.PodRunning || pod.Status.Phase == corev1.PodPending {
@@ -44,6 +53,48 @@ func ContainerRestarted(pod corev1.Pod) bool {
 	return false
 }
 
+// CrashLooping returns true when any container of the pod is waiting in CrashLoopBackOff.
+func CrashLooping(pod corev1.Pod) bool {
+	for _, stat := range slices.Concat(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses) {
+		if stat.State.Waiting != nil && stat.State.Waiting.Reason == crashLoopBackOffReason {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesPodFailurePolicy returns true when a terminated container of the pod, in its current or
+// last state, has an exit code matching any of the rules of the policy.
+func MatchesPodFailurePolicy(pod corev1.Pod, policy *leaderworkerset.PodFailurePolicy) bool {
+	if policy == nil {
+		return false
+	}
+	for _, stat := range slices.Concat(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses) {
+		for _, terminated := range []*corev1.ContainerStateTerminated{stat.State.Terminated, stat.LastTerminationState.Terminated} {
+			if terminated == nil || terminated.ExitCode == 0 {
+				continue
+			}
+			for _, rule := range policy.Rules {
+				if matchesOnExitCodes(stat.Name, terminated.ExitCode, rule.OnExitCodes) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func matchesOnExitCodes(containerName string, exitCode int32, requirement leaderworkerset.PodFailurePolicyOnExitCodesRequirement) bool {
+	if requirement.ContainerName != nil && *requirement.ContainerName != containerName {
+		return false
+	}
+	in := slices.Contains(requirement.Values, exitCode)
+	if requirement.Operator == leaderworkerset.PodFailurePolicyOnExitCodesOpNotIn {
+		return !in
+	}
+	return in
+}
+
 // PodDeleted checks if the worker pod has been deleted
 func PodDeleted(pod corev1.Pod) bool {
 	return pod.DeletionTimestamp != nil
@@ -54,11 +105,59 @@ func LeaderPod(pod corev1.Pod) bool {
 	return pod.Labels[leaderworkerset.WorkerIndexLabelKey] == "0"
 }
 
+// LeaderPods returns the leader pods among pods
+func LeaderPods(pods []corev1.Pod) []corev1.Pod {
+	var leaderPods []corev1.Pod
+	for _, pod := range pods {
+		if LeaderPod(pod) {
+			leaderPods = append(leaderPods, pod)
+		}
+	}
+	return leaderPods
+}
+
 // PodRunningAndReady checks if the pod condition is running and marked as ready.
 func PodRunningAndReady(pod corev1.Pod) bool {
 	return pod.Status.Phase == corev1.PodRunning && podReady(pod)
 }
 
+// GroupReady returns true when every pod of a group of the given size is running and ready. The
+// pods are expected to carry the worker indexes 1 to size-1, and 0 as well when hasLeader. Pods
+// being deleted don't count, while pods with an unexpected worker index are ignored.
+func GroupReady(pods []corev1.Pod, size int32, hasLeader bool) bool {
+	first := 1
+	if hasLeader {
+		first = 0
+	}
+	ready := make(map[int]bool, size)
+	for _, pod := range pods {
+		index, err := strconv.Atoi(pod.Labels[leaderworkerset.WorkerIndexLabelKey])
+		if err != nil || index < first || index >= int(size) || PodDeleted(pod) {
+			continue
+		}
+		ready[index] = ready[index] || PodRunningAndReady(pod)
+	}
+	for index := first; index < int(size); index++ {
+		if !ready[index] {
+			return false
+		}
+	}
+	return true
+}
+
+// UnschedulableSince returns the time since when the pod is pending because it's unschedulable,
+// and false if the pod is not unschedulable.
+func UnschedulableSince(pod corev1.Pod) (time.Time, bool) {
+	if pod.Status.Phase != corev1.PodPending {
+		return time.Time{}, false
+	}
+	_, condition := getPodCondition(&pod.Status, corev1.PodScheduled)
+	if condition == nil || condition.Status != corev1.ConditionFalse || condition.Reason != corev1.PodReasonUnschedulable {
+		return time.Time{}, false
+	}
+	return condition.LastTransitionTime.Time, true
+}
+
 func podReady(pod corev1.Pod) bool {
 	return podReadyConditionTrue(pod.Status)
 }
@@ -131,9 +230,27 @@ func AddLWSVariables(pod *corev1.Pod) error {
 		return fmt.Errorf("Failure constructing environment variables, no group index label found for pod %v", klog.KObj(pod))
 	}
 
+	// The leader pod name is prefixed with hostnamePrefix instead of the lws name when it's set,
+	// so prefer the actual leader pod name when it's known.
+	leaderName := fmt.Sprintf("%s-%s", lwsName, groupIndex)
+	if name, found := pod.Annotations[leaderworkerset.LeaderPodNameAnnotationKey]; found {
+		leaderName = name
+	} else if pod.Name != "" && pod.Labels[leaderworkerset.WorkerIndexLabelKey] == "0" {
+		leaderName = pod.Name
+	}
+
+	envNames, err := networkEnvNames(pod)
+	if err != nil {
+		return err
+	}
+
 	leaderAddressEnvVar := corev1.EnvVar{
-		Name:  leaderworkerset.LwsLeaderAddress,
-		Value: fmt.Sprintf("%s-%s.%s.%s", lwsName, groupIndex, pod.Spec.Subdomain, pod.ObjectMeta.Namespace),
+		Name:  EnvName(envNames, leaderworkerset.LwsLeaderAddress),
+		Value: fmt.Sprintf("%s.%s.%s", leaderName, pod.Spec.Subdomain, pod.ObjectMeta.Namespace),
+	}
+	// Without a subdomain, the leader is not addressable via DNS, fall back to the leader pod name.
+	if pod.Spec.Subdomain == "" {
+		leaderAddressEnvVar.Value = leaderName
 	}
 
 	size, found := pod.Annotations[leaderworkerset.SizeAnnotationKey]
@@ -143,7 +260,7 @@ func AddLWSVariables(pod *corev1.Pod) error {
 
 	// The group size is assumed to be the same as the number of replicas.
 	sizeEnvVar := corev1.EnvVar{
-		Name:  leaderworkerset.LwsGroupSize,
+		Name:  EnvName(envNames, leaderworkerset.LwsGroupSize),
 		Value: size,
 	}
 
@@ -153,22 +270,232 @@ func AddLWSVariables(pod *corev1.Pod) error {
 	}
 
 	workerIndexEnvVar := corev1.EnvVar{
-		Name:  leaderworkerset.LwsWorkerIndex,
+		Name:  EnvName(envNames, leaderworkerset.LwsWorkerIndex),
 		Value: workerIndex,
 	}
 
+	// The pods are named after their leader and worker index, so the address of a pod is the
+	// same across restarts.
+	podFQDNEnvVar := corev1.EnvVar{
+		Name:  leaderworkerset.LwsPodFQDN,
+		Value: fmt.Sprintf("%s.%s.%s", pod.Name, pod.Spec.Subdomain, pod.Namespace),
+	}
+	if pod.Spec.Subdomain == "" {
+		podFQDNEnvVar.Value = pod.Name
+	}
+
+	// The pod with worker index 0 is the leader whether or not a separate leaderTemplate
+	// is set, it's created from the worker template otherwise.
+	isLeaderEnvVar := corev1.EnvVar{
+		Name:  leaderworkerset.LwsIsLeader,
+		Value: strconv.FormatBool(workerIndex == "0"),
+	}
+	rankEnvVar := corev1.EnvVar{
+		Name:  leaderworkerset.LwsRank,
+		Value: workerIndex,
+	}
+
+	envVars := []corev1.EnvVar{sizeEnvVar, workerIndexEnvVar}
+	if pod.Annotations[leaderworkerset.InjectPeerAddressesAnnotationKey] == "true" {
+		groupSize, err := strconv.Atoi(size)
+		if err != nil {
+			return fmt.Errorf("Failure constructing environment variables, invalid size annotation %q for pod %v", size, klog.KObj(pod))
+		}
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  leaderworkerset.LwsPeerAddresses,
+			Value: strings.Join(PeerAddresses(leaderName, pod.Spec.Subdomain, pod.Namespace, groupSize), ","),
+		})
+	}
+	envVars = append(envVars, podFQDNEnvVar, isLeaderEnvVar, rankEnvVar)
+
 	// The order of injection needs attention, see
 	// https://github.com/kubernetes-sigs/lws/pull/152
 	for i := range pod.Spec.Containers {
-		addEnvVarsIfNotExists(&pod.Spec.Containers[i], leaderAddressEnvVar, sizeEnvVar, workerIndexEnvVar)
+		addEnvVarsIfNotExists(&pod.Spec.Containers[i], leaderAddressEnvVar, envVars...)
 	}
 	for i := range pod.Spec.InitContainers {
-		addEnvVarsIfNotExists(&pod.Spec.InitContainers[i], leaderAddressEnvVar, sizeEnvVar, workerIndexEnvVar)
+		addEnvVarsIfNotExists(&pod.Spec.InitContainers[i], leaderAddressEnvVar, envVars...)
 	}
 
 	return nil
 }
 
+// ValueFromEnvCollisions returns, by container name, the environment variables sourced via valueFrom,
+// e.g. from the downward API, that are overridden by the variables injected into the pod.
+func ValueFromEnvCollisions(pod *corev1.Pod) (map[string][]string, error) {
+	envNames, err := networkEnvNames(pod)
+	if err != nil {
+		return nil, err
+	}
+	injected := []string{
+		EnvName(envNames, leaderworkerset.LwsLeaderAddress),
+		EnvName(envNames, leaderworkerset.LwsGroupSize),
+		EnvName(envNames, leaderworkerset.LwsWorkerIndex),
+		leaderworkerset.LwsPodFQDN,
+		leaderworkerset.LwsIsLeader,
+		leaderworkerset.LwsRank,
+	}
+	if pod.Annotations[leaderworkerset.InjectPeerAddressesAnnotationKey] == "true" {
+		injected = append(injected, leaderworkerset.LwsPeerAddresses)
+	}
+	collisions := map[string][]string{}
+	for _, c := range slices.Concat(pod.Spec.InitContainers, pod.Spec.Containers) {
+		for _, env := range c.Env {
+			if env.ValueFrom != nil && slices.Contains(injected, env.Name) {
+				collisions[c.Name] = append(collisions[c.Name], env.Name)
+			}
+		}
+	}
+	return collisions, nil
+}
+
+// EnvName returns the name of the injected environment variable, or its override
+// from spec.leaderWorkerTemplate.networkEnvNames if any.
+func EnvName(overrides map[string]string, name string) string {
+	if override, found := overrides[name]; found {
+		return override
+	}
+	return name
+}
+
+// networkEnvNames returns the overrides of the injected environment variable names
+// the pod is annotated with.
+func networkEnvNames(pod *corev1.Pod) (map[string]string, error) {
+	value, found := pod.Annotations[leaderworkerset.NetworkEnvNamesAnnotationKey]
+	if !found {
+		return nil, nil
+	}
+	var envNames map[string]string
+	if err := json.Unmarshal([]byte(value), &envNames); err != nil {
+		return nil, fmt.Errorf("Failure constructing environment variables, invalid %s annotation for pod %v: %w", leaderworkerset.NetworkEnvNamesAnnotationKey, klog.KObj(pod), err)
+	}
+	return envNames, nil
+}
+
+// PeerAddresses returns the addresses of all the pods in the group, the leader first followed by
+// the workers in order of their index. Worker pods are named after the leader pod with their index
+// as the suffix, and share the subdomain of the leader pod.
+func PeerAddresses(leaderName, subdomain, namespace string, size int) []string {
+	addresses := make([]string, 0, size)
+	for i := 0; i < size; i++ {
+		name := leaderName
+		if i > 0 {
+			name = fmt.Sprintf("%s-%d", leaderName, i)
+		}
+		// Without a subdomain, the pods are not addressable via DNS, fall back to the pod names.
+		if subdomain == "" {
+			addresses = append(addresses, name)
+		} else {
+			addresses = append(addresses, fmt.Sprintf("%s.%s.%s", name, subdomain, namespace))
+		}
+	}
+	return addresses
+}
+
+// placeholderRegexp matches placeholders like {{.GroupIndex}} in annotation values and container
+// commands and args.
+var placeholderRegexp = regexp.MustCompile(`\{\{\s*\.(\w+)\s*\}\}`)
+
+// UnknownPlaceholders returns the placeholders in the value that are not one of the supported
+// placeholders.
+func UnknownPlaceholders(value string) []string {
+	var unknown []string
+	for _, match := range placeholderRegexp.FindAllStringSubmatch(value, -1) {
+		switch match[1] {
+		case leaderworkerset.GroupIndexPlaceholder, leaderworkerset.WorkerIndexPlaceholder, leaderworkerset.SizePlaceholder:
+		default:
+			unknown = append(unknown, match[0])
+		}
+	}
+	return unknown
+}
+
+// placeholderValues returns the values of the placeholders for the pod: its group index, worker
+// index and group size.
+func placeholderValues(pod *corev1.Pod) map[string]string {
+	return map[string]string{
+		leaderworkerset.GroupIndexPlaceholder:  pod.Labels[leaderworkerset.GroupIndexLabelKey],
+		leaderworkerset.WorkerIndexPlaceholder: pod.Labels[leaderworkerset.WorkerIndexLabelKey],
+		leaderworkerset.SizePlaceholder:        pod.Annotations[leaderworkerset.SizeAnnotationKey],
+	}
+}
+
+// expandPlaceholders replaces the placeholders in the value, it fails on unknown placeholders.
+func expandPlaceholders(value string, values map[string]string) (string, error) {
+	if !strings.Contains(value, "{{") {
+		return value, nil
+	}
+	if unknown := UnknownPlaceholders(value); len(unknown) > 0 {
+		return "", fmt.Errorf("unknown placeholders %v", unknown)
+	}
+	return placeholderRegexp.ReplaceAllStringFunc(value, func(placeholder string) string {
+		return values[placeholderRegexp.FindStringSubmatch(placeholder)[1]]
+	}), nil
+}
+
+// ExpandAnnotationPlaceholders replaces the placeholders in the pod annotation values with the
+// group index, worker index and group size of the pod.
+func ExpandAnnotationPlaceholders(pod *corev1.Pod) error {
+	values := placeholderValues(pod)
+	for key, value := range pod.Annotations {
+		expanded, err := expandPlaceholders(value, values)
+		if err != nil {
+			return fmt.Errorf("annotation %s of pod %v has %w", key, klog.KObj(pod), err)
+		}
+		pod.Annotations[key] = expanded
+	}
+	return nil
+}
+
+// ExpandContainerPlaceholders replaces the placeholders in the command and args of the containers
+// and init containers of the pod with the group index, worker index and group size of the pod.
+func ExpandContainerPlaceholders(pod *corev1.Pod) error {
+	values := placeholderValues(pod)
+	expand := func(container *corev1.Container) error {
+		for _, strs := range [][]string{container.Command, container.Args} {
+			for i, value := range strs {
+				expanded, err := expandPlaceholders(value, values)
+				if err != nil {
+					return fmt.Errorf("container %s of pod %v has %w", container.Name, klog.KObj(pod), err)
+				}
+				strs[i] = expanded
+			}
+		}
+		return nil
+	}
+	for i := range pod.Spec.InitContainers {
+		if err := expand(&pod.Spec.InitContainers[i]); err != nil {
+			return err
+		}
+	}
+	for i := range pod.Spec.Containers {
+		if err := expand(&pod.Spec.Containers[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HasReadinessGate returns true if the pod has a readiness gate with the given condition type.
+func HasReadinessGate(pod corev1.Pod, conditionType corev1.PodConditionType) bool {
+	for _, gate := range pod.Spec.ReadinessGates {
+		if gate.ConditionType == conditionType {
+			return true
+		}
+	}
+	return false
+}
+
+// HasSchedulingGate returns true if the pod has a scheduling gate with the given name.
+func HasSchedulingGate(pod corev1.Pod, name string) bool {
+	for _, gate := range pod.Spec.SchedulingGates {
+		if gate.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 // IsPodReady returns true if a pod is ready; false otherwise.
 func IsPodReady(pod *corev1.Pod) bool {
 	return IsPodReadyConditionTrue(pod.Status)
@@ -22,6 +22,10 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	leaderworkerset "sigs.k8s.io/lws/api/leaderworkerset/v1"
 	"sigs.k8s.io/lws/test/wrappers"
 )
 
@@ -100,6 +104,104 @@ func TestContainerRestarted(t *testing.T) {
 	}
 }
 
+func TestGroupReady(t *testing.T) {
+	groupPod := func(workerIndex int, ready bool) corev1.Pod {
+		pod := corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{leaderworkerset.WorkerIndexLabelKey: strconv.Itoa(workerIndex)},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		if ready {
+			pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		}
+		return pod
+	}
+	deletedPod := groupPod(1, true)
+	deletedPod.DeletionTimestamp = ptr.To(metav1.Now())
+
+	tests := []struct {
+		name      string
+		pods      []corev1.Pod
+		hasLeader bool
+		wantReady bool
+	}{
+		{
+			name:      "all pods ready",
+			pods:      []corev1.Pod{groupPod(0, true), groupPod(1, true), groupPod(2, true)},
+			hasLeader: true,
+			wantReady: true,
+		},
+		{
+			name:      "missing worker pod",
+			pods:      []corev1.Pod{groupPod(0, true), groupPod(2, true)},
+			hasLeader: true,
+		},
+		{
+			name:      "missing leader pod",
+			pods:      []corev1.Pod{groupPod(1, true), groupPod(2, true)},
+			hasLeader: true,
+		},
+		{
+			name:      "no pods",
+			hasLeader: true,
+		},
+		{
+			name:      "not ready worker pod",
+			pods:      []corev1.Pod{groupPod(0, true), groupPod(1, false), groupPod(2, true)},
+			hasLeader: true,
+		},
+		{
+			name:      "not ready leader pod",
+			pods:      []corev1.Pod{groupPod(0, false), groupPod(1, true), groupPod(2, true)},
+			hasLeader: true,
+		},
+		{
+			name: "pending pod with a ready condition",
+			pods: func() []corev1.Pod {
+				pending := groupPod(2, true)
+				pending.Status.Phase = corev1.PodPending
+				return []corev1.Pod{groupPod(0, true), groupPod(1, true), pending}
+			}(),
+			hasLeader: true,
+		},
+		{
+			name:      "deleted worker pod",
+			pods:      []corev1.Pod{groupPod(0, true), deletedPod, groupPod(2, true)},
+			hasLeader: true,
+		},
+		{
+			name:      "extra pod out of the size",
+			pods:      []corev1.Pod{groupPod(0, true), groupPod(1, true), groupPod(2, true), groupPod(3, false)},
+			hasLeader: true,
+			wantReady: true,
+		},
+		{
+			name:      "extra not ready pod with the same index",
+			pods:      []corev1.Pod{groupPod(0, true), groupPod(1, true), groupPod(1, false), groupPod(2, true)},
+			hasLeader: true,
+			wantReady: true,
+		},
+		{
+			name:      "workers only",
+			pods:      []corev1.Pod{groupPod(1, true), groupPod(2, true)},
+			wantReady: true,
+		},
+		{
+			name:      "workers only ignore the leader pod",
+			pods:      []corev1.Pod{groupPod(0, false), groupPod(1, true), groupPod(2, true)},
+			wantReady: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := GroupReady(tc.pods, 3, tc.hasLeader); got != tc.wantReady {
+				t.Errorf("unexpected group ready, want: %t, got: %t", tc.wantReady, got)
+			}
+		})
+	}
+}
+
 func TestAddLWSVariables(t *testing.T) {
 	tests := []struct {
 		name                     string
@@ -150,6 +252,40 @@ func TestAddLWSVariables(t *testing.T) {
 			expectedGroupSize:        2,
 			expectedWorkerIndex:      "3",
 		},
+		{
+			name: "Leader pod with hostnamePrefix",
+			pod: func() *corev1.Pod {
+				pod := wrappers.MakePodWithLabels("test-sample", "1", "0", "default", 2)
+				pod.Name = "prefix-1"
+				return pod
+			}(),
+			expectedLwsLeaderAddress: "prefix-1.test-sample.default",
+			expectedGroupSize:        2,
+			expectedWorkerIndex:      "0",
+		},
+		{
+			name: "Worker pod with hostnamePrefix",
+			pod: func() *corev1.Pod {
+				pod := wrappers.MakePodWithLabels("test-sample", "1", "3", "default", 2)
+				pod.Name = "prefix-1-3"
+				pod.Annotations[leaderworkerset.LeaderPodNameAnnotationKey] = "prefix-1"
+				return pod
+			}(),
+			expectedLwsLeaderAddress: "prefix-1.test-sample.default",
+			expectedGroupSize:        2,
+			expectedWorkerIndex:      "3",
+		},
+		{
+			name: "Worker pod without subdomain",
+			pod: func() *corev1.Pod {
+				pod := wrappers.MakePodWithLabels("test-sample", "1", "3", "default", 2)
+				pod.Spec.Subdomain = ""
+				return pod
+			}(),
+			expectedLwsLeaderAddress: "test-sample-1",
+			expectedGroupSize:        2,
+			expectedWorkerIndex:      "3",
+		},
 	}
 
 	for _, tc := range tests {
@@ -184,3 +320,283 @@ func TestAddLWSVariables(t *testing.T) {
 		})
 	}
 }
+
+func TestAddLWSVariablesPeerAddresses(t *testing.T) {
+	sharedAddresses := "test-sample-1.test-sample.default,test-sample-1-1.test-sample.default,test-sample-1-2.test-sample.default,test-sample-1-3.test-sample.default"
+	uniqueAddresses := "test-sample-1.test-sample-1.default,test-sample-1-1.test-sample-1.default,test-sample-1-2.test-sample-1.default,test-sample-1-3.test-sample-1.default"
+	tests := []struct {
+		name                  string
+		pod                   *corev1.Pod
+		subdomain             string
+		expectedPeerAddresses string
+	}{
+		{
+			name:                  "Leader pod, shared subdomain",
+			pod:                   wrappers.MakePodWithLabels("test-sample", "1", "0", "default", 4),
+			subdomain:             "test-sample",
+			expectedPeerAddresses: sharedAddresses,
+		},
+		{
+			name:                  "Worker pod, shared subdomain",
+			pod:                   wrappers.MakePodWithLabels("test-sample", "1", "2", "default", 4),
+			subdomain:             "test-sample",
+			expectedPeerAddresses: sharedAddresses,
+		},
+		{
+			name:                  "Leader pod, unique per replica subdomain",
+			pod:                   wrappers.MakePodWithLabels("test-sample", "1", "0", "default", 4),
+			subdomain:             "test-sample-1",
+			expectedPeerAddresses: uniqueAddresses,
+		},
+		{
+			name:                  "Worker pod, unique per replica subdomain",
+			pod:                   wrappers.MakePodWithLabels("test-sample", "1", "3", "default", 4),
+			subdomain:             "test-sample-1",
+			expectedPeerAddresses: uniqueAddresses,
+		},
+		{
+			name:                  "Worker pod without subdomain",
+			pod:                   wrappers.MakePodWithLabels("test-sample", "1", "3", "default", 4),
+			expectedPeerAddresses: "test-sample-1,test-sample-1-1,test-sample-1-2,test-sample-1-3",
+		},
+		{
+			name: "Worker pod with hostnamePrefix",
+			pod: func() *corev1.Pod {
+				pod := wrappers.MakePodWithLabels("test-sample", "1", "1", "default", 4)
+				pod.Annotations[leaderworkerset.LeaderPodNameAnnotationKey] = "prefix-1"
+				return pod
+			}(),
+			subdomain:             "test-sample",
+			expectedPeerAddresses: "prefix-1.test-sample.default,prefix-1-1.test-sample.default,prefix-1-2.test-sample.default,prefix-1-3.test-sample.default",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.pod.Spec.Subdomain = tc.subdomain
+			tc.pod.Annotations[leaderworkerset.InjectPeerAddressesAnnotationKey] = "true"
+			if err := AddLWSVariables(tc.pod); err != nil {
+				t.Fatalf("Error adding LWS variables: %s", err.Error())
+			}
+			containers := append(tc.pod.Spec.Containers, tc.pod.Spec.InitContainers...)
+			if len(containers) == 0 {
+				t.Fatalf("No contianers in podSpec %+v", tc.pod.Spec)
+			}
+
+			for _, container := range containers {
+				if len(container.Env) < 4 {
+					t.Fatalf("Failed to add LWS Variables to container %+v", container)
+				}
+				envVar := container.Env[3]
+				if envVar.Name != leaderworkerset.LwsPeerAddresses {
+					t.Errorf("Unexpected env var %s, want %s", envVar.Name, leaderworkerset.LwsPeerAddresses)
+				}
+				if diff := cmp.Diff(tc.expectedPeerAddresses, envVar.Value); diff != "" {
+					t.Errorf("Unexpected lws peer addresses (-want,+got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestAddLWSVariablesWithoutPeerAddresses(t *testing.T) {
+	pod := wrappers.MakePodWithLabels("test-sample", "1", "2", "default", 4)
+	if err := AddLWSVariables(pod); err != nil {
+		t.Fatalf("Error adding LWS variables: %s", err.Error())
+	}
+	for _, container := range append(pod.Spec.Containers, pod.Spec.InitContainers...) {
+		for _, env := range container.Env {
+			if env.Name == leaderworkerset.LwsPeerAddresses {
+				t.Errorf("Unexpected %s env var in container %s", leaderworkerset.LwsPeerAddresses, container.Name)
+			}
+		}
+	}
+}
+
+func TestAddLWSVariablesNetworkEnvNames(t *testing.T) {
+	tests := []struct {
+		name            string
+		networkEnvNames string
+		wantEnv         []corev1.EnvVar
+		wantErr         bool
+	}{
+		{
+			name: "default names",
+			wantEnv: []corev1.EnvVar{
+				{Name: leaderworkerset.LwsLeaderAddress, Value: "test-sample-1.test-sample.default"},
+				{Name: leaderworkerset.LwsGroupSize, Value: "4"},
+				{Name: leaderworkerset.LwsWorkerIndex, Value: "2"},
+			},
+		},
+		{
+			name:            "all names overridden",
+			networkEnvNames: `{"LWS_GROUP_SIZE":"WORLD_SIZE","LWS_LEADER_ADDRESS":"MASTER_ADDR","LWS_WORKER_INDEX":"RANK"}`,
+			wantEnv: []corev1.EnvVar{
+				{Name: "MASTER_ADDR", Value: "test-sample-1.test-sample.default"},
+				{Name: "WORLD_SIZE", Value: "4"},
+				{Name: "RANK", Value: "2"},
+			},
+		},
+		{
+			name:            "only the size overridden",
+			networkEnvNames: `{"LWS_GROUP_SIZE":"NPROC"}`,
+			wantEnv: []corev1.EnvVar{
+				{Name: leaderworkerset.LwsLeaderAddress, Value: "test-sample-1.test-sample.default"},
+				{Name: "NPROC", Value: "4"},
+				{Name: leaderworkerset.LwsWorkerIndex, Value: "2"},
+			},
+		},
+		{
+			name:            "invalid annotation",
+			networkEnvNames: `LWS_GROUP_SIZE=NPROC`,
+			wantErr:         true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := wrappers.MakePodWithLabels("test-sample", "1", "2", "default", 4)
+			pod.Spec.Subdomain = "test-sample"
+			if tc.networkEnvNames != "" {
+				pod.Annotations[leaderworkerset.NetworkEnvNamesAnnotationKey] = tc.networkEnvNames
+			}
+			err := AddLWSVariables(pod)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("unexpected error, want error: %t, got: %v", tc.wantErr, err)
+			}
+			if tc.wantErr {
+				return
+			}
+			for _, container := range append(pod.Spec.Containers, pod.Spec.InitContainers...) {
+				if diff := cmp.Diff(tc.wantEnv, container.Env[:len(tc.wantEnv)]); diff != "" {
+					t.Errorf("unexpected env vars in container %s (-want,+got):\n%s", container.Name, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestExpandAnnotationPlaceholders(t *testing.T) {
+	tests := []struct {
+		name                string
+		pod                 *corev1.Pod
+		annotations         map[string]string
+		expectedAnnotations map[string]string
+		expectedErr         bool
+	}{
+		{
+			name:        "Leader pod",
+			pod:         wrappers.MakePodWithLabels("test-sample", "2", "0", "default", 3),
+			annotations: map[string]string{"example.com/registration": "group-{{.GroupIndex}}-worker-{{.WorkerIndex}}-of-{{.Size}}"},
+			expectedAnnotations: map[string]string{
+				leaderworkerset.SizeAnnotationKey: "3",
+				"example.com/registration":        "group-2-worker-0-of-3",
+			},
+		},
+		{
+			name:        "Worker pod, placeholders with spaces",
+			pod:         wrappers.MakePodWithLabels("test-sample", "2", "1", "default", 3),
+			annotations: map[string]string{"example.com/registration": "{{ .GroupIndex }}/{{ .WorkerIndex }}"},
+			expectedAnnotations: map[string]string{
+				leaderworkerset.SizeAnnotationKey: "3",
+				"example.com/registration":        "2/1",
+			},
+		},
+		{
+			name:        "No placeholders",
+			pod:         wrappers.MakePodWithLabels("test-sample", "2", "1", "default", 3),
+			annotations: map[string]string{"example.com/registration": "static"},
+			expectedAnnotations: map[string]string{
+				leaderworkerset.SizeAnnotationKey: "3",
+				"example.com/registration":        "static",
+			},
+		},
+		{
+			name:        "Unknown placeholder",
+			pod:         wrappers.MakePodWithLabels("test-sample", "2", "1", "default", 3),
+			annotations: map[string]string{"example.com/registration": "{{.GroupName}}"},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for k, v := range tc.annotations {
+				tc.pod.Annotations[k] = v
+			}
+			err := ExpandAnnotationPlaceholders(tc.pod)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("Expected error %t, got %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr {
+				return
+			}
+			if diff := cmp.Diff(tc.expectedAnnotations, tc.pod.Annotations); diff != "" {
+				t.Errorf("Unexpected annotations (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestExpandContainerPlaceholders(t *testing.T) {
+	tests := []struct {
+		name        string
+		pod         *corev1.Pod
+		container   corev1.Container
+		wantCommand []string
+		wantArgs    []string
+		expectedErr bool
+	}{
+		{
+			name: "Leader pod",
+			pod:  wrappers.MakePodWithLabels("test-sample", "2", "0", "default", 3),
+			container: corev1.Container{
+				Name:    "main",
+				Command: []string{"serve", "--rank={{.WorkerIndex}}"},
+				Args:    []string{"--group={{.GroupIndex}}", "--world-size={{ .Size }}", "--static"},
+			},
+			wantCommand: []string{"serve", "--rank=0"},
+			wantArgs:    []string{"--group=2", "--world-size=3", "--static"},
+		},
+		{
+			name: "Worker pod",
+			pod:  wrappers.MakePodWithLabels("test-sample", "2", "1", "default", 3),
+			container: corev1.Container{
+				Name: "main",
+				Args: []string{"--node-rank={{.WorkerIndex}}", "--nnodes={{.Size}}"},
+			},
+			wantArgs: []string{"--node-rank=1", "--nnodes=3"},
+		},
+		{
+			name: "Unknown placeholder",
+			pod:  wrappers.MakePodWithLabels("test-sample", "2", "1", "default", 3),
+			container: corev1.Container{
+				Name: "main",
+				Args: []string{"--name={{.GroupName}}"},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.pod.Spec.Containers = []corev1.Container{tc.container}
+			tc.pod.Spec.InitContainers = []corev1.Container{*tc.container.DeepCopy()}
+			err := ExpandContainerPlaceholders(tc.pod)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("Expected error %t, got %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr {
+				return
+			}
+			for _, container := range append(tc.pod.Spec.InitContainers, tc.pod.Spec.Containers...) {
+				if diff := cmp.Diff(tc.wantCommand, container.Command); diff != "" {
+					t.Errorf("Unexpected command (-want +got): %s", diff)
+				}
+				if diff := cmp.Diff(tc.wantArgs, container.Args); diff != "" {
+					t.Errorf("Unexpected args (-want +got): %s", diff)
+				}
+			}
+		})
+	}
+}
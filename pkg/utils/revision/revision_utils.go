@@ -23,15 +23,18 @@ import (
 	"fmt"
 	"hash"
 	"hash/fnv"
+	"sort"
 
 	"github.com/davecgh/go-spew/spew"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -90,7 +93,25 @@ func NewRevision(ctx context.Context, k8sClient client.Client, lws *leaderworker
 	return cr, nil
 }
 
+// CreateRevision creates revision, unless an equal revision with the same revision key is kept in the
+// history, e.g. when rolling back to a previous template. The existing revision is then returned with
+// its Revision bumped to the one of revision, the way the StatefulSet history does, so that there is a
+// single revision per revision key.
 func CreateRevision(ctx context.Context, k8sClient client.Client, revision *appsv1.ControllerRevision, lws *leaderworkerset.LeaderWorkerSet) (*appsv1.ControllerRevision, error) {
+	existing, err := GetRevision(ctx, k8sClient, lws, GetRevisionKey(revision))
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && EqualRevision(existing, revision) {
+		if existing.Revision == revision.Revision {
+			return existing, nil
+		}
+		existing.Revision = revision.Revision
+		if err := k8sClient.Update(ctx, existing); err != nil {
+			return nil, err
+		}
+		return existing, nil
+	}
 	if err := k8sClient.Create(ctx, revision); err != nil {
 		return nil, err
 	}
@@ -190,8 +211,27 @@ func EqualRevision(lhs *appsv1.ControllerRevision, rhs *appsv1.ControllerRevisio
 	return bytes.Equal(lhs.Data.Raw, rhs.Data.Raw) && apiequality.Semantic.DeepEqual(lhs.Data.Object, rhs.Data.Object)
 }
 
-// TruncateRevisions cleans up all other controller revisions except the currentRevision.
-// currentRevision is the one that matches the revisionKey that is passed
+// SameRevision returns true if lhs and rhs hash to the same revision, i.e. updating lhs to rhs
+// doesn't trigger a rollout.
+func SameRevision(lhs, rhs *leaderworkerset.LeaderWorkerSet) (bool, error) {
+	lhsPatch, err := getPatch(lhs)
+	if err != nil {
+		return false, err
+	}
+	rhsPatch, err := getPatch(rhs)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(lhsPatch, rhsPatch), nil
+}
+
+// DefaultRevisionHistoryLimit is the number of old revisions kept when the
+// revisionHistoryLimit of the lws is unset.
+const DefaultRevisionHistoryLimit int32 = 10
+
+// TruncateRevisions deletes the oldest controller revisions beyond the revisionHistoryLimit of the lws.
+// The revision that matches the revisionKey, as well as the revisions still referenced by the
+// statefulsets or pods of the lws, are always kept and don't count towards the limit.
 func TruncateRevisions(ctx context.Context, k8sClient client.Client, lws *leaderworkerset.LeaderWorkerSet, revisionKey string) error {
 	selector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{MatchLabels: map[string]string{
 		leaderworkerset.SetNameLabelKey: lws.Name,
@@ -203,17 +243,66 @@ func TruncateRevisions(ctx context.Context, k8sClient client.Client, lws *leader
 	if err != nil {
 		return err
 	}
+	referencedKeys, err := referencedRevisionKeys(ctx, k8sClient, lws)
+	if err != nil {
+		return err
+	}
+	referencedKeys.Insert(revisionKey)
+
+	var history []*appsv1.ControllerRevision
+	for _, revision := range revisions {
+		if !referencedKeys.Has(GetRevisionKey(revision)) {
+			history = append(history, revision)
+		}
+	}
 
-	for i, revision := range revisions {
-		if GetRevisionKey(revision) != revisionKey {
-			if err := k8sClient.Delete(ctx, revisions[i]); err != nil {
-				return err
-			}
+	limit := int(DefaultRevisionHistoryLimit)
+	if lws.Spec.RevisionHistoryLimit != nil {
+		limit = int(*lws.Spec.RevisionHistoryLimit)
+	}
+	if len(history) <= limit {
+		return nil
+	}
+
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].Revision < history[j].Revision
+	})
+	for _, revision := range history[:len(history)-limit] {
+		if err := k8sClient.Delete(ctx, revision); client.IgnoreNotFound(err) != nil {
+			return err
 		}
 	}
 	return nil
 }
 
+// referencedRevisionKeys returns the revision keys of the statefulsets and pods of the lws,
+// the revisions they were created from are still in use.
+func referencedRevisionKeys(ctx context.Context, k8sClient client.Client, lws *leaderworkerset.LeaderWorkerSet) (sets.Set[string], error) {
+	keys := sets.New[string]()
+	matchingLabels := client.MatchingLabels{leaderworkerset.SetNameLabelKey: lws.Name}
+
+	var stsList appsv1.StatefulSetList
+	if err := k8sClient.List(ctx, &stsList, client.InNamespace(lws.Namespace), matchingLabels); err != nil {
+		return nil, err
+	}
+	for i := range stsList.Items {
+		if key := GetRevisionKey(&stsList.Items[i]); key != "" {
+			keys.Insert(key)
+		}
+	}
+
+	var podList corev1.PodList
+	if err := k8sClient.List(ctx, &podList, client.InNamespace(lws.Namespace), matchingLabels); err != nil {
+		return nil, err
+	}
+	for i := range podList.Items {
+		if key := GetRevisionKey(&podList.Items[i]); key != "" {
+			keys.Insert(key)
+		}
+	}
+	return keys, nil
+}
+
 // getPatch returns a strategic merge patch that can be applied to restore a LeaderWorkerSet to a
 // previous version. If the returned error is nil the patch is valid. The current state that we save is the
 // leaderWorkerTemplate and NetworkConfig. We can modify this later to encompass more state (or less) and
@@ -243,8 +332,23 @@ func getPatch(lws *leaderworkerset.LeaderWorkerSet) ([]byte, error) {
 	specCopy := make(map[string]interface{})
 	spec := raw["spec"].(map[string]interface{})
 	networkConfig := spec["networkConfig"].(map[string]interface{})
+	// PerGroupService only drives the per group services, the pods don't depend on it.
+	delete(networkConfig, "perGroupService")
 	specCopy["networkConfig"] = networkConfig
 	template := spec["leaderWorkerTemplate"].(map[string]interface{})
+	// MinReadySeconds only affects how the groups are counted in the status, so changing it
+	// must not create a new revision and trigger a rolling update.
+	delete(template, "minReadySeconds")
+	// Likewise OrderedTermination and ActiveDeadlineSeconds only affect how the groups are deleted.
+	delete(template, "orderedTermination")
+	delete(template, "activeDeadlineSeconds")
+	// FailedGroupRetention and PodFailurePolicy only affect whether the failed groups are recreated,
+	// they're read from the live lws.
+	delete(template, "failedGroupRetention")
+	delete(template, "podFailurePolicy")
+	// PerGroupEnv is applied to the pods of the targeted groups only when they're created, editing
+	// the variables of a group must not roll all of them.
+	delete(template, "perGroupEnv")
 	specCopy["leaderWorkerTemplate"] = template
 	networkConfig["$patch"] = "replace"
 	template["$patch"] = "replace"
@@ -18,11 +18,17 @@ package revision
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	leaderworkerset "sigs.k8s.io/lws/api/leaderworkerset/v1"
 	"sigs.k8s.io/lws/test/wrappers"
@@ -111,6 +117,62 @@ func TestEqualRevision(t *testing.T) {
 			rightRevisionKey: "",
 			equal:            true,
 		},
+		{
+			name:             "same LeaderWorkerTemplate, different minReadySeconds, should be equal",
+			leftLws:          wrappers.BuildLeaderWorkerSet("default").Obj(),
+			rightLws:         wrappers.BuildLeaderWorkerSet("default").MinReadySeconds(30).Obj(),
+			leftRevisionKey:  "",
+			rightRevisionKey: "",
+			equal:            true,
+		},
+		{
+			name:             "same LeaderWorkerTemplate, different orderedTermination, should be equal",
+			leftLws:          wrappers.BuildLeaderWorkerSet("default").Obj(),
+			rightLws:         wrappers.BuildLeaderWorkerSet("default").OrderedTermination(true).Obj(),
+			leftRevisionKey:  "",
+			rightRevisionKey: "",
+			equal:            true,
+		},
+		{
+			name:             "same LeaderWorkerTemplate, different activeDeadlineSeconds, should be equal",
+			leftLws:          wrappers.BuildLeaderWorkerSet("default").Obj(),
+			rightLws:         wrappers.BuildLeaderWorkerSet("default").ActiveDeadlineSeconds(3600).Obj(),
+			leftRevisionKey:  "",
+			rightRevisionKey: "",
+			equal:            true,
+		},
+		{
+			name:             "same LeaderWorkerTemplate, different failedGroupRetention, should be equal",
+			leftLws:          wrappers.BuildLeaderWorkerSet("default").Obj(),
+			rightLws:         wrappers.BuildLeaderWorkerSet("default").FailedGroupRetention(leaderworkerset.RetainFailedGroupRetention).Obj(),
+			leftRevisionKey:  "",
+			rightRevisionKey: "",
+			equal:            true,
+		},
+		{
+			name:             "same LeaderWorkerTemplate, different podFailurePolicy, should be equal",
+			leftLws:          wrappers.BuildLeaderWorkerSet("default").Obj(),
+			rightLws:         wrappers.BuildLeaderWorkerSet("default").PodFailurePolicy(&leaderworkerset.PodFailurePolicy{Rules: []leaderworkerset.PodFailurePolicyRule{{OnExitCodes: leaderworkerset.PodFailurePolicyOnExitCodesRequirement{Operator: leaderworkerset.PodFailurePolicyOnExitCodesOpIn, Values: []int32{42}}}}}).Obj(),
+			leftRevisionKey:  "",
+			rightRevisionKey: "",
+			equal:            true,
+		},
+		{
+			name:             "same LeaderWorkerTemplate, env of group 1 edited, should be equal",
+			leftLws:          wrappers.BuildLeaderWorkerSet("default").PerGroupEnv(0, "MODEL_SHARD", "a").PerGroupEnv(1, "MODEL_SHARD", "b").Obj(),
+			rightLws:         wrappers.BuildLeaderWorkerSet("default").PerGroupEnv(0, "MODEL_SHARD", "a").PerGroupEnv(1, "MODEL_SHARD", "c").Obj(),
+			leftRevisionKey:  "",
+			rightRevisionKey: "",
+			equal:            true,
+		},
+		{
+			name:             "same LeaderWorkerTemplate, different perGroupService, should be equal",
+			leftLws:          wrappers.BuildLeaderWorkerSet("default").Obj(),
+			rightLws:         wrappers.BuildLeaderWorkerSet("default").PerGroupService(true).Obj(),
+			leftRevisionKey:  "",
+			rightRevisionKey: "",
+			equal:            true,
+		},
 		{
 			name:             "left nil, right nil, should be equal",
 			leftLws:          nil,
@@ -224,3 +286,138 @@ func TestGetHighestRevision(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateRevision(t *testing.T) {
+	k8sClient := fake.NewClientBuilder().Build()
+	lws := wrappers.BuildLeaderWorkerSet("default").Obj()
+	updatedLws := wrappers.BuildLeaderWorkerSet("default").WorkerTemplateSpec(wrappers.MakeLeaderPodSpec()).Obj()
+
+	var revisions []*appsv1.ControllerRevision
+	// Update the template and roll it back to the first one.
+	for _, l := range []*leaderworkerset.LeaderWorkerSet{lws, updatedLws, lws} {
+		revision, err := NewRevision(context.TODO(), k8sClient, l, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		revision, err = CreateRevision(context.TODO(), k8sClient, revision, l)
+		if err != nil {
+			t.Fatal(err)
+		}
+		revisions = append(revisions, revision)
+	}
+
+	if revisions[2].Name != revisions[0].Name {
+		t.Errorf("expected the rollback to reuse revision %s, got %s", revisions[0].Name, revisions[2].Name)
+	}
+	if revisions[2].Revision != 3 {
+		t.Errorf("expected the reused revision to be bumped to 3, got %d", revisions[2].Revision)
+	}
+	var revisionList appsv1.ControllerRevisionList
+	if err := k8sClient.List(context.TODO(), &revisionList); err != nil {
+		t.Fatal(err)
+	}
+	if len(revisionList.Items) != 2 {
+		t.Errorf("expected 2 revisions, got %d", len(revisionList.Items))
+	}
+	revision, err := GetRevision(context.TODO(), k8sClient, lws, GetRevisionKey(revisions[0]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if revision.Revision != 3 {
+		t.Errorf("expected the stored revision to be bumped to 3, got %d", revision.Revision)
+	}
+}
+
+func TestTruncateRevisions(t *testing.T) {
+	revisionLabels := func(key string) map[string]string {
+		return map[string]string{
+			leaderworkerset.SetNameLabelKey: "test-sample",
+			leaderworkerset.RevisionKey:     key,
+		}
+	}
+	// rev-1 is the oldest and rev-15 is the latest revision.
+	makeRevisions := func() []client.Object {
+		var objs []client.Object
+		for i := 1; i <= 15; i++ {
+			objs = append(objs, &appsv1.ControllerRevision{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("test-sample-%d", i),
+					Namespace: "default",
+					Labels:    revisionLabels(fmt.Sprintf("rev-%d", i)),
+				},
+				Revision: int64(i),
+			})
+		}
+		return objs
+	}
+
+	tests := []struct {
+		name                 string
+		revisionHistoryLimit *int32
+		objects              []client.Object
+		wantRevisionKeys     []string
+	}{
+		{
+			name:             "unset limit keeps the default number of old revisions",
+			wantRevisionKeys: []string{"rev-5", "rev-6", "rev-7", "rev-8", "rev-9", "rev-10", "rev-11", "rev-12", "rev-13", "rev-14", "rev-15"},
+		},
+		{
+			name:                 "zero limit only keeps the current revision",
+			revisionHistoryLimit: ptr.To[int32](0),
+			wantRevisionKeys:     []string{"rev-15"},
+		},
+		{
+			name:                 "revisions referenced by statefulsets and pods are kept",
+			revisionHistoryLimit: ptr.To[int32](2),
+			objects: []client.Object{
+				&appsv1.StatefulSet{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-sample", Namespace: "default", Labels: revisionLabels("rev-14")},
+				},
+				&appsv1.StatefulSet{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-sample-0", Namespace: "default", Labels: revisionLabels("rev-3")},
+				},
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-sample-1", Namespace: "default", Labels: revisionLabels("rev-7")},
+				},
+			},
+			wantRevisionKeys: []string{"rev-3", "rev-7", "rev-12", "rev-13", "rev-14", "rev-15"},
+		},
+		{
+			name:                 "objects of other leaderworkersets don't reference revisions",
+			revisionHistoryLimit: ptr.To[int32](1),
+			objects: []client.Object{
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: "other-0", Namespace: "default", Labels: map[string]string{
+						leaderworkerset.SetNameLabelKey: "other",
+						leaderworkerset.RevisionKey:     "rev-2",
+					}},
+				},
+			},
+			wantRevisionKeys: []string{"rev-14", "rev-15"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sClient := fake.NewClientBuilder().WithObjects(append(makeRevisions(), tc.objects...)...).Build()
+			lws := wrappers.BuildLeaderWorkerSet("default").Obj()
+			lws.Spec.RevisionHistoryLimit = tc.revisionHistoryLimit
+
+			if err := TruncateRevisions(context.TODO(), k8sClient, lws, "rev-15"); err != nil {
+				t.Fatal(err)
+			}
+
+			var revisionList appsv1.ControllerRevisionList
+			if err := k8sClient.List(context.TODO(), &revisionList); err != nil {
+				t.Fatal(err)
+			}
+			var gotRevisionKeys []string
+			for i := range revisionList.Items {
+				gotRevisionKeys = append(gotRevisionKeys, GetRevisionKey(&revisionList.Items[i]))
+			}
+			if diff := cmp.Diff(tc.wantRevisionKeys, gotRevisionKeys, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("unexpected revisions (-want +got): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollout
+
+import (
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	leaderworkerset "sigs.k8s.io/lws/api/leaderworkerset/v1"
+	revisionutils "sigs.k8s.io/lws/pkg/utils/revision"
+)
+
+// MaxSurge returns the number of groups that can be created above replicas during a rolling
+// update. Percentages are rounded up unless the roundingPolicy is Floor, and it is never greater
+// than replicas.
+func MaxSurge(config *leaderworkerset.RollingUpdateConfiguration, replicas int32) (int32, error) {
+	if config == nil {
+		return 0, nil
+	}
+	maxSurge, err := scaledValue(config.MaxSurge, replicas, config.RoundingPolicy, true)
+	if err != nil {
+		return 0, err
+	}
+	// No need to burst more than the replicas.
+	return min(maxSurge, replicas), nil
+}
+
+// MaxUnavailable returns the number of groups that can be unavailable during a rolling update.
+// Percentages are rounded down unless the roundingPolicy is Ceil.
+func MaxUnavailable(config *leaderworkerset.RollingUpdateConfiguration, replicas int32) (int32, error) {
+	if config == nil {
+		return 0, nil
+	}
+	return scaledValue(config.MaxUnavailable, replicas, config.RoundingPolicy, false)
+}
+
+// scaledValue returns the absolute number of intOrPercent, where a percentage is of total and
+// rounded following the policy, or rounded up if roundUp when no policy is set.
+func scaledValue(intOrPercent intstr.IntOrString, total int32, policy leaderworkerset.RolloutRoundingPolicy, roundUp bool) (int32, error) {
+	switch policy {
+	case leaderworkerset.FloorRolloutRoundingPolicy:
+		roundUp = false
+	case leaderworkerset.CeilRolloutRoundingPolicy:
+		roundUp = true
+	}
+	value, err := intstr.GetScaledValueFromIntOrPercent(&intOrPercent, int(total), roundUp)
+	if err != nil {
+		return 0, err
+	}
+	return int32(value), nil
+}
+
+// SubGroupsPerGroup returns the number of subgroups in each group of lws, or 1 when it has no
+// subgroups. When size - 1 is divisible by subGroupSize, the leader is either excluded or joins
+// the first subgroup, otherwise size is divisible by subGroupSize.
+func SubGroupsPerGroup(lws *leaderworkerset.LeaderWorkerSet) int32 {
+	policy := lws.Spec.LeaderWorkerTemplate.SubGroupPolicy
+	if policy == nil || policy.SubGroupSize == nil || *policy.SubGroupSize <= 0 {
+		return 1
+	}
+	size, subGroupSize := ptr.Deref(lws.Spec.LeaderWorkerTemplate.Size, 1), *policy.SubGroupSize
+	if (size-1)%subGroupSize == 0 {
+		return max(1, (size-1)/subGroupSize)
+	}
+	return max(1, size/subGroupSize)
+}
+
+// MaxUnavailableGroups returns the number of groups of lws that can be unavailable during a
+// rolling update. With the SubGroup rollout granularity, maxUnavailable counts subgroups, so its
+// percentages are computed against the total number of subgroups, and it's rounded down to whole
+// groups, since a group is recreated as a whole, but never below one unless it is 0.
+func MaxUnavailableGroups(lws *leaderworkerset.LeaderWorkerSet, replicas int32) (int32, error) {
+	config := lws.Spec.RolloutStrategy.RollingUpdateConfiguration
+	if config == nil || config.Granularity != leaderworkerset.SubGroupRolloutGranularity {
+		return MaxUnavailable(config, replicas)
+	}
+	subGroups := SubGroupsPerGroup(lws)
+	maxUnavailable, err := MaxUnavailable(config, replicas*subGroups)
+	if err != nil || maxUnavailable == 0 {
+		return 0, err
+	}
+	return max(1, maxUnavailable/subGroups), nil
+}
+
+// Plan summarizes how the groups are reconciled when a LeaderWorkerSet is updated.
+type Plan struct {
+	// CreatedGroups is the number of groups created, including the surge groups.
+	CreatedGroups int32
+	// DeletedGroups is the number of groups deleted, including the surge groups.
+	DeletedGroups int32
+	// SurgeGroups is the number of groups created above replicas during the rolling update.
+	SurgeGroups int32
+	// MaxUnavailableGroups is the number of groups that can be unavailable at a time during the
+	// rolling update.
+	MaxUnavailableGroups int32
+}
+
+// ComputePlan returns the plan to reconcile the groups of oldLws, as observed in its status,
+// with the spec of newLws. Groups which are updated are deleted and created again, so they're
+// counted in both CreatedGroups and DeletedGroups.
+func ComputePlan(oldLws, newLws *leaderworkerset.LeaderWorkerSet) (Plan, error) {
+	var plan Plan
+	currentReplicas := oldLws.Status.Replicas
+	replicas := ptr.Deref(newLws.Spec.Replicas, 1)
+	if replicas > currentReplicas {
+		plan.CreatedGroups = replicas - currentReplicas
+	} else {
+		plan.DeletedGroups = currentReplicas - replicas
+	}
+
+	// Only the fields hashed into the revision trigger a rollout.
+	sameRevision, err := revisionutils.SameRevision(oldLws, newLws)
+	if err != nil {
+		return Plan{}, err
+	}
+	if sameRevision {
+		return plan, nil
+	}
+
+	if newLws.Spec.RolloutStrategy.Type == leaderworkerset.RecreateStrategyType {
+		return Plan{CreatedGroups: replicas, DeletedGroups: currentReplicas, MaxUnavailableGroups: replicas}, nil
+	}
+
+	config := newLws.Spec.RolloutStrategy.RollingUpdateConfiguration
+	var partition int32
+	if config != nil {
+		partition = ptr.Deref(config.Partition, 0)
+	}
+	updatedGroups := max(0, min(currentReplicas, replicas)-partition)
+	maxSurge, err := MaxSurge(config, replicas)
+	if err != nil {
+		return Plan{}, err
+	}
+	maxUnavailable, err := MaxUnavailableGroups(newLws, replicas)
+	if err != nil {
+		return Plan{}, err
+	}
+	plan.SurgeGroups = min(maxSurge, updatedGroups)
+	plan.MaxUnavailableGroups = maxUnavailable
+	plan.CreatedGroups += updatedGroups + plan.SurgeGroups
+	plan.DeletedGroups += updatedGroups + plan.SurgeGroups
+	return plan, nil
+}
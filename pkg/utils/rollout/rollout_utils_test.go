@@ -0,0 +1,384 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollout
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	leaderworkerset "sigs.k8s.io/lws/api/leaderworkerset/v1"
+)
+
+func TestMaxSurgeAndMaxUnavailable(t *testing.T) {
+	tests := []struct {
+		name               string
+		config             *leaderworkerset.RollingUpdateConfiguration
+		replicas           int32
+		wantMaxSurge       int32
+		wantMaxUnavailable int32
+		wantErr            bool
+	}{
+		{
+			name:     "nil configuration",
+			replicas: 4,
+		},
+		{
+			name: "integer values",
+			config: &leaderworkerset.RollingUpdateConfiguration{
+				MaxSurge:       intstr.FromInt32(2),
+				MaxUnavailable: intstr.FromInt32(1),
+			},
+			replicas:           4,
+			wantMaxSurge:       2,
+			wantMaxUnavailable: 1,
+		},
+		{
+			name: "percentages round maxSurge up and maxUnavailable down",
+			config: &leaderworkerset.RollingUpdateConfiguration{
+				MaxSurge:       intstr.FromString("30%"),
+				MaxUnavailable: intstr.FromString("30%"),
+			},
+			replicas:           5,
+			wantMaxSurge:       2,
+			wantMaxUnavailable: 1,
+		},
+		{
+			name: "percentages rounded down with the Floor policy",
+			config: &leaderworkerset.RollingUpdateConfiguration{
+				MaxSurge:       intstr.FromString("33%"),
+				MaxUnavailable: intstr.FromString("33%"),
+				RoundingPolicy: leaderworkerset.FloorRolloutRoundingPolicy,
+			},
+			replicas:           10,
+			wantMaxSurge:       3,
+			wantMaxUnavailable: 3,
+		},
+		{
+			name: "percentages rounded up with the Ceil policy",
+			config: &leaderworkerset.RollingUpdateConfiguration{
+				MaxSurge:       intstr.FromString("33%"),
+				MaxUnavailable: intstr.FromString("33%"),
+				RoundingPolicy: leaderworkerset.CeilRolloutRoundingPolicy,
+			},
+			replicas:           10,
+			wantMaxSurge:       4,
+			wantMaxUnavailable: 4,
+		},
+		{
+			name: "maxSurge is capped at replicas",
+			config: &leaderworkerset.RollingUpdateConfiguration{
+				MaxSurge: intstr.FromInt32(10),
+			},
+			replicas:     3,
+			wantMaxSurge: 3,
+		},
+		{
+			name: "invalid percentage",
+			config: &leaderworkerset.RollingUpdateConfiguration{
+				MaxSurge:       intstr.FromString("foo"),
+				MaxUnavailable: intstr.FromString("foo"),
+			},
+			replicas: 3,
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			maxSurge, err := MaxSurge(tc.config, tc.replicas)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("unexpected maxSurge error, want error: %t, got: %v", tc.wantErr, err)
+			}
+			if maxSurge != tc.wantMaxSurge {
+				t.Errorf("unexpected maxSurge, want: %d, got: %d", tc.wantMaxSurge, maxSurge)
+			}
+			maxUnavailable, err := MaxUnavailable(tc.config, tc.replicas)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("unexpected maxUnavailable error, want error: %t, got: %v", tc.wantErr, err)
+			}
+			if maxUnavailable != tc.wantMaxUnavailable {
+				t.Errorf("unexpected maxUnavailable, want: %d, got: %d", tc.wantMaxUnavailable, maxUnavailable)
+			}
+		})
+	}
+}
+
+func TestScaledValue(t *testing.T) {
+	tests := []struct {
+		name         string
+		intOrPercent intstr.IntOrString
+		policy       leaderworkerset.RolloutRoundingPolicy
+		roundUp      bool
+		want         int32
+	}{
+		{
+			name:         "33% of 10 without policy, rounded down",
+			intOrPercent: intstr.FromString("33%"),
+			want:         3,
+		},
+		{
+			name:         "33% of 10 without policy, rounded up",
+			intOrPercent: intstr.FromString("33%"),
+			roundUp:      true,
+			want:         4,
+		},
+		{
+			name:         "33% of 10 with the Floor policy",
+			intOrPercent: intstr.FromString("33%"),
+			policy:       leaderworkerset.FloorRolloutRoundingPolicy,
+			roundUp:      true,
+			want:         3,
+		},
+		{
+			name:         "33% of 10 with the Ceil policy",
+			intOrPercent: intstr.FromString("33%"),
+			policy:       leaderworkerset.CeilRolloutRoundingPolicy,
+			want:         4,
+		},
+		{
+			name:         "30% of 10 with the Ceil policy",
+			intOrPercent: intstr.FromString("30%"),
+			policy:       leaderworkerset.CeilRolloutRoundingPolicy,
+			want:         3,
+		},
+		{
+			name:         "absolute numbers aren't rounded",
+			intOrPercent: intstr.FromInt32(7),
+			policy:       leaderworkerset.CeilRolloutRoundingPolicy,
+			want:         7,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := scaledValue(tc.intOrPercent, 10, tc.policy, tc.roundUp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("unexpected value, want: %d, got: %d", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestMaxUnavailableGroups(t *testing.T) {
+	tests := []struct {
+		name           string
+		size           int32
+		subGroupPolicy *leaderworkerset.SubGroupPolicy
+		granularity    leaderworkerset.RolloutGranularity
+		maxUnavailable intstr.IntOrString
+		want           int32
+	}{
+		{
+			name:           "group granularity ignores subgroups",
+			size:           8,
+			subGroupPolicy: &leaderworkerset.SubGroupPolicy{SubGroupSize: ptr.To[int32](4)},
+			granularity:    leaderworkerset.GroupRolloutGranularity,
+			maxUnavailable: intstr.FromString("50%"),
+			want:           2,
+		},
+		{
+			name:           "size 8 with subgroups of 4, 50% of the subgroups",
+			size:           8,
+			subGroupPolicy: &leaderworkerset.SubGroupPolicy{SubGroupSize: ptr.To[int32](4)},
+			granularity:    leaderworkerset.SubGroupRolloutGranularity,
+			maxUnavailable: intstr.FromString("50%"),
+			want:           2,
+		},
+		{
+			name:           "size 8 with subgroups of 4, a single subgroup is rounded up to a group",
+			size:           8,
+			subGroupPolicy: &leaderworkerset.SubGroupPolicy{SubGroupSize: ptr.To[int32](4)},
+			granularity:    leaderworkerset.SubGroupRolloutGranularity,
+			maxUnavailable: intstr.FromInt32(1),
+			want:           1,
+		},
+		{
+			name:           "size 8 with subgroups of 4, 0 subgroups",
+			size:           8,
+			subGroupPolicy: &leaderworkerset.SubGroupPolicy{SubGroupSize: ptr.To[int32](4)},
+			granularity:    leaderworkerset.SubGroupRolloutGranularity,
+			maxUnavailable: intstr.FromInt32(0),
+			want:           0,
+		},
+		{
+			name: "size 9 with subgroups of 4 excluding the leader",
+			size: 9,
+			subGroupPolicy: &leaderworkerset.SubGroupPolicy{
+				Type:         ptr.To(leaderworkerset.SubGroupPolicyTypeLeaderExcluded),
+				SubGroupSize: ptr.To[int32](4),
+			},
+			granularity:    leaderworkerset.SubGroupRolloutGranularity,
+			maxUnavailable: intstr.FromString("75%"),
+			want:           3,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := &leaderworkerset.LeaderWorkerSet{
+				Spec: leaderworkerset.LeaderWorkerSetSpec{
+					LeaderWorkerTemplate: leaderworkerset.LeaderWorkerTemplate{
+						Size:           ptr.To(tc.size),
+						SubGroupPolicy: tc.subGroupPolicy,
+					},
+					RolloutStrategy: leaderworkerset.RolloutStrategy{
+						RollingUpdateConfiguration: &leaderworkerset.RollingUpdateConfiguration{
+							MaxUnavailable: tc.maxUnavailable,
+							Granularity:    tc.granularity,
+						},
+					},
+				},
+			}
+			got, err := MaxUnavailableGroups(lws, 4)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("unexpected maxUnavailable groups, want: %d, got: %d", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestComputePlan(t *testing.T) {
+	rollingUpdate := func(maxUnavailable, maxSurge int32, partition *int32) leaderworkerset.RolloutStrategy {
+		return leaderworkerset.RolloutStrategy{
+			Type: leaderworkerset.RollingUpdateStrategyType,
+			RollingUpdateConfiguration: &leaderworkerset.RollingUpdateConfiguration{
+				MaxUnavailable: intstr.FromInt32(maxUnavailable),
+				MaxSurge:       intstr.FromInt32(maxSurge),
+				Partition:      partition,
+			},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		currentReplicas int32
+		replicas        int32
+		templateUpdated bool
+		// unhashedUpdate updates a field of the template which isn't hashed into the revision.
+		unhashedUpdate  bool
+		rolloutStrategy leaderworkerset.RolloutStrategy
+		want            Plan
+	}{
+		{
+			name:            "no change",
+			currentReplicas: 4,
+			replicas:        4,
+			rolloutStrategy: rollingUpdate(1, 0, nil),
+		},
+		{
+			name:            "scale up",
+			currentReplicas: 2,
+			replicas:        4,
+			rolloutStrategy: rollingUpdate(1, 0, nil),
+			want:            Plan{CreatedGroups: 2},
+		},
+		{
+			name:            "scale down",
+			currentReplicas: 4,
+			replicas:        1,
+			rolloutStrategy: rollingUpdate(1, 0, nil),
+			want:            Plan{DeletedGroups: 3},
+		},
+		{
+			name:            "update of a field not hashed into the revision",
+			currentReplicas: 4,
+			replicas:        4,
+			unhashedUpdate:  true,
+			rolloutStrategy: rollingUpdate(1, 0, nil),
+		},
+		{
+			name:            "rolling update with maxUnavailable",
+			currentReplicas: 4,
+			replicas:        4,
+			templateUpdated: true,
+			rolloutStrategy: rollingUpdate(2, 0, nil),
+			want:            Plan{CreatedGroups: 4, DeletedGroups: 4, MaxUnavailableGroups: 2},
+		},
+		{
+			name:            "rolling update with maxSurge",
+			currentReplicas: 4,
+			replicas:        4,
+			templateUpdated: true,
+			rolloutStrategy: rollingUpdate(1, 2, nil),
+			want:            Plan{CreatedGroups: 6, DeletedGroups: 6, SurgeGroups: 2, MaxUnavailableGroups: 1},
+		},
+		{
+			name:            "rolling update with partition",
+			currentReplicas: 4,
+			replicas:        4,
+			templateUpdated: true,
+			rolloutStrategy: rollingUpdate(1, 2, ptr.To[int32](3)),
+			want:            Plan{CreatedGroups: 2, DeletedGroups: 2, SurgeGroups: 1, MaxUnavailableGroups: 1},
+		},
+		{
+			name:            "rolling update together with scale up",
+			currentReplicas: 2,
+			replicas:        4,
+			templateUpdated: true,
+			rolloutStrategy: rollingUpdate(1, 1, nil),
+			want:            Plan{CreatedGroups: 5, DeletedGroups: 3, SurgeGroups: 1, MaxUnavailableGroups: 1},
+		},
+		{
+			name:            "recreate",
+			currentReplicas: 4,
+			replicas:        3,
+			templateUpdated: true,
+			rolloutStrategy: leaderworkerset.RolloutStrategy{Type: leaderworkerset.RecreateStrategyType},
+			want:            Plan{CreatedGroups: 3, DeletedGroups: 4, MaxUnavailableGroups: 3},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			oldLws := &leaderworkerset.LeaderWorkerSet{
+				Spec: leaderworkerset.LeaderWorkerSetSpec{
+					Replicas: ptr.To(tc.currentReplicas),
+					LeaderWorkerTemplate: leaderworkerset.LeaderWorkerTemplate{
+						Size: ptr.To[int32](2),
+					},
+					RolloutStrategy: tc.rolloutStrategy,
+				},
+				Status: leaderworkerset.LeaderWorkerSetStatus{Replicas: tc.currentReplicas},
+			}
+			newLws := oldLws.DeepCopy()
+			newLws.Spec.Replicas = ptr.To(tc.replicas)
+			if tc.templateUpdated {
+				newLws.Spec.LeaderWorkerTemplate.WorkerTemplate.Labels = map[string]string{"foo": "bar"}
+			}
+			if tc.unhashedUpdate {
+				newLws.Spec.LeaderWorkerTemplate.MinReadySeconds = 30
+			}
+
+			got, err := ComputePlan(oldLws, newLws)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected plan (-want +got): %s", diff)
+			}
+		})
+	}
+}
@@ -19,30 +19,76 @@ package webhooks
 import (
 	"context"
 	"fmt"
+	"maps"
 	"math"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	v1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
+	controllerutils "sigs.k8s.io/lws/pkg/utils/controller"
+	podutils "sigs.k8s.io/lws/pkg/utils/pod"
+	rolloututils "sigs.k8s.io/lws/pkg/utils/rollout"
 )
 
-type LeaderWorkerSetWebhook struct{}
+type LeaderWorkerSetWebhook struct {
+	// MaxReplicasPerLws is the cluster-wide maximum of replicas of a LeaderWorkerSet,
+	// 0 means no limit.
+	MaxReplicasPerLws int32
+	// RequireLeaderReadinessProbe rejects the LeaderWorkerSets with the LeaderReady startup
+	// policy whose leader has no readiness probe, instead of only warning about them.
+	RequireLeaderReadinessProbe bool
+	// RequireLeaderRestartPolicyAlways rejects the LeaderWorkerSets with the LeaderReady startup
+	// policy whose leader doesn't restart its containers, instead of only warning about them.
+	RequireLeaderRestartPolicyAlways bool
+	// NodeReader lists the nodes to warn about exclusive topology keys no node carries,
+	// nil disables the check.
+	NodeReader client.Reader
+	// ServiceAccountReader gets the ServiceAccounts overriding the ones of the templates to warn
+	// about the missing ones, nil disables the check.
+	ServiceAccountReader client.Reader
+	// RevisionReader lists the ControllerRevisions of the LeaderWorkerSet to reject the pin-group
+	// annotations pointing at unknown revisions, nil disables the check.
+	RevisionReader client.Reader
+}
+
+//+kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get
 
 // SetupLeaderWorkerSetWebhook will setup the manager to manage the webhooks
-func SetupLeaderWorkerSetWebhook(mgr ctrl.Manager) error {
+func SetupLeaderWorkerSetWebhook(mgr ctrl.Manager, maxReplicasPerLws int32, requireLeaderReadinessProbe, requireLeaderRestartPolicyAlways bool) error {
+	wh := &LeaderWorkerSetWebhook{
+		MaxReplicasPerLws:                maxReplicasPerLws,
+		RequireLeaderReadinessProbe:      requireLeaderReadinessProbe,
+		RequireLeaderRestartPolicyAlways: requireLeaderRestartPolicyAlways,
+		NodeReader:                       mgr.GetCache(),
+		ServiceAccountReader:             mgr.GetAPIReader(),
+		RevisionReader:                   mgr.GetAPIReader(),
+	}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&v1.LeaderWorkerSet{}).
-		WithDefaulter(&LeaderWorkerSetWebhook{}).
-		WithValidator(&LeaderWorkerSetWebhook{}).
+		WithDefaulter(wh).
+		WithValidator(wh).
 		Complete()
 }
 
@@ -61,15 +107,18 @@ func (r *LeaderWorkerSetWebhook) Default(ctx context.Context, obj runtime.Object
 		lws.Spec.LeaderWorkerTemplate.RestartPolicy = v1.NoneRestartPolicy
 	}
 
-	if lws.Spec.RolloutStrategy.Type == "" {
-		lws.Spec.RolloutStrategy.Type = v1.RollingUpdateStrategyType
+	if lws.Spec.LeaderWorkerTemplate.FailedGroupRetention == "" {
+		lws.Spec.LeaderWorkerTemplate.FailedGroupRetention = v1.RecreateFailedGroupRetention
 	}
 
-	if lws.Spec.RolloutStrategy.Type == v1.RollingUpdateStrategyType && lws.Spec.RolloutStrategy.RollingUpdateConfiguration == nil {
-		lws.Spec.RolloutStrategy.RollingUpdateConfiguration = &v1.RollingUpdateConfiguration{
-			MaxUnavailable: intstr.FromInt32(1),
-			MaxSurge:       intstr.FromInt32(0),
-		}
+	defaultRolloutStrategy(&lws.Spec.RolloutStrategy)
+
+	if lws.Spec.ScaleDownPolicy == "" {
+		lws.Spec.ScaleDownPolicy = v1.HighestIndexFirstScaleDownPolicy
+	}
+
+	if lws.Spec.Suspend == nil {
+		lws.Spec.Suspend = ptr.To(false)
 	}
 
 	if lws.Spec.NetworkConfig == nil {
@@ -86,6 +135,23 @@ func (r *LeaderWorkerSetWebhook) Default(ctx context.Context, obj runtime.Object
 	return nil
 }
 
+// defaultRolloutStrategy fills in the rollout strategy when it is omitted. An explicitly
+// configured rollingUpdateConfiguration is never replaced as a whole, the omitted
+// maxUnavailable and maxSurge fields are defaulted by the CRD schema before the
+// webhook is called.
+func defaultRolloutStrategy(strategy *v1.RolloutStrategy) {
+	if strategy.Type == "" {
+		strategy.Type = v1.RollingUpdateStrategyType
+	}
+
+	if strategy.Type == v1.RollingUpdateStrategyType && strategy.RollingUpdateConfiguration == nil {
+		strategy.RollingUpdateConfiguration = &v1.RollingUpdateConfiguration{
+			MaxUnavailable: intstr.FromInt32(1),
+			MaxSurge:       intstr.FromInt32(0),
+		}
+	}
+}
+
 //+kubebuilder:webhook:path=/validate-leaderworkerset-x-k8s-io-v1-leaderworkerset,mutating=false,failurePolicy=fail,sideEffects=None,groups=leaderworkerset.x-k8s.io,resources=leaderworkersets,verbs=create;update,versions=v1,name=vleaderworkerset.kb.io,admissionReviewVersions=v1
 
 var _ webhook.CustomValidator = &LeaderWorkerSetWebhook{}
@@ -93,7 +159,18 @@ var _ webhook.CustomValidator = &LeaderWorkerSetWebhook{}
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *LeaderWorkerSetWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	allErrs := r.generalValidate(obj)
-	return nil, allErrs.ToAggregate()
+	lws := obj.(*v1.LeaderWorkerSet)
+	allErrs = append(allErrs, r.validateReplicasLimit(lws, field.NewPath("spec", "replicas"))...)
+	allErrs = append(allErrs, validateSizeWithLeaderTemplate(field.NewPath("spec", "leaderWorkerTemplate", "size"), lws)...)
+	allErrs = append(allErrs, r.validatePinGroupRevisions(ctx, field.NewPath("metadata", "annotations"), nil, lws)...)
+	probeErrs, probeWarnings := r.validateLeaderReadinessProbe(lws)
+	allErrs = append(allErrs, probeErrs...)
+	restartPolicyErrs, restartPolicyWarnings := r.validateLeaderRestartPolicy(lws)
+	allErrs = append(allErrs, restartPolicyErrs...)
+	warnings := append(resourceWarnings(lws), probeWarnings...)
+	warnings = append(warnings, restartPolicyWarnings...)
+	warnings = append(warnings, r.exclusiveTopologyWarnings(ctx, lws)...)
+	return append(warnings, r.serviceAccountWarnings(ctx, lws)...), allErrs.ToAggregate()
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -103,7 +180,19 @@ func (r *LeaderWorkerSetWebhook) ValidateUpdate(ctx context.Context, oldObj, new
 
 	oldLws := oldObj.(*v1.LeaderWorkerSet)
 	newLws := newObj.(*v1.LeaderWorkerSet)
-	allErrs = append(allErrs, apivalidation.ValidateImmutableField(*newLws.Spec.LeaderWorkerTemplate.Size, *oldLws.Spec.LeaderWorkerTemplate.Size, field.NewPath("spec", "leaderWorkerTemplate", "size"))...)
+	allErrs = append(allErrs, validateSizeUpdate(oldLws, newLws, specPath.Child("leaderWorkerTemplate", "size"))...)
+	allErrs = append(allErrs, r.validatePinGroupRevisions(ctx, field.NewPath("metadata", "annotations"), oldLws, newLws)...)
+	allErrs = append(allErrs, validateLeaderTemplateUpdate(oldLws, newLws, specPath.Child("leaderWorkerTemplate", "leaderTemplate"))...)
+	// A LeaderWorkerSet created with size 1 and a leaderTemplate before this was rejected can't drop
+	// its leaderTemplate, so only reject the combination when the update introduces it.
+	if len(validateSizeWithLeaderTemplate(nil, oldLws)) == 0 {
+		allErrs = append(allErrs, validateSizeWithLeaderTemplate(specPath.Child("leaderWorkerTemplate", "size"), newLws)...)
+	}
+	// Only check the limit when scaling up, so that a LeaderWorkerSet created before the limit
+	// was lowered can still be updated or scaled down.
+	if totalReplicas(newLws) > totalReplicas(oldLws) {
+		allErrs = append(allErrs, r.validateReplicasLimit(newLws, specPath.Child("replicas"))...)
+	}
 	if newLws.Spec.LeaderWorkerTemplate.SubGroupPolicy != nil && oldLws.Spec.LeaderWorkerTemplate.SubGroupPolicy != nil {
 		allErrs = append(allErrs, apivalidation.ValidateImmutableField(*newLws.Spec.LeaderWorkerTemplate.SubGroupPolicy.SubGroupSize, *oldLws.Spec.LeaderWorkerTemplate.SubGroupPolicy.SubGroupSize, field.NewPath("spec", "leaderWorkerTemplate", "SubGroupPolicy", "subGroupSize"))...)
 	}
@@ -113,11 +202,186 @@ func (r *LeaderWorkerSetWebhook) ValidateUpdate(ctx context.Context, oldObj, new
 	if newLws.Spec.LeaderWorkerTemplate.SubGroupPolicy == nil && oldLws.Spec.LeaderWorkerTemplate.SubGroupPolicy != nil {
 		allErrs = append(allErrs, field.Invalid(specPath.Child("leaderWorkerTemplate", "SubGroupPolicy", "subGroupSize"), oldLws.Spec.LeaderWorkerTemplate.SubGroupPolicy.SubGroupSize, "cannot remove subGroupSize after enabled"))
 	}
+	if oldLws.Spec.NetworkConfig != nil && newLws.Spec.NetworkConfig != nil {
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newLws.Spec.NetworkConfig.HostnamePrefix, oldLws.Spec.NetworkConfig.HostnamePrefix, specPath.Child("networkConfig", "hostnamePrefix"))...)
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newLws.Spec.NetworkConfig.StartIndex, oldLws.Spec.NetworkConfig.StartIndex, specPath.Child("networkConfig", "startIndex"))...)
+	}
 	if newLws.Spec.NetworkConfig != nil && newLws.Spec.NetworkConfig.SubdomainPolicy == nil {
 		allErrs = append(allErrs, field.Invalid(specPath.Child("networkConfig", "subdomainPolicy"), oldLws.Spec.NetworkConfig.SubdomainPolicy, "cannot set subdomainPolicy as null"))
 	}
+	allErrs = append(allErrs, validateSubdomainPolicyUpdate(oldLws, newLws, specPath.Child("networkConfig", "subdomainPolicy"))...)
+	allErrs = append(allErrs, validateVolumeClaimTemplatesUpdate(oldLws, newLws, specPath.Child("leaderWorkerTemplate"))...)
+	allErrs = append(allErrs, validateNetworkEnvNamesUpdate(oldLws, newLws, specPath.Child("leaderWorkerTemplate", "networkEnvNames"))...)
+	probeErrs, probeWarnings := r.validateLeaderReadinessProbe(newLws)
+	allErrs = append(allErrs, probeErrs...)
+	restartPolicyErrs, restartPolicyWarnings := r.validateLeaderRestartPolicy(newLws)
+	allErrs = append(allErrs, restartPolicyErrs...)
+	if len(allErrs) > 0 {
+		return nil, allErrs.ToAggregate()
+	}
+
+	warnings := append(resourceWarnings(newLws), probeWarnings...)
+	warnings = append(warnings, restartPolicyWarnings...)
+	warnings = append(warnings, r.exclusiveTopologyWarnings(ctx, newLws)...)
+	warnings = append(warnings, r.serviceAccountWarnings(ctx, newLws)...)
+	if _, ok := newLws.Annotations[v1.DryRunPlanAnnotationKey]; ok {
+		warnings = append(warnings, dryRunPlanWarning(oldLws, newLws))
+	}
+	return warnings, nil
+}
+
+// exclusiveTopologyWarnings warns when exclusive placement is enabled with a topology key that no
+// node carries, the groups would stay pending until such nodes join the cluster. The check is best
+// effort, the LeaderWorkerSet is admitted without a warning when the nodes can't be listed.
+func (r *LeaderWorkerSetWebhook) exclusiveTopologyWarnings(ctx context.Context, lws *v1.LeaderWorkerSet) admission.Warnings {
+	topologyKey := controllerutils.ExclusiveTopologyKey(lws)
+	if r.NodeReader == nil || topologyKey == "" {
+		return nil
+	}
+	var nodes corev1.NodeList
+	if err := r.NodeReader.List(ctx, &nodes, client.HasLabels{topologyKey}); err != nil {
+		logf.FromContext(ctx).Error(err, "Listing the nodes with the exclusive topology key", "topologyKey", topologyKey)
+		return nil
+	}
+	if len(nodes.Items) > 0 {
+		return nil
+	}
+	return admission.Warnings{fmt.Sprintf("no node in the cluster has the exclusive topology key %q, the groups won't be scheduled until such nodes are added", topologyKey)}
+}
+
+// serviceAccountWarnings warns about the leaderServiceAccountName and workerServiceAccountName
+// that don't exist in the namespace of the LeaderWorkerSet, the pods would be rejected until the
+// ServiceAccounts are created. Like for the exclusive topology, the check is best effort.
+func (r *LeaderWorkerSetWebhook) serviceAccountWarnings(ctx context.Context, lws *v1.LeaderWorkerSet) admission.Warnings {
+	if r.ServiceAccountReader == nil {
+		return nil
+	}
+	var warnings admission.Warnings
+	for _, sa := range []struct{ field, name string }{
+		{field: "leaderServiceAccountName", name: lws.Spec.LeaderWorkerTemplate.LeaderServiceAccountName},
+		{field: "workerServiceAccountName", name: lws.Spec.LeaderWorkerTemplate.WorkerServiceAccountName},
+	} {
+		if sa.name == "" {
+			continue
+		}
+		var serviceAccount corev1.ServiceAccount
+		err := r.ServiceAccountReader.Get(ctx, types.NamespacedName{Namespace: lws.Namespace, Name: sa.name}, &serviceAccount)
+		if apierrors.IsNotFound(err) {
+			warnings = append(warnings, fmt.Sprintf("%s: ServiceAccount %q not found, the pods won't be created until it is", field.NewPath("spec", "leaderWorkerTemplate", sa.field), sa.name))
+		} else if err != nil {
+			logf.FromContext(ctx).Error(err, "Getting the ServiceAccount", "serviceAccount", klog.KRef(lws.Namespace, sa.name))
+		}
+	}
+	return warnings
+}
 
-	return nil, allErrs.ToAggregate()
+// resourceWarnings warns about the containers of the templates whose resource requests exceed their
+// limits, the pods would only be rejected once created otherwise.
+func resourceWarnings(lws *v1.LeaderWorkerSet) admission.Warnings {
+	var warnings admission.Warnings
+	templatePath := field.NewPath("spec", "leaderWorkerTemplate")
+	if lws.Spec.LeaderWorkerTemplate.LeaderTemplate != nil {
+		warnings = append(warnings, requestsExceedingLimits(templatePath.Child("leaderTemplate", "spec"), &lws.Spec.LeaderWorkerTemplate.LeaderTemplate.Spec)...)
+	}
+	warnings = append(warnings, requestsExceedingLimits(templatePath.Child("workerTemplate", "spec"), &lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec)...)
+	return warnings
+}
+
+func requestsExceedingLimits(fldPath *field.Path, podSpec *corev1.PodSpec) admission.Warnings {
+	var warnings admission.Warnings
+	check := func(containerPath *field.Path, container *corev1.Container) {
+		for _, name := range slices.Sorted(maps.Keys(container.Resources.Requests)) {
+			request := container.Resources.Requests[name]
+			if limit, ok := container.Resources.Limits[name]; ok && request.Cmp(limit) > 0 {
+				warnings = append(warnings, fmt.Sprintf("%s: container %q requests %s of %s, which is greater than its limit %s",
+					containerPath, container.Name, request.String(), name, limit.String()))
+			}
+		}
+	}
+	for i := range podSpec.InitContainers {
+		check(fldPath.Child("initContainers").Index(i), &podSpec.InitContainers[i])
+	}
+	for i := range podSpec.Containers {
+		check(fldPath.Child("containers").Index(i), &podSpec.Containers[i])
+	}
+	return warnings
+}
+
+// validateLeaderReadinessProbe checks that the leader defines a readiness probe when the workers
+// are gated on the Ready condition of the leader pod by the LeaderReady startup policy. A missing
+// probe is returned as a warning, or as an error when RequireLeaderReadinessProbe is set.
+func (r *LeaderWorkerSetWebhook) validateLeaderReadinessProbe(lws *v1.LeaderWorkerSet) (field.ErrorList, admission.Warnings) {
+	if lws.Spec.StartupPolicy != v1.LeaderReadyStartupPolicy || lws.Spec.LeaderReadyConfiguration != nil {
+		return nil, nil
+	}
+	templatePath := field.NewPath("spec", "leaderWorkerTemplate", "leaderTemplate")
+	podSpec := &lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec
+	if lws.Spec.LeaderWorkerTemplate.LeaderTemplate != nil {
+		podSpec = &lws.Spec.LeaderWorkerTemplate.LeaderTemplate.Spec
+	} else {
+		// The leader is created from the worker template when there's no leader template.
+		templatePath = field.NewPath("spec", "leaderWorkerTemplate", "workerTemplate")
+	}
+	if hasReadinessProbe(podSpec) {
+		return nil, nil
+	}
+	msg := "no container of the leader defines a readinessProbe, the workers of a group are only created once the leader pod is ready with the LeaderReady startupPolicy"
+	if r.RequireLeaderReadinessProbe {
+		return field.ErrorList{field.Required(templatePath, msg)}, nil
+	}
+	return nil, admission.Warnings{fmt.Sprintf("%s: %s", templatePath, msg)}
+}
+
+// validateLeaderRestartPolicy checks that the leader restarts its containers when the workers are
+// gated on the leader being ready by the LeaderReady startup policy, since a leader which exited
+// for good would stall its group. A restartPolicy other than Always is returned as a warning, or as
+// an error when RequireLeaderRestartPolicyAlways is set.
+func (r *LeaderWorkerSetWebhook) validateLeaderRestartPolicy(lws *v1.LeaderWorkerSet) (field.ErrorList, admission.Warnings) {
+	if lws.Spec.StartupPolicy != v1.LeaderReadyStartupPolicy {
+		return nil, nil
+	}
+	templatePath := field.NewPath("spec", "leaderWorkerTemplate", "leaderTemplate")
+	podSpec := &lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec
+	if lws.Spec.LeaderWorkerTemplate.LeaderTemplate != nil {
+		podSpec = &lws.Spec.LeaderWorkerTemplate.LeaderTemplate.Spec
+	} else {
+		// The leader is created from the worker template when there's no leader template.
+		templatePath = field.NewPath("spec", "leaderWorkerTemplate", "workerTemplate")
+	}
+	if podSpec.RestartPolicy == "" || podSpec.RestartPolicy == corev1.RestartPolicyAlways {
+		return nil, nil
+	}
+	fldPath := templatePath.Child("spec", "restartPolicy")
+	msg := "the leader isn't restarted once it exits, which stalls its group with the LeaderReady startupPolicy"
+	if r.RequireLeaderRestartPolicyAlways {
+		return field.ErrorList{field.Invalid(fldPath, podSpec.RestartPolicy, msg)}, nil
+	}
+	return nil, admission.Warnings{fmt.Sprintf("%s: %s", fldPath, msg)}
+}
+
+// hasReadinessProbe returns whether a container or a sidecar of the pod spec defines a readiness probe.
+func hasReadinessProbe(podSpec *corev1.PodSpec) bool {
+	for _, container := range podSpec.Containers {
+		if container.ReadinessProbe != nil {
+			return true
+		}
+	}
+	for _, container := range podSpec.InitContainers {
+		if container.ReadinessProbe != nil && ptr.Deref(container.RestartPolicy, "") == corev1.ContainerRestartPolicyAlways {
+			return true
+		}
+	}
+	return false
+}
+
+// dryRunPlanWarning describes the rollout plan of the update as a warning.
+func dryRunPlanWarning(oldLws, newLws *v1.LeaderWorkerSet) string {
+	plan, err := rolloututils.ComputePlan(oldLws, newLws)
+	if err != nil {
+		return fmt.Sprintf("dry-run plan: failed to compute the rollout plan: %v", err)
+	}
+	return fmt.Sprintf("dry-run plan: %d groups will be created and %d groups will be deleted, with up to %d surge groups and %d unavailable groups at a time",
+		plan.CreatedGroups, plan.DeletedGroups, plan.SurgeGroups, plan.MaxUnavailableGroups)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -125,6 +389,21 @@ func (r *LeaderWorkerSetWebhook) ValidateDelete(ctx context.Context, obj runtime
 	return nil, nil
 }
 
+// validateReplicasLimit ensures the replicas, together with the standby replicas which are groups
+// as well, don't exceed the maximum configured by the admin.
+func (r *LeaderWorkerSetWebhook) validateReplicasLimit(lws *v1.LeaderWorkerSet, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if r.MaxReplicasPerLws > 0 && lws.Spec.Replicas != nil && totalReplicas(lws) > r.MaxReplicasPerLws {
+		allErrs = append(allErrs, field.Invalid(fldPath, *lws.Spec.Replicas, fmt.Sprintf("must be less than or equal to %d with the standbyReplicas, the maximum replicas per LeaderWorkerSet allowed in the cluster", r.MaxReplicasPerLws)))
+	}
+	return allErrs
+}
+
+// totalReplicas returns the number of groups of the lws, including the standby groups.
+func totalReplicas(lws *v1.LeaderWorkerSet) int32 {
+	return ptr.Deref(lws.Spec.Replicas, 1) + ptr.Deref(lws.Spec.StandbyReplicas, 0)
+}
+
 func (r *LeaderWorkerSetWebhook) generalValidate(obj runtime.Object) field.ErrorList {
 	lws := obj.(*v1.LeaderWorkerSet)
 	specPath := field.NewPath("spec")
@@ -133,46 +412,160 @@ func (r *LeaderWorkerSetWebhook) generalValidate(obj runtime.Object) field.Error
 	// Since the lws name is used as the name for headless service, it must be DNS-1035 compliant
 	ValidateName := apivalidation.NameIsDNS1035Label
 	allErrs := apivalidation.ValidateObjectMeta(&lws.ObjectMeta, true, apivalidation.ValidateNameFunc(ValidateName), field.NewPath("metadata"))
+	allErrs = append(allErrs, validateRestartGroupAnnotations(metadataPath.Child("annotations"), lws.Annotations)...)
+	allErrs = append(allErrs, validatePinGroupAnnotations(metadataPath.Child("annotations"), lws.Annotations)...)
 	// Ensure replicas and groups number are valid
-	if lws.Spec.Replicas != nil && *lws.Spec.Replicas < 0 {
-		allErrs = append(allErrs, field.Invalid(specPath.Child("replicas"), lws.Spec.Replicas, "replicas must be equal or greater than 0"))
+	if lws.Spec.Replicas != nil {
+		allErrs = append(allErrs, validateNonnegativeField(int64(*lws.Spec.Replicas), specPath.Child("replicas"))...)
 	}
-	if *lws.Spec.LeaderWorkerTemplate.Size < 1 {
-		allErrs = append(allErrs, field.Invalid(specPath.Child("leaderWorkerTemplate", "size"), lws.Spec.LeaderWorkerTemplate.Size, "size must be equal or greater than 1"))
+	if lws.Spec.MaxReplicas != nil {
+		if *lws.Spec.MaxReplicas < 0 {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("maxReplicas"), lws.Spec.MaxReplicas, "maxReplicas must be equal or greater than 0"))
+		} else if lws.Spec.Replicas != nil && totalReplicas(lws) > *lws.Spec.MaxReplicas {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("replicas"), lws.Spec.Replicas, fmt.Sprintf("replicas plus standbyReplicas must not be greater than maxReplicas %d", *lws.Spec.MaxReplicas)))
+		}
 	}
+	if lws.Spec.MinReplicas != nil {
+		if *lws.Spec.MinReplicas < 0 {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("minReplicas"), lws.Spec.MinReplicas, "minReplicas must be equal or greater than 0"))
+		} else if lws.Spec.Replicas != nil && *lws.Spec.MinReplicas > *lws.Spec.Replicas {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("minReplicas"), lws.Spec.MinReplicas, fmt.Sprintf("minReplicas must not be greater than replicas %d", *lws.Spec.Replicas)))
+		}
+	}
+	if lws.Spec.StandbyReplicas != nil {
+		allErrs = append(allErrs, validateNonnegativeField(int64(*lws.Spec.StandbyReplicas), specPath.Child("standbyReplicas"))...)
+	}
+	if lws.Spec.LeaderWorkerTemplate.MinReadySeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("leaderWorkerTemplate", "minReadySeconds"), lws.Spec.LeaderWorkerTemplate.MinReadySeconds, "minReadySeconds must be equal or greater than 0"))
+	}
+	if deadline := lws.Spec.LeaderWorkerTemplate.ActiveDeadlineSeconds; deadline != nil && *deadline <= 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("leaderWorkerTemplate", "activeDeadlineSeconds"), *deadline, "activeDeadlineSeconds must be greater than 0"))
+	}
+	allErrs = append(allErrs, validateSize(specPath.Child("leaderWorkerTemplate", "size"), lws)...)
 	if int64(*lws.Spec.Replicas)*int64(*lws.Spec.LeaderWorkerTemplate.Size) > math.MaxInt32 {
 		allErrs = append(allErrs, field.Invalid(specPath.Child("replicas"), lws.Spec.Replicas, fmt.Sprintf("the product of replicas and worker replicas must not exceed %d", math.MaxInt32)))
 	}
 
-	maxUnavailable := lws.Spec.RolloutStrategy.RollingUpdateConfiguration.MaxUnavailable
-	maxUnavailablePath := specPath.Child("rolloutStrategy", "rollingUpdateConfiguration", "maxUnavailable")
-	if lws.Spec.RolloutStrategy.RollingUpdateConfiguration != nil {
-		allErrs = append(allErrs, validatePositiveIntOrPercent(maxUnavailable, maxUnavailablePath)...)
-		// This is aligned with Statefulset.
-		allErrs = append(allErrs, isNotMoreThan100Percent(maxUnavailable, maxUnavailablePath)...)
+	if lws.Spec.StartupPolicy == v1.WorkersFirstStartupPolicy {
+		if lws.Spec.LeaderWorkerTemplate.LeaderTemplate == nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("startupPolicy"), lws.Spec.StartupPolicy, "leaderTemplate must be set when startupPolicy is WorkersFirst"))
+		}
+		// With exclusive placement, workers are only created after the leader is scheduled.
+		if _, found := lws.Annotations[v1.ExclusiveKeyAnnotationKey]; found {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("startupPolicy"), lws.Spec.StartupPolicy, fmt.Sprintf("cannot be WorkersFirst when %s is set", v1.ExclusiveKeyAnnotationKey)))
+		} else if lws.Spec.LeaderWorkerTemplate.ExclusiveTopology != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("startupPolicy"), lws.Spec.StartupPolicy, "cannot be WorkersFirst when leaderWorkerTemplate.exclusiveTopology is set"))
+		}
 	}
 
-	maxSurge := lws.Spec.RolloutStrategy.RollingUpdateConfiguration.MaxSurge
-	maxSurgePath := specPath.Child("rolloutStrategy", "rollingUpdateConfiguration", "maxSurge")
-	if lws.Spec.RolloutStrategy.RollingUpdateConfiguration != nil {
-		allErrs = append(allErrs, validatePositiveIntOrPercent(maxSurge, maxSurgePath)...)
-		allErrs = append(allErrs, isNotMoreThan100Percent(maxSurge, maxSurgePath)...)
+	if lws.Spec.LeaderReadyConfiguration != nil {
+		allErrs = append(allErrs, validateLeaderReadyConfiguration(specPath.Child("leaderReadyConfiguration"), lws)...)
 	}
-	maxUnavailableValue, err := intstr.GetScaledValueFromIntOrPercent(&maxUnavailable, int(*lws.Spec.Replicas), false)
-	if err != nil {
-		allErrs = append(allErrs, field.Invalid(maxUnavailablePath, maxUnavailable, "invalid value"))
+	if lws.Spec.LeaderStartupDelaySeconds != nil {
+		allErrs = append(allErrs, validateLeaderStartupDelaySeconds(specPath.Child("leaderStartupDelaySeconds"), lws)...)
 	}
-	maxSurgeValue, err := intstr.GetScaledValueFromIntOrPercent(&maxSurge, int(*lws.Spec.Replicas), true)
-	if err != nil {
-		allErrs = append(allErrs, field.Invalid(maxSurgePath, maxSurge, "invalid value"))
+
+	templatePath := specPath.Child("leaderWorkerTemplate")
+	if lws.Spec.LeaderWorkerTemplate.ExclusiveTopology != nil {
+		allErrs = append(allErrs, validateExclusiveTopology(templatePath.Child("exclusiveTopology"), lws)...)
+	}
+	if lws.Spec.LeaderWorkerTemplate.LeaderTemplate != nil {
+		allErrs = append(allErrs, validateAnnotationPlaceholders(templatePath.Child("leaderTemplate", "metadata", "annotations"), lws.Spec.LeaderWorkerTemplate.LeaderTemplate.Annotations)...)
+	}
+	allErrs = append(allErrs, validateAnnotationPlaceholders(templatePath.Child("workerTemplate", "metadata", "annotations"), lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Annotations)...)
+	if lws.Spec.LeaderWorkerTemplate.LeaderTemplate != nil {
+		leaderSpecPath := templatePath.Child("leaderTemplate", "spec")
+		allErrs = append(allErrs, validateContainerPlaceholders(leaderSpecPath.Child("initContainers"), lws.Spec.LeaderWorkerTemplate.LeaderTemplate.Spec.InitContainers)...)
+		allErrs = append(allErrs, validateContainerPlaceholders(leaderSpecPath.Child("containers"), lws.Spec.LeaderWorkerTemplate.LeaderTemplate.Spec.Containers)...)
+		allErrs = append(allErrs, validateHasContainers(leaderSpecPath.Child("containers"), &lws.Spec.LeaderWorkerTemplate.LeaderTemplate.Spec)...)
+	}
+	workerSpecPath := templatePath.Child("workerTemplate", "spec")
+	allErrs = append(allErrs, validateHasContainers(workerSpecPath.Child("containers"), &lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec)...)
+	allErrs = append(allErrs, validateContainerPlaceholders(workerSpecPath.Child("initContainers"), lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec.InitContainers)...)
+	allErrs = append(allErrs, validateContainerPlaceholders(workerSpecPath.Child("containers"), lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec.Containers)...)
+	allErrs = append(allErrs, validateContainerPlaceholders(templatePath.Child("commonContainers"), lws.Spec.LeaderWorkerTemplate.CommonContainers)...)
+	allErrs = append(allErrs, validateContainerPlaceholders(templatePath.Child("leaderGroupInitContainers"), lws.Spec.LeaderWorkerTemplate.LeaderGroupInitContainers)...)
+	if lws.Spec.LeaderWorkerTemplate.LeaderTemplate != nil {
+		allErrs = append(allErrs, validateReservedLabels(templatePath.Child("leaderTemplate", "metadata", "labels"), lws.Spec.LeaderWorkerTemplate.LeaderTemplate.Labels)...)
+	}
+	allErrs = append(allErrs, validateReservedLabels(templatePath.Child("workerTemplate", "metadata", "labels"), lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Labels)...)
+	allErrs = append(allErrs, validateInheritLabels(templatePath.Child("inheritLabels"), lws.Spec.LeaderWorkerTemplate.InheritLabels)...)
+	allErrs = append(allErrs, validateVolumeClaimTemplates(templatePath, lws)...)
+	allErrs = append(allErrs, validateMembershipVolume(templatePath, lws)...)
+	allErrs = append(allErrs, validateNetworkEnvNames(templatePath.Child("networkEnvNames"), lws.Spec.LeaderWorkerTemplate.NetworkEnvNames)...)
+	reservedEnvVarNames := injectedEnvVarNames(lws.Spec.LeaderWorkerTemplate.NetworkEnvNames)
+	if lws.Spec.LeaderWorkerTemplate.LeaderTemplate != nil {
+		allErrs = append(allErrs, validateReservedEnvVars(templatePath.Child("leaderTemplate", "spec"), &lws.Spec.LeaderWorkerTemplate.LeaderTemplate.Spec, reservedEnvVarNames)...)
+	}
+	allErrs = append(allErrs, validateReservedEnvVars(templatePath.Child("workerTemplate", "spec"), &lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec, reservedEnvVarNames)...)
+	allErrs = append(allErrs, validatePerGroupEnv(templatePath.Child("perGroupEnv"), lws, reservedEnvVarNames)...)
+
+	allErrs = append(allErrs, validateGroupSpreadConstraints(templatePath.Child("groupSpreadConstraints"), lws.Spec.LeaderWorkerTemplate.GroupSpreadConstraints)...)
+	allErrs = append(allErrs, validateCommonContainers(templatePath.Child("commonContainers"), lws)...)
+	allErrs = append(allErrs, validateLeaderGroupInitContainers(templatePath.Child("leaderGroupInitContainers"), lws)...)
+	if priorityClassName := lws.Spec.LeaderWorkerTemplate.LeaderPriorityClassName; priorityClassName != "" {
+		for _, msg := range utilvalidation.IsDNS1123Subdomain(priorityClassName) {
+			allErrs = append(allErrs, field.Invalid(templatePath.Child("leaderPriorityClassName"), priorityClassName, msg))
+		}
+	}
+	if gracePeriod := lws.Spec.LeaderWorkerTemplate.LeaderTerminationGracePeriodSeconds; gracePeriod != nil {
+		allErrs = append(allErrs, validateNonnegativeField(*gracePeriod, templatePath.Child("leaderTerminationGracePeriodSeconds"))...)
+	}
+	if name := lws.Spec.LeaderWorkerTemplate.LeaderServiceAccountName; name != "" {
+		for _, msg := range apivalidation.ValidateServiceAccountName(name, false) {
+			allErrs = append(allErrs, field.Invalid(templatePath.Child("leaderServiceAccountName"), name, msg))
+		}
+	}
+	if name := lws.Spec.LeaderWorkerTemplate.WorkerServiceAccountName; name != "" {
+		for _, msg := range apivalidation.ValidateServiceAccountName(name, false) {
+			allErrs = append(allErrs, field.Invalid(templatePath.Child("workerServiceAccountName"), name, msg))
+		}
+	}
+	if lws.Spec.LeaderWorkerTemplate.GangSchedulingLabelKey != "" {
+		allErrs = append(allErrs, validateGangSchedulingLabelKey(templatePath.Child("gangSchedulingLabelKey"), lws)...)
 	}
-	if maxUnavailableValue == 0 && maxSurgeValue == 0 && *lws.Spec.Replicas != 0 {
-		// Both MaxSurge and MaxUnavailable cannot be zero.
-		allErrs = append(allErrs, field.Invalid(maxUnavailablePath, maxUnavailable, "must not be 0 when `maxSurge` is 0"))
+	if lws.Spec.LeaderWorkerTemplate.PodFailurePolicy != nil {
+		allErrs = append(allErrs, validatePodFailurePolicy(templatePath.Child("podFailurePolicy"), lws)...)
+	}
+	if lws.Spec.LeaderWorkerTemplate.LeaderTemplate != nil {
+		allErrs = append(allErrs, validateHostNetwork(templatePath, lws)...)
+	}
+	if lws.Spec.LeaderWorkerTemplate.LeaderTemplate != nil && controllerutils.ExclusiveTopologyKey(lws) != "" {
+		allErrs = append(allErrs, validateExclusiveNodeSelectors(templatePath, lws)...)
+	}
+	if controllerutils.ExclusiveTopologyKey(lws) != "" {
+		allErrs = append(allErrs, validateExclusiveSpreadConstraints(templatePath, lws)...)
+	}
+	if lws.Spec.LeaderWorkerTemplate.LeaderTemplate != nil && usesGangScheduling(lws) {
+		allErrs = append(allErrs, validateGangSchedulerName(templatePath, lws)...)
+	}
+
+	if lws.Spec.NetworkConfig != nil && lws.Spec.NetworkConfig.HostnamePrefix != "" {
+		allErrs = append(allErrs, validateHostnamePrefix(specPath.Child("networkConfig", "hostnamePrefix"), lws)...)
+	}
+	if lws.Spec.NetworkConfig != nil {
+		allErrs = append(allErrs, validateNonnegativeField(int64(lws.Spec.NetworkConfig.StartIndex), specPath.Child("networkConfig", "startIndex"))...)
+	}
+	if lws.Spec.NetworkConfig != nil && lws.Spec.NetworkConfig.PerGroupService {
+		allErrs = append(allErrs, validatePerGroupService(specPath.Child("networkConfig", "perGroupService"), lws)...)
+	}
+
+	if lws.Spec.RolloutStrategy.RollingUpdateConfiguration != nil {
+		rollingUpdateConfigurationPath := specPath.Child("rolloutStrategy", "rollingUpdateConfiguration")
+		if lws.Spec.RolloutStrategy.Type == v1.RecreateStrategyType {
+			allErrs = append(allErrs, field.Invalid(rollingUpdateConfigurationPath, lws.Spec.RolloutStrategy.RollingUpdateConfiguration, fmt.Sprintf("must not be set when type is %s", v1.RecreateStrategyType)))
+		} else {
+			allErrs = append(allErrs, validateRollingUpdateConfiguration(rollingUpdateConfigurationPath, lws)...)
+		}
 	}
 
 	if lws.Spec.LeaderWorkerTemplate.SubGroupPolicy != nil {
 		allErrs = append(allErrs, validateUpdateSubGroupPolicy(specPath, lws)...)
+		// Runs on updates as well, since size can be changed.
+		allErrs = append(allErrs, validateSubGroupSizeDividesSize(specPath.Child("leaderWorkerTemplate", "subGroupPolicy", "subGroupSize"), lws)...)
+		if lws.Spec.LeaderWorkerTemplate.SubGroupPolicy.SubGroupTopologyKey != nil {
+			allErrs = append(allErrs, validateSubGroupTopologyKey(specPath.Child("leaderWorkerTemplate", "subGroupPolicy", "subGroupTopologyKey"), lws)...)
+		}
 	} else {
 		if _, foundSubEpKey := lws.Annotations[v1.SubGroupExclusiveKeyAnnotationKey]; foundSubEpKey {
 			allErrs = append(allErrs, field.Invalid(metadataPath.Child("annotations", v1.SubGroupExclusiveKeyAnnotationKey), lws.Annotations[v1.SubGroupExclusiveKeyAnnotationKey], "cannot have subgroup-exclusive-topology without subGroupSize set"))
@@ -182,6 +575,848 @@ func (r *LeaderWorkerSetWebhook) generalValidate(obj runtime.Object) field.Error
 	return allErrs
 }
 
+// validateLeaderStartupDelaySeconds validates that the leader startup delay is non-negative, and
+// that it's not set with the WorkersFirst startup policy, which creates the workers first.
+func validateLeaderStartupDelaySeconds(fldPath *field.Path, lws *v1.LeaderWorkerSet) field.ErrorList {
+	delay := *lws.Spec.LeaderStartupDelaySeconds
+	allErrs := validateNonnegativeField(int64(delay), fldPath)
+	if lws.Spec.StartupPolicy == v1.WorkersFirstStartupPolicy {
+		allErrs = append(allErrs, field.Invalid(fldPath, delay, fmt.Sprintf("cannot be set when startupPolicy is %s", v1.WorkersFirstStartupPolicy)))
+	}
+	return allErrs
+}
+
+// validateLeaderReadyConfiguration validates that the leaderReadyConfiguration is only set with the
+// LeaderReady startup policy, and that it references exactly one valid condition type or annotation.
+func validateLeaderReadyConfiguration(fldPath *field.Path, lws *v1.LeaderWorkerSet) field.ErrorList {
+	allErrs := field.ErrorList{}
+	config := lws.Spec.LeaderReadyConfiguration
+	if lws.Spec.StartupPolicy != v1.LeaderReadyStartupPolicy {
+		allErrs = append(allErrs, field.Invalid(fldPath, config, fmt.Sprintf("must only be set when startupPolicy is %s", v1.LeaderReadyStartupPolicy)))
+	}
+	if config.ConditionType != nil && config.Annotation != nil {
+		return append(allErrs, field.Invalid(fldPath, config, "conditionType and annotation are mutually exclusive"))
+	}
+	if config.ConditionType == nil && config.Annotation == nil {
+		return append(allErrs, field.Required(fldPath, "one of conditionType or annotation must be set"))
+	}
+	if config.ConditionType != nil {
+		conditionType := string(*config.ConditionType)
+		for _, msg := range utilvalidation.IsQualifiedName(conditionType) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("conditionType"), conditionType, msg))
+		}
+	}
+	if config.Annotation != nil {
+		// Annotation keys are validated case-insensitively, same as the apiserver does.
+		for _, msg := range utilvalidation.IsQualifiedName(strings.ToLower(*config.Annotation)) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("annotation"), *config.Annotation, msg))
+		}
+	}
+	return allErrs
+}
+
+// validateExclusiveTopology validates that the topology key is a valid label key, and that it doesn't
+// conflict with the exclusive-topology annotation.
+func validateExclusiveTopology(fldPath *field.Path, lws *v1.LeaderWorkerSet) field.ErrorList {
+	allErrs := field.ErrorList{}
+	topologyKey := lws.Spec.LeaderWorkerTemplate.ExclusiveTopology.TopologyKey
+	topologyKeyPath := fldPath.Child("topologyKey")
+	if topologyKey == "" {
+		return append(allErrs, field.Required(topologyKeyPath, "topologyKey must not be empty"))
+	}
+	for _, msg := range utilvalidation.IsQualifiedName(topologyKey) {
+		allErrs = append(allErrs, field.Invalid(topologyKeyPath, topologyKey, msg))
+	}
+	if annotationKey, found := lws.Annotations[v1.ExclusiveKeyAnnotationKey]; found && annotationKey != topologyKey {
+		allErrs = append(allErrs, field.Invalid(topologyKeyPath, topologyKey, fmt.Sprintf("conflicts with the %s annotation %q", v1.ExclusiveKeyAnnotationKey, annotationKey)))
+	}
+	return allErrs
+}
+
+// validateSubGroupTopologyKey validates that the subgroup topology key is a valid label key, and that the
+// group is exclusively placed as well, since the subgroups are pinned to sub-domains of the group domain.
+func validateSubGroupTopologyKey(fldPath *field.Path, lws *v1.LeaderWorkerSet) field.ErrorList {
+	allErrs := field.ErrorList{}
+	subGroupTopologyKey := *lws.Spec.LeaderWorkerTemplate.SubGroupPolicy.SubGroupTopologyKey
+	if subGroupTopologyKey == "" {
+		return append(allErrs, field.Required(fldPath, "subGroupTopologyKey must not be empty"))
+	}
+	for _, msg := range utilvalidation.IsQualifiedName(subGroupTopologyKey) {
+		allErrs = append(allErrs, field.Invalid(fldPath, subGroupTopologyKey, msg))
+	}
+	if controllerutils.ExclusiveTopologyKey(lws) == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath, subGroupTopologyKey, fmt.Sprintf("requires exclusiveTopology or the %s annotation to be set", v1.ExclusiveKeyAnnotationKey)))
+	}
+	if annotationKey, found := lws.Annotations[v1.SubGroupExclusiveKeyAnnotationKey]; found && annotationKey != subGroupTopologyKey {
+		allErrs = append(allErrs, field.Invalid(fldPath, subGroupTopologyKey, fmt.Sprintf("conflicts with the %s annotation %q", v1.SubGroupExclusiveKeyAnnotationKey, annotationKey)))
+	}
+	return allErrs
+}
+
+// validateExclusiveNodeSelectors rejects leader and worker templates whose nodeSelectors require
+// different values for the same key, with exclusive placement the leader and the workers of a group
+// have to land in the same topology domain, so the group would never be scheduled.
+func validateExclusiveNodeSelectors(fldPath *field.Path, lws *v1.LeaderWorkerSet) field.ErrorList {
+	allErrs := field.ErrorList{}
+	leaderNodeSelector := lws.Spec.LeaderWorkerTemplate.LeaderTemplate.Spec.NodeSelector
+	workerNodeSelector := lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec.NodeSelector
+	for _, key := range slices.Sorted(maps.Keys(leaderNodeSelector)) {
+		if workerValue, found := workerNodeSelector[key]; found && workerValue != leaderNodeSelector[key] {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("leaderTemplate", "spec", "nodeSelector").Key(key), leaderNodeSelector[key],
+				fmt.Sprintf("conflicts with the workerTemplate nodeSelector %q when exclusive placement is enabled", workerValue)))
+		}
+	}
+	return allErrs
+}
+
+// validateHostNetwork requires the leader and worker templates to agree on hostNetwork, the pods of
+// a group address each other by their DNS names, which hostNetwork pods resolve differently.
+func validateHostNetwork(fldPath *field.Path, lws *v1.LeaderWorkerSet) field.ErrorList {
+	leaderHostNetwork := lws.Spec.LeaderWorkerTemplate.LeaderTemplate.Spec.HostNetwork
+	workerHostNetwork := lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec.HostNetwork
+	if leaderHostNetwork == workerHostNetwork {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(fldPath.Child("leaderTemplate", "spec", "hostNetwork"), leaderHostNetwork,
+		fmt.Sprintf("must match the workerTemplate hostNetwork %t", workerHostNetwork))}
+}
+
+// groupLabelKeys are the labels a topology spread constraint selects the pods of a single group by.
+var groupLabelKeys = []string{v1.GroupUniqueHashLabelKey, v1.GroupIndexLabelKey}
+
+// validateExclusiveSpreadConstraints rejects topology spread constraints of the templates which
+// spread the pods of a group across the exclusive topology domains: exclusive placement pins a
+// group to a single domain, so such a constraint can't be satisfied.
+func validateExclusiveSpreadConstraints(fldPath *field.Path, lws *v1.LeaderWorkerSet) field.ErrorList {
+	allErrs := field.ErrorList{}
+	topologyKey := controllerutils.ExclusiveTopologyKey(lws)
+	validate := func(templatePath *field.Path, constraints []corev1.TopologySpreadConstraint) {
+		for i, constraint := range constraints {
+			if constraint.TopologyKey == topologyKey && intraGroupSpreadConstraint(constraint) {
+				allErrs = append(allErrs, field.Invalid(templatePath.Child("spec", "topologySpreadConstraints").Index(i).Child("topologyKey"), constraint.TopologyKey,
+					"cannot spread the pods of a group across the exclusive topology domains"))
+			}
+		}
+	}
+	if lws.Spec.LeaderWorkerTemplate.LeaderTemplate != nil {
+		validate(fldPath.Child("leaderTemplate"), lws.Spec.LeaderWorkerTemplate.LeaderTemplate.Spec.TopologySpreadConstraints)
+	}
+	validate(fldPath.Child("workerTemplate"), lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec.TopologySpreadConstraints)
+	return allErrs
+}
+
+// intraGroupSpreadConstraint returns true if the topology spread constraint selects the pods of a
+// single group, through its labelSelector or matchLabelKeys.
+func intraGroupSpreadConstraint(constraint corev1.TopologySpreadConstraint) bool {
+	for _, key := range groupLabelKeys {
+		if slices.Contains(constraint.MatchLabelKeys, key) {
+			return true
+		}
+		if constraint.LabelSelector == nil {
+			continue
+		}
+		if _, found := constraint.LabelSelector.MatchLabels[key]; found {
+			return true
+		}
+		if slices.ContainsFunc(constraint.LabelSelector.MatchExpressions, func(r metav1.LabelSelectorRequirement) bool {
+			return r.Key == key && r.Operator == metav1.LabelSelectorOpIn
+		}) {
+			return true
+		}
+	}
+	return false
+}
+
+// gangSchedulingKeys are the labels and annotations gang schedulers group the pods by: the
+// coscheduling plugin of the scheduler-plugins and Volcano.
+var gangSchedulingKeys = []string{"scheduling.x-k8s.io/pod-group", "scheduling.k8s.io/group-name"}
+
+// usesGangScheduling returns true if the LeaderWorkerSet or one of its templates carries a gang
+// scheduling label or annotation.
+func usesGangScheduling(lws *v1.LeaderWorkerSet) bool {
+	if lws.Spec.LeaderWorkerTemplate.GangSchedulingLabelKey != "" {
+		return true
+	}
+	metas := []map[string]string{
+		lws.Annotations,
+		lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Labels,
+		lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Annotations,
+	}
+	if lws.Spec.LeaderWorkerTemplate.LeaderTemplate != nil {
+		metas = append(metas, lws.Spec.LeaderWorkerTemplate.LeaderTemplate.Labels, lws.Spec.LeaderWorkerTemplate.LeaderTemplate.Annotations)
+	}
+	for _, m := range metas {
+		for _, key := range gangSchedulingKeys {
+			if _, found := m[key]; found {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validateGangSchedulingLabelKey validates that the gangSchedulingLabelKey is a valid label key
+// outside of the leaderworkerset prefix, and that the label values of all the groups are valid.
+func validateGangSchedulingLabelKey(fldPath *field.Path, lws *v1.LeaderWorkerSet) field.ErrorList {
+	allErrs := field.ErrorList{}
+	labelKey := lws.Spec.LeaderWorkerTemplate.GangSchedulingLabelKey
+	for _, msg := range utilvalidation.IsQualifiedName(labelKey) {
+		allErrs = append(allErrs, field.Invalid(fldPath, labelKey, msg))
+	}
+	if strings.HasPrefix(labelKey, reservedLabelPrefix) {
+		allErrs = append(allErrs, field.Invalid(fldPath, labelKey, fmt.Sprintf("labels with the %q prefix are reserved for leaderworkerset", reservedLabelPrefix)))
+	}
+	maxIndex := max(ptr.Deref(lws.Spec.Replicas, 1)-1, 0)
+	if lws.Spec.NetworkConfig != nil {
+		maxIndex += lws.Spec.NetworkConfig.StartIndex
+	}
+	if labelValue := fmt.Sprintf("%s-%d", lws.Name, maxIndex); len(labelValue) > utilvalidation.LabelValueMaxLength {
+		allErrs = append(allErrs, field.Invalid(fldPath, labelKey, fmt.Sprintf("label value %q must be no more than %d characters", labelValue, utilvalidation.LabelValueMaxLength)))
+	}
+	return allErrs
+}
+
+// validateGangSchedulerName requires the leader and worker templates to use the same scheduler
+// with gang scheduling, the pods of a group are only scheduled together by a single scheduler.
+func validateGangSchedulerName(fldPath *field.Path, lws *v1.LeaderWorkerSet) field.ErrorList {
+	leaderSchedulerName := lws.Spec.LeaderWorkerTemplate.LeaderTemplate.Spec.SchedulerName
+	workerSchedulerName := lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec.SchedulerName
+	// An empty scheduler name is defaulted to the default scheduler by the apiserver.
+	if leaderSchedulerName == "" {
+		leaderSchedulerName = corev1.DefaultSchedulerName
+	}
+	if workerSchedulerName == "" {
+		workerSchedulerName = corev1.DefaultSchedulerName
+	}
+	if leaderSchedulerName == workerSchedulerName {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(fldPath.Child("leaderTemplate", "spec", "schedulerName"), lws.Spec.LeaderWorkerTemplate.LeaderTemplate.Spec.SchedulerName,
+		fmt.Sprintf("must match the workerTemplate schedulerName %q when gang scheduling is used", workerSchedulerName))}
+}
+
+// supportedWhenUnsatisfiable are the supported values of whenUnsatisfiable of the group spread constraints.
+var supportedWhenUnsatisfiable = []string{string(corev1.DoNotSchedule), string(corev1.ScheduleAnyway)}
+
+// validateGroupSpreadConstraints validates the topologyKey, maxSkew and whenUnsatisfiable of the group
+// spread constraints, the labelSelector is set by the pod webhook so it must not be set.
+func validateGroupSpreadConstraints(fldPath *field.Path, constraints []corev1.TopologySpreadConstraint) field.ErrorList {
+	allErrs := field.ErrorList{}
+	type constraintKey struct {
+		topologyKey       string
+		whenUnsatisfiable corev1.UnsatisfiableConstraintAction
+	}
+	existingConstraints := make(map[constraintKey]bool)
+	for i, constraint := range constraints {
+		idxPath := fldPath.Index(i)
+		if constraint.MaxSkew < 1 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("maxSkew"), constraint.MaxSkew, "must be greater than or equal to 1"))
+		}
+		if constraint.TopologyKey == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("topologyKey"), "can not be empty"))
+		} else {
+			for _, msg := range utilvalidation.IsQualifiedName(constraint.TopologyKey) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("topologyKey"), constraint.TopologyKey, msg))
+			}
+		}
+		if !slices.Contains(supportedWhenUnsatisfiable, string(constraint.WhenUnsatisfiable)) {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("whenUnsatisfiable"), constraint.WhenUnsatisfiable, supportedWhenUnsatisfiable))
+		}
+		if constraint.LabelSelector != nil {
+			allErrs = append(allErrs, field.Forbidden(idxPath.Child("labelSelector"), "is set to select all the leader pods of the LeaderWorkerSet"))
+		}
+		key := constraintKey{topologyKey: constraint.TopologyKey, whenUnsatisfiable: constraint.WhenUnsatisfiable}
+		if existingConstraints[key] {
+			allErrs = append(allErrs, field.Duplicate(idxPath, fmt.Sprintf("{%v, %v}", constraint.TopologyKey, constraint.WhenUnsatisfiable)))
+		}
+		existingConstraints[key] = true
+	}
+	return allErrs
+}
+
+// validateHostnamePrefix validates that the hostname of the leader pod with the highest index,
+// <hostnamePrefix>-<index>, is a valid DNS-1123 label.
+func validateHostnamePrefix(fldPath *field.Path, lws *v1.LeaderWorkerSet) field.ErrorList {
+	allErrs := field.ErrorList{}
+	hostnamePrefix := lws.Spec.NetworkConfig.HostnamePrefix
+	for _, msg := range utilvalidation.IsDNS1123Label(hostnamePrefix) {
+		allErrs = append(allErrs, field.Invalid(fldPath, hostnamePrefix, msg))
+	}
+	maxIndex := lws.Spec.NetworkConfig.StartIndex + max(ptr.Deref(lws.Spec.Replicas, 1)-1, 0)
+	if hostname := fmt.Sprintf("%s-%d", hostnamePrefix, maxIndex); len(hostname) > utilvalidation.DNS1123LabelMaxLength {
+		allErrs = append(allErrs, field.Invalid(fldPath, hostnamePrefix, fmt.Sprintf("leader pod hostname %q must be no more than %d characters", hostname, utilvalidation.DNS1123LabelMaxLength)))
+	}
+	return allErrs
+}
+
+// validateCommonContainers validates that the common containers have unique valid names that don't collide
+// with the containers of the templates, and that only native sidecars set a restartPolicy.
+func validateCommonContainers(fldPath *field.Path, lws *v1.LeaderWorkerSet) field.ErrorList {
+	allErrs := field.ErrorList{}
+	templateContainers := sets.New[string]()
+	podSpecs := []corev1.PodSpec{lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec}
+	if lws.Spec.LeaderWorkerTemplate.LeaderTemplate != nil {
+		podSpecs = append(podSpecs, lws.Spec.LeaderWorkerTemplate.LeaderTemplate.Spec)
+	}
+	for _, podSpec := range podSpecs {
+		for _, container := range slices.Concat(podSpec.InitContainers, podSpec.Containers) {
+			templateContainers.Insert(container.Name)
+		}
+	}
+	names := sets.New[string]()
+	for i, container := range lws.Spec.LeaderWorkerTemplate.CommonContainers {
+		namePath := fldPath.Index(i).Child("name")
+		if container.Name == "" {
+			allErrs = append(allErrs, field.Required(namePath, ""))
+		} else {
+			for _, msg := range utilvalidation.IsDNS1123Label(container.Name) {
+				allErrs = append(allErrs, field.Invalid(namePath, container.Name, msg))
+			}
+		}
+		if names.Has(container.Name) || templateContainers.Has(container.Name) {
+			allErrs = append(allErrs, field.Duplicate(namePath, container.Name))
+		}
+		names.Insert(container.Name)
+		if container.RestartPolicy != nil && *container.RestartPolicy != corev1.ContainerRestartPolicyAlways {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Index(i).Child("restartPolicy"), *container.RestartPolicy, []corev1.ContainerRestartPolicy{corev1.ContainerRestartPolicyAlways}))
+		}
+	}
+	return allErrs
+}
+
+// validateLeaderGroupInitContainers validates that the leader group init containers have unique valid names
+// that don't collide with the containers of the leader pod, and that they don't set a restartPolicy.
+func validateLeaderGroupInitContainers(fldPath *field.Path, lws *v1.LeaderWorkerSet) field.ErrorList {
+	allErrs := field.ErrorList{}
+	// The leader pod is created from the worker template when there is no leader template.
+	leaderPodSpec := lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec
+	if lws.Spec.LeaderWorkerTemplate.LeaderTemplate != nil {
+		leaderPodSpec = lws.Spec.LeaderWorkerTemplate.LeaderTemplate.Spec
+	}
+	leaderContainers := sets.New[string]()
+	for _, container := range slices.Concat(leaderPodSpec.InitContainers, leaderPodSpec.Containers, lws.Spec.LeaderWorkerTemplate.CommonContainers) {
+		leaderContainers.Insert(container.Name)
+	}
+	names := sets.New[string]()
+	for i, container := range lws.Spec.LeaderWorkerTemplate.LeaderGroupInitContainers {
+		namePath := fldPath.Index(i).Child("name")
+		if container.Name == "" {
+			allErrs = append(allErrs, field.Required(namePath, ""))
+		} else {
+			for _, msg := range utilvalidation.IsDNS1123Label(container.Name) {
+				allErrs = append(allErrs, field.Invalid(namePath, container.Name, msg))
+			}
+		}
+		if names.Has(container.Name) || leaderContainers.Has(container.Name) {
+			allErrs = append(allErrs, field.Duplicate(namePath, container.Name))
+		}
+		names.Insert(container.Name)
+		if container.RestartPolicy != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Index(i).Child("restartPolicy"), "must not be set for init containers run once per group"))
+		}
+	}
+	return allErrs
+}
+
+// maxPodFailurePolicyExitCodes is the maximum number of exit codes of a pod failure policy rule,
+// same as for Jobs.
+const maxPodFailurePolicyExitCodes = 255
+
+// validatePodFailurePolicy validates the operators and exit codes of the pod failure policy rules, and
+// that their container names refer to containers of the pods.
+func validatePodFailurePolicy(fldPath *field.Path, lws *v1.LeaderWorkerSet) field.ErrorList {
+	allErrs := field.ErrorList{}
+	containerNames := sets.New[string]()
+	podSpecs := []corev1.PodSpec{lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec}
+	if lws.Spec.LeaderWorkerTemplate.LeaderTemplate != nil {
+		podSpecs = append(podSpecs, lws.Spec.LeaderWorkerTemplate.LeaderTemplate.Spec)
+	}
+	for _, podSpec := range podSpecs {
+		for _, container := range slices.Concat(podSpec.InitContainers, podSpec.Containers) {
+			containerNames.Insert(container.Name)
+		}
+	}
+	for _, container := range slices.Concat(lws.Spec.LeaderWorkerTemplate.CommonContainers, lws.Spec.LeaderWorkerTemplate.LeaderGroupInitContainers) {
+		containerNames.Insert(container.Name)
+	}
+
+	rulesPath := fldPath.Child("rules")
+	if len(lws.Spec.LeaderWorkerTemplate.PodFailurePolicy.Rules) == 0 {
+		allErrs = append(allErrs, field.Required(rulesPath, ""))
+	}
+	for i, rule := range lws.Spec.LeaderWorkerTemplate.PodFailurePolicy.Rules {
+		requirementPath := rulesPath.Index(i).Child("onExitCodes")
+		requirement := rule.OnExitCodes
+		if requirement.ContainerName != nil && !containerNames.Has(*requirement.ContainerName) {
+			allErrs = append(allErrs, field.Invalid(requirementPath.Child("containerName"), *requirement.ContainerName, "must be the name of a container of the leader or worker template"))
+		}
+		switch requirement.Operator {
+		case v1.PodFailurePolicyOnExitCodesOpIn, v1.PodFailurePolicyOnExitCodesOpNotIn:
+		default:
+			allErrs = append(allErrs, field.NotSupported(requirementPath.Child("operator"), requirement.Operator, []v1.PodFailurePolicyOnExitCodesOperator{v1.PodFailurePolicyOnExitCodesOpIn, v1.PodFailurePolicyOnExitCodesOpNotIn}))
+		}
+		valuesPath := requirementPath.Child("values")
+		if len(requirement.Values) == 0 {
+			allErrs = append(allErrs, field.Required(valuesPath, ""))
+		}
+		if len(requirement.Values) > maxPodFailurePolicyExitCodes {
+			allErrs = append(allErrs, field.TooMany(valuesPath, len(requirement.Values), maxPodFailurePolicyExitCodes))
+		}
+		for j, value := range requirement.Values {
+			if requirement.Operator == v1.PodFailurePolicyOnExitCodesOpIn && value == 0 {
+				allErrs = append(allErrs, field.Invalid(valuesPath.Index(j), value, "must not be 0 for the In operator"))
+			}
+			if j > 0 && requirement.Values[j-1] >= value {
+				allErrs = append(allErrs, field.Invalid(valuesPath.Index(j), value, "must be unique and ordered in increasing order"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validatePerGroupService validates that the per group services don't collide with the headless
+// services of subdomainPolicy UniquePerReplica, that their names are valid, and that the leader
+// pod has ports for them to expose.
+func validatePerGroupService(fldPath *field.Path, lws *v1.LeaderWorkerSet) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if ptr.Deref(lws.Spec.NetworkConfig.SubdomainPolicy, v1.SubdomainShared) == v1.SubdomainUniquePerReplica {
+		allErrs = append(allErrs, field.Invalid(fldPath, true, fmt.Sprintf("cannot be enabled with subdomainPolicy %s, whose headless services have the same names", v1.SubdomainUniquePerReplica)))
+	}
+	maxIndex := lws.Spec.NetworkConfig.StartIndex + max(ptr.Deref(lws.Spec.Replicas, 1)-1, 0)
+	if serviceName := fmt.Sprintf("%s-%d", lws.Name, maxIndex); len(serviceName) > utilvalidation.DNS1035LabelMaxLength {
+		allErrs = append(allErrs, field.Invalid(fldPath, true, fmt.Sprintf("service name %q must be no more than %d characters", serviceName, utilvalidation.DNS1035LabelMaxLength)))
+	}
+	leaderTemplate := lws.Spec.LeaderWorkerTemplate.WorkerTemplate
+	if lws.Spec.LeaderWorkerTemplate.LeaderTemplate != nil {
+		leaderTemplate = *lws.Spec.LeaderWorkerTemplate.LeaderTemplate
+	}
+	if !slices.ContainsFunc(leaderTemplate.Spec.Containers, func(c corev1.Container) bool { return len(c.Ports) > 0 }) {
+		allErrs = append(allErrs, field.Invalid(fldPath, true, "requires the leader pod to have at least one container port"))
+	}
+	return allErrs
+}
+
+// validateAnnotationPlaceholders rejects annotation values with placeholders other than
+// {{.GroupIndex}}, {{.WorkerIndex}} and {{.Size}}.
+func validateAnnotationPlaceholders(fldPath *field.Path, annotations map[string]string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, key := range slices.Sorted(maps.Keys(annotations)) {
+		value := annotations[key]
+		if unknown := podutils.UnknownPlaceholders(value); len(unknown) > 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(key), value, fmt.Sprintf("unknown placeholders %s, supported placeholders are {{.%s}}, {{.%s}} and {{.%s}}",
+				strings.Join(unknown, ", "), v1.GroupIndexPlaceholder, v1.WorkerIndexPlaceholder, v1.SizePlaceholder)))
+		}
+	}
+	return allErrs
+}
+
+// validateContainerPlaceholders rejects container commands and args with placeholders other than
+// {{.GroupIndex}}, {{.WorkerIndex}} and {{.Size}}.
+func validateContainerPlaceholders(fldPath *field.Path, containers []corev1.Container) field.ErrorList {
+	allErrs := field.ErrorList{}
+	validate := func(fldPath *field.Path, strs []string) {
+		for i, value := range strs {
+			if unknown := podutils.UnknownPlaceholders(value); len(unknown) > 0 {
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(i), value, fmt.Sprintf("unknown placeholders %s, supported placeholders are {{.%s}}, {{.%s}} and {{.%s}}",
+					strings.Join(unknown, ", "), v1.GroupIndexPlaceholder, v1.WorkerIndexPlaceholder, v1.SizePlaceholder)))
+			}
+		}
+	}
+	for i, container := range containers {
+		validate(fldPath.Index(i).Child("command"), container.Command)
+		validate(fldPath.Index(i).Child("args"), container.Args)
+	}
+	return allErrs
+}
+
+// validateHasContainers requires the pod template to have at least one container, the StatefulSets
+// created from it would be rejected otherwise, long after the LeaderWorkerSet was admitted.
+func validateHasContainers(fldPath *field.Path, podSpec *corev1.PodSpec) field.ErrorList {
+	if len(podSpec.Containers) > 0 {
+		return nil
+	}
+	return field.ErrorList{field.Required(fldPath, "must have at least one container")}
+}
+
+// validateRestartGroupAnnotations validates that the restart-group annotations are suffixed by a
+// group index and set to an RFC 3339 timestamp.
+func validateRestartGroupAnnotations(fldPath *field.Path, annotations map[string]string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, key := range slices.Sorted(maps.Keys(annotations)) {
+		indexStr, found := strings.CutPrefix(key, v1.RestartGroupAnnotationKeyPrefix)
+		if !found {
+			continue
+		}
+		if index, err := strconv.Atoi(indexStr); err != nil || index < 0 || strconv.Itoa(index) != indexStr {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(key), key, "must be suffixed by a group index"))
+		}
+		if _, err := time.Parse(time.RFC3339, annotations[key]); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(key), annotations[key], "must be an RFC 3339 timestamp"))
+		}
+	}
+	return allErrs
+}
+
+// validatePinGroupAnnotations validates that the pin-group annotations are suffixed by a group index
+// and set to a revision hash, i.e. a valid label value.
+func validatePinGroupAnnotations(fldPath *field.Path, annotations map[string]string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, key := range slices.Sorted(maps.Keys(annotations)) {
+		indexStr, found := strings.CutPrefix(key, v1.PinGroupAnnotationKeyPrefix)
+		if !found {
+			continue
+		}
+		if index, err := strconv.Atoi(indexStr); err != nil || index < 0 || strconv.Itoa(index) != indexStr {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(key), key, "must be suffixed by a group index"))
+		}
+		if annotations[key] == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Key(key), "must be the revision to pin the group to"))
+		}
+		for _, msg := range utilvalidation.IsValidLabelValue(annotations[key]) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(key), annotations[key], msg))
+		}
+	}
+	return allErrs
+}
+
+// validatePinGroupRevisions rejects the pin-group annotations pointing at a revision the LeaderWorkerSet
+// doesn't have. Only the annotations added or changed since oldLws are checked, an old revision may have
+// been truncated since the group was pinned to it.
+func (r *LeaderWorkerSetWebhook) validatePinGroupRevisions(ctx context.Context, fldPath *field.Path, oldLws, newLws *v1.LeaderWorkerSet) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if r.RevisionReader == nil {
+		return allErrs
+	}
+	for _, key := range slices.Sorted(maps.Keys(newLws.Annotations)) {
+		revisionKey := newLws.Annotations[key]
+		if !strings.HasPrefix(key, v1.PinGroupAnnotationKeyPrefix) || revisionKey == "" {
+			continue
+		}
+		if oldLws != nil && oldLws.Annotations[key] == revisionKey {
+			continue
+		}
+		var revisions appsv1.ControllerRevisionList
+		if err := r.RevisionReader.List(ctx, &revisions, client.InNamespace(newLws.Namespace), client.MatchingLabels{
+			v1.SetNameLabelKey: newLws.Name,
+			v1.RevisionKey:     revisionKey,
+		}); err != nil {
+			allErrs = append(allErrs, field.InternalError(fldPath.Key(key), err))
+			continue
+		}
+		found := slices.ContainsFunc(revisions.Items, func(revision appsv1.ControllerRevision) bool {
+			owner := metav1.GetControllerOfNoCopy(&revision)
+			return owner != nil && owner.UID == newLws.UID
+		})
+		if !found {
+			allErrs = append(allErrs, field.NotFound(fldPath.Key(key), revisionKey))
+		}
+	}
+	return allErrs
+}
+
+// reservedLabelPrefix is the prefix of the labels managed by leaderworkerset on the pods.
+const reservedLabelPrefix = "leaderworkerset.sigs.k8s.io/"
+
+// validateReservedLabels rejects template labels under the leaderworkerset prefix, they're set
+// by the controller and the pod webhook, user defined values would be overridden or break the
+// selectors used to find the pods of a group.
+func validateReservedLabels(fldPath *field.Path, labels map[string]string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		if strings.HasPrefix(key, reservedLabelPrefix) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(key), labels[key], fmt.Sprintf("labels with the %q prefix are reserved for leaderworkerset", reservedLabelPrefix)))
+		}
+	}
+	return allErrs
+}
+
+// validateMembershipVolume forbids volumes named after the membership volume in the templates when
+// publishMembershipConfigMap is enabled, since the volume is added by the controller.
+func validateMembershipVolume(fldPath *field.Path, lws *v1.LeaderWorkerSet) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if !lws.Spec.LeaderWorkerTemplate.PublishMembershipConfigMap {
+		return allErrs
+	}
+	check := func(volumesPath *field.Path, volumes []corev1.Volume) {
+		for i, volume := range volumes {
+			if volume.Name == v1.MembershipVolumeName {
+				allErrs = append(allErrs, field.Invalid(volumesPath.Index(i).Child("name"), volume.Name, "is reserved for the membership configmap when publishMembershipConfigMap is enabled"))
+			}
+		}
+	}
+	if lws.Spec.LeaderWorkerTemplate.LeaderTemplate != nil {
+		check(fldPath.Child("leaderTemplate", "spec", "volumes"), lws.Spec.LeaderWorkerTemplate.LeaderTemplate.Spec.Volumes)
+	}
+	check(fldPath.Child("workerTemplate", "spec", "volumes"), lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec.Volumes)
+	return allErrs
+}
+
+// validateInheritLabels validates that the inherited label keys are unique valid label keys, which
+// are not reserved for leaderworkerset. Keys missing on the lws are allowed, they're ignored.
+func validateInheritLabels(fldPath *field.Path, keys []string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := sets.New[string]()
+	for i, key := range keys {
+		for _, msg := range utilvalidation.IsQualifiedName(key) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), key, msg))
+		}
+		if strings.HasPrefix(key, reservedLabelPrefix) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), key, fmt.Sprintf("labels with the %q prefix are reserved for leaderworkerset", reservedLabelPrefix)))
+		}
+		if seen.Has(key) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), key))
+		}
+		seen.Insert(key)
+	}
+	return allErrs
+}
+
+// defaultEnvVarNames are the environment variables injected into every container by the pod webhook.
+var defaultEnvVarNames = []string{v1.LwsLeaderAddress, v1.LwsGroupSize, v1.LwsWorkerIndex, v1.LwsPeerAddresses, v1.LwsPodFQDN, v1.LwsIsLeader, v1.LwsRank}
+
+// overridableEnvVarNames are the injected environment variables that can be renamed via networkEnvNames.
+var overridableEnvVarNames = []string{v1.LwsGroupSize, v1.LwsLeaderAddress, v1.LwsWorkerIndex}
+
+// injectedEnvVarNames returns the names of the environment variables injected by the pod webhook
+// with the overrides applied.
+func injectedEnvVarNames(overrides map[string]string) []string {
+	names := make([]string, 0, len(defaultEnvVarNames))
+	for _, name := range defaultEnvVarNames {
+		names = append(names, podutils.EnvName(overrides, name))
+	}
+	return names
+}
+
+// validateNetworkEnvNames validates that only the overridable environment variables are renamed,
+// to valid names which don't collide with the name of any other injected environment variable.
+func validateNetworkEnvNames(fldPath *field.Path, overrides map[string]string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, name := range slices.Sorted(maps.Keys(overrides)) {
+		if !slices.Contains(overridableEnvVarNames, name) {
+			allErrs = append(allErrs, field.NotSupported(fldPath, name, overridableEnvVarNames))
+			continue
+		}
+		override := overrides[name]
+		for _, msg := range utilvalidation.IsCIdentifier(override) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(name), override, msg))
+		}
+		for _, other := range defaultEnvVarNames {
+			if other != name && podutils.EnvName(overrides, other) == override {
+				allErrs = append(allErrs, field.Duplicate(fldPath.Key(name), override))
+				break
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateReservedEnvVars rejects containers defining an environment variable that is injected by
+// the pod webhook, since the user defined value would be silently overridden.
+func validateReservedEnvVars(fldPath *field.Path, podSpec *corev1.PodSpec, reservedEnvVarNames []string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	validateContainers := func(containersPath *field.Path, containers []corev1.Container) {
+		for i, container := range containers {
+			for j, env := range container.Env {
+				if slices.Contains(reservedEnvVarNames, env.Name) {
+					allErrs = append(allErrs, field.Invalid(containersPath.Index(i).Child("env").Index(j).Child("name"), env.Name,
+						fmt.Sprintf("environment variable is reserved and injected into container %q by leaderworkerset", container.Name)))
+				}
+			}
+		}
+	}
+	validateContainers(fldPath.Child("initContainers"), podSpec.InitContainers)
+	validateContainers(fldPath.Child("containers"), podSpec.Containers)
+	return allErrs
+}
+
+// validatePerGroupEnv validates that every group index is targeted at most once and lower than replicas,
+// and that the environment variables have valid names, none of them reserved.
+func validatePerGroupEnv(fldPath *field.Path, lws *v1.LeaderWorkerSet, reservedEnvVarNames []string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	replicas := ptr.Deref(lws.Spec.Replicas, 1)
+	groupIndexes := sets.New[int32]()
+	for i, groupEnv := range lws.Spec.LeaderWorkerTemplate.PerGroupEnv {
+		groupIndexPath := fldPath.Index(i).Child("groupIndex")
+		if groupEnv.GroupIndex < 0 || groupEnv.GroupIndex >= replicas {
+			allErrs = append(allErrs, field.Invalid(groupIndexPath, groupEnv.GroupIndex, fmt.Sprintf("must be in the range [0, %d)", replicas)))
+		} else if groupIndexes.Has(groupEnv.GroupIndex) {
+			allErrs = append(allErrs, field.Duplicate(groupIndexPath, groupEnv.GroupIndex))
+		}
+		groupIndexes.Insert(groupEnv.GroupIndex)
+		for j, env := range groupEnv.Env {
+			namePath := fldPath.Index(i).Child("env").Index(j).Child("name")
+			for _, msg := range utilvalidation.IsEnvVarName(env.Name) {
+				allErrs = append(allErrs, field.Invalid(namePath, env.Name, msg))
+			}
+			if slices.Contains(reservedEnvVarNames, env.Name) {
+				allErrs = append(allErrs, field.Invalid(namePath, env.Name, "environment variable is reserved and injected by leaderworkerset"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateRollingUpdateConfiguration validates maxUnavailable and maxSurge individually, and
+// rejects the configuration when both of them resolve to 0 against the current replicas, since
+// the rolling update could never make progress in that case.
+func validateRollingUpdateConfiguration(fldPath *field.Path, lws *v1.LeaderWorkerSet) field.ErrorList {
+	allErrs := field.ErrorList{}
+	config := lws.Spec.RolloutStrategy.RollingUpdateConfiguration
+
+	maxUnavailable := config.MaxUnavailable
+	maxUnavailablePath := fldPath.Child("maxUnavailable")
+	allErrs = append(allErrs, validatePositiveIntOrPercent(maxUnavailable, maxUnavailablePath)...)
+	// This is aligned with Statefulset.
+	allErrs = append(allErrs, isNotMoreThan100Percent(maxUnavailable, maxUnavailablePath)...)
+
+	maxSurge := config.MaxSurge
+	maxSurgePath := fldPath.Child("maxSurge")
+	allErrs = append(allErrs, validatePositiveIntOrPercent(maxSurge, maxSurgePath)...)
+	allErrs = append(allErrs, isNotMoreThan100Percent(maxSurge, maxSurgePath)...)
+
+	if config.DrainGracePeriodSeconds != nil {
+		allErrs = append(allErrs, validateNonnegativeField(int64(*config.DrainGracePeriodSeconds), fldPath.Child("drainGracePeriodSeconds"))...)
+	}
+
+	replicas := int(ptr.Deref(lws.Spec.Replicas, 1))
+	if config.Partition != nil && (*config.Partition < 0 || int(*config.Partition) > replicas) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("partition"), *config.Partition, fmt.Sprintf("must be between 0 and replicas %d", replicas)))
+	}
+	if config.Granularity == v1.SubGroupRolloutGranularity && lws.Spec.LeaderWorkerTemplate.SubGroupPolicy == nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("granularity"), config.Granularity, "requires leaderWorkerTemplate.subGroupPolicy to be set"))
+	}
+	// With the SubGroup granularity, maxUnavailable is scaled against the number of subgroups.
+	maxUnavailableValue, err := rolloututils.MaxUnavailableGroups(lws, int32(replicas))
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(maxUnavailablePath, maxUnavailable, "invalid value"))
+		return allErrs
+	}
+	maxSurgeValue, err := rolloututils.MaxSurge(config, int32(replicas))
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(maxSurgePath, maxSurge, "invalid value"))
+		return allErrs
+	}
+	if maxUnavailableValue == 0 && maxSurgeValue == 0 && replicas != 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, config, "maxUnavailable and maxSurge must not both be 0"))
+	}
+	return allErrs
+}
+
+// validateSize requires every group to hold at least the leader.
+func validateSize(fldPath *field.Path, lws *v1.LeaderWorkerSet) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if *lws.Spec.LeaderWorkerTemplate.Size < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath, lws.Spec.LeaderWorkerTemplate.Size, "size must be equal or greater than 1"))
+	}
+	return allErrs
+}
+
+// validateSizeWithLeaderTemplate rejects a group of size 1 with a leaderTemplate, the group would
+// only hold the leader so the leaderTemplate would be the only template ever used.
+func validateSizeWithLeaderTemplate(fldPath *field.Path, lws *v1.LeaderWorkerSet) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if ptr.Deref(lws.Spec.LeaderWorkerTemplate.Size, 1) == 1 && lws.Spec.LeaderWorkerTemplate.LeaderTemplate != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, lws.Spec.LeaderWorkerTemplate.Size, "size must be greater than 1 when leaderTemplate is set, a group of size 1 has no workers"))
+	}
+	return allErrs
+}
+
+// validateSizeUpdate forbids changing the group size while a rolling update is still in
+// progress, otherwise groups of the old and new cardinality would be mixed together and
+// worker pods of the old groups may be left behind.
+func validateSizeUpdate(oldLws, newLws *v1.LeaderWorkerSet, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	oldSize, newSize := ptr.Deref(oldLws.Spec.LeaderWorkerTemplate.Size, 1), ptr.Deref(newLws.Spec.LeaderWorkerTemplate.Size, 1)
+	if oldSize == newSize {
+		return allErrs
+	}
+	if oldLws.Status.UpdatedReplicas < oldLws.Status.Replicas {
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("cannot be changed while a rolling update is in progress, updated replicas %d out of %d", oldLws.Status.UpdatedReplicas, oldLws.Status.Replicas)))
+	}
+	return allErrs
+}
+
+// validateLeaderTemplateUpdate forbids adding or removing the leaderTemplate after creation,
+// since whether the leader has its own template changes the layout of the pods in a group.
+func validateLeaderTemplateUpdate(oldLws, newLws *v1.LeaderWorkerSet, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	oldTemplate, newTemplate := oldLws.Spec.LeaderWorkerTemplate.LeaderTemplate, newLws.Spec.LeaderWorkerTemplate.LeaderTemplate
+	if oldTemplate == nil && newTemplate != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "cannot be added after the lws is created"))
+	}
+	if oldTemplate != nil && newTemplate == nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "cannot be removed after the lws is created"))
+	}
+	return allErrs
+}
+
+// validateSubdomainPolicyUpdate forbids changing the subdomainPolicy after creation, since it changes
+// the DNS names the pods of running groups resolve each other by. An unset subdomainPolicy is the
+// Shared default, a null one on update is rejected separately.
+func validateSubdomainPolicyUpdate(oldLws, newLws *v1.LeaderWorkerSet, fldPath *field.Path) field.ErrorList {
+	if newLws.Spec.NetworkConfig == nil || newLws.Spec.NetworkConfig.SubdomainPolicy == nil {
+		return nil
+	}
+	oldPolicy := v1.SubdomainShared
+	if oldLws.Spec.NetworkConfig != nil && oldLws.Spec.NetworkConfig.SubdomainPolicy != nil {
+		oldPolicy = *oldLws.Spec.NetworkConfig.SubdomainPolicy
+	}
+	return apivalidation.ValidateImmutableField(*newLws.Spec.NetworkConfig.SubdomainPolicy, oldPolicy, fldPath)
+}
+
+// validateNetworkEnvNamesUpdate forbids changing the networkEnvNames while a rollout is in progress,
+// the groups of the old and new revisions would look each other up by different variable names
+// otherwise. They can be changed once all the groups are updated.
+func validateNetworkEnvNamesUpdate(oldLws, newLws *v1.LeaderWorkerSet, fldPath *field.Path) field.ErrorList {
+	if maps.Equal(oldLws.Spec.LeaderWorkerTemplate.NetworkEnvNames, newLws.Spec.LeaderWorkerTemplate.NetworkEnvNames) {
+		return nil
+	}
+	if oldLws.Status.UpdatedReplicas < oldLws.Status.Replicas {
+		return field.ErrorList{field.Forbidden(fldPath, fmt.Sprintf("cannot be changed while a rollout is in progress, %d of %d groups are updated", oldLws.Status.UpdatedReplicas, oldLws.Status.Replicas))}
+	}
+	return nil
+}
+
+// validateVolumeClaimTemplates validates that the volume claim templates have unique names which are
+// valid volume names, and that the retention policy is either Retain or Delete.
+func validateVolumeClaimTemplates(fldPath *field.Path, lws *v1.LeaderWorkerSet) field.ErrorList {
+	allErrs := field.ErrorList{}
+	templatesPath := fldPath.Child("volumeClaimTemplates")
+	names := sets.New[string]()
+	for i, claim := range lws.Spec.LeaderWorkerTemplate.VolumeClaimTemplates {
+		namePath := templatesPath.Index(i).Child("metadata", "name")
+		for _, msg := range utilvalidation.IsDNS1123Label(claim.Name) {
+			allErrs = append(allErrs, field.Invalid(namePath, claim.Name, msg))
+		}
+		if names.Has(claim.Name) {
+			allErrs = append(allErrs, field.Duplicate(namePath, claim.Name))
+		}
+		names.Insert(claim.Name)
+	}
+	policy := lws.Spec.LeaderWorkerTemplate.PersistentVolumeClaimRetentionPolicy
+	if policy == nil {
+		return allErrs
+	}
+	policyPath := fldPath.Child("persistentVolumeClaimRetentionPolicy")
+	validValues := []string{string(appsv1.RetainPersistentVolumeClaimRetentionPolicyType), string(appsv1.DeletePersistentVolumeClaimRetentionPolicyType)}
+	if policy.WhenDeleted != "" && !slices.Contains(validValues, string(policy.WhenDeleted)) {
+		allErrs = append(allErrs, field.NotSupported(policyPath.Child("whenDeleted"), policy.WhenDeleted, validValues))
+	}
+	if policy.WhenScaled != "" && !slices.Contains(validValues, string(policy.WhenScaled)) {
+		allErrs = append(allErrs, field.NotSupported(policyPath.Child("whenScaled"), policy.WhenScaled, validValues))
+	}
+	return allErrs
+}
+
+// validateVolumeClaimTemplatesUpdate forbids changing the volume claim templates and their retention
+// policy, since the volume claim templates of the statefulsets can't be updated.
+func validateVolumeClaimTemplatesUpdate(oldLws, newLws *v1.LeaderWorkerSet, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(newLws.Spec.LeaderWorkerTemplate.VolumeClaimTemplates, oldLws.Spec.LeaderWorkerTemplate.VolumeClaimTemplates, fldPath.Child("volumeClaimTemplates"))...)
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(newLws.Spec.LeaderWorkerTemplate.PersistentVolumeClaimRetentionPolicy, oldLws.Spec.LeaderWorkerTemplate.PersistentVolumeClaimRetentionPolicy, fldPath.Child("persistentVolumeClaimRetentionPolicy"))...)
+	return allErrs
+}
+
 // This is mostly inspired by https://github.com/kubernetes/kubernetes/blob/be4b7176dc131ea842cab6882cd4a06dbfeed12a/pkg/apis/apps/validation/validation.go#L460,
 // but it's not importable.
 
@@ -227,7 +1462,29 @@ func getPercentValue(intOrStringValue intstr.IntOrString) (int, bool) {
 func validateNonnegativeField(value int64, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if value < 0 {
-		allErrs = append(allErrs, field.Invalid(fldPath, value, "must be grater than or equal to 0"))
+		allErrs = append(allErrs, field.Invalid(fldPath, value, "must be greater than or equal to 0"))
+	}
+	return allErrs
+}
+
+// validateSubGroupSizeDividesSize rejects a subGroupSize that divides neither size nor size - 1,
+// which would leave the last subgroup with fewer pods. When size - 1 is divisible, the leader is
+// the extra pod of the first subgroup. With LeaderExcluded, only the workers are partitioned into
+// subgroups, which is covered by validateUpdateSubGroupPolicy.
+func validateSubGroupSizeDividesSize(fldPath *field.Path, lws *v1.LeaderWorkerSet) field.ErrorList {
+	allErrs := field.ErrorList{}
+	subGroupPolicy := lws.Spec.LeaderWorkerTemplate.SubGroupPolicy
+	if subGroupPolicy.Type != nil && *subGroupPolicy.Type == v1.SubGroupPolicyTypeLeaderExcluded {
+		return allErrs
+	}
+	size := *lws.Spec.LeaderWorkerTemplate.Size
+	subGroupSize := *subGroupPolicy.SubGroupSize
+	// Invalid or larger than size subGroupSize is already reported by validateUpdateSubGroupPolicy.
+	if subGroupSize < 1 || subGroupSize > size {
+		return allErrs
+	}
+	if size%subGroupSize != 0 && (size-1)%subGroupSize != 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, subGroupSize, fmt.Sprintf("size %d or size - 1 must be divisible by subGroupSize", size)))
 	}
 	return allErrs
 }
@@ -239,9 +1496,6 @@ func validateUpdateSubGroupPolicy(specPath *field.Path, lws *v1.LeaderWorkerSet)
 	if subGroupSize < 1 {
 		allErrs = append(allErrs, field.Invalid(specPath.Child("leaderWorkerTemplate", "SubGroupPolicy", "subGroupSize"), lws.Spec.LeaderWorkerTemplate.SubGroupPolicy.SubGroupSize, "subGroupSize must be equal or greater than 1"))
 	}
-	if (size%subGroupSize != 0) && ((size-1)%subGroupSize != 0) {
-		allErrs = append(allErrs, field.Invalid(specPath.Child("leaderWorkerTemplate", "SubGroupPolicy", "subGroupSize"), lws.Spec.LeaderWorkerTemplate.SubGroupPolicy.SubGroupSize, "size or size - 1 must be divisible by subGroupSize"))
-	}
 	if size < subGroupSize {
 		allErrs = append(allErrs, field.Invalid(specPath.Child("leaderWorkerTemplate", "SubGroupPolicy", "subGroupSize"), lws.Spec.LeaderWorkerTemplate.SubGroupPolicy.SubGroupSize, "subGroupSize cannot be larger than size"))
 	}
@@ -17,11 +17,29 @@ limitations under the License.
 package webhooks
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	v1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
 )
 
 func TestGetPercentValue(t *testing.T) {
@@ -96,7 +114,7 @@ func TestValidateNonnegativeOrZeroField(t *testing.T) {
 					Type:     field.ErrorTypeInvalid,
 					Field:    "test",
 					BadValue: int64(-1),
-					Detail:   "must be grater than or equal to 0",
+					Detail:   "must be greater than or equal to 0",
 				},
 			},
 		},
@@ -184,3 +202,3025 @@ func TestIsNotMoreThan100Percent(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateRollingUpdateConfiguration(t *testing.T) {
+	tests := []struct {
+		name                    string
+		replicas                int32
+		maxUnavailable          intstr.IntOrString
+		maxSurge                intstr.IntOrString
+		drainGracePeriodSeconds *int32
+		partition               *int32
+		granularity             v1.RolloutGranularity
+		subGroupPolicy          *v1.SubGroupPolicy
+		wantErr                 bool
+		wantErrField            string
+	}{
+		{
+			name:           "both int 0",
+			replicas:       2,
+			maxUnavailable: intstr.FromInt32(0),
+			maxSurge:       intstr.FromInt32(0),
+			wantErr:        true,
+		},
+		{
+			name:           "both 0%",
+			replicas:       2,
+			maxUnavailable: intstr.FromString("0%"),
+			maxSurge:       intstr.FromString("0%"),
+			wantErr:        true,
+		},
+		{
+			name:           "maxUnavailable int 0 and maxSurge 0%",
+			replicas:       2,
+			maxUnavailable: intstr.FromInt32(0),
+			maxSurge:       intstr.FromString("0%"),
+			wantErr:        true,
+		},
+		{
+			name:           "maxUnavailable 0% and maxSurge int 0",
+			replicas:       2,
+			maxUnavailable: intstr.FromString("0%"),
+			maxSurge:       intstr.FromInt32(0),
+			wantErr:        true,
+		},
+		{
+			name:           "maxUnavailable rounds down to 0 and maxSurge int 0",
+			replicas:       2,
+			maxUnavailable: intstr.FromString("25%"),
+			maxSurge:       intstr.FromInt32(0),
+			wantErr:        true,
+		},
+		{
+			name:           "maxUnavailable int 0 and maxSurge rounds up to 1",
+			replicas:       2,
+			maxUnavailable: intstr.FromInt32(0),
+			maxSurge:       intstr.FromString("25%"),
+		},
+		{
+			name:           "maxUnavailable int 1 and maxSurge int 0",
+			replicas:       2,
+			maxUnavailable: intstr.FromInt32(1),
+			maxSurge:       intstr.FromInt32(0),
+		},
+		{
+			name:           "both 0 with zero replicas",
+			replicas:       0,
+			maxUnavailable: intstr.FromInt32(0),
+			maxSurge:       intstr.FromString("0%"),
+		},
+		{
+			name:                    "drainGracePeriodSeconds 0",
+			replicas:                2,
+			maxUnavailable:          intstr.FromInt32(1),
+			maxSurge:                intstr.FromInt32(0),
+			drainGracePeriodSeconds: ptr.To[int32](0),
+		},
+		{
+			name:                    "positive drainGracePeriodSeconds",
+			replicas:                2,
+			maxUnavailable:          intstr.FromInt32(1),
+			maxSurge:                intstr.FromInt32(0),
+			drainGracePeriodSeconds: ptr.To[int32](30),
+		},
+		{
+			name:                    "negative drainGracePeriodSeconds",
+			replicas:                2,
+			maxUnavailable:          intstr.FromInt32(1),
+			maxSurge:                intstr.FromInt32(0),
+			drainGracePeriodSeconds: ptr.To[int32](-1),
+			wantErr:                 true,
+			wantErrField:            "spec.rolloutStrategy.rollingUpdateConfiguration.drainGracePeriodSeconds",
+		},
+		{
+			name:           "partition 0",
+			replicas:       2,
+			maxUnavailable: intstr.FromInt32(1),
+			maxSurge:       intstr.FromInt32(0),
+			partition:      ptr.To[int32](0),
+		},
+		{
+			name:           "partition equal to replicas",
+			replicas:       2,
+			maxUnavailable: intstr.FromInt32(1),
+			maxSurge:       intstr.FromInt32(0),
+			partition:      ptr.To[int32](2),
+		},
+		{
+			name:           "partition greater than replicas",
+			replicas:       2,
+			maxUnavailable: intstr.FromInt32(1),
+			maxSurge:       intstr.FromInt32(0),
+			partition:      ptr.To[int32](3),
+			wantErr:        true,
+			wantErrField:   "spec.rolloutStrategy.rollingUpdateConfiguration.partition",
+		},
+		{
+			name:           "negative partition",
+			replicas:       2,
+			maxUnavailable: intstr.FromInt32(1),
+			maxSurge:       intstr.FromInt32(0),
+			partition:      ptr.To[int32](-1),
+			wantErr:        true,
+			wantErrField:   "spec.rolloutStrategy.rollingUpdateConfiguration.partition",
+		},
+		{
+			name:           "subgroup granularity without subGroupPolicy",
+			replicas:       2,
+			maxUnavailable: intstr.FromInt32(1),
+			maxSurge:       intstr.FromInt32(0),
+			granularity:    v1.SubGroupRolloutGranularity,
+			wantErr:        true,
+			wantErrField:   "spec.rolloutStrategy.rollingUpdateConfiguration.granularity",
+		},
+		{
+			name:           "maxUnavailable rounds down to 0 groups but not to 0 subgroups",
+			replicas:       2,
+			maxUnavailable: intstr.FromString("30%"),
+			maxSurge:       intstr.FromInt32(0),
+			granularity:    v1.SubGroupRolloutGranularity,
+			subGroupPolicy: &v1.SubGroupPolicy{SubGroupSize: ptr.To[int32](4)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := &v1.LeaderWorkerSet{
+				Spec: v1.LeaderWorkerSetSpec{
+					Replicas: ptr.To(tc.replicas),
+					LeaderWorkerTemplate: v1.LeaderWorkerTemplate{
+						Size:           ptr.To[int32](8),
+						SubGroupPolicy: tc.subGroupPolicy,
+					},
+					RolloutStrategy: v1.RolloutStrategy{
+						RollingUpdateConfiguration: &v1.RollingUpdateConfiguration{
+							MaxUnavailable:          tc.maxUnavailable,
+							MaxSurge:                tc.maxSurge,
+							DrainGracePeriodSeconds: tc.drainGracePeriodSeconds,
+							Partition:               tc.partition,
+							Granularity:             tc.granularity,
+						},
+					},
+				},
+			}
+			fldPath := field.NewPath("spec", "rolloutStrategy", "rollingUpdateConfiguration")
+			errs := validateRollingUpdateConfiguration(fldPath, lws)
+			if gotErr := len(errs) > 0; gotErr != tc.wantErr {
+				t.Fatalf("unexpected errors, want error: %t, got: %v", tc.wantErr, errs)
+			}
+			wantErrField := fldPath.String()
+			if tc.wantErrField != "" {
+				wantErrField = tc.wantErrField
+			}
+			for _, err := range errs {
+				if err.Field != wantErrField {
+					t.Errorf("unexpected error field, want: %s, got: %s", wantErrField, err.Field)
+				}
+			}
+		})
+	}
+}
+
+func TestDefaultRolloutStrategy(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    v1.RolloutStrategy
+		expected v1.RolloutStrategy
+	}{
+		{
+			name:  "rollout strategy omitted",
+			input: v1.RolloutStrategy{},
+			expected: v1.RolloutStrategy{
+				Type: v1.RollingUpdateStrategyType,
+				RollingUpdateConfiguration: &v1.RollingUpdateConfiguration{
+					MaxUnavailable: intstr.FromInt32(1),
+					MaxSurge:       intstr.FromInt32(0),
+				},
+			},
+		},
+		{
+			name: "only type set",
+			input: v1.RolloutStrategy{
+				Type: v1.RollingUpdateStrategyType,
+			},
+			expected: v1.RolloutStrategy{
+				Type: v1.RollingUpdateStrategyType,
+				RollingUpdateConfiguration: &v1.RollingUpdateConfiguration{
+					MaxUnavailable: intstr.FromInt32(1),
+					MaxSurge:       intstr.FromInt32(0),
+				},
+			},
+		},
+		{
+			name: "only rollingUpdateConfiguration set",
+			input: v1.RolloutStrategy{
+				RollingUpdateConfiguration: &v1.RollingUpdateConfiguration{
+					MaxUnavailable: intstr.FromInt32(2),
+					MaxSurge:       intstr.FromString("50%"),
+				},
+			},
+			expected: v1.RolloutStrategy{
+				Type: v1.RollingUpdateStrategyType,
+				RollingUpdateConfiguration: &v1.RollingUpdateConfiguration{
+					MaxUnavailable: intstr.FromInt32(2),
+					MaxSurge:       intstr.FromString("50%"),
+				},
+			},
+		},
+		{
+			name: "partial rollingUpdateConfiguration is not overwritten",
+			input: v1.RolloutStrategy{
+				Type: v1.RollingUpdateStrategyType,
+				RollingUpdateConfiguration: &v1.RollingUpdateConfiguration{
+					MaxUnavailable: intstr.FromInt32(1),
+					MaxSurge:       intstr.FromInt32(3),
+				},
+			},
+			expected: v1.RolloutStrategy{
+				Type: v1.RollingUpdateStrategyType,
+				RollingUpdateConfiguration: &v1.RollingUpdateConfiguration{
+					MaxUnavailable: intstr.FromInt32(1),
+					MaxSurge:       intstr.FromInt32(3),
+				},
+			},
+		},
+		{
+			name: "recreate strategy doesn't get a default rollingUpdateConfiguration",
+			input: v1.RolloutStrategy{
+				Type: v1.RecreateStrategyType,
+			},
+			expected: v1.RolloutStrategy{
+				Type: v1.RecreateStrategyType,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := &v1.LeaderWorkerSet{
+				Spec: v1.LeaderWorkerSetSpec{
+					RolloutStrategy: tc.input,
+				},
+			}
+			if err := (&LeaderWorkerSetWebhook{}).Default(context.Background(), lws); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, lws.Spec.RolloutStrategy); diff != "" {
+				t.Errorf("unexpected result: (-want, +got) %s", diff)
+			}
+		})
+	}
+}
+
+func TestDefaultScaleDownPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    v1.ScaleDownPolicyType
+		expected v1.ScaleDownPolicyType
+	}{
+		{
+			name:     "scaleDownPolicy omitted",
+			expected: v1.HighestIndexFirstScaleDownPolicy,
+		},
+		{
+			name:     "scaleDownPolicy set",
+			input:    v1.LowestIndexFirstScaleDownPolicy,
+			expected: v1.LowestIndexFirstScaleDownPolicy,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := &v1.LeaderWorkerSet{
+				Spec: v1.LeaderWorkerSetSpec{
+					ScaleDownPolicy: tc.input,
+				},
+			}
+			if err := (&LeaderWorkerSetWebhook{}).Default(context.Background(), lws); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if lws.Spec.ScaleDownPolicy != tc.expected {
+				t.Errorf("unexpected scaleDownPolicy, want: %s, got: %s", tc.expected, lws.Spec.ScaleDownPolicy)
+			}
+		})
+	}
+}
+
+func TestDefaultSuspend(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *bool
+		expected bool
+	}{
+		{
+			name:     "suspend omitted",
+			expected: false,
+		},
+		{
+			name:     "suspend set",
+			input:    ptr.To(true),
+			expected: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := &v1.LeaderWorkerSet{
+				Spec: v1.LeaderWorkerSetSpec{
+					Suspend: tc.input,
+				},
+			}
+			if err := (&LeaderWorkerSetWebhook{}).Default(context.Background(), lws); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if lws.Spec.Suspend == nil || *lws.Spec.Suspend != tc.expected {
+				t.Errorf("unexpected suspend, want: %t, got: %v", tc.expected, lws.Spec.Suspend)
+			}
+		})
+	}
+}
+
+func TestValidateSizeUpdate(t *testing.T) {
+	tests := []struct {
+		name            string
+		oldSize         int32
+		newSize         int32
+		replicas        int32
+		updatedReplicas int32
+		wantErr         bool
+	}{
+		{
+			name:            "size unchanged during rolling update",
+			oldSize:         2,
+			newSize:         2,
+			replicas:        3,
+			updatedReplicas: 1,
+		},
+		{
+			name:            "size changed during rolling update",
+			oldSize:         2,
+			newSize:         4,
+			replicas:        3,
+			updatedReplicas: 1,
+			wantErr:         true,
+		},
+		{
+			name:            "size changed after rolling update completed",
+			oldSize:         2,
+			newSize:         4,
+			replicas:        3,
+			updatedReplicas: 3,
+		},
+		{
+			name:    "size changed before any replica is created",
+			oldSize: 2,
+			newSize: 4,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			oldLws := &v1.LeaderWorkerSet{
+				Spec: v1.LeaderWorkerSetSpec{
+					LeaderWorkerTemplate: v1.LeaderWorkerTemplate{Size: ptr.To(tc.oldSize)},
+				},
+				Status: v1.LeaderWorkerSetStatus{
+					Replicas:        tc.replicas,
+					UpdatedReplicas: tc.updatedReplicas,
+				},
+			}
+			newLws := oldLws.DeepCopy()
+			newLws.Spec.LeaderWorkerTemplate.Size = ptr.To(tc.newSize)
+
+			fldPath := field.NewPath("spec", "leaderWorkerTemplate", "size")
+			errs := validateSizeUpdate(oldLws, newLws, fldPath)
+			if gotErr := len(errs) > 0; gotErr != tc.wantErr {
+				t.Fatalf("unexpected errors, want error: %t, got: %v", tc.wantErr, errs)
+			}
+			for _, err := range errs {
+				if err.Field != fldPath.String() {
+					t.Errorf("unexpected error field, want: %s, got: %s", fldPath.String(), err.Field)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateLeaderTemplateUpdate(t *testing.T) {
+	leaderTemplate := &corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "leader", Image: "nginx"}}},
+	}
+	tests := []struct {
+		name              string
+		oldLeaderTemplate *corev1.PodTemplateSpec
+		newLeaderTemplate *corev1.PodTemplateSpec
+		wantErr           bool
+	}{
+		{
+			name:              "leaderTemplate added",
+			newLeaderTemplate: leaderTemplate,
+			wantErr:           true,
+		},
+		{
+			name:              "leaderTemplate removed",
+			oldLeaderTemplate: leaderTemplate,
+			wantErr:           true,
+		},
+		{
+			name:              "leaderTemplate unchanged",
+			oldLeaderTemplate: leaderTemplate,
+			newLeaderTemplate: leaderTemplate,
+		},
+		{
+			name: "leaderTemplate unset",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			oldLws := &v1.LeaderWorkerSet{
+				Spec: v1.LeaderWorkerSetSpec{
+					LeaderWorkerTemplate: v1.LeaderWorkerTemplate{LeaderTemplate: tc.oldLeaderTemplate},
+				},
+			}
+			newLws := &v1.LeaderWorkerSet{
+				Spec: v1.LeaderWorkerSetSpec{
+					LeaderWorkerTemplate: v1.LeaderWorkerTemplate{LeaderTemplate: tc.newLeaderTemplate},
+				},
+			}
+
+			fldPath := field.NewPath("spec", "leaderWorkerTemplate", "leaderTemplate")
+			errs := validateLeaderTemplateUpdate(oldLws, newLws, fldPath)
+			if gotErr := len(errs) > 0; gotErr != tc.wantErr {
+				t.Fatalf("unexpected errors, want error: %t, got: %v", tc.wantErr, errs)
+			}
+			for _, err := range errs {
+				if err.Field != fldPath.String() {
+					t.Errorf("unexpected error field, want: %s, got: %s", fldPath.String(), err.Field)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateSubdomainPolicyUpdate(t *testing.T) {
+	tests := []struct {
+		name             string
+		oldNetworkConfig *v1.NetworkConfig
+		newNetworkConfig *v1.NetworkConfig
+		wantErr          bool
+	}{
+		{
+			name:             "unchanged",
+			oldNetworkConfig: &v1.NetworkConfig{SubdomainPolicy: ptr.To(v1.SubdomainUniquePerReplica)},
+			newNetworkConfig: &v1.NetworkConfig{SubdomainPolicy: ptr.To(v1.SubdomainUniquePerReplica)},
+		},
+		{
+			name:             "changed from Shared to UniquePerReplica",
+			oldNetworkConfig: &v1.NetworkConfig{SubdomainPolicy: ptr.To(v1.SubdomainShared)},
+			newNetworkConfig: &v1.NetworkConfig{SubdomainPolicy: ptr.To(v1.SubdomainUniquePerReplica)},
+			wantErr:          true,
+		},
+		{
+			name:             "changed from UniquePerReplica to Shared",
+			oldNetworkConfig: &v1.NetworkConfig{SubdomainPolicy: ptr.To(v1.SubdomainUniquePerReplica)},
+			newNetworkConfig: &v1.NetworkConfig{SubdomainPolicy: ptr.To(v1.SubdomainShared)},
+			wantErr:          true,
+		},
+		{
+			name:             "nil set to the Shared default",
+			newNetworkConfig: &v1.NetworkConfig{SubdomainPolicy: ptr.To(v1.SubdomainShared)},
+		},
+		{
+			name:             "nil set to UniquePerReplica",
+			newNetworkConfig: &v1.NetworkConfig{SubdomainPolicy: ptr.To(v1.SubdomainUniquePerReplica)},
+			wantErr:          true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			oldLws := &v1.LeaderWorkerSet{Spec: v1.LeaderWorkerSetSpec{NetworkConfig: tc.oldNetworkConfig}}
+			newLws := &v1.LeaderWorkerSet{Spec: v1.LeaderWorkerSetSpec{NetworkConfig: tc.newNetworkConfig}}
+
+			fldPath := field.NewPath("spec", "networkConfig", "subdomainPolicy")
+			errs := validateSubdomainPolicyUpdate(oldLws, newLws, fldPath)
+			if gotErr := len(errs) > 0; gotErr != tc.wantErr {
+				t.Fatalf("unexpected errors, want error: %t, got: %v", tc.wantErr, errs)
+			}
+			for _, err := range errs {
+				if err.Field != fldPath.String() {
+					t.Errorf("unexpected error field, want: %s, got: %s", fldPath.String(), err.Field)
+				}
+			}
+		})
+	}
+}
+
+func TestResourceWarnings(t *testing.T) {
+	container := func(name, request, limit string) corev1.Container {
+		return corev1.Container{
+			Name: name,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(request), corev1.ResourceMemory: resource.MustParse("1Gi")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(limit)},
+			},
+		}
+	}
+	tests := []struct {
+		name           string
+		leaderTemplate *corev1.PodTemplateSpec
+		workerSpec     corev1.PodSpec
+		wantWarnings   admission.Warnings
+	}{
+		{
+			name:       "requests within limits",
+			workerSpec: corev1.PodSpec{Containers: []corev1.Container{container("worker", "1", "1")}},
+		},
+		{
+			name:       "worker requests exceed limits",
+			workerSpec: corev1.PodSpec{Containers: []corev1.Container{container("sidecar", "500m", "1"), container("worker", "2", "1")}},
+			wantWarnings: admission.Warnings{
+				`spec.leaderWorkerTemplate.workerTemplate.spec.containers[1]: container "worker" requests 2 of cpu, which is greater than its limit 1`,
+			},
+		},
+		{
+			name:           "leader and worker init container requests exceed limits",
+			leaderTemplate: &corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{container("leader", "1500m", "1")}}},
+			workerSpec: corev1.PodSpec{
+				InitContainers: []corev1.Container{container("init", "2", "1")},
+				Containers:     []corev1.Container{container("worker", "1", "1")},
+			},
+			wantWarnings: admission.Warnings{
+				`spec.leaderWorkerTemplate.leaderTemplate.spec.containers[0]: container "leader" requests 1500m of cpu, which is greater than its limit 1`,
+				`spec.leaderWorkerTemplate.workerTemplate.spec.initContainers[0]: container "init" requests 2 of cpu, which is greater than its limit 1`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := &v1.LeaderWorkerSet{
+				Spec: v1.LeaderWorkerSetSpec{
+					LeaderWorkerTemplate: v1.LeaderWorkerTemplate{
+						LeaderTemplate: tc.leaderTemplate,
+						WorkerTemplate: corev1.PodTemplateSpec{Spec: tc.workerSpec},
+					},
+				},
+			}
+			if diff := cmp.Diff(tc.wantWarnings, resourceWarnings(lws)); diff != "" {
+				t.Errorf("unexpected warnings (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateUpdateDryRunPlan(t *testing.T) {
+	oldLws := &v1.LeaderWorkerSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1.LeaderWorkerSetSpec{
+			Replicas: ptr.To[int32](4),
+			LeaderWorkerTemplate: v1.LeaderWorkerTemplate{
+				Size: ptr.To[int32](2),
+				WorkerTemplate: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "worker", Image: "nginx"}}},
+				},
+			},
+			RolloutStrategy: v1.RolloutStrategy{
+				Type: v1.RollingUpdateStrategyType,
+				RollingUpdateConfiguration: &v1.RollingUpdateConfiguration{
+					MaxUnavailable: intstr.FromInt32(1),
+					MaxSurge:       intstr.FromInt32(1),
+				},
+			},
+			StartupPolicy: v1.LeaderCreatedStartupPolicy,
+		},
+		Status: v1.LeaderWorkerSetStatus{Replicas: 4},
+	}
+
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		wantWarnings admission.Warnings
+	}{
+		{
+			name: "without the annotation",
+		},
+		{
+			name:        "with the annotation",
+			annotations: map[string]string{v1.DryRunPlanAnnotationKey: ""},
+			wantWarnings: admission.Warnings{
+				"dry-run plan: 5 groups will be created and 5 groups will be deleted, with up to 1 surge groups and 1 unavailable groups at a time",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			newLws := oldLws.DeepCopy()
+			newLws.Annotations = tc.annotations
+			newLws.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec.Containers[0].Image = "nginx:latest"
+
+			webhook := &LeaderWorkerSetWebhook{}
+			warnings, err := webhook.ValidateUpdate(context.Background(), oldLws, newLws)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantWarnings, warnings); diff != "" {
+				t.Errorf("unexpected warnings (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateExclusiveTopology(t *testing.T) {
+	fldPath := field.NewPath("spec", "leaderWorkerTemplate", "exclusiveTopology")
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		topologyKey   string
+		wantErrFields []string
+	}{
+		{
+			name:        "hostname",
+			topologyKey: "kubernetes.io/hostname",
+		},
+		{
+			name:        "custom rack label",
+			topologyKey: "example.com/rack",
+		},
+		{
+			name:          "empty topology key",
+			wantErrFields: []string{fldPath.Child("topologyKey").String()},
+		},
+		{
+			name:          "invalid topology key",
+			topologyKey:   "example.com/rack/name",
+			wantErrFields: []string{fldPath.Child("topologyKey").String()},
+		},
+		{
+			name:        "same key as the exclusive-topology annotation",
+			annotations: map[string]string{v1.ExclusiveKeyAnnotationKey: "example.com/rack"},
+			topologyKey: "example.com/rack",
+		},
+		{
+			name:          "conflicting exclusive-topology annotation",
+			annotations:   map[string]string{v1.ExclusiveKeyAnnotationKey: "kubernetes.io/hostname"},
+			topologyKey:   "example.com/rack",
+			wantErrFields: []string{fldPath.Child("topologyKey").String()},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := &v1.LeaderWorkerSet{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations},
+				Spec: v1.LeaderWorkerSetSpec{
+					LeaderWorkerTemplate: v1.LeaderWorkerTemplate{
+						ExclusiveTopology: &v1.ExclusiveTopology{TopologyKey: tc.topologyKey},
+					},
+				},
+			}
+			var gotErrFields []string
+			for _, err := range validateExclusiveTopology(fldPath, lws) {
+				gotErrFields = append(gotErrFields, err.Field)
+			}
+			if diff := cmp.Diff(tc.wantErrFields, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateSubGroupTopologyKey(t *testing.T) {
+	fldPath := field.NewPath("spec", "leaderWorkerTemplate", "subGroupPolicy", "subGroupTopologyKey")
+	tests := []struct {
+		name                string
+		annotations         map[string]string
+		exclusiveTopology   *v1.ExclusiveTopology
+		subGroupTopologyKey string
+		wantErrFields       []string
+	}{
+		{
+			name:                "hostname within the rack of the group",
+			exclusiveTopology:   &v1.ExclusiveTopology{TopologyKey: "example.com/rack"},
+			subGroupTopologyKey: "kubernetes.io/hostname",
+		},
+		{
+			name:                "group placed with the exclusive-topology annotation",
+			annotations:         map[string]string{v1.ExclusiveKeyAnnotationKey: "example.com/rack"},
+			subGroupTopologyKey: "kubernetes.io/hostname",
+		},
+		{
+			name:                "group not exclusively placed",
+			subGroupTopologyKey: "kubernetes.io/hostname",
+			wantErrFields:       []string{fldPath.String()},
+		},
+		{
+			name:              "empty subgroup topology key",
+			exclusiveTopology: &v1.ExclusiveTopology{TopologyKey: "example.com/rack"},
+			wantErrFields:     []string{fldPath.String()},
+		},
+		{
+			name:                "invalid subgroup topology key",
+			exclusiveTopology:   &v1.ExclusiveTopology{TopologyKey: "example.com/rack"},
+			subGroupTopologyKey: "example.com/host/name",
+			wantErrFields:       []string{fldPath.String()},
+		},
+		{
+			name:                "conflicting subgroup-exclusive-topology annotation",
+			annotations:         map[string]string{v1.SubGroupExclusiveKeyAnnotationKey: "example.com/block"},
+			exclusiveTopology:   &v1.ExclusiveTopology{TopologyKey: "example.com/rack"},
+			subGroupTopologyKey: "kubernetes.io/hostname",
+			wantErrFields:       []string{fldPath.String()},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := &v1.LeaderWorkerSet{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations},
+				Spec: v1.LeaderWorkerSetSpec{
+					LeaderWorkerTemplate: v1.LeaderWorkerTemplate{
+						ExclusiveTopology: tc.exclusiveTopology,
+						SubGroupPolicy: &v1.SubGroupPolicy{
+							SubGroupSize:        ptr.To[int32](2),
+							SubGroupTopologyKey: ptr.To(tc.subGroupTopologyKey),
+						},
+					},
+				},
+			}
+			var gotErrFields []string
+			for _, err := range validateSubGroupTopologyKey(fldPath, lws) {
+				gotErrFields = append(gotErrFields, err.Field)
+			}
+			if diff := cmp.Diff(tc.wantErrFields, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestExclusiveTopologyWarnings(t *testing.T) {
+	node := func(name string, labels map[string]string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		topologyKey  string
+		nodes        []client.Object
+		listErr      error
+		wantWarnings admission.Warnings
+	}{
+		{
+			name:  "exclusive placement disabled",
+			nodes: []client.Object{node("node-1", nil)},
+		},
+		{
+			name:        "a node carries the topology key",
+			topologyKey: "example.com/rack",
+			nodes:       []client.Object{node("node-1", nil), node("node-2", map[string]string{"example.com/rack": "rack-1"})},
+		},
+		{
+			name:         "no node carries the topology key",
+			topologyKey:  "example.com/rack",
+			nodes:        []client.Object{node("node-1", map[string]string{"kubernetes.io/hostname": "node-1"})},
+			wantWarnings: admission.Warnings{`no node in the cluster has the exclusive topology key "example.com/rack", the groups won't be scheduled until such nodes are added`},
+		},
+		{
+			name:         "no node carries the exclusive-topology annotation key",
+			annotations:  map[string]string{v1.ExclusiveKeyAnnotationKey: "example.com/zone"},
+			nodes:        []client.Object{node("node-1", map[string]string{"example.com/rack": "rack-1"})},
+			wantWarnings: admission.Warnings{`no node in the cluster has the exclusive topology key "example.com/zone", the groups won't be scheduled until such nodes are added`},
+		},
+		{
+			name:        "listing the nodes fails",
+			topologyKey: "example.com/rack",
+			listErr:     errors.New("cache not synced"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := &v1.LeaderWorkerSet{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			if tc.topologyKey != "" {
+				lws.Spec.LeaderWorkerTemplate.ExclusiveTopology = &v1.ExclusiveTopology{TopologyKey: tc.topologyKey}
+			}
+			builder := fake.NewClientBuilder().WithObjects(tc.nodes...)
+			if tc.listErr != nil {
+				builder = builder.WithInterceptorFuncs(interceptor.Funcs{
+					List: func(context.Context, client.WithWatch, client.ObjectList, ...client.ListOption) error {
+						return tc.listErr
+					},
+				})
+			}
+			wh := &LeaderWorkerSetWebhook{NodeReader: builder.Build()}
+			if diff := cmp.Diff(tc.wantWarnings, wh.exclusiveTopologyWarnings(context.Background(), lws)); diff != "" {
+				t.Errorf("unexpected warnings (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestServiceAccountWarnings(t *testing.T) {
+	serviceAccount := func(namespace, name string) *corev1.ServiceAccount {
+		return &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+	tests := []struct {
+		name                     string
+		leaderServiceAccountName string
+		workerServiceAccountName string
+		serviceAccounts          []client.Object
+		getErr                   error
+		wantWarnings             admission.Warnings
+	}{
+		{
+			name:            "no override",
+			serviceAccounts: []client.Object{serviceAccount("default", "coordinator")},
+		},
+		{
+			name:                     "both service accounts exist",
+			leaderServiceAccountName: "coordinator",
+			workerServiceAccountName: "worker",
+			serviceAccounts:          []client.Object{serviceAccount("default", "coordinator"), serviceAccount("default", "worker")},
+		},
+		{
+			name:                     "worker service account missing",
+			leaderServiceAccountName: "coordinator",
+			workerServiceAccountName: "worker",
+			serviceAccounts:          []client.Object{serviceAccount("default", "coordinator")},
+			wantWarnings:             admission.Warnings{`spec.leaderWorkerTemplate.workerServiceAccountName: ServiceAccount "worker" not found, the pods won't be created until it is`},
+		},
+		{
+			name:                     "leader service account in another namespace",
+			leaderServiceAccountName: "coordinator",
+			serviceAccounts:          []client.Object{serviceAccount("other", "coordinator")},
+			wantWarnings:             admission.Warnings{`spec.leaderWorkerTemplate.leaderServiceAccountName: ServiceAccount "coordinator" not found, the pods won't be created until it is`},
+		},
+		{
+			name:                     "getting the service account fails",
+			leaderServiceAccountName: "coordinator",
+			getErr:                   errors.New("forbidden"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := &v1.LeaderWorkerSet{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}}
+			lws.Spec.LeaderWorkerTemplate.LeaderServiceAccountName = tc.leaderServiceAccountName
+			lws.Spec.LeaderWorkerTemplate.WorkerServiceAccountName = tc.workerServiceAccountName
+			builder := fake.NewClientBuilder().WithObjects(tc.serviceAccounts...)
+			if tc.getErr != nil {
+				builder = builder.WithInterceptorFuncs(interceptor.Funcs{
+					Get: func(context.Context, client.WithWatch, client.ObjectKey, client.Object, ...client.GetOption) error {
+						return tc.getErr
+					},
+				})
+			}
+			wh := &LeaderWorkerSetWebhook{ServiceAccountReader: builder.Build()}
+			if diff := cmp.Diff(tc.wantWarnings, wh.serviceAccountWarnings(context.Background(), lws)); diff != "" {
+				t.Errorf("unexpected warnings (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateCommonContainers(t *testing.T) {
+	fldPath := field.NewPath("spec", "leaderWorkerTemplate", "commonContainers")
+	tests := []struct {
+		name             string
+		commonContainers []corev1.Container
+		wantErrFields    []string
+	}{
+		{
+			name:             "container and native sidecar",
+			commonContainers: []corev1.Container{{Name: "metrics"}, {Name: "logger", RestartPolicy: ptr.To(corev1.ContainerRestartPolicyAlways)}},
+		},
+		{
+			name:             "collides with a leader container",
+			commonContainers: []corev1.Container{{Name: "leader"}},
+			wantErrFields:    []string{fldPath.Index(0).Child("name").String()},
+		},
+		{
+			name:             "collides with a worker init container",
+			commonContainers: []corev1.Container{{Name: "init"}},
+			wantErrFields:    []string{fldPath.Index(0).Child("name").String()},
+		},
+		{
+			name:             "duplicated names",
+			commonContainers: []corev1.Container{{Name: "logger"}, {Name: "logger"}},
+			wantErrFields:    []string{fldPath.Index(1).Child("name").String()},
+		},
+		{
+			name:             "invalid name",
+			commonContainers: []corev1.Container{{Name: "Logger"}},
+			wantErrFields:    []string{fldPath.Index(0).Child("name").String()},
+		},
+		{
+			name:             "restartPolicy other than Always",
+			commonContainers: []corev1.Container{{Name: "logger", RestartPolicy: ptr.To[corev1.ContainerRestartPolicy]("OnFailure")}},
+			wantErrFields:    []string{fldPath.Index(0).Child("restartPolicy").String()},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := &v1.LeaderWorkerSet{
+				Spec: v1.LeaderWorkerSetSpec{
+					LeaderWorkerTemplate: v1.LeaderWorkerTemplate{
+						LeaderTemplate: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "leader"}}},
+						},
+						WorkerTemplate: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								InitContainers: []corev1.Container{{Name: "init"}},
+								Containers:     []corev1.Container{{Name: "worker"}},
+							},
+						},
+						CommonContainers: tc.commonContainers,
+					},
+				},
+			}
+			var gotErrFields []string
+			for _, err := range validateCommonContainers(fldPath, lws) {
+				gotErrFields = append(gotErrFields, err.Field)
+			}
+			if diff := cmp.Diff(tc.wantErrFields, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateLeaderGroupInitContainers(t *testing.T) {
+	fldPath := field.NewPath("spec", "leaderWorkerTemplate", "leaderGroupInitContainers")
+	tests := []struct {
+		name                      string
+		noLeaderTemplate          bool
+		leaderGroupInitContainers []corev1.Container
+		wantErrFields             []string
+	}{
+		{
+			name:                      "valid init container",
+			leaderGroupInitContainers: []corev1.Container{{Name: "coordinate"}},
+		},
+		{
+			name:                      "same name as a worker container",
+			leaderGroupInitContainers: []corev1.Container{{Name: "worker"}},
+		},
+		{
+			name:                      "collides with a worker container without leader template",
+			noLeaderTemplate:          true,
+			leaderGroupInitContainers: []corev1.Container{{Name: "worker"}},
+			wantErrFields:             []string{fldPath.Index(0).Child("name").String()},
+		},
+		{
+			name:                      "collides with a leader container",
+			leaderGroupInitContainers: []corev1.Container{{Name: "leader"}},
+			wantErrFields:             []string{fldPath.Index(0).Child("name").String()},
+		},
+		{
+			name:                      "collides with a common container",
+			leaderGroupInitContainers: []corev1.Container{{Name: "logger"}},
+			wantErrFields:             []string{fldPath.Index(0).Child("name").String()},
+		},
+		{
+			name:                      "duplicated names",
+			leaderGroupInitContainers: []corev1.Container{{Name: "coordinate"}, {Name: "coordinate"}},
+			wantErrFields:             []string{fldPath.Index(1).Child("name").String()},
+		},
+		{
+			name:                      "invalid name",
+			leaderGroupInitContainers: []corev1.Container{{Name: "Coordinate"}},
+			wantErrFields:             []string{fldPath.Index(0).Child("name").String()},
+		},
+		{
+			name:                      "restartPolicy set",
+			leaderGroupInitContainers: []corev1.Container{{Name: "coordinate", RestartPolicy: ptr.To(corev1.ContainerRestartPolicyAlways)}},
+			wantErrFields:             []string{fldPath.Index(0).Child("restartPolicy").String()},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := &v1.LeaderWorkerSet{
+				Spec: v1.LeaderWorkerSetSpec{
+					LeaderWorkerTemplate: v1.LeaderWorkerTemplate{
+						LeaderTemplate: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "leader"}}},
+						},
+						WorkerTemplate: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "worker"}}},
+						},
+						CommonContainers:          []corev1.Container{{Name: "logger"}},
+						LeaderGroupInitContainers: tc.leaderGroupInitContainers,
+					},
+				},
+			}
+			if tc.noLeaderTemplate {
+				lws.Spec.LeaderWorkerTemplate.LeaderTemplate = nil
+			}
+			var gotErrFields []string
+			for _, err := range validateLeaderGroupInitContainers(fldPath, lws) {
+				gotErrFields = append(gotErrFields, err.Field)
+			}
+			if diff := cmp.Diff(tc.wantErrFields, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidatePodFailurePolicy(t *testing.T) {
+	fldPath := field.NewPath("spec", "leaderWorkerTemplate", "podFailurePolicy")
+	rulePath := fldPath.Child("rules").Index(0).Child("onExitCodes")
+	tests := []struct {
+		name          string
+		requirement   v1.PodFailurePolicyOnExitCodesRequirement
+		wantErrFields []string
+	}{
+		{
+			name:        "In on a worker container",
+			requirement: v1.PodFailurePolicyOnExitCodesRequirement{ContainerName: ptr.To("worker"), Operator: v1.PodFailurePolicyOnExitCodesOpIn, Values: []int32{1, 42}},
+		},
+		{
+			name:        "NotIn including 0",
+			requirement: v1.PodFailurePolicyOnExitCodesRequirement{Operator: v1.PodFailurePolicyOnExitCodesOpNotIn, Values: []int32{0, 137, 143}},
+		},
+		{
+			name:          "unknown container",
+			requirement:   v1.PodFailurePolicyOnExitCodesRequirement{ContainerName: ptr.To("sidecar"), Operator: v1.PodFailurePolicyOnExitCodesOpIn, Values: []int32{1}},
+			wantErrFields: []string{rulePath.Child("containerName").String()},
+		},
+		{
+			name:          "unsupported operator",
+			requirement:   v1.PodFailurePolicyOnExitCodesRequirement{Operator: "Exists", Values: []int32{1}},
+			wantErrFields: []string{rulePath.Child("operator").String()},
+		},
+		{
+			name:          "no values",
+			requirement:   v1.PodFailurePolicyOnExitCodesRequirement{Operator: v1.PodFailurePolicyOnExitCodesOpIn},
+			wantErrFields: []string{rulePath.Child("values").String()},
+		},
+		{
+			name:          "In including 0",
+			requirement:   v1.PodFailurePolicyOnExitCodesRequirement{Operator: v1.PodFailurePolicyOnExitCodesOpIn, Values: []int32{0, 1}},
+			wantErrFields: []string{rulePath.Child("values").Index(0).String()},
+		},
+		{
+			name:          "values not in increasing order",
+			requirement:   v1.PodFailurePolicyOnExitCodesRequirement{Operator: v1.PodFailurePolicyOnExitCodesOpIn, Values: []int32{2, 1, 1}},
+			wantErrFields: []string{rulePath.Child("values").Index(1).String(), rulePath.Child("values").Index(2).String()},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := &v1.LeaderWorkerSet{
+				Spec: v1.LeaderWorkerSetSpec{
+					LeaderWorkerTemplate: v1.LeaderWorkerTemplate{
+						WorkerTemplate: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "worker"}}},
+						},
+						PodFailurePolicy: &v1.PodFailurePolicy{
+							Rules: []v1.PodFailurePolicyRule{{OnExitCodes: tc.requirement}},
+						},
+					},
+				},
+			}
+			var gotErrFields []string
+			for _, err := range validatePodFailurePolicy(fldPath, lws) {
+				gotErrFields = append(gotErrFields, err.Field)
+			}
+			if diff := cmp.Diff(tc.wantErrFields, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateHasContainers(t *testing.T) {
+	fldPath := field.NewPath("spec", "leaderWorkerTemplate", "workerTemplate", "spec", "containers")
+	tests := []struct {
+		name          string
+		containers    []corev1.Container
+		wantErrFields []string
+	}{
+		{
+			name:          "nil containers",
+			wantErrFields: []string{fldPath.String()},
+		},
+		{
+			name:          "empty containers",
+			containers:    []corev1.Container{},
+			wantErrFields: []string{fldPath.String()},
+		},
+		{
+			name:       "one container",
+			containers: []corev1.Container{{Name: "main", Image: "busybox"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotErrFields []string
+			for _, err := range validateHasContainers(fldPath, &corev1.PodSpec{Containers: tc.containers}) {
+				gotErrFields = append(gotErrFields, err.Field)
+			}
+			if diff := cmp.Diff(tc.wantErrFields, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateRestartGroupAnnotations(t *testing.T) {
+	fldPath := field.NewPath("metadata", "annotations")
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		wantErrFields []string
+	}{
+		{
+			name: "valid",
+			annotations: map[string]string{
+				v1.RestartGroupAnnotationKeyPrefix + "0":  "2025-01-01T00:00:00Z",
+				v1.RestartGroupAnnotationKeyPrefix + "12": "2025-01-01T01:00:00+01:00",
+				"other": "value",
+			},
+		},
+		{
+			name:          "not a group index",
+			annotations:   map[string]string{v1.RestartGroupAnnotationKeyPrefix + "a": "2025-01-01T00:00:00Z"},
+			wantErrFields: []string{fldPath.Key(v1.RestartGroupAnnotationKeyPrefix + "a").String()},
+		},
+		{
+			name:          "negative group index",
+			annotations:   map[string]string{v1.RestartGroupAnnotationKeyPrefix + "-1": "2025-01-01T00:00:00Z"},
+			wantErrFields: []string{fldPath.Key(v1.RestartGroupAnnotationKeyPrefix + "-1").String()},
+		},
+		{
+			name:          "not a timestamp",
+			annotations:   map[string]string{v1.RestartGroupAnnotationKeyPrefix + "0": "now"},
+			wantErrFields: []string{fldPath.Key(v1.RestartGroupAnnotationKeyPrefix + "0").String()},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotErrFields []string
+			for _, err := range validateRestartGroupAnnotations(fldPath, tc.annotations) {
+				gotErrFields = append(gotErrFields, err.Field)
+			}
+			if diff := cmp.Diff(tc.wantErrFields, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidatePinGroupAnnotations(t *testing.T) {
+	fldPath := field.NewPath("metadata", "annotations")
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		wantErrFields []string
+	}{
+		{
+			name: "valid",
+			annotations: map[string]string{
+				v1.PinGroupAnnotationKeyPrefix + "0":  "5d8f7c9b4",
+				v1.PinGroupAnnotationKeyPrefix + "12": "6c7b9f8d5",
+				"other":                               "value",
+			},
+		},
+		{
+			name:          "not a group index",
+			annotations:   map[string]string{v1.PinGroupAnnotationKeyPrefix + "a": "5d8f7c9b4"},
+			wantErrFields: []string{fldPath.Key(v1.PinGroupAnnotationKeyPrefix + "a").String()},
+		},
+		{
+			name:          "empty revision",
+			annotations:   map[string]string{v1.PinGroupAnnotationKeyPrefix + "0": ""},
+			wantErrFields: []string{fldPath.Key(v1.PinGroupAnnotationKeyPrefix + "0").String()},
+		},
+		{
+			name:          "not a revision hash",
+			annotations:   map[string]string{v1.PinGroupAnnotationKeyPrefix + "0": "revision 1"},
+			wantErrFields: []string{fldPath.Key(v1.PinGroupAnnotationKeyPrefix + "0").String()},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotErrFields []string
+			for _, err := range validatePinGroupAnnotations(fldPath, tc.annotations) {
+				gotErrFields = append(gotErrFields, err.Field)
+			}
+			if diff := cmp.Diff(tc.wantErrFields, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidatePinGroupRevisions(t *testing.T) {
+	fldPath := field.NewPath("metadata", "annotations")
+	pinKey := v1.PinGroupAnnotationKeyPrefix + "0"
+	lws := &v1.LeaderWorkerSet{ObjectMeta: metav1.ObjectMeta{Name: "test-sample", Namespace: "default", UID: "lws-uid"}}
+	revision := func(name, revisionKey string, ownerUID types.UID) *appsv1.ControllerRevision {
+		return &appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    map[string]string{v1.SetNameLabelKey: "test-sample", v1.RevisionKey: revisionKey},
+				OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: "leaderworkerset.x-k8s.io/v1", Kind: "LeaderWorkerSet", Name: "test-sample", UID: ownerUID, Controller: ptr.To(true)},
+				},
+			},
+		}
+	}
+	revisions := []client.Object{
+		revision("test-sample-5d8f7c9b4", "5d8f7c9b4", "lws-uid"),
+		revision("test-sample-6c7b9f8d5", "6c7b9f8d5", "other-uid"),
+	}
+	tests := []struct {
+		name           string
+		oldAnnotations map[string]string
+		annotations    map[string]string
+		wantErrFields  []string
+	}{
+		{
+			name:        "existing revision",
+			annotations: map[string]string{pinKey: "5d8f7c9b4"},
+		},
+		{
+			name:          "unknown revision",
+			annotations:   map[string]string{pinKey: "7a6b5c4d3"},
+			wantErrFields: []string{fldPath.Key(pinKey).String()},
+		},
+		{
+			name:          "revision of another leaderworkerset",
+			annotations:   map[string]string{pinKey: "6c7b9f8d5"},
+			wantErrFields: []string{fldPath.Key(pinKey).String()},
+		},
+		{
+			name:           "unchanged pin to a truncated revision",
+			oldAnnotations: map[string]string{pinKey: "7a6b5c4d3"},
+			annotations:    map[string]string{pinKey: "7a6b5c4d3"},
+		},
+		{
+			name:           "pin changed to an unknown revision",
+			oldAnnotations: map[string]string{pinKey: "5d8f7c9b4"},
+			annotations:    map[string]string{pinKey: "7a6b5c4d3"},
+			wantErrFields:  []string{fldPath.Key(pinKey).String()},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var oldLws *v1.LeaderWorkerSet
+			if tc.oldAnnotations != nil {
+				oldLws = lws.DeepCopy()
+				oldLws.Annotations = tc.oldAnnotations
+			}
+			newLws := lws.DeepCopy()
+			newLws.Annotations = tc.annotations
+			wh := &LeaderWorkerSetWebhook{RevisionReader: fake.NewClientBuilder().WithObjects(revisions...).Build()}
+			var gotErrFields []string
+			for _, err := range wh.validatePinGroupRevisions(context.Background(), fldPath, oldLws, newLws) {
+				gotErrFields = append(gotErrFields, err.Field)
+			}
+			if diff := cmp.Diff(tc.wantErrFields, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidatePerGroupService(t *testing.T) {
+	fldPath := field.NewPath("spec", "networkConfig", "perGroupService")
+	withPorts := corev1.PodSpec{Containers: []corev1.Container{{Name: "leader", Ports: []corev1.ContainerPort{{ContainerPort: 8080}}}}}
+	withoutPorts := corev1.PodSpec{Containers: []corev1.Container{{Name: "leader"}}}
+	tests := []struct {
+		name            string
+		lwsName         string
+		subdomainPolicy v1.SubdomainPolicy
+		leaderTemplate  *corev1.PodTemplateSpec
+		workerSpec      corev1.PodSpec
+		wantErrFields   []string
+	}{
+		{
+			name:            "shared subdomain",
+			subdomainPolicy: v1.SubdomainShared,
+			workerSpec:      withPorts,
+		},
+		{
+			name:            "subdomainPolicy None",
+			subdomainPolicy: v1.SubdomainNone,
+			workerSpec:      withPorts,
+		},
+		{
+			name:            "unique subdomain per replica",
+			subdomainPolicy: v1.SubdomainUniquePerReplica,
+			workerSpec:      withPorts,
+			wantErrFields:   []string{fldPath.String()},
+		},
+		{
+			name:            "ports from the leader template",
+			subdomainPolicy: v1.SubdomainShared,
+			leaderTemplate:  &corev1.PodTemplateSpec{Spec: withPorts},
+			workerSpec:      withoutPorts,
+		},
+		{
+			name:            "leader without ports",
+			subdomainPolicy: v1.SubdomainShared,
+			leaderTemplate:  &corev1.PodTemplateSpec{Spec: withoutPorts},
+			workerSpec:      withPorts,
+			wantErrFields:   []string{fldPath.String()},
+		},
+		{
+			name:            "service name too long",
+			lwsName:         strings.Repeat("a", 62),
+			subdomainPolicy: v1.SubdomainShared,
+			workerSpec:      withPorts,
+			wantErrFields:   []string{fldPath.String()},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lwsName := "test"
+			if tc.lwsName != "" {
+				lwsName = tc.lwsName
+			}
+			lws := &v1.LeaderWorkerSet{
+				ObjectMeta: metav1.ObjectMeta{Name: lwsName},
+				Spec: v1.LeaderWorkerSetSpec{
+					Replicas: ptr.To[int32](2),
+					LeaderWorkerTemplate: v1.LeaderWorkerTemplate{
+						LeaderTemplate: tc.leaderTemplate,
+						WorkerTemplate: corev1.PodTemplateSpec{Spec: tc.workerSpec},
+					},
+					NetworkConfig: &v1.NetworkConfig{SubdomainPolicy: ptr.To(tc.subdomainPolicy), PerGroupService: true},
+				},
+			}
+			var gotErrFields []string
+			for _, err := range validatePerGroupService(fldPath, lws) {
+				gotErrFields = append(gotErrFields, err.Field)
+			}
+			if diff := cmp.Diff(tc.wantErrFields, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateLeaderStartupDelaySeconds(t *testing.T) {
+	fldPath := field.NewPath("spec", "leaderStartupDelaySeconds")
+	tests := []struct {
+		name          string
+		delay         int32
+		startupPolicy v1.StartupPolicyType
+		wantErrFields []string
+	}{
+		{
+			name:          "zero",
+			startupPolicy: v1.LeaderCreatedStartupPolicy,
+		},
+		{
+			name:          "positive with LeaderCreated",
+			delay:         30,
+			startupPolicy: v1.LeaderCreatedStartupPolicy,
+		},
+		{
+			name:          "positive with LeaderReady",
+			delay:         30,
+			startupPolicy: v1.LeaderReadyStartupPolicy,
+		},
+		{
+			name:          "negative",
+			delay:         -1,
+			startupPolicy: v1.LeaderCreatedStartupPolicy,
+			wantErrFields: []string{fldPath.String()},
+		},
+		{
+			name:          "with WorkersFirst",
+			delay:         30,
+			startupPolicy: v1.WorkersFirstStartupPolicy,
+			wantErrFields: []string{fldPath.String()},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := &v1.LeaderWorkerSet{Spec: v1.LeaderWorkerSetSpec{
+				StartupPolicy:             tc.startupPolicy,
+				LeaderStartupDelaySeconds: ptr.To(tc.delay),
+			}}
+			var gotErrFields []string
+			for _, err := range validateLeaderStartupDelaySeconds(fldPath, lws) {
+				gotErrFields = append(gotErrFields, err.Field)
+			}
+			if diff := cmp.Diff(tc.wantErrFields, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateLeaderReadyConfiguration(t *testing.T) {
+	fldPath := field.NewPath("spec", "leaderReadyConfiguration")
+	tests := []struct {
+		name          string
+		startupPolicy v1.StartupPolicyType
+		config        v1.LeaderReadyConfiguration
+		wantErrFields []string
+	}{
+		{
+			name:          "condition type",
+			startupPolicy: v1.LeaderReadyStartupPolicy,
+			config:        v1.LeaderReadyConfiguration{ConditionType: ptr.To[corev1.PodConditionType]("example.com/started")},
+		},
+		{
+			name:          "annotation",
+			startupPolicy: v1.LeaderReadyStartupPolicy,
+			config:        v1.LeaderReadyConfiguration{Annotation: ptr.To("example.com/Started")},
+		},
+		{
+			name:          "other startup policy",
+			startupPolicy: v1.LeaderCreatedStartupPolicy,
+			config:        v1.LeaderReadyConfiguration{Annotation: ptr.To("example.com/started")},
+			wantErrFields: []string{fldPath.String()},
+		},
+		{
+			name:          "neither condition type nor annotation",
+			startupPolicy: v1.LeaderReadyStartupPolicy,
+			wantErrFields: []string{fldPath.String()},
+		},
+		{
+			name:          "both condition type and annotation",
+			startupPolicy: v1.LeaderReadyStartupPolicy,
+			config: v1.LeaderReadyConfiguration{
+				ConditionType: ptr.To[corev1.PodConditionType]("example.com/started"),
+				Annotation:    ptr.To("example.com/started"),
+			},
+			wantErrFields: []string{fldPath.String()},
+		},
+		{
+			name:          "invalid condition type",
+			startupPolicy: v1.LeaderReadyStartupPolicy,
+			config:        v1.LeaderReadyConfiguration{ConditionType: ptr.To[corev1.PodConditionType]("example.com/started/now")},
+			wantErrFields: []string{fldPath.Child("conditionType").String()},
+		},
+		{
+			name:          "invalid annotation",
+			startupPolicy: v1.LeaderReadyStartupPolicy,
+			config:        v1.LeaderReadyConfiguration{Annotation: ptr.To("started!")},
+			wantErrFields: []string{fldPath.Child("annotation").String()},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := &v1.LeaderWorkerSet{
+				Spec: v1.LeaderWorkerSetSpec{
+					StartupPolicy:            tc.startupPolicy,
+					LeaderReadyConfiguration: &tc.config,
+				},
+			}
+			var gotErrFields []string
+			for _, err := range validateLeaderReadyConfiguration(fldPath, lws) {
+				gotErrFields = append(gotErrFields, err.Field)
+			}
+			if diff := cmp.Diff(tc.wantErrFields, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateHostnamePrefix(t *testing.T) {
+	fldPath := field.NewPath("spec", "networkConfig", "hostnamePrefix")
+	tests := []struct {
+		name           string
+		hostnamePrefix string
+		replicas       int32
+		startIndex     int32
+		wantErr        bool
+	}{
+		{
+			name:           "short prefix",
+			hostnamePrefix: "prefix",
+			replicas:       100,
+		},
+		{
+			name:           "hostname of the last leader is exactly 63 characters",
+			hostnamePrefix: strings.Repeat("a", 61),
+			replicas:       10,
+		},
+		{
+			name:           "hostname of the last leader exceeds 63 characters",
+			hostnamePrefix: strings.Repeat("a", 61),
+			replicas:       11,
+			wantErr:        true,
+		},
+		{
+			name:           "hostname exceeds 63 characters with zero replicas",
+			hostnamePrefix: strings.Repeat("a", 62),
+			replicas:       0,
+			wantErr:        true,
+		},
+		{
+			name:           "hostname of the last leader exceeds 63 characters with startIndex",
+			hostnamePrefix: strings.Repeat("a", 61),
+			replicas:       10,
+			startIndex:     1,
+			wantErr:        true,
+		},
+		{
+			name:           "prefix is not a DNS-1123 label",
+			hostnamePrefix: "Prefix_1",
+			replicas:       1,
+			wantErr:        true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := &v1.LeaderWorkerSet{
+				Spec: v1.LeaderWorkerSetSpec{
+					Replicas:      ptr.To(tc.replicas),
+					NetworkConfig: &v1.NetworkConfig{HostnamePrefix: tc.hostnamePrefix, StartIndex: tc.startIndex},
+				},
+			}
+			errs := validateHostnamePrefix(fldPath, lws)
+			if gotErr := len(errs) > 0; gotErr != tc.wantErr {
+				t.Fatalf("unexpected errors, want error: %t, got: %v", tc.wantErr, errs)
+			}
+			for _, err := range errs {
+				if err.Field != fldPath.String() {
+					t.Errorf("unexpected error field, want: %s, got: %s", fldPath.String(), err.Field)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateAnnotationPlaceholders(t *testing.T) {
+	fldPath := field.NewPath("spec", "leaderWorkerTemplate", "workerTemplate", "metadata", "annotations")
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		wantErrFields []string
+	}{
+		{
+			name:        "no placeholders",
+			annotations: map[string]string{"example.com/a": "value"},
+		},
+		{
+			name:        "known placeholders",
+			annotations: map[string]string{"example.com/a": "{{.GroupIndex}}-{{ .WorkerIndex }}-{{.Size}}"},
+		},
+		{
+			name: "unknown placeholders",
+			annotations: map[string]string{
+				"example.com/a": "{{.GroupIndex}}",
+				"example.com/b": "{{.GroupName}}",
+				"example.com/c": "{{.Replicas}}-{{.Size}}",
+			},
+			wantErrFields: []string{
+				fldPath.Key("example.com/b").String(),
+				fldPath.Key("example.com/c").String(),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotErrFields []string
+			for _, err := range validateAnnotationPlaceholders(fldPath, tc.annotations) {
+				gotErrFields = append(gotErrFields, err.Field)
+			}
+			if diff := cmp.Diff(tc.wantErrFields, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateContainerPlaceholders(t *testing.T) {
+	fldPath := field.NewPath("spec", "leaderWorkerTemplate", "workerTemplate", "spec", "containers")
+	tests := []struct {
+		name          string
+		containers    []corev1.Container
+		wantErrFields []string
+	}{
+		{
+			name:       "no placeholders",
+			containers: []corev1.Container{{Name: "main", Command: []string{"serve"}, Args: []string{"--port=8080"}}},
+		},
+		{
+			name:       "known placeholders",
+			containers: []corev1.Container{{Name: "main", Command: []string{"serve", "--rank={{.WorkerIndex}}"}, Args: []string{"--group={{ .GroupIndex }}", "--nnodes={{.Size}}"}}},
+		},
+		{
+			name: "unknown placeholders",
+			containers: []corev1.Container{
+				{Name: "main", Args: []string{"--group={{.GroupIndex}}"}},
+				{Name: "sidecar", Command: []string{"{{.GroupName}}"}, Args: []string{"--static", "--replicas={{.Replicas}}-{{.Size}}"}},
+			},
+			wantErrFields: []string{
+				fldPath.Index(1).Child("command").Index(0).String(),
+				fldPath.Index(1).Child("args").Index(1).String(),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotErrFields []string
+			for _, err := range validateContainerPlaceholders(fldPath, tc.containers) {
+				gotErrFields = append(gotErrFields, err.Field)
+			}
+			if diff := cmp.Diff(tc.wantErrFields, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateReservedLabels(t *testing.T) {
+	fldPath := field.NewPath("spec", "leaderWorkerTemplate", "leaderTemplate", "metadata", "labels")
+	reservedKeys := []string{
+		v1.SetNameLabelKey,
+		v1.GroupIndexLabelKey,
+		v1.WorkerIndexLabelKey,
+		v1.GroupUniqueHashLabelKey,
+		v1.RevisionKey,
+		v1.SubGroupIndexLabelKey,
+		v1.SubGroupUniqueHashLabelKey,
+	}
+	for _, key := range reservedKeys {
+		t.Run(key, func(t *testing.T) {
+			labels := map[string]string{"app": "test", key: "value"}
+			var gotErrFields []string
+			for _, err := range validateReservedLabels(fldPath, labels) {
+				gotErrFields = append(gotErrFields, err.Field)
+			}
+			if diff := cmp.Diff([]string{fldPath.Key(key).String()}, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+		})
+	}
+
+	t.Run("no reserved labels", func(t *testing.T) {
+		labels := map[string]string{"app": "test", "example.com/leaderworkerset.sigs.k8s.io": "value"}
+		if errs := validateReservedLabels(fldPath, labels); len(errs) != 0 {
+			t.Errorf("unexpected errors: %v", errs)
+		}
+	})
+}
+
+func TestValidateExclusiveNodeSelectors(t *testing.T) {
+	fldPath := field.NewPath("spec", "leaderWorkerTemplate")
+	tests := []struct {
+		name               string
+		leaderNodeSelector map[string]string
+		workerNodeSelector map[string]string
+		wantErrFields      []string
+	}{
+		{
+			name: "empty selectors",
+		},
+		{
+			name:               "empty leader selector",
+			workerNodeSelector: map[string]string{"cloud.google.com/gke-accelerator": "tpu"},
+		},
+		{
+			name:               "empty worker selector",
+			leaderNodeSelector: map[string]string{"cloud.google.com/gke-accelerator": "tpu"},
+		},
+		{
+			name:               "compatible selectors",
+			leaderNodeSelector: map[string]string{"pool": "a", "cloud.google.com/gke-accelerator": "tpu"},
+			workerNodeSelector: map[string]string{"pool": "a", "disk": "ssd"},
+		},
+		{
+			name:               "conflicting selectors",
+			leaderNodeSelector: map[string]string{"pool": "a", "zone": "us-east1-b", "disk": "ssd"},
+			workerNodeSelector: map[string]string{"pool": "b", "zone": "us-east1-c", "disk": "ssd"},
+			wantErrFields: []string{
+				fldPath.Child("leaderTemplate", "spec", "nodeSelector").Key("pool").String(),
+				fldPath.Child("leaderTemplate", "spec", "nodeSelector").Key("zone").String(),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := &v1.LeaderWorkerSet{
+				Spec: v1.LeaderWorkerSetSpec{
+					LeaderWorkerTemplate: v1.LeaderWorkerTemplate{
+						LeaderTemplate: &corev1.PodTemplateSpec{Spec: corev1.PodSpec{NodeSelector: tc.leaderNodeSelector}},
+						WorkerTemplate: corev1.PodTemplateSpec{Spec: corev1.PodSpec{NodeSelector: tc.workerNodeSelector}},
+					},
+				},
+			}
+			var gotErrFields []string
+			for _, err := range validateExclusiveNodeSelectors(fldPath, lws) {
+				gotErrFields = append(gotErrFields, err.Field)
+			}
+			if diff := cmp.Diff(tc.wantErrFields, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateHostNetwork(t *testing.T) {
+	fldPath := field.NewPath("spec", "leaderWorkerTemplate")
+	tests := []struct {
+		name              string
+		leaderHostNetwork bool
+		workerHostNetwork bool
+		wantErrFields     []string
+	}{
+		{
+			name: "neither uses hostNetwork",
+		},
+		{
+			name:              "both use hostNetwork",
+			leaderHostNetwork: true,
+			workerHostNetwork: true,
+		},
+		{
+			name:              "only the leader uses hostNetwork",
+			leaderHostNetwork: true,
+			wantErrFields:     []string{fldPath.Child("leaderTemplate", "spec", "hostNetwork").String()},
+		},
+		{
+			name:              "only the workers use hostNetwork",
+			workerHostNetwork: true,
+			wantErrFields:     []string{fldPath.Child("leaderTemplate", "spec", "hostNetwork").String()},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := &v1.LeaderWorkerSet{
+				Spec: v1.LeaderWorkerSetSpec{
+					LeaderWorkerTemplate: v1.LeaderWorkerTemplate{
+						LeaderTemplate: &corev1.PodTemplateSpec{Spec: corev1.PodSpec{HostNetwork: tc.leaderHostNetwork}},
+						WorkerTemplate: corev1.PodTemplateSpec{Spec: corev1.PodSpec{HostNetwork: tc.workerHostNetwork}},
+					},
+				},
+			}
+			var gotErrFields []string
+			for _, err := range validateHostNetwork(fldPath, lws) {
+				gotErrFields = append(gotErrFields, err.Field)
+			}
+			if diff := cmp.Diff(tc.wantErrFields, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateExclusiveSpreadConstraints(t *testing.T) {
+	fldPath := field.NewPath("spec", "leaderWorkerTemplate")
+	groupKeySelector := &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+		Key:      v1.GroupUniqueHashLabelKey,
+		Operator: metav1.LabelSelectorOpIn,
+		Values:   []string{"abc"},
+	}}}
+	tests := []struct {
+		name              string
+		leaderConstraints []corev1.TopologySpreadConstraint
+		workerConstraints []corev1.TopologySpreadConstraint
+		wantErrFields     []string
+	}{
+		{
+			name: "no spread constraints",
+		},
+		{
+			name:              "intra-group constraint on another topology key",
+			workerConstraints: []corev1.TopologySpreadConstraint{{TopologyKey: "kubernetes.io/hostname", MatchLabelKeys: []string{v1.GroupUniqueHashLabelKey}}},
+		},
+		{
+			name: "constraint on the exclusive topology key across groups",
+			workerConstraints: []corev1.TopologySpreadConstraint{{
+				TopologyKey:   "topology.kubernetes.io/zone",
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{v1.SetNameLabelKey: "test-sample"}},
+			}},
+		},
+		{
+			name:              "intra-group constraint by matchLabelKeys on the exclusive topology key",
+			workerConstraints: []corev1.TopologySpreadConstraint{{TopologyKey: "topology.kubernetes.io/zone", MatchLabelKeys: []string{v1.GroupIndexLabelKey}}},
+			wantErrFields: []string{
+				fldPath.Child("workerTemplate", "spec", "topologySpreadConstraints").Index(0).Child("topologyKey").String(),
+			},
+		},
+		{
+			name: "intra-group constraints by labelSelector on the exclusive topology key",
+			leaderConstraints: []corev1.TopologySpreadConstraint{
+				{TopologyKey: "kubernetes.io/hostname", LabelSelector: groupKeySelector},
+				{TopologyKey: "topology.kubernetes.io/zone", LabelSelector: groupKeySelector},
+			},
+			workerConstraints: []corev1.TopologySpreadConstraint{{
+				TopologyKey:   "topology.kubernetes.io/zone",
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{v1.GroupUniqueHashLabelKey: "abc"}},
+			}},
+			wantErrFields: []string{
+				fldPath.Child("leaderTemplate", "spec", "topologySpreadConstraints").Index(1).Child("topologyKey").String(),
+				fldPath.Child("workerTemplate", "spec", "topologySpreadConstraints").Index(0).Child("topologyKey").String(),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := &v1.LeaderWorkerSet{
+				Spec: v1.LeaderWorkerSetSpec{
+					LeaderWorkerTemplate: v1.LeaderWorkerTemplate{
+						ExclusiveTopology: &v1.ExclusiveTopology{TopologyKey: "topology.kubernetes.io/zone"},
+						LeaderTemplate:    &corev1.PodTemplateSpec{Spec: corev1.PodSpec{TopologySpreadConstraints: tc.leaderConstraints}},
+						WorkerTemplate:    corev1.PodTemplateSpec{Spec: corev1.PodSpec{TopologySpreadConstraints: tc.workerConstraints}},
+					},
+				},
+			}
+			var gotErrFields []string
+			for _, err := range validateExclusiveSpreadConstraints(fldPath, lws) {
+				gotErrFields = append(gotErrFields, err.Field)
+			}
+			if diff := cmp.Diff(tc.wantErrFields, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateGangSchedulerName(t *testing.T) {
+	fldPath := field.NewPath("spec", "leaderWorkerTemplate")
+	podGroupLabels := map[string]string{"scheduling.x-k8s.io/pod-group": "sample"}
+	tests := []struct {
+		name                string
+		lwsAnnotations      map[string]string
+		workerLabels        map[string]string
+		gangLabelKey        string
+		leaderSchedulerName string
+		workerSchedulerName string
+		wantErrFields       []string
+	}{
+		{
+			name:                "mismatched scheduler names without gang scheduling",
+			leaderSchedulerName: "default-scheduler",
+			workerSchedulerName: "scheduler-plugins-scheduler",
+		},
+		{
+			name:                "matching scheduler names",
+			workerLabels:        podGroupLabels,
+			leaderSchedulerName: "scheduler-plugins-scheduler",
+			workerSchedulerName: "scheduler-plugins-scheduler",
+		},
+		{
+			name:                "empty scheduler name matches the default scheduler",
+			workerLabels:        podGroupLabels,
+			workerSchedulerName: "default-scheduler",
+		},
+		{
+			name:                "mismatched scheduler names",
+			workerLabels:        podGroupLabels,
+			leaderSchedulerName: "default-scheduler",
+			workerSchedulerName: "scheduler-plugins-scheduler",
+			wantErrFields:       []string{fldPath.Child("leaderTemplate", "spec", "schedulerName").String()},
+		},
+		{
+			name:                "mismatched scheduler names with volcano annotation",
+			lwsAnnotations:      map[string]string{"scheduling.k8s.io/group-name": "sample"},
+			workerSchedulerName: "volcano",
+			wantErrFields:       []string{fldPath.Child("leaderTemplate", "spec", "schedulerName").String()},
+		},
+		{
+			name:                "mismatched scheduler names with gangSchedulingLabelKey",
+			gangLabelKey:        "scheduling.x-k8s.io/pod-group",
+			workerSchedulerName: "scheduler-plugins-scheduler",
+			wantErrFields:       []string{fldPath.Child("leaderTemplate", "spec", "schedulerName").String()},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := &v1.LeaderWorkerSet{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tc.lwsAnnotations},
+				Spec: v1.LeaderWorkerSetSpec{
+					LeaderWorkerTemplate: v1.LeaderWorkerTemplate{
+						LeaderTemplate: &corev1.PodTemplateSpec{Spec: corev1.PodSpec{SchedulerName: tc.leaderSchedulerName}},
+						WorkerTemplate: corev1.PodTemplateSpec{
+							ObjectMeta: metav1.ObjectMeta{Labels: tc.workerLabels},
+							Spec:       corev1.PodSpec{SchedulerName: tc.workerSchedulerName},
+						},
+						GangSchedulingLabelKey: tc.gangLabelKey,
+					},
+				},
+			}
+			var gotErrFields []string
+			if usesGangScheduling(lws) {
+				for _, err := range validateGangSchedulerName(fldPath, lws) {
+					gotErrFields = append(gotErrFields, err.Field)
+				}
+			}
+			if diff := cmp.Diff(tc.wantErrFields, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateGangSchedulingLabelKey(t *testing.T) {
+	fldPath := field.NewPath("spec", "leaderWorkerTemplate", "gangSchedulingLabelKey")
+	tests := []struct {
+		name          string
+		lwsName       string
+		replicas      int32
+		labelKey      string
+		wantErrFields []string
+	}{
+		{
+			name:     "valid label key",
+			lwsName:  "sample",
+			replicas: 3,
+			labelKey: "scheduling.x-k8s.io/pod-group",
+		},
+		{
+			name:          "invalid label key",
+			lwsName:       "sample",
+			replicas:      3,
+			labelKey:      "scheduling.x-k8s.io/pod group",
+			wantErrFields: []string{fldPath.String()},
+		},
+		{
+			name:          "reserved label key",
+			lwsName:       "sample",
+			replicas:      3,
+			labelKey:      "leaderworkerset.sigs.k8s.io/pod-group",
+			wantErrFields: []string{fldPath.String()},
+		},
+		{
+			name:          "label value too long",
+			lwsName:       strings.Repeat("a", 61),
+			replicas:      11,
+			labelKey:      "scheduling.x-k8s.io/pod-group",
+			wantErrFields: []string{fldPath.String()},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := &v1.LeaderWorkerSet{
+				ObjectMeta: metav1.ObjectMeta{Name: tc.lwsName},
+				Spec: v1.LeaderWorkerSetSpec{
+					Replicas:             ptr.To(tc.replicas),
+					LeaderWorkerTemplate: v1.LeaderWorkerTemplate{GangSchedulingLabelKey: tc.labelKey},
+				},
+			}
+			var gotErrFields []string
+			for _, err := range validateGangSchedulingLabelKey(fldPath, lws) {
+				gotErrFields = append(gotErrFields, err.Field)
+			}
+			if diff := cmp.Diff(tc.wantErrFields, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateSize(t *testing.T) {
+	fldPath := field.NewPath("spec", "leaderWorkerTemplate", "size")
+	tests := []struct {
+		name           string
+		size           int32
+		leaderTemplate *corev1.PodTemplateSpec
+		wantErrFields  []string
+	}{
+		{
+			name:          "size 0",
+			size:          0,
+			wantErrFields: []string{fldPath.String()},
+		},
+		{
+			name: "size 1 without leaderTemplate",
+			size: 1,
+		},
+		{
+			name:           "size 1 with leaderTemplate",
+			size:           1,
+			leaderTemplate: &corev1.PodTemplateSpec{},
+			wantErrFields:  []string{fldPath.String()},
+		},
+		{
+			name:           "size greater than 1 with leaderTemplate",
+			size:           3,
+			leaderTemplate: &corev1.PodTemplateSpec{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := &v1.LeaderWorkerSet{
+				Spec: v1.LeaderWorkerSetSpec{
+					LeaderWorkerTemplate: v1.LeaderWorkerTemplate{
+						Size:           ptr.To(tc.size),
+						LeaderTemplate: tc.leaderTemplate,
+					},
+				},
+			}
+			var gotErrFields []string
+			for _, err := range append(validateSize(fldPath, lws), validateSizeWithLeaderTemplate(fldPath, lws)...) {
+				gotErrFields = append(gotErrFields, err.Field)
+			}
+			if diff := cmp.Diff(tc.wantErrFields, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateGroupSpreadConstraints(t *testing.T) {
+	fldPath := field.NewPath("spec", "leaderWorkerTemplate", "groupSpreadConstraints")
+	tests := []struct {
+		name          string
+		constraints   []corev1.TopologySpreadConstraint
+		wantErrFields []string
+	}{
+		{
+			name: "no constraints",
+		},
+		{
+			name: "valid constraints",
+			constraints: []corev1.TopologySpreadConstraint{
+				{MaxSkew: 1, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: corev1.DoNotSchedule},
+				{MaxSkew: 2, TopologyKey: "kubernetes.io/hostname", WhenUnsatisfiable: corev1.ScheduleAnyway},
+			},
+		},
+		{
+			name: "maxSkew less than 1",
+			constraints: []corev1.TopologySpreadConstraint{
+				{MaxSkew: 0, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: corev1.DoNotSchedule},
+			},
+			wantErrFields: []string{fldPath.Index(0).Child("maxSkew").String()},
+		},
+		{
+			name: "empty topologyKey",
+			constraints: []corev1.TopologySpreadConstraint{
+				{MaxSkew: 1, WhenUnsatisfiable: corev1.DoNotSchedule},
+			},
+			wantErrFields: []string{fldPath.Index(0).Child("topologyKey").String()},
+		},
+		{
+			name: "invalid topologyKey",
+			constraints: []corev1.TopologySpreadConstraint{
+				{MaxSkew: 1, TopologyKey: "example.com/rack/name", WhenUnsatisfiable: corev1.DoNotSchedule},
+			},
+			wantErrFields: []string{fldPath.Index(0).Child("topologyKey").String()},
+		},
+		{
+			name: "unsupported whenUnsatisfiable",
+			constraints: []corev1.TopologySpreadConstraint{
+				{MaxSkew: 1, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: "Never"},
+			},
+			wantErrFields: []string{fldPath.Index(0).Child("whenUnsatisfiable").String()},
+		},
+		{
+			name: "labelSelector set",
+			constraints: []corev1.TopologySpreadConstraint{
+				{MaxSkew: 1, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: corev1.DoNotSchedule, LabelSelector: &metav1.LabelSelector{}},
+			},
+			wantErrFields: []string{fldPath.Index(0).Child("labelSelector").String()},
+		},
+		{
+			name: "duplicate constraints",
+			constraints: []corev1.TopologySpreadConstraint{
+				{MaxSkew: 1, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: corev1.DoNotSchedule},
+				{MaxSkew: 2, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: corev1.DoNotSchedule},
+			},
+			wantErrFields: []string{fldPath.Index(1).String()},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotErrFields []string
+			for _, err := range validateGroupSpreadConstraints(fldPath, tc.constraints) {
+				gotErrFields = append(gotErrFields, err.Field)
+			}
+			if diff := cmp.Diff(tc.wantErrFields, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateReservedEnvVars(t *testing.T) {
+	fldPath := field.NewPath("spec", "leaderWorkerTemplate", "workerTemplate", "spec")
+	tests := []struct {
+		name            string
+		podSpec         corev1.PodSpec
+		networkEnvNames map[string]string
+		wantErrFields   []string
+	}{
+		{
+			name: "no reserved env vars",
+			podSpec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "worker", Env: []corev1.EnvVar{{Name: "FOO", Value: "bar"}}}},
+			},
+		},
+		{
+			name: "reserved env vars in containers",
+			podSpec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "sidecar"},
+					{Name: "worker", Env: []corev1.EnvVar{
+						{Name: "FOO", Value: "bar"},
+						{Name: v1.LwsLeaderAddress, Value: "leader"},
+						{Name: v1.LwsGroupSize, Value: "4"},
+					}},
+				},
+			},
+			wantErrFields: []string{
+				fldPath.Child("containers").Index(1).Child("env").Index(1).Child("name").String(),
+				fldPath.Child("containers").Index(1).Child("env").Index(2).Child("name").String(),
+			},
+		},
+		{
+			name: "reserved env vars in init containers",
+			podSpec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "init", Env: []corev1.EnvVar{{Name: v1.LwsWorkerIndex, Value: "0"}}}},
+				Containers:     []corev1.Container{{Name: "worker"}},
+			},
+			wantErrFields: []string{
+				fldPath.Child("initContainers").Index(0).Child("env").Index(0).Child("name").String(),
+			},
+		},
+		{
+			name: "renamed env vars are reserved instead of the default names",
+			podSpec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "worker", Env: []corev1.EnvVar{
+					{Name: v1.LwsGroupSize, Value: "4"},
+					{Name: "WORLD_SIZE", Value: "4"},
+				}}},
+			},
+			networkEnvNames: map[string]string{v1.LwsGroupSize: "WORLD_SIZE"},
+			wantErrFields: []string{
+				fldPath.Child("containers").Index(0).Child("env").Index(1).Child("name").String(),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotErrFields []string
+			for _, err := range validateReservedEnvVars(fldPath, &tc.podSpec, injectedEnvVarNames(tc.networkEnvNames)) {
+				gotErrFields = append(gotErrFields, err.Field)
+			}
+			if diff := cmp.Diff(tc.wantErrFields, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidatePerGroupEnv(t *testing.T) {
+	fldPath := field.NewPath("spec", "leaderWorkerTemplate", "perGroupEnv")
+	env := func(names ...string) []corev1.EnvVar {
+		var env []corev1.EnvVar
+		for _, name := range names {
+			env = append(env, corev1.EnvVar{Name: name, Value: "value"})
+		}
+		return env
+	}
+	tests := []struct {
+		name          string
+		replicas      int32
+		perGroupEnv   []v1.PerGroupEnv
+		wantErrFields []string
+	}{
+		{
+			name:     "distinct groups",
+			replicas: 3,
+			perGroupEnv: []v1.PerGroupEnv{
+				{GroupIndex: 0, Env: env("MODEL_SHARD")},
+				{GroupIndex: 2, Env: env("MODEL_SHARD", "SHARD_COUNT")},
+			},
+		},
+		{
+			name:     "group index out of range",
+			replicas: 3,
+			perGroupEnv: []v1.PerGroupEnv{
+				{GroupIndex: 3, Env: env("MODEL_SHARD")},
+				{GroupIndex: -1, Env: env("MODEL_SHARD")},
+			},
+			wantErrFields: []string{
+				fldPath.Index(0).Child("groupIndex").String(),
+				fldPath.Index(1).Child("groupIndex").String(),
+			},
+		},
+		{
+			name:     "duplicate group index",
+			replicas: 3,
+			perGroupEnv: []v1.PerGroupEnv{
+				{GroupIndex: 1, Env: env("MODEL_SHARD")},
+				{GroupIndex: 1, Env: env("SHARD_COUNT")},
+			},
+			wantErrFields: []string{fldPath.Index(1).Child("groupIndex").String()},
+		},
+		{
+			name:     "invalid env var name",
+			replicas: 3,
+			perGroupEnv: []v1.PerGroupEnv{
+				{GroupIndex: 0, Env: env("MODEL_SHARD", "MODEL=SHARD")},
+			},
+			wantErrFields: []string{fldPath.Index(0).Child("env").Index(1).Child("name").String()},
+		},
+		{
+			name:     "reserved env var name",
+			replicas: 3,
+			perGroupEnv: []v1.PerGroupEnv{
+				{GroupIndex: 0, Env: env(v1.LwsWorkerIndex)},
+			},
+			wantErrFields: []string{fldPath.Index(0).Child("env").Index(0).Child("name").String()},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := &v1.LeaderWorkerSet{
+				Spec: v1.LeaderWorkerSetSpec{
+					Replicas:             ptr.To(tc.replicas),
+					LeaderWorkerTemplate: v1.LeaderWorkerTemplate{PerGroupEnv: tc.perGroupEnv},
+				},
+			}
+			var gotErrFields []string
+			for _, err := range validatePerGroupEnv(fldPath, lws, injectedEnvVarNames(nil)) {
+				gotErrFields = append(gotErrFields, err.Field)
+			}
+			if diff := cmp.Diff(tc.wantErrFields, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateNetworkEnvNames(t *testing.T) {
+	fldPath := field.NewPath("spec", "leaderWorkerTemplate", "networkEnvNames")
+	tests := []struct {
+		name            string
+		networkEnvNames map[string]string
+		wantErrs        field.ErrorList
+	}{
+		{
+			name: "no overrides",
+		},
+		{
+			name: "valid overrides",
+			networkEnvNames: map[string]string{
+				v1.LwsGroupSize:     "WORLD_SIZE",
+				v1.LwsLeaderAddress: "MASTER_ADDR",
+				v1.LwsWorkerIndex:   "RANK",
+			},
+		},
+		{
+			name:            "swapped names",
+			networkEnvNames: map[string]string{v1.LwsGroupSize: v1.LwsWorkerIndex, v1.LwsWorkerIndex: v1.LwsGroupSize},
+		},
+		{
+			name:            "unsupported env var",
+			networkEnvNames: map[string]string{v1.LwsPeerAddresses: "PEERS"},
+			wantErrs:        field.ErrorList{field.NotSupported(fldPath, v1.LwsPeerAddresses, overridableEnvVarNames)},
+		},
+		{
+			name:            "invalid name",
+			networkEnvNames: map[string]string{v1.LwsGroupSize: "WORLD-SIZE"},
+			wantErrs:        field.ErrorList{field.Invalid(fldPath.Key(v1.LwsGroupSize), "WORLD-SIZE", "")},
+		},
+		{
+			name:            "collides with another override",
+			networkEnvNames: map[string]string{v1.LwsGroupSize: "RANK", v1.LwsWorkerIndex: "RANK"},
+			wantErrs: field.ErrorList{
+				field.Duplicate(fldPath.Key(v1.LwsGroupSize), "RANK"),
+				field.Duplicate(fldPath.Key(v1.LwsWorkerIndex), "RANK"),
+			},
+		},
+		{
+			name:            "collides with a default name",
+			networkEnvNames: map[string]string{v1.LwsGroupSize: v1.LwsWorkerIndex},
+			wantErrs:        field.ErrorList{field.Duplicate(fldPath.Key(v1.LwsGroupSize), v1.LwsWorkerIndex)},
+		},
+		{
+			name:            "collides with the peer addresses",
+			networkEnvNames: map[string]string{v1.LwsLeaderAddress: v1.LwsPeerAddresses},
+			wantErrs:        field.ErrorList{field.Duplicate(fldPath.Key(v1.LwsLeaderAddress), v1.LwsPeerAddresses)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := validateNetworkEnvNames(fldPath, tc.networkEnvNames)
+			if diff := cmp.Diff(tc.wantErrs, errs, cmpopts.IgnoreFields(field.Error{}, "Detail"), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("unexpected errors (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateNetworkEnvNamesUpdate(t *testing.T) {
+	tests := []struct {
+		name               string
+		oldNetworkEnvNames map[string]string
+		newNetworkEnvNames map[string]string
+		replicas           int32
+		updatedReplicas    int32
+		wantErr            bool
+	}{
+		{
+			name:               "unchanged during a rollout",
+			oldNetworkEnvNames: map[string]string{v1.LwsGroupSize: "WORLD_SIZE"},
+			newNetworkEnvNames: map[string]string{v1.LwsGroupSize: "WORLD_SIZE"},
+			replicas:           3,
+			updatedReplicas:    1,
+		},
+		{
+			name:               "changed during a rollout",
+			oldNetworkEnvNames: map[string]string{v1.LwsGroupSize: "WORLD_SIZE"},
+			newNetworkEnvNames: map[string]string{v1.LwsGroupSize: "NUM_NODES"},
+			replicas:           3,
+			updatedReplicas:    1,
+			wantErr:            true,
+		},
+		{
+			name:               "added during a rollout",
+			newNetworkEnvNames: map[string]string{v1.LwsGroupSize: "WORLD_SIZE"},
+			replicas:           3,
+			updatedReplicas:    2,
+			wantErr:            true,
+		},
+		{
+			name:               "removed during a rollout",
+			oldNetworkEnvNames: map[string]string{v1.LwsGroupSize: "WORLD_SIZE"},
+			replicas:           3,
+			wantErr:            true,
+		},
+		{
+			name:               "changed once rolled out",
+			oldNetworkEnvNames: map[string]string{v1.LwsGroupSize: "WORLD_SIZE"},
+			newNetworkEnvNames: map[string]string{v1.LwsGroupSize: "NUM_NODES"},
+			replicas:           3,
+			updatedReplicas:    3,
+		},
+		{
+			name:               "added without groups",
+			newNetworkEnvNames: map[string]string{v1.LwsGroupSize: "WORLD_SIZE"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			oldLws := &v1.LeaderWorkerSet{
+				Spec:   v1.LeaderWorkerSetSpec{LeaderWorkerTemplate: v1.LeaderWorkerTemplate{NetworkEnvNames: tc.oldNetworkEnvNames}},
+				Status: v1.LeaderWorkerSetStatus{Replicas: tc.replicas, UpdatedReplicas: tc.updatedReplicas},
+			}
+			newLws := oldLws.DeepCopy()
+			newLws.Spec.LeaderWorkerTemplate.NetworkEnvNames = tc.newNetworkEnvNames
+
+			fldPath := field.NewPath("spec", "leaderWorkerTemplate", "networkEnvNames")
+			errs := validateNetworkEnvNamesUpdate(oldLws, newLws, fldPath)
+			if gotErr := len(errs) > 0; gotErr != tc.wantErr {
+				t.Fatalf("unexpected errors, want error: %t, got: %v", tc.wantErr, errs)
+			}
+			for _, err := range errs {
+				if err.Field != fldPath.String() {
+					t.Errorf("unexpected error field, want: %s, got: %s", fldPath.String(), err.Field)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateSubGroupSizeDividesSize(t *testing.T) {
+	tests := []struct {
+		name           string
+		size           int32
+		subGroupSize   int32
+		subGroupPolicy v1.SubGroupPolicyType
+		wantErr        bool
+	}{
+		{
+			name:           "size divisible by subGroupSize",
+			size:           4,
+			subGroupSize:   2,
+			subGroupPolicy: v1.SubGroupPolicyTypeLeaderWorker,
+		},
+		{
+			name:           "neither size nor size - 1 divisible by subGroupSize",
+			size:           6,
+			subGroupSize:   4,
+			subGroupPolicy: v1.SubGroupPolicyTypeLeaderWorker,
+			wantErr:        true,
+		},
+		{
+			name:           "size - 1 divisible by subGroupSize, the leader is the extra pod",
+			size:           5,
+			subGroupSize:   4,
+			subGroupPolicy: v1.SubGroupPolicyTypeLeaderWorker,
+		},
+		{
+			name:           "subGroupSize equals size",
+			size:           3,
+			subGroupSize:   3,
+			subGroupPolicy: v1.SubGroupPolicyTypeLeaderWorker,
+		},
+		{
+			name:           "size - 1 divisible by subGroupSize with LeaderExcluded",
+			size:           5,
+			subGroupSize:   2,
+			subGroupPolicy: v1.SubGroupPolicyTypeLeaderExcluded,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := &v1.LeaderWorkerSet{
+				Spec: v1.LeaderWorkerSetSpec{
+					LeaderWorkerTemplate: v1.LeaderWorkerTemplate{
+						Size: ptr.To(tc.size),
+						SubGroupPolicy: &v1.SubGroupPolicy{
+							Type:         ptr.To(tc.subGroupPolicy),
+							SubGroupSize: ptr.To(tc.subGroupSize),
+						},
+					},
+				},
+			}
+			fldPath := field.NewPath("spec", "leaderWorkerTemplate", "subGroupPolicy", "subGroupSize")
+			errs := validateSubGroupSizeDividesSize(fldPath, lws)
+			if gotErr := len(errs) > 0; gotErr != tc.wantErr {
+				t.Fatalf("unexpected errors, want error: %t, got: %v", tc.wantErr, errs)
+			}
+			for _, err := range errs {
+				if err.Field != fldPath.String() {
+					t.Errorf("unexpected error field, want: %s, got: %s", fldPath.String(), err.Field)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateSubGroupSizeDividesSizeUpdate(t *testing.T) {
+	lws := &v1.LeaderWorkerSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1.LeaderWorkerSetSpec{
+			Replicas: ptr.To[int32](1),
+			LeaderWorkerTemplate: v1.LeaderWorkerTemplate{
+				Size: ptr.To[int32](4),
+				SubGroupPolicy: &v1.SubGroupPolicy{
+					Type:         ptr.To(v1.SubGroupPolicyTypeLeaderWorker),
+					SubGroupSize: ptr.To[int32](4),
+				},
+				WorkerTemplate: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "worker", Image: "nginx"}}},
+				},
+			},
+			RolloutStrategy: v1.RolloutStrategy{
+				Type: v1.RollingUpdateStrategyType,
+				RollingUpdateConfiguration: &v1.RollingUpdateConfiguration{
+					MaxUnavailable: intstr.FromInt32(1),
+				},
+			},
+			StartupPolicy: v1.LeaderCreatedStartupPolicy,
+		},
+	}
+	newLws := lws.DeepCopy()
+	newLws.Spec.LeaderWorkerTemplate.Size = ptr.To[int32](6)
+	webhook := &LeaderWorkerSetWebhook{}
+
+	_, err := webhook.ValidateUpdate(context.Background(), lws, newLws)
+	if err == nil {
+		t.Fatal("expected the update of size to 6 to be rejected with subGroupSize 4")
+	}
+	wantField := "spec.leaderWorkerTemplate.subGroupPolicy.subGroupSize"
+	var gotErrFields []string
+	for _, e := range err.(utilerrors.Aggregate).Errors() {
+		gotErrFields = append(gotErrFields, e.(*field.Error).Field)
+	}
+	if diff := cmp.Diff([]string{wantField}, gotErrFields); diff != "" {
+		t.Errorf("unexpected error fields (-want +got): %s", diff)
+	}
+
+	newLws.Spec.LeaderWorkerTemplate.Size = ptr.To[int32](5)
+	if _, err := webhook.ValidateUpdate(context.Background(), lws, newLws); err != nil {
+		t.Errorf("unexpected error updating size to 5 with subGroupSize 4: %v", err)
+	}
+}
+
+func TestValidateReplicas(t *testing.T) {
+	replicasPath := field.NewPath("spec", "replicas").String()
+	tests := []struct {
+		name              string
+		maxReplicasPerLws int32
+		maxReplicas       *int32
+		oldReplicas       *int32
+		replicas          int32
+		standbyReplicas   int32
+		wantErrFields     []string
+	}{
+		{
+			name:          "negative",
+			replicas:      -1,
+			wantErrFields: []string{replicasPath},
+		},
+		{
+			name:              "zero",
+			maxReplicasPerLws: 4,
+			replicas:          0,
+		},
+		{
+			name:              "at the limit",
+			maxReplicasPerLws: 4,
+			replicas:          4,
+		},
+		{
+			name:              "over the limit",
+			maxReplicasPerLws: 4,
+			replicas:          5,
+			wantErrFields:     []string{replicasPath},
+		},
+		{
+			name:     "no limit",
+			replicas: 1000,
+		},
+		{
+			name:              "scaling up over the limit",
+			maxReplicasPerLws: 4,
+			oldReplicas:       ptr.To[int32](4),
+			replicas:          6,
+			wantErrFields:     []string{replicasPath},
+		},
+		{
+			name:              "scaling down while still over the limit",
+			maxReplicasPerLws: 4,
+			oldReplicas:       ptr.To[int32](8),
+			replicas:          6,
+		},
+		{
+			name:              "standby replicas over the limit",
+			maxReplicasPerLws: 4,
+			replicas:          4,
+			standbyReplicas:   1,
+			wantErrFields:     []string{replicasPath},
+		},
+		{
+			name:            "at maxReplicas with standby replicas",
+			maxReplicas:     ptr.To[int32](4),
+			replicas:        3,
+			standbyReplicas: 1,
+		},
+		{
+			name:            "standby replicas over maxReplicas",
+			maxReplicas:     ptr.To[int32](4),
+			replicas:        4,
+			standbyReplicas: 1,
+			wantErrFields:   []string{replicasPath},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := &v1.LeaderWorkerSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec: v1.LeaderWorkerSetSpec{
+					Replicas:        ptr.To(tc.replicas),
+					MaxReplicas:     tc.maxReplicas,
+					StandbyReplicas: ptr.To(tc.standbyReplicas),
+					LeaderWorkerTemplate: v1.LeaderWorkerTemplate{
+						Size: ptr.To[int32](2),
+						WorkerTemplate: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "worker", Image: "nginx"}}},
+						},
+					},
+					RolloutStrategy: v1.RolloutStrategy{
+						Type: v1.RollingUpdateStrategyType,
+						RollingUpdateConfiguration: &v1.RollingUpdateConfiguration{
+							MaxUnavailable: intstr.FromInt32(1),
+						},
+					},
+					StartupPolicy: v1.LeaderCreatedStartupPolicy,
+				},
+			}
+			webhook := &LeaderWorkerSetWebhook{MaxReplicasPerLws: tc.maxReplicasPerLws}
+
+			var err error
+			if tc.oldReplicas != nil {
+				oldLws := lws.DeepCopy()
+				oldLws.Spec.Replicas = tc.oldReplicas
+				_, err = webhook.ValidateUpdate(context.Background(), oldLws, lws)
+			} else {
+				_, err = webhook.ValidateCreate(context.Background(), lws)
+			}
+			var gotErrFields []string
+			if err != nil {
+				for _, e := range err.(utilerrors.Aggregate).Errors() {
+					gotErrFields = append(gotErrFields, e.(*field.Error).Field)
+				}
+			}
+			if diff := cmp.Diff(tc.wantErrFields, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateInheritLabels(t *testing.T) {
+	fldPath := field.NewPath("spec", "leaderWorkerTemplate", "inheritLabels")
+	tests := []struct {
+		name          string
+		keys          []string
+		wantErrFields []string
+	}{
+		{
+			name: "valid",
+			keys: []string{"cost-center", "example.com/team"},
+		},
+		{
+			name:          "reserved prefix",
+			keys:          []string{"cost-center", v1.SetNameLabelKey},
+			wantErrFields: []string{fldPath.Index(1).String()},
+		},
+		{
+			name:          "invalid key",
+			keys:          []string{"not a label"},
+			wantErrFields: []string{fldPath.Index(0).String()},
+		},
+		{
+			name:          "duplicate key",
+			keys:          []string{"team", "team"},
+			wantErrFields: []string{fldPath.Index(1).String()},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotErrFields []string
+			for _, err := range validateInheritLabels(fldPath, tc.keys) {
+				gotErrFields = append(gotErrFields, err.Field)
+			}
+			if diff := cmp.Diff(tc.wantErrFields, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateVolumeClaimTemplates(t *testing.T) {
+	fldPath := field.NewPath("spec", "leaderWorkerTemplate")
+	claim := func(name string) corev1.PersistentVolumeClaim {
+		return corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+	tests := []struct {
+		name            string
+		claims          []corev1.PersistentVolumeClaim
+		retentionPolicy *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy
+		wantErrFields   []string
+	}{
+		{
+			name:   "valid",
+			claims: []corev1.PersistentVolumeClaim{claim("model-cache"), claim("scratch")},
+			retentionPolicy: &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+				WhenDeleted: appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+				WhenScaled:  appsv1.RetainPersistentVolumeClaimRetentionPolicyType,
+			},
+		},
+		{
+			name:          "invalid name",
+			claims:        []corev1.PersistentVolumeClaim{claim("Model_Cache")},
+			wantErrFields: []string{"spec.leaderWorkerTemplate.volumeClaimTemplates[0].metadata.name"},
+		},
+		{
+			name:          "duplicate name",
+			claims:        []corev1.PersistentVolumeClaim{claim("model-cache"), claim("model-cache")},
+			wantErrFields: []string{"spec.leaderWorkerTemplate.volumeClaimTemplates[1].metadata.name"},
+		},
+		{
+			name:   "unsupported retention policy",
+			claims: []corev1.PersistentVolumeClaim{claim("model-cache")},
+			retentionPolicy: &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+				WhenDeleted: "Keep",
+				WhenScaled:  "Remove",
+			},
+			wantErrFields: []string{
+				"spec.leaderWorkerTemplate.persistentVolumeClaimRetentionPolicy.whenDeleted",
+				"spec.leaderWorkerTemplate.persistentVolumeClaimRetentionPolicy.whenScaled",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := &v1.LeaderWorkerSet{Spec: v1.LeaderWorkerSetSpec{LeaderWorkerTemplate: v1.LeaderWorkerTemplate{
+				VolumeClaimTemplates:                 tc.claims,
+				PersistentVolumeClaimRetentionPolicy: tc.retentionPolicy,
+			}}}
+			var gotErrFields []string
+			for _, err := range validateVolumeClaimTemplates(fldPath, lws) {
+				gotErrFields = append(gotErrFields, err.Field)
+			}
+			if diff := cmp.Diff(tc.wantErrFields, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateVolumeClaimTemplatesUpdate(t *testing.T) {
+	claims := []corev1.PersistentVolumeClaim{{ObjectMeta: metav1.ObjectMeta{Name: "model-cache"}}}
+	retain := &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{WhenDeleted: appsv1.RetainPersistentVolumeClaimRetentionPolicyType}
+	remove := &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{WhenDeleted: appsv1.DeletePersistentVolumeClaimRetentionPolicyType}
+	tests := []struct {
+		name          string
+		oldTemplate   v1.LeaderWorkerTemplate
+		newTemplate   v1.LeaderWorkerTemplate
+		wantErrFields []string
+	}{
+		{
+			name:        "unchanged",
+			oldTemplate: v1.LeaderWorkerTemplate{VolumeClaimTemplates: claims, PersistentVolumeClaimRetentionPolicy: retain},
+			newTemplate: v1.LeaderWorkerTemplate{VolumeClaimTemplates: claims, PersistentVolumeClaimRetentionPolicy: retain},
+		},
+		{
+			name:          "volumeClaimTemplates added",
+			newTemplate:   v1.LeaderWorkerTemplate{VolumeClaimTemplates: claims},
+			wantErrFields: []string{"spec.leaderWorkerTemplate.volumeClaimTemplates"},
+		},
+		{
+			name:          "retention policy changed",
+			oldTemplate:   v1.LeaderWorkerTemplate{VolumeClaimTemplates: claims, PersistentVolumeClaimRetentionPolicy: retain},
+			newTemplate:   v1.LeaderWorkerTemplate{VolumeClaimTemplates: claims, PersistentVolumeClaimRetentionPolicy: remove},
+			wantErrFields: []string{"spec.leaderWorkerTemplate.persistentVolumeClaimRetentionPolicy"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			oldLws := &v1.LeaderWorkerSet{Spec: v1.LeaderWorkerSetSpec{LeaderWorkerTemplate: tc.oldTemplate}}
+			newLws := &v1.LeaderWorkerSet{Spec: v1.LeaderWorkerSetSpec{LeaderWorkerTemplate: tc.newTemplate}}
+			var gotErrFields []string
+			for _, err := range validateVolumeClaimTemplatesUpdate(oldLws, newLws, field.NewPath("spec", "leaderWorkerTemplate")) {
+				gotErrFields = append(gotErrFields, err.Field)
+			}
+			if diff := cmp.Diff(tc.wantErrFields, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateLeaderReadinessProbe(t *testing.T) {
+	probe := &corev1.Probe{ProbeHandler: corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(8080)}}}
+	podTemplate := func(readinessProbe *corev1.Probe) *corev1.PodTemplateSpec {
+		return &corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "leader", Image: "nginx", ReadinessProbe: readinessProbe}}},
+		}
+	}
+	tests := []struct {
+		name                        string
+		startupPolicy               v1.StartupPolicyType
+		leaderReadyConfiguration    *v1.LeaderReadyConfiguration
+		leaderTemplate              *corev1.PodTemplateSpec
+		workerTemplate              *corev1.PodTemplateSpec
+		requireLeaderReadinessProbe bool
+		wantErrFields               []string
+		wantWarnings                admission.Warnings
+	}{
+		{
+			name:           "LeaderReady with a readiness probe on the leader",
+			startupPolicy:  v1.LeaderReadyStartupPolicy,
+			leaderTemplate: podTemplate(probe),
+			workerTemplate: podTemplate(nil),
+		},
+		{
+			name:          "LeaderReady with a readiness probe on a sidecar of the leader",
+			startupPolicy: v1.LeaderReadyStartupPolicy,
+			leaderTemplate: &corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "proxy", Image: "nginx", RestartPolicy: ptr.To(corev1.ContainerRestartPolicyAlways), ReadinessProbe: probe}},
+				Containers:     []corev1.Container{{Name: "leader", Image: "nginx"}},
+			}},
+			workerTemplate: podTemplate(nil),
+		},
+		{
+			name:           "LeaderReady without a readiness probe on the leader",
+			startupPolicy:  v1.LeaderReadyStartupPolicy,
+			leaderTemplate: podTemplate(nil),
+			workerTemplate: podTemplate(probe),
+			wantWarnings: admission.Warnings{
+				"spec.leaderWorkerTemplate.leaderTemplate: no container of the leader defines a readinessProbe, the workers of a group are only created once the leader pod is ready with the LeaderReady startupPolicy",
+			},
+		},
+		{
+			name:                        "LeaderReady without a readiness probe on the leader when required",
+			startupPolicy:               v1.LeaderReadyStartupPolicy,
+			leaderTemplate:              podTemplate(nil),
+			workerTemplate:              podTemplate(probe),
+			requireLeaderReadinessProbe: true,
+			wantErrFields:               []string{"spec.leaderWorkerTemplate.leaderTemplate"},
+		},
+		{
+			name:           "LeaderReady with a readiness probe on the worker template used by the leader",
+			startupPolicy:  v1.LeaderReadyStartupPolicy,
+			workerTemplate: podTemplate(probe),
+		},
+		{
+			name:                        "LeaderReady without a readiness probe on the worker template used by the leader",
+			startupPolicy:               v1.LeaderReadyStartupPolicy,
+			workerTemplate:              podTemplate(nil),
+			requireLeaderReadinessProbe: true,
+			wantErrFields:               []string{"spec.leaderWorkerTemplate.workerTemplate"},
+		},
+		{
+			name:                        "LeaderReady gated on a custom condition",
+			startupPolicy:               v1.LeaderReadyStartupPolicy,
+			leaderReadyConfiguration:    &v1.LeaderReadyConfiguration{ConditionType: ptr.To[corev1.PodConditionType]("example.com/model-loaded")},
+			leaderTemplate:              podTemplate(nil),
+			workerTemplate:              podTemplate(nil),
+			requireLeaderReadinessProbe: true,
+		},
+		{
+			name:                        "LeaderCreated without a readiness probe",
+			startupPolicy:               v1.LeaderCreatedStartupPolicy,
+			leaderTemplate:              podTemplate(nil),
+			workerTemplate:              podTemplate(nil),
+			requireLeaderReadinessProbe: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := &v1.LeaderWorkerSet{Spec: v1.LeaderWorkerSetSpec{
+				StartupPolicy:            tc.startupPolicy,
+				LeaderReadyConfiguration: tc.leaderReadyConfiguration,
+				LeaderWorkerTemplate: v1.LeaderWorkerTemplate{
+					LeaderTemplate: tc.leaderTemplate,
+					WorkerTemplate: *tc.workerTemplate,
+				},
+			}}
+			webhook := &LeaderWorkerSetWebhook{RequireLeaderReadinessProbe: tc.requireLeaderReadinessProbe}
+			errs, warnings := webhook.validateLeaderReadinessProbe(lws)
+			var gotErrFields []string
+			for _, err := range errs {
+				gotErrFields = append(gotErrFields, err.Field)
+			}
+			if diff := cmp.Diff(tc.wantErrFields, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+			if diff := cmp.Diff(tc.wantWarnings, warnings); diff != "" {
+				t.Errorf("unexpected warnings (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateLeaderRestartPolicy(t *testing.T) {
+	msg := "the leader isn't restarted once it exits, which stalls its group with the LeaderReady startupPolicy"
+	leaderPath := "spec.leaderWorkerTemplate.leaderTemplate.spec.restartPolicy"
+	workerPath := "spec.leaderWorkerTemplate.workerTemplate.spec.restartPolicy"
+	type testCase struct {
+		name                             string
+		startupPolicy                    v1.StartupPolicyType
+		restartPolicy                    corev1.RestartPolicy
+		withoutLeaderTemplate            bool
+		requireLeaderRestartPolicyAlways bool
+		wantErrFields                    []string
+		wantWarnings                     admission.Warnings
+	}
+	var tests []testCase
+	for _, startupPolicy := range []v1.StartupPolicyType{v1.LeaderCreatedStartupPolicy, v1.LeaderReadyStartupPolicy, v1.WorkersFirstStartupPolicy} {
+		for _, restartPolicy := range []corev1.RestartPolicy{"", corev1.RestartPolicyAlways, corev1.RestartPolicyOnFailure, corev1.RestartPolicyNever} {
+			tc := testCase{
+				name:          fmt.Sprintf("%s with restartPolicy %q", startupPolicy, restartPolicy),
+				startupPolicy: startupPolicy,
+				restartPolicy: restartPolicy,
+			}
+			if startupPolicy == v1.LeaderReadyStartupPolicy && restartPolicy != "" && restartPolicy != corev1.RestartPolicyAlways {
+				tc.wantWarnings = admission.Warnings{leaderPath + ": " + msg}
+			}
+			tests = append(tests, tc)
+		}
+	}
+	tests = append(tests,
+		testCase{
+			name:                             "LeaderReady with restartPolicy Never when Always is required",
+			startupPolicy:                    v1.LeaderReadyStartupPolicy,
+			restartPolicy:                    corev1.RestartPolicyNever,
+			requireLeaderRestartPolicyAlways: true,
+			wantErrFields:                    []string{leaderPath},
+		},
+		testCase{
+			name:                             "LeaderCreated with restartPolicy Never when Always is required",
+			startupPolicy:                    v1.LeaderCreatedStartupPolicy,
+			restartPolicy:                    corev1.RestartPolicyNever,
+			requireLeaderRestartPolicyAlways: true,
+		},
+		testCase{
+			name:                  "LeaderReady with restartPolicy Never on the worker template used by the leader",
+			startupPolicy:         v1.LeaderReadyStartupPolicy,
+			restartPolicy:         corev1.RestartPolicyNever,
+			withoutLeaderTemplate: true,
+			wantWarnings:          admission.Warnings{workerPath + ": " + msg},
+		},
+	)
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := &v1.LeaderWorkerSet{Spec: v1.LeaderWorkerSetSpec{
+				StartupPolicy: tc.startupPolicy,
+				LeaderWorkerTemplate: v1.LeaderWorkerTemplate{
+					WorkerTemplate: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "worker", Image: "nginx"}}}},
+				},
+			}}
+			if tc.withoutLeaderTemplate {
+				lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec.RestartPolicy = tc.restartPolicy
+			} else {
+				lws.Spec.LeaderWorkerTemplate.LeaderTemplate = &corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					RestartPolicy: tc.restartPolicy,
+					Containers:    []corev1.Container{{Name: "leader", Image: "nginx"}},
+				}}
+			}
+			webhook := &LeaderWorkerSetWebhook{RequireLeaderRestartPolicyAlways: tc.requireLeaderRestartPolicyAlways}
+			errs, warnings := webhook.validateLeaderRestartPolicy(lws)
+			var gotErrFields []string
+			for _, err := range errs {
+				gotErrFields = append(gotErrFields, err.Field)
+			}
+			if diff := cmp.Diff(tc.wantErrFields, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+			if diff := cmp.Diff(tc.wantWarnings, warnings); diff != "" {
+				t.Errorf("unexpected warnings (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateMembershipVolume(t *testing.T) {
+	fldPath := field.NewPath("spec", "leaderWorkerTemplate")
+	podTemplate := func(volumeNames ...string) corev1.PodTemplateSpec {
+		var volumes []corev1.Volume
+		for _, name := range volumeNames {
+			volumes = append(volumes, corev1.Volume{Name: name})
+		}
+		return corev1.PodTemplateSpec{Spec: corev1.PodSpec{Volumes: volumes}}
+	}
+	tests := []struct {
+		name           string
+		enabled        bool
+		leaderTemplate *corev1.PodTemplateSpec
+		workerTemplate corev1.PodTemplateSpec
+		wantErrFields  []string
+	}{
+		{
+			name:           "no conflicting volume",
+			enabled:        true,
+			leaderTemplate: ptr.To(podTemplate("cache")),
+			workerTemplate: podTemplate("cache"),
+		},
+		{
+			name:           "conflicting volumes",
+			enabled:        true,
+			leaderTemplate: ptr.To(podTemplate(v1.MembershipVolumeName)),
+			workerTemplate: podTemplate("cache", v1.MembershipVolumeName),
+			wantErrFields: []string{
+				"spec.leaderWorkerTemplate.leaderTemplate.spec.volumes[0].name",
+				"spec.leaderWorkerTemplate.workerTemplate.spec.volumes[1].name",
+			},
+		},
+		{
+			name:           "conflicting volume when disabled",
+			workerTemplate: podTemplate(v1.MembershipVolumeName),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lws := &v1.LeaderWorkerSet{Spec: v1.LeaderWorkerSetSpec{LeaderWorkerTemplate: v1.LeaderWorkerTemplate{
+				PublishMembershipConfigMap: tc.enabled,
+				LeaderTemplate:             tc.leaderTemplate,
+				WorkerTemplate:             tc.workerTemplate,
+			}}}
+			var gotErrFields []string
+			for _, err := range validateMembershipVolume(fldPath, lws) {
+				gotErrFields = append(gotErrFields, err.Field)
+			}
+			if diff := cmp.Diff(tc.wantErrFields, gotErrFields); diff != "" {
+				t.Errorf("unexpected error fields (-want +got): %s", diff)
+			}
+		})
+	}
+}
@@ -17,30 +17,53 @@ package webhooks
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
+	"strings"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	leaderworkerset "sigs.k8s.io/lws/api/leaderworkerset/v1"
 	"sigs.k8s.io/lws/pkg/utils"
 	acceleratorutils "sigs.k8s.io/lws/pkg/utils/accelerators"
+	controllerutils "sigs.k8s.io/lws/pkg/utils/controller"
 	podutils "sigs.k8s.io/lws/pkg/utils/pod"
 	statefulsetutils "sigs.k8s.io/lws/pkg/utils/statefulset"
 )
 
-type PodWebhook struct{}
+// EnvVarOverridden is the reason of the event recorded on a pod when an injected environment
+// variable overrides one sourced via valueFrom.
+const EnvVarOverridden = "EnvVarOverridden"
+
+type PodWebhook struct {
+	// Record records the events on the pods, no event is recorded when nil.
+	Record record.EventRecorder
+	// Reader gets the LeaderWorkerSet and the leader statefulset of the leader pods, to set the
+	// environment variables of their group from its perGroupEnv and to keep the groups past their
+	// activeDeadlineSeconds down, nil disables both for the leader pods.
+	Reader client.Reader
+}
 
 func SetupPodWebhook(mgr ctrl.Manager) error {
+	wh := &PodWebhook{Record: mgr.GetEventRecorderFor("leaderworkerset"), Reader: mgr.GetClient()}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&corev1.Pod{}).
-		WithDefaulter(&PodWebhook{}).
-		WithValidator(&PodWebhook{}).
+		WithDefaulter(wh).
+		WithValidator(wh).
 		Complete()
 }
 
@@ -136,12 +159,38 @@ func (p *PodWebhook) Default(ctx context.Context, obj runtime.Object) error {
 				SetExclusiveAffinities(pod, subGroupUniqueKey, subEpKey, leaderworkerset.SubGroupUniqueHashLabelKey)
 			}
 		}
+		if deletionCost, found := pod.Annotations[leaderworkerset.LeaderPodDeletionCostAnnotationKey]; found {
+			// The pod deletion cost must be a valid int32.
+			if _, err := strconv.ParseInt(deletionCost, 10, 32); err != nil {
+				return fmt.Errorf("invalid %s annotation %q for pod %s: %w", leaderworkerset.LeaderPodDeletionCostAnnotationKey, deletionCost, pod.Name, err)
+			}
+			pod.Annotations[corev1.PodDeletionCost] = deletionCost
+		}
+		applyLeaderPriorityClassName(pod)
+		if err := applyLeaderTerminationGracePeriodSeconds(pod); err != nil {
+			return err
+		}
+		if err := applyGroupSpreadConstraints(pod); err != nil {
+			return err
+		}
+		if err := applyLeaderGroupInitContainers(pod); err != nil {
+			return err
+		}
+		if err := p.setLeaderPerGroupEnv(ctx, pod); err != nil {
+			return err
+		}
+		if err := p.gateDeadlineExceededGroup(ctx, pod); err != nil {
+			return err
+		}
 	} else {
 		_, workerIndex := statefulsetutils.GetParentNameAndOrdinal(pod.Name)
 		if workerIndex == -1 {
 			return fmt.Errorf("parsing pod ordinal for pod %s", pod.Name)
 		}
 		pod.Labels[leaderworkerset.WorkerIndexLabelKey] = fmt.Sprint(workerIndex)
+		if pod.Annotations[leaderworkerset.WorkerReadinessFollowsLeaderAnnotationKey] == "true" && !podutils.HasReadinessGate(*pod, leaderworkerset.LeaderReadyPodConditionType) {
+			pod.Spec.ReadinessGates = append(pod.Spec.ReadinessGates, corev1.PodReadinessGate{ConditionType: leaderworkerset.LeaderReadyPodConditionType})
+		}
 		subGroupSize, foundSubGroupSize := pod.Annotations[leaderworkerset.SubGroupSizeAnnotationKey]
 		if foundSubGroupSize && pod.Labels[leaderworkerset.SubGroupIndexLabelKey] == "" {
 			subGroupSizeInt, err := strconv.Atoi(subGroupSize)
@@ -159,6 +208,14 @@ func (p *PodWebhook) Default(ctx context.Context, obj runtime.Object) error {
 		}
 	}
 
+	applyServiceAccountName(pod)
+	applyGangSchedulingLabel(pod)
+
+	// Pods are not reachable via a headless service, don't keep the subdomain set by the statefulset.
+	if pod.Annotations[leaderworkerset.SubdomainPolicyAnnotationKey] == string(leaderworkerset.SubdomainNone) {
+		pod.Spec.Subdomain = ""
+	}
+
 	// injecting env vars if needed
 	if acceleratorutils.PodRequestsTPUs(pod.Spec) {
 		if err := acceleratorutils.AddTPUVariables(pod, podCount); err != nil {
@@ -166,13 +223,287 @@ func (p *PodWebhook) Default(ctx context.Context, obj runtime.Object) error {
 		}
 	}
 
+	if err := applyCommonContainers(pod); err != nil {
+		return err
+	}
+	if err := applyPerGroupEnv(pod); err != nil {
+		return err
+	}
+
+	setMembershipConfigMap(pod)
+
+	if err := p.warnValueFromEnvCollisions(pod); err != nil {
+		return err
+	}
 	if err := podutils.AddLWSVariables(pod); err != nil {
 		return err
 	}
 
+	if err := podutils.ExpandAnnotationPlaceholders(pod); err != nil {
+		return err
+	}
+	if err := podutils.ExpandContainerPlaceholders(pod); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// warnValueFromEnvCollisions records a warning event on the pod for every container with an environment
+// variable sourced via valueFrom, e.g. from the downward API, which is about to be overridden by an
+// injected variable. Unlike literal values, those are likely set by another mutating webhook or
+// the platform, and the override would go unnoticed otherwise.
+func (p *PodWebhook) warnValueFromEnvCollisions(pod *corev1.Pod) error {
+	collisions, err := podutils.ValueFromEnvCollisions(pod)
+	if err != nil {
+		return err
+	}
+	if p.Record == nil {
+		return nil
+	}
+	for _, container := range slices.Sorted(maps.Keys(collisions)) {
+		p.Record.Eventf(pod, corev1.EventTypeWarning, EnvVarOverridden,
+			"Environment variables %s of container %s sourced via valueFrom are overridden by the injected variables",
+			strings.Join(collisions[container], ", "), container)
+	}
+	return nil
+}
+
+// applyGroupSpreadConstraints adds the topology spread constraints of the group-spread-constraints
+// annotation to the leader pod, selecting all the leader pods of the LeaderWorkerSet, so that the
+// groups are spread across the topology domains. The revision is added to the matchLabelKeys, so
+// that the leader pods of the old and new revisions are spread separately during rollouts.
+func applyGroupSpreadConstraints(pod *corev1.Pod) error {
+	value, found := pod.Annotations[leaderworkerset.GroupSpreadConstraintsAnnotationKey]
+	if !found {
+		return nil
+	}
+	var constraints []corev1.TopologySpreadConstraint
+	if err := json.Unmarshal([]byte(value), &constraints); err != nil {
+		return fmt.Errorf("invalid %s annotation for pod %s: %w", leaderworkerset.GroupSpreadConstraintsAnnotationKey, pod.Name, err)
+	}
+	for _, constraint := range constraints {
+		constraint.LabelSelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				leaderworkerset.SetNameLabelKey:     pod.Labels[leaderworkerset.SetNameLabelKey],
+				leaderworkerset.WorkerIndexLabelKey: "0",
+			},
+		}
+		if !slices.Contains(constraint.MatchLabelKeys, leaderworkerset.RevisionKey) {
+			constraint.MatchLabelKeys = append(constraint.MatchLabelKeys, leaderworkerset.RevisionKey)
+		}
+		if !slices.ContainsFunc(pod.Spec.TopologySpreadConstraints, func(c corev1.TopologySpreadConstraint) bool {
+			return equality.Semantic.DeepEqual(c, constraint)
+		}) {
+			pod.Spec.TopologySpreadConstraints = append(pod.Spec.TopologySpreadConstraints, constraint)
+		}
+	}
+	return nil
+}
+
+// applyCommonContainers appends the common containers of the annotation to the pod, the ones with
+// restartPolicy Always to the init containers as native sidecars. Containers already in the pod are skipped.
+func applyCommonContainers(pod *corev1.Pod) error {
+	value, found := pod.Annotations[leaderworkerset.CommonContainersAnnotationKey]
+	if !found {
+		return nil
+	}
+	var containers []corev1.Container
+	if err := json.Unmarshal([]byte(value), &containers); err != nil {
+		return fmt.Errorf("invalid %s annotation for pod %s: %w", leaderworkerset.CommonContainersAnnotationKey, pod.Name, err)
+	}
+	hasContainer := func(name string) bool {
+		named := func(c corev1.Container) bool { return c.Name == name }
+		return slices.ContainsFunc(pod.Spec.Containers, named) || slices.ContainsFunc(pod.Spec.InitContainers, named)
+	}
+	for _, container := range containers {
+		if hasContainer(container.Name) {
+			continue
+		}
+		if ptr.Deref(container.RestartPolicy, "") == corev1.ContainerRestartPolicyAlways {
+			pod.Spec.InitContainers = append(pod.Spec.InitContainers, container)
+		} else {
+			pod.Spec.Containers = append(pod.Spec.Containers, container)
+		}
+	}
+	return nil
+}
+
+// setLeaderPerGroupEnv sets the per-group-env annotation of the leader pod to the environment variables
+// of its group from the perGroupEnv of the lws. The leader statefulset is shared by all the groups, so
+// the variables are looked up when the pod is created rather than carried by its template, otherwise
+// editing the variables of a group would update the leader pods of all of them.
+func (p *PodWebhook) setLeaderPerGroupEnv(ctx context.Context, pod *corev1.Pod) error {
+	if p.Reader == nil {
+		return nil
+	}
+	var lws leaderworkerset.LeaderWorkerSet
+	if err := p.Reader.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: pod.Labels[leaderworkerset.SetNameLabelKey]}, &lws); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	groupIndex := pod.Labels[leaderworkerset.GroupIndexLabelKey]
+	env, found := controllerutils.PerGroupEnv(&lws)[groupIndex]
+	if !found {
+		return nil
+	}
+	perGroupEnv, err := json.Marshal(map[string][]corev1.EnvVar{groupIndex: env})
+	if err != nil {
+		return err
+	}
+	pod.Annotations[leaderworkerset.PerGroupEnvAnnotationKey] = string(perGroupEnv)
+	return nil
+}
+
+// gateDeadlineExceededGroup adds the DeadlineExceededSchedulingGate to the leader pod when its group is
+// recorded as past its activeDeadlineSeconds on the leader statefulset, so that the group recreated by the
+// statefulset stays down.
+func (p *PodWebhook) gateDeadlineExceededGroup(ctx context.Context, pod *corev1.Pod) error {
+	if p.Reader == nil || podutils.HasSchedulingGate(*pod, leaderworkerset.DeadlineExceededSchedulingGate) {
+		return nil
+	}
+	leaderStsName, _ := statefulsetutils.GetParentNameAndOrdinal(pod.Name)
+	var leaderSts appsv1.StatefulSet
+	if err := p.Reader.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: leaderStsName}, &leaderSts); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	exceededGroups, err := controllerutils.DeadlineExceededGroups(&leaderSts)
+	if err != nil {
+		return err
+	}
+	groupIndex, err := strconv.Atoi(pod.Labels[leaderworkerset.GroupIndexLabelKey])
+	if err != nil {
+		return err
+	}
+	if slices.Contains(exceededGroups, groupIndex) {
+		pod.Spec.SchedulingGates = append(pod.Spec.SchedulingGates, corev1.PodSchedulingGate{Name: leaderworkerset.DeadlineExceededSchedulingGate})
+	}
+	return nil
+}
+
+// applyPerGroupEnv sets the environment variables of the group of the pod from the per-group-env
+// annotation on all its containers, overriding the variables of the template with the same name.
+func applyPerGroupEnv(pod *corev1.Pod) error {
+	value, found := pod.Annotations[leaderworkerset.PerGroupEnvAnnotationKey]
+	if !found {
+		return nil
+	}
+	var perGroupEnv map[string][]corev1.EnvVar
+	if err := json.Unmarshal([]byte(value), &perGroupEnv); err != nil {
+		return fmt.Errorf("invalid %s annotation for pod %s: %w", leaderworkerset.PerGroupEnvAnnotationKey, pod.Name, err)
+	}
+	env := perGroupEnv[pod.Labels[leaderworkerset.GroupIndexLabelKey]]
+	if len(env) == 0 {
+		return nil
+	}
+	setEnv := func(containers []corev1.Container) {
+		for i := range containers {
+			for _, envVar := range env {
+				if j := slices.IndexFunc(containers[i].Env, func(e corev1.EnvVar) bool { return e.Name == envVar.Name }); j != -1 {
+					containers[i].Env[j] = envVar
+				} else {
+					containers[i].Env = append(containers[i].Env, envVar)
+				}
+			}
+		}
+	}
+	setEnv(pod.Spec.InitContainers)
+	setEnv(pod.Spec.Containers)
+	return nil
+}
+
+// applyLeaderGroupInitContainers appends the init containers of the leader-group-init-containers
+// annotation to the leader pod. Containers already in the pod are skipped.
+func applyLeaderGroupInitContainers(pod *corev1.Pod) error {
+	value, found := pod.Annotations[leaderworkerset.LeaderGroupInitContainersAnnotationKey]
+	if !found {
+		return nil
+	}
+	var containers []corev1.Container
+	if err := json.Unmarshal([]byte(value), &containers); err != nil {
+		return fmt.Errorf("invalid %s annotation for pod %s: %w", leaderworkerset.LeaderGroupInitContainersAnnotationKey, pod.Name, err)
+	}
+	for _, container := range containers {
+		named := func(c corev1.Container) bool { return c.Name == container.Name }
+		if slices.ContainsFunc(pod.Spec.Containers, named) || slices.ContainsFunc(pod.Spec.InitContainers, named) {
+			continue
+		}
+		pod.Spec.InitContainers = append(pod.Spec.InitContainers, container)
+	}
+	return nil
+}
+
+// applyLeaderPriorityClassName sets the priorityClassName of the leader-priority-class-name
+// annotation on the leader pod. The Priority admission plugin already resolved the priority of
+// the class of the template, so it's cleared for the plugin to resolve it again from the new
+// class, in-tree admission plugins being reinvoked once a webhook mutated the pod.
+func applyLeaderPriorityClassName(pod *corev1.Pod) {
+	priorityClassName, found := pod.Annotations[leaderworkerset.LeaderPriorityClassNameAnnotationKey]
+	if !found || pod.Spec.PriorityClassName == priorityClassName {
+		return
+	}
+	pod.Spec.PriorityClassName = priorityClassName
+	pod.Spec.Priority = nil
+	pod.Spec.PreemptionPolicy = nil
+}
+
+// applyLeaderTerminationGracePeriodSeconds sets the terminationGracePeriodSeconds of the
+// leader-termination-grace-period-seconds annotation on the leader pod, the workers keeping
+// the one of their template.
+func applyLeaderTerminationGracePeriodSeconds(pod *corev1.Pod) error {
+	gracePeriod, found := pod.Annotations[leaderworkerset.LeaderTerminationGracePeriodSecondsAnnotationKey]
+	if !found {
+		return nil
+	}
+	seconds, err := strconv.ParseInt(gracePeriod, 10, 64)
+	if err != nil || seconds < 0 {
+		return fmt.Errorf("invalid %s annotation %q for pod %s", leaderworkerset.LeaderTerminationGracePeriodSecondsAnnotationKey, gracePeriod, pod.Name)
+	}
+	pod.Spec.TerminationGracePeriodSeconds = &seconds
 	return nil
 }
 
+// applyServiceAccountName sets the serviceAccountName of the service-account-name annotation on
+// the pod, the leaders and the workers carrying the one of their role. The deprecated
+// serviceAccount field is kept in sync, the ServiceAccount admission plugin checking the new
+// account exists once reinvoked.
+func applyServiceAccountName(pod *corev1.Pod) {
+	serviceAccountName, found := pod.Annotations[leaderworkerset.ServiceAccountNameAnnotationKey]
+	if !found || pod.Spec.ServiceAccountName == serviceAccountName {
+		return
+	}
+	pod.Spec.ServiceAccountName = serviceAccountName
+	pod.Spec.DeprecatedServiceAccount = serviceAccountName
+}
+
+// podGroupMinAvailableAnnotationKey is the annotation the coscheduling plugin reads the minimum
+// number of pods of a gang from.
+const podGroupMinAvailableAnnotationKey = "pod-group.scheduling.sigs.k8s.io/min-available"
+
+// applyGangSchedulingLabel sets the label of the gang-scheduling-label-key annotation on the pod to
+// the same value for all the pods of the group, <lws name>-<group index>, and the min-available
+// annotation to the size of the group, so that gang schedulers schedule the group as a whole.
+func applyGangSchedulingLabel(pod *corev1.Pod) {
+	labelKey, found := pod.Annotations[leaderworkerset.GangSchedulingLabelKeyAnnotationKey]
+	if !found {
+		return
+	}
+	pod.Labels[labelKey] = fmt.Sprintf("%s-%s", pod.Labels[leaderworkerset.SetNameLabelKey], pod.Labels[leaderworkerset.GroupIndexLabelKey])
+	pod.Annotations[podGroupMinAvailableAnnotationKey] = pod.Annotations[leaderworkerset.SizeAnnotationKey]
+}
+
+// setMembershipConfigMap points the membership volume of the pod at the ConfigMap of its group.
+func setMembershipConfigMap(pod *corev1.Pod) {
+	if pod.Annotations[leaderworkerset.MembershipConfigMapAnnotationKey] != "true" {
+		return
+	}
+	for i := range pod.Spec.Volumes {
+		volume := &pod.Spec.Volumes[i]
+		if volume.Name == leaderworkerset.MembershipVolumeName && volume.ConfigMap != nil {
+			volume.ConfigMap.Name = controllerutils.MembershipConfigMapName(pod.Labels[leaderworkerset.SetNameLabelKey], pod.Labels[leaderworkerset.GroupIndexLabelKey])
+		}
+	}
+}
+
 func genGroupUniqueKey(ns string, podName string) string {
 	return utils.Sha1Hash(fmt.Sprintf("%s/%s", ns, podName))
 }
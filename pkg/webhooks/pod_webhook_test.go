@@ -17,13 +17,24 @@ limitations under the License.
 package webhooks
 
 import (
+	"context"
+	"fmt"
+	"slices"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	leaderworkerset "sigs.k8s.io/lws/api/leaderworkerset/v1"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	podutils "sigs.k8s.io/lws/pkg/utils/pod"
 )
 
 func TestGenGroupUniqueKey(t *testing.T) {
@@ -168,6 +179,1476 @@ func TestSetExclusiveAffinities(t *testing.T) {
 	}
 }
 
+func TestDefaultExclusiveTopology(t *testing.T) {
+	for _, topologyKey := range []string{"kubernetes.io/hostname", "topology.kubernetes.io/zone", "example.com/rack"} {
+		t.Run(topologyKey, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-sample-1",
+					Namespace: "default",
+					Labels: map[string]string{
+						leaderworkerset.SetNameLabelKey:     "test-sample",
+						leaderworkerset.WorkerIndexLabelKey: "0",
+					},
+					Annotations: map[string]string{
+						leaderworkerset.SizeAnnotationKey:         "2",
+						leaderworkerset.ExclusiveKeyAnnotationKey: topologyKey,
+					},
+				},
+				Spec: corev1.PodSpec{
+					Subdomain:  "test-sample",
+					Containers: []corev1.Container{{Name: "leader"}},
+				},
+			}
+			if err := (&PodWebhook{}).Default(context.TODO(), pod); err != nil {
+				t.Fatal(err)
+			}
+
+			groupUniqueKey := genGroupUniqueKey("default", "test-sample-1")
+			wantAffinity := &corev1.Affinity{
+				PodAffinity: &corev1.PodAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
+						TopologyKey: topologyKey,
+						LabelSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+							{
+								Key:      leaderworkerset.GroupUniqueHashLabelKey,
+								Operator: metav1.LabelSelectorOpIn,
+								Values:   []string{groupUniqueKey},
+							},
+						}},
+					}},
+				},
+				PodAntiAffinity: &corev1.PodAntiAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
+						TopologyKey: topologyKey,
+						LabelSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+							{
+								Key:      leaderworkerset.GroupUniqueHashLabelKey,
+								Operator: metav1.LabelSelectorOpExists,
+							},
+							{
+								Key:      leaderworkerset.GroupUniqueHashLabelKey,
+								Operator: metav1.LabelSelectorOpNotIn,
+								Values:   []string{groupUniqueKey},
+							},
+						}},
+					}},
+				},
+			}
+			if diff := cmp.Diff(wantAffinity, pod.Spec.Affinity); diff != "" {
+				t.Errorf("unexpected affinity (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestDefaultSubGroupTopology(t *testing.T) {
+	const (
+		groupTopologyKey    = "example.com/rack"
+		subGroupTopologyKey = "kubernetes.io/hostname"
+	)
+	// A group of size 4 with two subgroups of size 2: the leader and the first worker form subgroup 0,
+	// the two other workers form subgroup 1.
+	tests := []struct {
+		name             string
+		podName          string
+		workerIndex      string
+		wantSubGroup     string
+		wantTopologyKeys []string
+	}{
+		{
+			name:             "leader",
+			podName:          "test-sample-1",
+			workerIndex:      "0",
+			wantSubGroup:     "0",
+			wantTopologyKeys: []string{groupTopologyKey, subGroupTopologyKey},
+		},
+		{
+			name:             "worker of the leader subgroup",
+			podName:          "test-sample-1-1",
+			workerIndex:      "1",
+			wantSubGroup:     "0",
+			wantTopologyKeys: []string{subGroupTopologyKey},
+		},
+		{
+			name:             "first worker of the second subgroup",
+			podName:          "test-sample-1-2",
+			workerIndex:      "2",
+			wantSubGroup:     "1",
+			wantTopologyKeys: []string{subGroupTopologyKey},
+		},
+		{
+			name:             "second worker of the second subgroup",
+			podName:          "test-sample-1-3",
+			workerIndex:      "3",
+			wantSubGroup:     "1",
+			wantTopologyKeys: []string{subGroupTopologyKey},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      tc.podName,
+					Namespace: "default",
+					Labels: map[string]string{
+						leaderworkerset.SetNameLabelKey:    "test-sample",
+						leaderworkerset.GroupIndexLabelKey: "1",
+					},
+					Annotations: map[string]string{
+						leaderworkerset.SizeAnnotationKey:                 "4",
+						leaderworkerset.ExclusiveKeyAnnotationKey:         groupTopologyKey,
+						leaderworkerset.SubGroupSizeAnnotationKey:         "2",
+						leaderworkerset.SubGroupExclusiveKeyAnnotationKey: subGroupTopologyKey,
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "main"}},
+				},
+			}
+			if tc.workerIndex == "0" {
+				pod.Labels[leaderworkerset.WorkerIndexLabelKey] = "0"
+				pod.Annotations[leaderworkerset.SubGroupPolicyTypeAnnotationKey] = string(leaderworkerset.SubGroupPolicyTypeLeaderWorker)
+			} else {
+				pod.Annotations[leaderworkerset.LeaderPodNameAnnotationKey] = "test-sample-1"
+			}
+			if err := (&PodWebhook{}).Default(context.TODO(), pod); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := pod.Labels[leaderworkerset.SubGroupIndexLabelKey]; got != tc.wantSubGroup {
+				t.Errorf("unexpected subgroup index, want %q, got %q", tc.wantSubGroup, got)
+			}
+			subGroupUniqueKey := genGroupUniqueKey("test-sample-1", tc.wantSubGroup)
+			if got := pod.Labels[leaderworkerset.SubGroupUniqueHashLabelKey]; got != subGroupUniqueKey {
+				t.Errorf("unexpected subgroup unique key, want %q, got %q", subGroupUniqueKey, got)
+			}
+			for _, topologyKey := range tc.wantTopologyKeys {
+				if !exclusiveAffinityApplied(*pod, topologyKey) {
+					t.Errorf("expected exclusive affinity on topology key %s", topologyKey)
+				}
+			}
+			var gotAffinityTopologyKeys []string
+			for _, term := range pod.Spec.Affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+				gotAffinityTopologyKeys = append(gotAffinityTopologyKeys, term.TopologyKey)
+				if term.TopologyKey == subGroupTopologyKey {
+					wantSelector := &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+						Key:      leaderworkerset.SubGroupUniqueHashLabelKey,
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   []string{subGroupUniqueKey},
+					}}}
+					if diff := cmp.Diff(wantSelector, term.LabelSelector); diff != "" {
+						t.Errorf("unexpected subgroup affinity selector (-want +got): %s", diff)
+					}
+				}
+			}
+			if diff := cmp.Diff(tc.wantTopologyKeys, gotAffinityTopologyKeys); diff != "" {
+				t.Errorf("unexpected affinity topology keys (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestDefaultLeaderPodDeletionCost(t *testing.T) {
+	tests := []struct {
+		name             string
+		podName          string
+		workerIndex      string
+		deletionCost     string
+		wantDeletionCost string
+		wantErr          bool
+	}{
+		{
+			name:             "leader pod",
+			podName:          "test-sample-1",
+			workerIndex:      "0",
+			deletionCost:     "100",
+			wantDeletionCost: "100",
+		},
+		{
+			name:             "leader pod with a negative cost",
+			podName:          "test-sample-1",
+			workerIndex:      "0",
+			deletionCost:     "-2147483648",
+			wantDeletionCost: "-2147483648",
+		},
+		{
+			name:         "worker pod",
+			podName:      "test-sample-1-1",
+			deletionCost: "100",
+		},
+		{
+			name:        "leader pod without deletion cost",
+			podName:     "test-sample-1",
+			workerIndex: "0",
+		},
+		{
+			name:         "leader pod with a deletion cost out of the int32 range",
+			podName:      "test-sample-1",
+			workerIndex:  "0",
+			deletionCost: "2147483648",
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      tc.podName,
+					Namespace: "default",
+					Labels: map[string]string{
+						leaderworkerset.SetNameLabelKey:    "test-sample",
+						leaderworkerset.GroupIndexLabelKey: "1",
+					},
+					Annotations: map[string]string{
+						leaderworkerset.SizeAnnotationKey:          "2",
+						leaderworkerset.LeaderPodNameAnnotationKey: "test-sample-1",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Subdomain:  "test-sample",
+					Containers: []corev1.Container{{Name: "main"}},
+				},
+			}
+			if tc.workerIndex != "" {
+				pod.Labels[leaderworkerset.WorkerIndexLabelKey] = tc.workerIndex
+			}
+			if tc.deletionCost != "" {
+				pod.Annotations[leaderworkerset.LeaderPodDeletionCostAnnotationKey] = tc.deletionCost
+			}
+			err := (&PodWebhook{}).Default(context.TODO(), pod)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("unexpected error, want error: %t, got: %v", tc.wantErr, err)
+			}
+			if tc.wantErr {
+				return
+			}
+			deletionCost, found := pod.Annotations[corev1.PodDeletionCost]
+			if wantFound := tc.wantDeletionCost != ""; found != wantFound || deletionCost != tc.wantDeletionCost {
+				t.Errorf("unexpected %s annotation, want: %q, got: %q", corev1.PodDeletionCost, tc.wantDeletionCost, deletionCost)
+			}
+		})
+	}
+}
+
+func TestDefaultGroupSpreadConstraints(t *testing.T) {
+	constraints := `[{"maxSkew":1,"topologyKey":"topology.kubernetes.io/zone","whenUnsatisfiable":"DoNotSchedule"}]`
+	leaderSelector := &metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			leaderworkerset.SetNameLabelKey:     "test-sample",
+			leaderworkerset.WorkerIndexLabelKey: "0",
+		},
+	}
+	revisionKeys := []string{leaderworkerset.RevisionKey}
+	tests := []struct {
+		name            string
+		podName         string
+		workerIndex     string
+		annotation      string
+		existing        []corev1.TopologySpreadConstraint
+		wantConstraints []corev1.TopologySpreadConstraint
+	}{
+		{
+			name:        "leader pod",
+			podName:     "test-sample-1",
+			workerIndex: "0",
+			annotation:  constraints,
+			wantConstraints: []corev1.TopologySpreadConstraint{
+				{MaxSkew: 1, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: corev1.DoNotSchedule, LabelSelector: leaderSelector, MatchLabelKeys: revisionKeys},
+			},
+		},
+		{
+			name:        "leader pod with existing constraints",
+			podName:     "test-sample-1",
+			workerIndex: "0",
+			annotation:  constraints,
+			existing: []corev1.TopologySpreadConstraint{
+				{MaxSkew: 1, TopologyKey: "kubernetes.io/hostname", WhenUnsatisfiable: corev1.ScheduleAnyway},
+			},
+			wantConstraints: []corev1.TopologySpreadConstraint{
+				{MaxSkew: 1, TopologyKey: "kubernetes.io/hostname", WhenUnsatisfiable: corev1.ScheduleAnyway},
+				{MaxSkew: 1, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: corev1.DoNotSchedule, LabelSelector: leaderSelector, MatchLabelKeys: revisionKeys},
+			},
+		},
+		{
+			name:        "leader pod already defaulted",
+			podName:     "test-sample-1",
+			workerIndex: "0",
+			annotation:  constraints,
+			existing: []corev1.TopologySpreadConstraint{
+				{MaxSkew: 1, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: corev1.DoNotSchedule, LabelSelector: leaderSelector, MatchLabelKeys: revisionKeys},
+			},
+			wantConstraints: []corev1.TopologySpreadConstraint{
+				{MaxSkew: 1, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: corev1.DoNotSchedule, LabelSelector: leaderSelector, MatchLabelKeys: revisionKeys},
+			},
+		},
+		{
+			name:        "leader pod with matchLabelKeys",
+			podName:     "test-sample-1",
+			workerIndex: "0",
+			annotation:  `[{"maxSkew":1,"topologyKey":"topology.kubernetes.io/zone","whenUnsatisfiable":"DoNotSchedule","matchLabelKeys":["app"]}]`,
+			wantConstraints: []corev1.TopologySpreadConstraint{
+				{MaxSkew: 1, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: corev1.DoNotSchedule, LabelSelector: leaderSelector, MatchLabelKeys: []string{"app", leaderworkerset.RevisionKey}},
+			},
+		},
+		{
+			name:       "worker pod",
+			podName:    "test-sample-1-1",
+			annotation: constraints,
+		},
+		{
+			name:        "leader pod without the annotation",
+			podName:     "test-sample-1",
+			workerIndex: "0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      tc.podName,
+					Namespace: "default",
+					Labels: map[string]string{
+						leaderworkerset.SetNameLabelKey:    "test-sample",
+						leaderworkerset.GroupIndexLabelKey: "1",
+					},
+					Annotations: map[string]string{
+						leaderworkerset.SizeAnnotationKey:          "2",
+						leaderworkerset.LeaderPodNameAnnotationKey: "test-sample-1",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Subdomain:                 "test-sample",
+					Containers:                []corev1.Container{{Name: "main"}},
+					TopologySpreadConstraints: tc.existing,
+				},
+			}
+			if tc.workerIndex != "" {
+				pod.Labels[leaderworkerset.WorkerIndexLabelKey] = tc.workerIndex
+			}
+			if tc.annotation != "" {
+				pod.Annotations[leaderworkerset.GroupSpreadConstraintsAnnotationKey] = tc.annotation
+			}
+			if err := (&PodWebhook{}).Default(context.TODO(), pod); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.wantConstraints, pod.Spec.TopologySpreadConstraints); diff != "" {
+				t.Errorf("unexpected topology spread constraints (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestDefaultCommonContainers(t *testing.T) {
+	tests := []struct {
+		name               string
+		podName            string
+		workerIndex        string
+		annotation         string
+		containers         []corev1.Container
+		wantContainers     []string
+		wantInitContainers []string
+	}{
+		{
+			name:           "leader pod",
+			podName:        "test-sample-1",
+			workerIndex:    "0",
+			annotation:     `[{"name":"logger","image":"fluent-bit"}]`,
+			containers:     []corev1.Container{{Name: "main"}},
+			wantContainers: []string{"main", "logger"},
+		},
+		{
+			name:           "worker pod",
+			podName:        "test-sample-1-1",
+			annotation:     `[{"name":"logger","image":"fluent-bit"}]`,
+			containers:     []corev1.Container{{Name: "main"}},
+			wantContainers: []string{"main", "logger"},
+		},
+		{
+			name:               "native sidecar",
+			podName:            "test-sample-1-1",
+			annotation:         `[{"name":"logger","image":"fluent-bit","restartPolicy":"Always"},{"name":"metrics","image":"exporter"}]`,
+			containers:         []corev1.Container{{Name: "main"}},
+			wantContainers:     []string{"main", "metrics"},
+			wantInitContainers: []string{"logger"},
+		},
+		{
+			name:           "container already in the pod",
+			podName:        "test-sample-1",
+			workerIndex:    "0",
+			annotation:     `[{"name":"logger","image":"fluent-bit"}]`,
+			containers:     []corev1.Container{{Name: "main"}, {Name: "logger"}},
+			wantContainers: []string{"main", "logger"},
+		},
+		{
+			name:           "without the annotation",
+			podName:        "test-sample-1",
+			workerIndex:    "0",
+			containers:     []corev1.Container{{Name: "main"}},
+			wantContainers: []string{"main"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      tc.podName,
+					Namespace: "default",
+					Labels: map[string]string{
+						leaderworkerset.SetNameLabelKey:    "test-sample",
+						leaderworkerset.GroupIndexLabelKey: "1",
+					},
+					Annotations: map[string]string{
+						leaderworkerset.SizeAnnotationKey:          "2",
+						leaderworkerset.LeaderPodNameAnnotationKey: "test-sample-1",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Subdomain:  "test-sample",
+					Containers: tc.containers,
+				},
+			}
+			if tc.workerIndex != "" {
+				pod.Labels[leaderworkerset.WorkerIndexLabelKey] = tc.workerIndex
+			}
+			if tc.annotation != "" {
+				pod.Annotations[leaderworkerset.CommonContainersAnnotationKey] = tc.annotation
+			}
+			if err := (&PodWebhook{}).Default(context.TODO(), pod); err != nil {
+				t.Fatal(err)
+			}
+			names := func(containers []corev1.Container) []string {
+				var names []string
+				for _, c := range containers {
+					names = append(names, c.Name)
+				}
+				return names
+			}
+			if diff := cmp.Diff(tc.wantContainers, names(pod.Spec.Containers)); diff != "" {
+				t.Errorf("unexpected containers (-want +got): %s", diff)
+			}
+			if diff := cmp.Diff(tc.wantInitContainers, names(pod.Spec.InitContainers)); diff != "" {
+				t.Errorf("unexpected init containers (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestDefaultPerGroupEnv(t *testing.T) {
+	const annotation = `{"0":[{"name":"MODEL_SHARD","value":"shard-0"}],"1":[{"name":"MODEL_SHARD","value":"shard-1"},{"name":"SHARD_COUNT","value":"2"}]}`
+	tests := []struct {
+		name        string
+		podName     string
+		workerIndex string
+		groupIndex  string
+		annotation  string
+		env         []corev1.EnvVar
+		wantEnv     map[string]string
+		wantErr     bool
+	}{
+		{
+			name:        "leader pod",
+			podName:     "test-sample-1",
+			workerIndex: "0",
+			groupIndex:  "1",
+			annotation:  annotation,
+			wantEnv:     map[string]string{"MODEL_SHARD": "shard-1", "SHARD_COUNT": "2"},
+		},
+		{
+			name:       "worker pod",
+			podName:    "test-sample-0-1",
+			groupIndex: "0",
+			annotation: annotation,
+			wantEnv:    map[string]string{"MODEL_SHARD": "shard-0", "SHARD_COUNT": ""},
+		},
+		{
+			name:        "template env var overridden",
+			podName:     "test-sample-1",
+			workerIndex: "0",
+			groupIndex:  "1",
+			annotation:  annotation,
+			env:         []corev1.EnvVar{{Name: "MODEL_SHARD", Value: "default"}, {Name: "DEBUG", Value: "true"}},
+			wantEnv:     map[string]string{"MODEL_SHARD": "shard-1", "SHARD_COUNT": "2", "DEBUG": "true"},
+		},
+		{
+			name:       "group without overrides",
+			podName:    "test-sample-2-1",
+			groupIndex: "2",
+			annotation: annotation,
+			env:        []corev1.EnvVar{{Name: "MODEL_SHARD", Value: "default"}},
+			wantEnv:    map[string]string{"MODEL_SHARD": "default"},
+		},
+		{
+			name:        "invalid annotation",
+			podName:     "test-sample-1",
+			workerIndex: "0",
+			groupIndex:  "1",
+			annotation:  `[{"name":"MODEL_SHARD"}]`,
+			wantErr:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      tc.podName,
+					Namespace: "default",
+					Labels: map[string]string{
+						leaderworkerset.SetNameLabelKey:    "test-sample",
+						leaderworkerset.GroupIndexLabelKey: tc.groupIndex,
+					},
+					Annotations: map[string]string{
+						leaderworkerset.SizeAnnotationKey:          "2",
+						leaderworkerset.LeaderPodNameAnnotationKey: fmt.Sprintf("test-sample-%s", tc.groupIndex),
+						leaderworkerset.PerGroupEnvAnnotationKey:   tc.annotation,
+					},
+				},
+				Spec: corev1.PodSpec{
+					Subdomain:      "test-sample",
+					InitContainers: []corev1.Container{{Name: "init", Env: slices.Clone(tc.env)}},
+					Containers:     []corev1.Container{{Name: "main", Env: slices.Clone(tc.env)}},
+				},
+			}
+			if tc.workerIndex != "" {
+				pod.Labels[leaderworkerset.WorkerIndexLabelKey] = tc.workerIndex
+			}
+			err := (&PodWebhook{}).Default(context.TODO(), pod)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("unexpected error, want error: %t, got: %v", tc.wantErr, err)
+			}
+			if tc.wantErr {
+				return
+			}
+			for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+				for name, want := range tc.wantEnv {
+					got := ""
+					for _, env := range container.Env {
+						if env.Name == name {
+							got = env.Value
+						}
+					}
+					if got != want {
+						t.Errorf("unexpected %s env var of container %s, want: %q, got: %q", name, container.Name, want, got)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDefaultLeaderPerGroupEnv(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := leaderworkerset.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := appsv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	lws := &leaderworkerset.LeaderWorkerSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-sample", Namespace: "default"},
+		Spec: leaderworkerset.LeaderWorkerSetSpec{
+			NetworkConfig: &leaderworkerset.NetworkConfig{StartIndex: 1},
+			LeaderWorkerTemplate: leaderworkerset.LeaderWorkerTemplate{
+				PerGroupEnv: []leaderworkerset.PerGroupEnv{{GroupIndex: 0, Env: []corev1.EnvVar{{Name: "MODEL_SHARD", Value: "shard-0"}}}},
+			},
+		},
+	}
+	tests := []struct {
+		name           string
+		groupIndex     string
+		objects        []client.Object
+		wantAnnotation string
+		wantShard      string
+	}{
+		{
+			name:           "group with overrides, shifted by the start index",
+			groupIndex:     "1",
+			objects:        []client.Object{lws},
+			wantAnnotation: `{"1":[{"name":"MODEL_SHARD","value":"shard-0"}]}`,
+			wantShard:      "shard-0",
+		},
+		{
+			name:       "group without overrides",
+			groupIndex: "2",
+			objects:    []client.Object{lws},
+		},
+		{
+			name:       "leaderworkerset not found",
+			groupIndex: "1",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-sample-" + tc.groupIndex,
+					Namespace: "default",
+					Labels: map[string]string{
+						leaderworkerset.SetNameLabelKey:     "test-sample",
+						leaderworkerset.GroupIndexLabelKey:  tc.groupIndex,
+						leaderworkerset.WorkerIndexLabelKey: "0",
+					},
+					Annotations: map[string]string{leaderworkerset.SizeAnnotationKey: "2"},
+				},
+				Spec: corev1.PodSpec{Subdomain: "test-sample", Containers: []corev1.Container{{Name: "main"}}},
+			}
+			wh := &PodWebhook{Reader: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.objects...).Build()}
+			if err := wh.Default(context.TODO(), pod); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := pod.Annotations[leaderworkerset.PerGroupEnvAnnotationKey]; got != tc.wantAnnotation {
+				t.Errorf("unexpected %s annotation, want: %s, got: %s", leaderworkerset.PerGroupEnvAnnotationKey, tc.wantAnnotation, got)
+			}
+			got := ""
+			for _, env := range pod.Spec.Containers[0].Env {
+				if env.Name == "MODEL_SHARD" {
+					got = env.Value
+				}
+			}
+			if got != tc.wantShard {
+				t.Errorf("unexpected MODEL_SHARD env var, want: %q, got: %q", tc.wantShard, got)
+			}
+		})
+	}
+}
+
+func TestDefaultLeaderDeadlineExceeded(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := leaderworkerset.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := appsv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	leaderSts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-sample",
+			Namespace:   "default",
+			Annotations: map[string]string{leaderworkerset.DeadlineExceededGroupsAnnotationKey: "[0,2]"},
+		},
+	}
+	tests := []struct {
+		name       string
+		groupIndex string
+		objects    []client.Object
+		wantGated  bool
+	}{
+		{
+			name:       "group past its deadline",
+			groupIndex: "2",
+			objects:    []client.Object{leaderSts},
+			wantGated:  true,
+		},
+		{
+			name:       "group within its deadline",
+			groupIndex: "1",
+			objects:    []client.Object{leaderSts},
+		},
+		{
+			name:       "leader statefulset not found",
+			groupIndex: "2",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-sample-" + tc.groupIndex,
+					Namespace: "default",
+					Labels: map[string]string{
+						leaderworkerset.SetNameLabelKey:     "test-sample",
+						leaderworkerset.GroupIndexLabelKey:  tc.groupIndex,
+						leaderworkerset.WorkerIndexLabelKey: "0",
+					},
+					Annotations: map[string]string{leaderworkerset.SizeAnnotationKey: "2"},
+				},
+				Spec: corev1.PodSpec{Subdomain: "test-sample", Containers: []corev1.Container{{Name: "main"}}},
+			}
+			wh := &PodWebhook{Reader: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.objects...).Build()}
+			if err := wh.Default(context.TODO(), pod); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gated := podutils.HasSchedulingGate(*pod, leaderworkerset.DeadlineExceededSchedulingGate); gated != tc.wantGated {
+				t.Errorf("unexpected %s scheduling gate, want: %t, got: %t", leaderworkerset.DeadlineExceededSchedulingGate, tc.wantGated, gated)
+			}
+		})
+	}
+}
+
+func TestDefaultLeaderGroupInitContainers(t *testing.T) {
+	tests := []struct {
+		name               string
+		podName            string
+		workerIndex        string
+		annotation         string
+		initContainers     []corev1.Container
+		wantInitContainers []string
+	}{
+		{
+			name:               "leader pod",
+			podName:            "test-sample-1",
+			workerIndex:        "0",
+			annotation:         `[{"name":"coordinate","image":"busybox"}]`,
+			initContainers:     []corev1.Container{{Name: "init"}},
+			wantInitContainers: []string{"init", "coordinate"},
+		},
+		{
+			name:               "worker pod unaffected",
+			podName:            "test-sample-1-1",
+			annotation:         `[{"name":"coordinate","image":"busybox"}]`,
+			initContainers:     []corev1.Container{{Name: "init"}},
+			wantInitContainers: []string{"init"},
+		},
+		{
+			name:               "container already in the pod",
+			podName:            "test-sample-1",
+			workerIndex:        "0",
+			annotation:         `[{"name":"coordinate","image":"busybox"}]`,
+			initContainers:     []corev1.Container{{Name: "coordinate"}},
+			wantInitContainers: []string{"coordinate"},
+		},
+		{
+			name:        "without the annotation",
+			podName:     "test-sample-1",
+			workerIndex: "0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      tc.podName,
+					Namespace: "default",
+					Labels: map[string]string{
+						leaderworkerset.SetNameLabelKey:    "test-sample",
+						leaderworkerset.GroupIndexLabelKey: "1",
+					},
+					Annotations: map[string]string{
+						leaderworkerset.SizeAnnotationKey:          "2",
+						leaderworkerset.LeaderPodNameAnnotationKey: "test-sample-1",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Subdomain:      "test-sample",
+					InitContainers: tc.initContainers,
+					Containers:     []corev1.Container{{Name: "main"}},
+				},
+			}
+			if tc.workerIndex != "" {
+				pod.Labels[leaderworkerset.WorkerIndexLabelKey] = tc.workerIndex
+			}
+			if tc.annotation != "" {
+				pod.Annotations[leaderworkerset.LeaderGroupInitContainersAnnotationKey] = tc.annotation
+			}
+			if err := (&PodWebhook{}).Default(context.TODO(), pod); err != nil {
+				t.Fatal(err)
+			}
+			names := func(containers []corev1.Container) []string {
+				var names []string
+				for _, c := range containers {
+					names = append(names, c.Name)
+				}
+				return names
+			}
+			if diff := cmp.Diff(tc.wantInitContainers, names(pod.Spec.InitContainers)); diff != "" {
+				t.Errorf("unexpected init containers (-want +got): %s", diff)
+			}
+			if diff := cmp.Diff([]string{"main"}, names(pod.Spec.Containers)); diff != "" {
+				t.Errorf("unexpected containers (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestDefaultLeaderPriorityClassName(t *testing.T) {
+	preemptLowerPriority := corev1.PreemptLowerPriority
+	tests := []struct {
+		name                  string
+		podName               string
+		workerIndex           string
+		annotation            string
+		priorityClassName     string
+		wantPriorityClassName string
+		wantPriorityCleared   bool
+	}{
+		{
+			name:                  "leader pod",
+			podName:               "test-sample-1",
+			workerIndex:           "0",
+			annotation:            "leader-critical",
+			wantPriorityClassName: "leader-critical",
+			wantPriorityCleared:   true,
+		},
+		{
+			name:                  "leader template class overridden",
+			podName:               "test-sample-1",
+			workerIndex:           "0",
+			annotation:            "leader-critical",
+			priorityClassName:     "batch-low",
+			wantPriorityClassName: "leader-critical",
+			wantPriorityCleared:   true,
+		},
+		{
+			name:                  "same class as the leader template",
+			podName:               "test-sample-1",
+			workerIndex:           "0",
+			annotation:            "leader-critical",
+			priorityClassName:     "leader-critical",
+			wantPriorityClassName: "leader-critical",
+		},
+		{
+			name:                  "worker pod unaffected",
+			podName:               "test-sample-1-1",
+			annotation:            "leader-critical",
+			priorityClassName:     "batch-low",
+			wantPriorityClassName: "batch-low",
+		},
+		{
+			name:                  "without the annotation",
+			podName:               "test-sample-1",
+			workerIndex:           "0",
+			priorityClassName:     "batch-low",
+			wantPriorityClassName: "batch-low",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      tc.podName,
+					Namespace: "default",
+					Labels: map[string]string{
+						leaderworkerset.SetNameLabelKey:    "test-sample",
+						leaderworkerset.GroupIndexLabelKey: "1",
+					},
+					Annotations: map[string]string{
+						leaderworkerset.SizeAnnotationKey:          "2",
+						leaderworkerset.LeaderPodNameAnnotationKey: "test-sample-1",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Subdomain:         "test-sample",
+					Containers:        []corev1.Container{{Name: "main"}},
+					PriorityClassName: tc.priorityClassName,
+					// As resolved by the Priority admission plugin before the webhook.
+					Priority:         ptr.To[int32](100),
+					PreemptionPolicy: &preemptLowerPriority,
+				},
+			}
+			if tc.workerIndex != "" {
+				pod.Labels[leaderworkerset.WorkerIndexLabelKey] = tc.workerIndex
+			}
+			if tc.annotation != "" {
+				pod.Annotations[leaderworkerset.LeaderPriorityClassNameAnnotationKey] = tc.annotation
+			}
+			if err := (&PodWebhook{}).Default(context.TODO(), pod); err != nil {
+				t.Fatal(err)
+			}
+			if pod.Spec.PriorityClassName != tc.wantPriorityClassName {
+				t.Errorf("unexpected priorityClassName, want: %q, got: %q", tc.wantPriorityClassName, pod.Spec.PriorityClassName)
+			}
+			if cleared := pod.Spec.Priority == nil && pod.Spec.PreemptionPolicy == nil; cleared != tc.wantPriorityCleared {
+				t.Errorf("unexpected priority and preemptionPolicy, want cleared: %t, got: %v, %v", tc.wantPriorityCleared, pod.Spec.Priority, pod.Spec.PreemptionPolicy)
+			}
+		})
+	}
+}
+
+func TestDefaultLeaderTerminationGracePeriodSeconds(t *testing.T) {
+	tests := []struct {
+		name            string
+		podName         string
+		workerIndex     string
+		annotation      string
+		gracePeriod     *int64
+		wantGracePeriod *int64
+		wantErr         bool
+	}{
+		{
+			name:            "leader pod",
+			podName:         "test-sample-1",
+			workerIndex:     "0",
+			annotation:      "120",
+			wantGracePeriod: ptr.To[int64](120),
+		},
+		{
+			name:            "leader template grace period overridden",
+			podName:         "test-sample-1",
+			workerIndex:     "0",
+			annotation:      "120",
+			gracePeriod:     ptr.To[int64](30),
+			wantGracePeriod: ptr.To[int64](120),
+		},
+		{
+			name:            "leader pod with a zero grace period",
+			podName:         "test-sample-1",
+			workerIndex:     "0",
+			annotation:      "0",
+			gracePeriod:     ptr.To[int64](30),
+			wantGracePeriod: ptr.To[int64](0),
+		},
+		{
+			name:            "worker pod keeps the template grace period",
+			podName:         "test-sample-1-1",
+			annotation:      "120",
+			gracePeriod:     ptr.To[int64](30),
+			wantGracePeriod: ptr.To[int64](30),
+		},
+		{
+			name:            "without the annotation",
+			podName:         "test-sample-1",
+			workerIndex:     "0",
+			gracePeriod:     ptr.To[int64](30),
+			wantGracePeriod: ptr.To[int64](30),
+		},
+		{
+			name:        "negative grace period",
+			podName:     "test-sample-1",
+			workerIndex: "0",
+			annotation:  "-1",
+			wantErr:     true,
+		},
+		{
+			name:        "invalid grace period",
+			podName:     "test-sample-1",
+			workerIndex: "0",
+			annotation:  "two minutes",
+			wantErr:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      tc.podName,
+					Namespace: "default",
+					Labels: map[string]string{
+						leaderworkerset.SetNameLabelKey:    "test-sample",
+						leaderworkerset.GroupIndexLabelKey: "1",
+					},
+					Annotations: map[string]string{
+						leaderworkerset.SizeAnnotationKey:          "2",
+						leaderworkerset.LeaderPodNameAnnotationKey: "test-sample-1",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Subdomain:                     "test-sample",
+					Containers:                    []corev1.Container{{Name: "main"}},
+					TerminationGracePeriodSeconds: tc.gracePeriod,
+				},
+			}
+			if tc.workerIndex != "" {
+				pod.Labels[leaderworkerset.WorkerIndexLabelKey] = tc.workerIndex
+			}
+			if tc.annotation != "" {
+				pod.Annotations[leaderworkerset.LeaderTerminationGracePeriodSecondsAnnotationKey] = tc.annotation
+			}
+			err := (&PodWebhook{}).Default(context.TODO(), pod)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("unexpected error, want error: %t, got: %v", tc.wantErr, err)
+			}
+			if tc.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tc.wantGracePeriod, pod.Spec.TerminationGracePeriodSeconds); diff != "" {
+				t.Errorf("unexpected terminationGracePeriodSeconds (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestDefaultServiceAccountName(t *testing.T) {
+	tests := []struct {
+		name                   string
+		podName                string
+		workerIndex            string
+		annotation             string
+		serviceAccountName     string
+		wantServiceAccountName string
+	}{
+		{
+			name:                   "leader pod",
+			podName:                "test-sample-1",
+			workerIndex:            "0",
+			annotation:             "coordinator",
+			serviceAccountName:     "default",
+			wantServiceAccountName: "coordinator",
+		},
+		{
+			name:                   "worker pod",
+			podName:                "test-sample-1-1",
+			annotation:             "worker",
+			serviceAccountName:     "default",
+			wantServiceAccountName: "worker",
+		},
+		{
+			name:                   "worker pod without the annotation",
+			podName:                "test-sample-1-1",
+			serviceAccountName:     "default",
+			wantServiceAccountName: "default",
+		},
+		{
+			name:                   "leader pod without the annotation",
+			podName:                "test-sample-1",
+			workerIndex:            "0",
+			serviceAccountName:     "custom",
+			wantServiceAccountName: "custom",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      tc.podName,
+					Namespace: "default",
+					Labels: map[string]string{
+						leaderworkerset.SetNameLabelKey:    "test-sample",
+						leaderworkerset.GroupIndexLabelKey: "1",
+					},
+					Annotations: map[string]string{
+						leaderworkerset.SizeAnnotationKey:          "2",
+						leaderworkerset.LeaderPodNameAnnotationKey: "test-sample-1",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Subdomain:  "test-sample",
+					Containers: []corev1.Container{{Name: "main"}},
+					// As defaulted by the ServiceAccount admission plugin before the webhook.
+					ServiceAccountName:       tc.serviceAccountName,
+					DeprecatedServiceAccount: tc.serviceAccountName,
+				},
+			}
+			if tc.workerIndex != "" {
+				pod.Labels[leaderworkerset.WorkerIndexLabelKey] = tc.workerIndex
+			}
+			if tc.annotation != "" {
+				pod.Annotations[leaderworkerset.ServiceAccountNameAnnotationKey] = tc.annotation
+			}
+			if err := (&PodWebhook{}).Default(context.TODO(), pod); err != nil {
+				t.Fatal(err)
+			}
+			if pod.Spec.ServiceAccountName != tc.wantServiceAccountName {
+				t.Errorf("unexpected serviceAccountName, want: %q, got: %q", tc.wantServiceAccountName, pod.Spec.ServiceAccountName)
+			}
+			if pod.Spec.DeprecatedServiceAccount != tc.wantServiceAccountName {
+				t.Errorf("unexpected serviceAccount, want: %q, got: %q", tc.wantServiceAccountName, pod.Spec.DeprecatedServiceAccount)
+			}
+		})
+	}
+}
+
+func TestDefaultGangSchedulingLabel(t *testing.T) {
+	tests := []struct {
+		name              string
+		labelKey          string
+		wantLabels        map[string]string
+		wantMinAvailables []string
+	}{
+		{
+			name:     "all the pods of the group share the label",
+			labelKey: "scheduling.x-k8s.io/pod-group",
+			wantLabels: map[string]string{
+				"test-sample-1":   "test-sample-1",
+				"test-sample-1-1": "test-sample-1",
+				"test-sample-1-2": "test-sample-1",
+			},
+			wantMinAvailables: []string{"3", "3", "3"},
+		},
+		{
+			name: "without the annotation",
+			wantLabels: map[string]string{
+				"test-sample-1":   "",
+				"test-sample-1-1": "",
+				"test-sample-1-2": "",
+			},
+			wantMinAvailables: []string{"", "", ""},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotMinAvailables []string
+			for _, podName := range []string{"test-sample-1", "test-sample-1-1", "test-sample-1-2"} {
+				pod := &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      podName,
+						Namespace: "default",
+						Labels: map[string]string{
+							leaderworkerset.SetNameLabelKey:    "test-sample",
+							leaderworkerset.GroupIndexLabelKey: "1",
+						},
+						Annotations: map[string]string{
+							leaderworkerset.SizeAnnotationKey:          "3",
+							leaderworkerset.LeaderPodNameAnnotationKey: "test-sample-1",
+						},
+					},
+					Spec: corev1.PodSpec{
+						Subdomain:  "test-sample",
+						Containers: []corev1.Container{{Name: "main"}},
+					},
+				}
+				if podName == "test-sample-1" {
+					pod.Labels[leaderworkerset.WorkerIndexLabelKey] = "0"
+				}
+				if tc.labelKey != "" {
+					pod.Annotations[leaderworkerset.GangSchedulingLabelKeyAnnotationKey] = tc.labelKey
+				}
+				if err := (&PodWebhook{}).Default(context.TODO(), pod); err != nil {
+					t.Fatal(err)
+				}
+				if tc.labelKey != "" && pod.Labels[tc.labelKey] != tc.wantLabels[podName] {
+					t.Errorf("unexpected %s label of pod %s, want: %q, got: %q", tc.labelKey, podName, tc.wantLabels[podName], pod.Labels[tc.labelKey])
+				}
+				gotMinAvailables = append(gotMinAvailables, pod.Annotations[podGroupMinAvailableAnnotationKey])
+			}
+			if diff := cmp.Diff(tc.wantMinAvailables, gotMinAvailables); diff != "" {
+				t.Errorf("unexpected min-available annotations (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestDefaultPodIdentityEnvVars(t *testing.T) {
+	tests := []struct {
+		name            string
+		podName         string
+		workerIndex     string
+		subdomain       string
+		wantWorkerIndex string
+		wantPodFQDN     string
+	}{
+		{
+			name:            "leader pod",
+			podName:         "test-sample-1",
+			workerIndex:     "0",
+			subdomain:       "test-sample",
+			wantWorkerIndex: "0",
+			wantPodFQDN:     "test-sample-1.test-sample.default",
+		},
+		{
+			name:            "first worker pod",
+			podName:         "test-sample-1-1",
+			subdomain:       "test-sample",
+			wantWorkerIndex: "1",
+			wantPodFQDN:     "test-sample-1-1.test-sample.default",
+		},
+		{
+			name:            "last worker pod",
+			podName:         "test-sample-1-3",
+			subdomain:       "test-sample",
+			wantWorkerIndex: "3",
+			wantPodFQDN:     "test-sample-1-3.test-sample.default",
+		},
+		{
+			name:            "worker pod with a subdomain per group",
+			podName:         "test-sample-1-2",
+			subdomain:       "test-sample-1",
+			wantWorkerIndex: "2",
+			wantPodFQDN:     "test-sample-1-2.test-sample-1.default",
+		},
+		{
+			name:            "worker pod without subdomain",
+			podName:         "test-sample-1-2",
+			wantWorkerIndex: "2",
+			wantPodFQDN:     "test-sample-1-2",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			makePod := func() *corev1.Pod {
+				pod := &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      tc.podName,
+						Namespace: "default",
+						Labels: map[string]string{
+							leaderworkerset.SetNameLabelKey:    "test-sample",
+							leaderworkerset.GroupIndexLabelKey: "1",
+						},
+						Annotations: map[string]string{
+							leaderworkerset.SizeAnnotationKey:          "4",
+							leaderworkerset.LeaderPodNameAnnotationKey: "test-sample-1",
+						},
+					},
+					Spec: corev1.PodSpec{
+						Subdomain:  tc.subdomain,
+						Containers: []corev1.Container{{Name: "main"}},
+					},
+				}
+				if tc.workerIndex != "" {
+					pod.Labels[leaderworkerset.WorkerIndexLabelKey] = tc.workerIndex
+				}
+				if err := (&PodWebhook{}).Default(context.TODO(), pod); err != nil {
+					t.Fatal(err)
+				}
+				return pod
+			}
+			env := func(pod *corev1.Pod) map[string]string {
+				env := map[string]string{}
+				for _, e := range pod.Spec.Containers[0].Env {
+					if e.Name == leaderworkerset.LwsWorkerIndex || e.Name == leaderworkerset.LwsPodFQDN {
+						env[e.Name] = e.Value
+					}
+				}
+				return env
+			}
+			want := map[string]string{
+				leaderworkerset.LwsWorkerIndex: tc.wantWorkerIndex,
+				leaderworkerset.LwsPodFQDN:     tc.wantPodFQDN,
+			}
+			if diff := cmp.Diff(want, env(makePod())); diff != "" {
+				t.Errorf("unexpected env vars (-want +got): %s", diff)
+			}
+			// The pod recreated on restart gets the same values.
+			if diff := cmp.Diff(want, env(makePod())); diff != "" {
+				t.Errorf("unexpected env vars of the recreated pod (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestDefaultLeaderRankEnvVars(t *testing.T) {
+	tests := []struct {
+		name         string
+		podName      string
+		workerIndex  string
+		container    string
+		wantIsLeader string
+		wantRank     string
+	}{
+		{
+			name:         "leader pod created from the leader template",
+			podName:      "test-sample-1",
+			workerIndex:  "0",
+			container:    "leader",
+			wantIsLeader: "true",
+			wantRank:     "0",
+		},
+		{
+			name:         "worker pod with a separate leader template",
+			podName:      "test-sample-1-2",
+			workerIndex:  "2",
+			container:    "worker",
+			wantIsLeader: "false",
+			wantRank:     "2",
+		},
+		{
+			name:         "leader pod created from the worker template",
+			podName:      "test-sample-1",
+			workerIndex:  "0",
+			container:    "worker",
+			wantIsLeader: "true",
+			wantRank:     "0",
+		},
+		{
+			name:         "worker pod without a leader template",
+			podName:      "test-sample-1-3",
+			workerIndex:  "3",
+			container:    "worker",
+			wantIsLeader: "false",
+			wantRank:     "3",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      tc.podName,
+					Namespace: "default",
+					Labels: map[string]string{
+						leaderworkerset.SetNameLabelKey:     "test-sample",
+						leaderworkerset.GroupIndexLabelKey:  "1",
+						leaderworkerset.WorkerIndexLabelKey: tc.workerIndex,
+					},
+					Annotations: map[string]string{
+						leaderworkerset.SizeAnnotationKey: "4",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Subdomain:      "test-sample",
+					InitContainers: []corev1.Container{{Name: "init"}},
+					Containers:     []corev1.Container{{Name: tc.container}},
+				},
+			}
+			if tc.workerIndex != "0" {
+				pod.Annotations[leaderworkerset.LeaderPodNameAnnotationKey] = "test-sample-1"
+			}
+			if err := (&PodWebhook{}).Default(context.TODO(), pod); err != nil {
+				t.Fatal(err)
+			}
+			want := map[string]string{
+				leaderworkerset.LwsIsLeader: tc.wantIsLeader,
+				leaderworkerset.LwsRank:     tc.wantRank,
+			}
+			for _, c := range slices.Concat(pod.Spec.InitContainers, pod.Spec.Containers) {
+				got := map[string]string{}
+				for _, e := range c.Env {
+					if e.Name == leaderworkerset.LwsIsLeader || e.Name == leaderworkerset.LwsRank {
+						got[e.Name] = e.Value
+					}
+				}
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("unexpected env vars of container %q (-want +got): %s", c.Name, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestDefaultMembershipConfigMap(t *testing.T) {
+	tests := []struct {
+		name              string
+		podName           string
+		labels            map[string]string
+		annotation        bool
+		wantConfigMapName string
+	}{
+		{
+			name:              "leader pod",
+			podName:           "test-sample-2",
+			labels:            map[string]string{leaderworkerset.WorkerIndexLabelKey: "0"},
+			annotation:        true,
+			wantConfigMapName: "test-sample-2-membership",
+		},
+		{
+			name:              "worker pod",
+			podName:           "test-sample-1-1",
+			labels:            map[string]string{leaderworkerset.GroupIndexLabelKey: "1"},
+			annotation:        true,
+			wantConfigMapName: "test-sample-1-membership",
+		},
+		{
+			name:              "without the annotation",
+			podName:           "test-sample-2",
+			labels:            map[string]string{leaderworkerset.WorkerIndexLabelKey: "0"},
+			wantConfigMapName: "test-sample-membership",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      tc.podName,
+					Namespace: "default",
+					Labels:    map[string]string{leaderworkerset.SetNameLabelKey: "test-sample"},
+					Annotations: map[string]string{
+						leaderworkerset.SizeAnnotationKey: "2",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "main"}},
+					Volumes: []corev1.Volume{{
+						Name: leaderworkerset.MembershipVolumeName,
+						VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "test-sample-membership"},
+						}},
+					}},
+				},
+			}
+			for k, v := range tc.labels {
+				pod.Labels[k] = v
+			}
+			if tc.podName != "test-sample-2" {
+				pod.Annotations[leaderworkerset.LeaderPodNameAnnotationKey] = "test-sample-1"
+			}
+			if tc.annotation {
+				pod.Annotations[leaderworkerset.MembershipConfigMapAnnotationKey] = "true"
+			}
+			if err := (&PodWebhook{}).Default(context.TODO(), pod); err != nil {
+				t.Fatal(err)
+			}
+			if got := pod.Spec.Volumes[0].ConfigMap.Name; got != tc.wantConfigMapName {
+				t.Errorf("unexpected configmap name, want: %q, got: %q", tc.wantConfigMapName, got)
+			}
+		})
+	}
+}
+
+func TestDefaultValueFromEnvCollisions(t *testing.T) {
+	fieldRef := &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}}
+	tests := []struct {
+		name            string
+		env             []corev1.EnvVar
+		networkEnvNames string
+		wantEvents      int
+	}{
+		{
+			name: "no collision",
+			env:  []corev1.EnvVar{{Name: "POD_NAME", ValueFrom: fieldRef}},
+		},
+		{
+			name: "literal value collision",
+			env:  []corev1.EnvVar{{Name: leaderworkerset.LwsWorkerIndex, Value: "7"}},
+		},
+		{
+			name:       "valueFrom collision",
+			env:        []corev1.EnvVar{{Name: leaderworkerset.LwsWorkerIndex, ValueFrom: fieldRef}},
+			wantEvents: 1,
+		},
+		{
+			name:       "valueFrom collisions in the same container",
+			env:        []corev1.EnvVar{{Name: leaderworkerset.LwsWorkerIndex, ValueFrom: fieldRef}, {Name: leaderworkerset.LwsPodFQDN, ValueFrom: fieldRef}},
+			wantEvents: 1,
+		},
+		{
+			name:            "valueFrom collision with an overridden name",
+			env:             []corev1.EnvVar{{Name: "RANK", ValueFrom: fieldRef}},
+			networkEnvNames: `{"LWS_WORKER_INDEX":"RANK"}`,
+			wantEvents:      1,
+		},
+		{
+			name:            "valueFrom under a renamed default name",
+			env:             []corev1.EnvVar{{Name: leaderworkerset.LwsWorkerIndex, ValueFrom: fieldRef}},
+			networkEnvNames: `{"LWS_WORKER_INDEX":"RANK"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-sample-1-1",
+					Namespace: "default",
+					Labels: map[string]string{
+						leaderworkerset.SetNameLabelKey:    "test-sample",
+						leaderworkerset.GroupIndexLabelKey: "1",
+					},
+					Annotations: map[string]string{
+						leaderworkerset.SizeAnnotationKey:          "2",
+						leaderworkerset.LeaderPodNameAnnotationKey: "test-sample-1",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "main", Env: tc.env}},
+				},
+			}
+			if tc.networkEnvNames != "" {
+				pod.Annotations[leaderworkerset.NetworkEnvNamesAnnotationKey] = tc.networkEnvNames
+			}
+			recorder := record.NewFakeRecorder(10)
+			if err := (&PodWebhook{Record: recorder}).Default(context.TODO(), pod); err != nil {
+				t.Fatal(err)
+			}
+			if gotEvents := len(recorder.Events); gotEvents != tc.wantEvents {
+				t.Errorf("unexpected number of events, want: %d, got: %d", tc.wantEvents, gotEvents)
+			}
+		})
+	}
+}
+
 func TestExclusiveAffinityApplied(t *testing.T) {
 	tests := []struct {
 		name                              string
@@ -100,7 +100,7 @@ var _ = ginkgo.Describe("leaderWorkerSet e2e tests", func() {
 	})
 
 	ginkgo.It("Can create/update a lws with size=1", func() {
-		lws = wrappers.BuildLeaderWorkerSet(ns.Name).Replica(4).MaxSurge(1).Size(1).RestartPolicy(v1.RecreateGroupOnPodRestart).Obj()
+		lws = wrappers.BuildLeaderWorkerSet(ns.Name).Replica(4).MaxSurge(1).Size(1).LeaderTemplateNil().RestartPolicy(v1.RecreateGroupOnPodRestart).Obj()
 		testing.MustCreateLws(ctx, k8sClient, lws)
 
 		testing.ExpectValidLeaderStatefulSet(ctx, k8sClient, lws, 4)
@@ -210,13 +210,12 @@ var _ = ginkgo.Describe("leaderWorkerSet e2e tests", func() {
 		}
 	})
 
-	ginkgo.It("When changing subdomainPolicy, adds correct env vars", func() {
+	ginkgo.It("With subdomainPolicy UniquePerReplica, adds correct env vars", func() {
 		leaderPodSpec := wrappers.MakeLeaderPodSpecWithTPUResource()
 		workerPodSpec := wrappers.MakeWorkerPodSpecWithTPUResource()
-		lws := wrappers.BuildLeaderWorkerSet(ns.Name).Replica(1).Size(2).LeaderTemplateSpec(leaderPodSpec).WorkerTemplateSpec(workerPodSpec).Obj()
+		lws := wrappers.BuildLeaderWorkerSet(ns.Name).Replica(1).Size(2).LeaderTemplateSpec(leaderPodSpec).WorkerTemplateSpec(workerPodSpec).
+			SubdomainPolicy(leaderworkerset.SubdomainUniquePerReplica).Obj()
 		testing.MustCreateLws(ctx, k8sClient, lws)
-		testing.ExpectValidPods(ctx, k8sClient, lws, &corev1.PodList{})
-		testing.UpdateSubdomainPolicy(ctx, k8sClient, lws, leaderworkerset.SubdomainUniquePerReplica)
 		lwsPods := &corev1.PodList{}
 		testing.ExpectValidPods(ctx, k8sClient, lws, lwsPods)
 
@@ -224,15 +223,6 @@ var _ = ginkgo.Describe("leaderWorkerSet e2e tests", func() {
 			gomega.Expect(testing.HasTPUEnvVarsPopulated(pod)).To(gomega.BeTrue())
 			gomega.Expect(testing.CheckTPUContainerHasCorrectEnvVars(pod, "test-sample-0.test-sample-0,test-sample-0-1.test-sample-0")).Should(gomega.Succeed())
 		}
-
-		testing.UpdateSubdomainPolicy(ctx, k8sClient, lws, leaderworkerset.SubdomainShared)
-		lwsPodsAfterUpgrade := &corev1.PodList{}
-		testing.ExpectValidPods(ctx, k8sClient, lws, lwsPodsAfterUpgrade)
-
-		for _, pod := range lwsPodsAfterUpgrade.Items {
-			gomega.Expect(testing.HasTPUEnvVarsPopulated(pod)).To(gomega.BeTrue())
-			gomega.Expect(testing.CheckTPUContainerHasCorrectEnvVars(pod, "test-sample-0.test-sample,test-sample-0-1.test-sample")).Should(gomega.Succeed())
-		}
 	})
 
 	ginkgo.It("headless services scale up during MaxSurge", func() {
@@ -17,6 +17,7 @@ package webhooks
 
 import (
 	"context"
+	"strings"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/onsi/ginkgo/v2"
@@ -76,12 +77,12 @@ var _ = ginkgo.Describe("leaderworkerset defaulting, creation and update", func(
 		}),
 		ginkgo.Entry("apply defaulting logic for size", &testDefaultingCase{
 			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
-				lwsWrapper := wrappers.BuildLeaderWorkerSet(ns.Name)
+				lwsWrapper := wrappers.BuildLeaderWorkerSet(ns.Name).LeaderTemplateNil()
 				lwsWrapper.Spec.LeaderWorkerTemplate.Size = nil
 				return lwsWrapper
 			},
 			getExpectedLWS: func(lws *leaderworkerset.LeaderWorkerSet) *wrappers.LeaderWorkerSetWrapper {
-				return wrappers.BuildLeaderWorkerSet(ns.Name).Size(1).RestartPolicy(leaderworkerset.RecreateGroupOnPodRestart)
+				return wrappers.BuildLeaderWorkerSet(ns.Name).Size(1).LeaderTemplateNil().RestartPolicy(leaderworkerset.RecreateGroupOnPodRestart)
 			},
 		}),
 		ginkgo.Entry("defaulting logic won't apply when shouldn't", &testDefaultingCase{
@@ -225,12 +226,97 @@ var _ = ginkgo.Describe("leaderworkerset defaulting, creation and update", func(
 			},
 			lwsCreationShouldFail: true,
 		}),
+		ginkgo.Entry("creation with invalid leaderPriorityClassName should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lwsWrapper := wrappers.BuildLeaderWorkerSet(ns.Name).Size(2)
+				lwsWrapper.Spec.LeaderWorkerTemplate.LeaderPriorityClassName = "Leader_Critical"
+				return lwsWrapper
+			},
+			lwsCreationShouldFail: true,
+		}),
+		ginkgo.Entry("creation with valid leaderPriorityClassName should succeed", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lwsWrapper := wrappers.BuildLeaderWorkerSet(ns.Name).Size(2)
+				lwsWrapper.Spec.LeaderWorkerTemplate.LeaderPriorityClassName = "leader-critical"
+				return lwsWrapper
+			},
+			lwsCreationShouldFail: false,
+		}),
+		ginkgo.Entry("creation with negative leaderTerminationGracePeriodSeconds should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lwsWrapper := wrappers.BuildLeaderWorkerSet(ns.Name).Size(2)
+				lwsWrapper.Spec.LeaderWorkerTemplate.LeaderTerminationGracePeriodSeconds = ptr.To[int64](-1)
+				return lwsWrapper
+			},
+			lwsCreationShouldFail: true,
+		}),
+		ginkgo.Entry("creation with leaderTerminationGracePeriodSeconds should succeed", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lwsWrapper := wrappers.BuildLeaderWorkerSet(ns.Name).Size(2)
+				lwsWrapper.Spec.LeaderWorkerTemplate.LeaderTerminationGracePeriodSeconds = ptr.To[int64](120)
+				return lwsWrapper
+			},
+			lwsCreationShouldFail: false,
+		}),
+		ginkgo.Entry("creation with invalid leaderServiceAccountName should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lwsWrapper := wrappers.BuildLeaderWorkerSet(ns.Name).Size(2)
+				lwsWrapper.Spec.LeaderWorkerTemplate.LeaderServiceAccountName = "Coordinator"
+				return lwsWrapper
+			},
+			lwsCreationShouldFail: true,
+		}),
+		ginkgo.Entry("creation with invalid workerServiceAccountName should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lwsWrapper := wrappers.BuildLeaderWorkerSet(ns.Name).Size(2)
+				lwsWrapper.Spec.LeaderWorkerTemplate.WorkerServiceAccountName = "worker_sa"
+				return lwsWrapper
+			},
+			lwsCreationShouldFail: true,
+		}),
+		ginkgo.Entry("creation with valid service account names should succeed", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lwsWrapper := wrappers.BuildLeaderWorkerSet(ns.Name).Size(2)
+				lwsWrapper.Spec.LeaderWorkerTemplate.LeaderServiceAccountName = "coordinator"
+				lwsWrapper.Spec.LeaderWorkerTemplate.WorkerServiceAccountName = "worker"
+				return lwsWrapper
+			},
+			lwsCreationShouldFail: false,
+		}),
+		ginkgo.Entry("creation with hostNetwork only on the leader should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lwsWrapper := wrappers.BuildLeaderWorkerSet(ns.Name).Size(2)
+				lwsWrapper.Spec.LeaderWorkerTemplate.LeaderTemplate.Spec.HostNetwork = true
+				return lwsWrapper
+			},
+			lwsCreationShouldFail: true,
+		}),
+		ginkgo.Entry("creation with no worker containers should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lwsWrapper := wrappers.BuildLeaderWorkerSet(ns.Name).Size(2)
+				lwsWrapper.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec.Containers = []corev1.Container{}
+				return lwsWrapper
+			},
+			lwsCreationShouldFail: true,
+		}),
 		ginkgo.Entry("creation with invalid size should fail", &testValidationCase{
 			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
 				return wrappers.BuildLeaderWorkerSet(ns.Name).Replica(2).Size(-1)
 			},
 			lwsCreationShouldFail: true,
 		}),
+		ginkgo.Entry("creation with size 1 and a leaderTemplate should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				return wrappers.BuildLeaderWorkerSet(ns.Name).Replica(2).Size(1)
+			},
+			lwsCreationShouldFail: true,
+		}),
+		ginkgo.Entry("creation with size 1 and no leaderTemplate should succeed", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				return wrappers.BuildLeaderWorkerSet(ns.Name).Replica(2).Size(1).LeaderTemplateNil()
+			},
+			lwsCreationShouldFail: false,
+		}),
 		ginkgo.Entry("creation with invalid replicas should fail", &testValidationCase{
 			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
 				return wrappers.BuildLeaderWorkerSet(ns.Name).Size(2).Replica(-1)
@@ -243,6 +329,198 @@ var _ = ginkgo.Describe("leaderworkerset defaulting, creation and update", func(
 			},
 			lwsCreationShouldFail: true,
 		}),
+		ginkgo.Entry("creation with startupPolicy WorkersFirst and leaderTemplate should succeed", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				return wrappers.BuildLeaderWorkerSet(ns.Name).StartupPolicy(leaderworkerset.WorkersFirstStartupPolicy)
+			},
+			lwsCreationShouldFail: false,
+		}),
+		ginkgo.Entry("creation with startupPolicy WorkersFirst but without leaderTemplate should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lws := wrappers.BuildLeaderWorkerSet(ns.Name).StartupPolicy(leaderworkerset.WorkersFirstStartupPolicy)
+				lws.Spec.LeaderWorkerTemplate.LeaderTemplate = nil
+				return lws
+			},
+			lwsCreationShouldFail: true,
+		}),
+		ginkgo.Entry("creation with startupPolicy WorkersFirst and exclusive placement should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				return wrappers.BuildLeaderWorkerSet(ns.Name).StartupPolicy(leaderworkerset.WorkersFirstStartupPolicy).ExclusivePlacement()
+			},
+			lwsCreationShouldFail: true,
+		}),
+		ginkgo.Entry("creation with startupPolicy WorkersFirst and exclusiveTopology should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lws := wrappers.BuildLeaderWorkerSet(ns.Name).StartupPolicy(leaderworkerset.WorkersFirstStartupPolicy)
+				lws.Spec.LeaderWorkerTemplate.ExclusiveTopology = &leaderworkerset.ExclusiveTopology{TopologyKey: "kubernetes.io/hostname"}
+				return lws
+			},
+			lwsCreationShouldFail: true,
+		}),
+		ginkgo.Entry("creation with exclusiveTopology should succeed", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lws := wrappers.BuildLeaderWorkerSet(ns.Name)
+				lws.Spec.LeaderWorkerTemplate.ExclusiveTopology = &leaderworkerset.ExclusiveTopology{TopologyKey: "topology.kubernetes.io/zone"}
+				return lws
+			},
+			lwsCreationShouldFail: false,
+		}),
+		ginkgo.Entry("creation with invalid exclusiveTopology topologyKey should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lws := wrappers.BuildLeaderWorkerSet(ns.Name)
+				lws.Spec.LeaderWorkerTemplate.ExclusiveTopology = &leaderworkerset.ExclusiveTopology{TopologyKey: "example.com/rack/name"}
+				return lws
+			},
+			lwsCreationShouldFail: true,
+		}),
+		ginkgo.Entry("creation with exclusiveTopology conflicting with the exclusive-topology annotation should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lws := wrappers.BuildLeaderWorkerSet(ns.Name).ExclusivePlacement()
+				lws.Spec.LeaderWorkerTemplate.ExclusiveTopology = &leaderworkerset.ExclusiveTopology{TopologyKey: "kubernetes.io/hostname"}
+				return lws
+			},
+			lwsCreationShouldFail: true,
+		}),
+		ginkgo.Entry("creation with exclusiveTopology and conflicting nodeSelectors should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lws := wrappers.BuildLeaderWorkerSet(ns.Name)
+				lws.Spec.LeaderWorkerTemplate.ExclusiveTopology = &leaderworkerset.ExclusiveTopology{TopologyKey: "topology.kubernetes.io/zone"}
+				lws.Spec.LeaderWorkerTemplate.LeaderTemplate.Spec.NodeSelector = map[string]string{"pool": "a"}
+				lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec.NodeSelector = map[string]string{"pool": "b"}
+				return lws
+			},
+			lwsCreationShouldFail: true,
+		}),
+		ginkgo.Entry("creation with conflicting nodeSelectors and without exclusive placement should succeed", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lws := wrappers.BuildLeaderWorkerSet(ns.Name)
+				lws.Spec.LeaderWorkerTemplate.LeaderTemplate.Spec.NodeSelector = map[string]string{"pool": "a"}
+				lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec.NodeSelector = map[string]string{"pool": "b"}
+				return lws
+			},
+			lwsCreationShouldFail: false,
+		}),
+		ginkgo.Entry("creation with hostnamePrefix should succeed", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lws := wrappers.BuildLeaderWorkerSet(ns.Name)
+				lws.Spec.NetworkConfig = &leaderworkerset.NetworkConfig{SubdomainPolicy: ptr.To(leaderworkerset.SubdomainShared), HostnamePrefix: "short"}
+				return lws
+			},
+			lwsCreationShouldFail: false,
+		}),
+		ginkgo.Entry("creation with hostnamePrefix exceeding the hostname length limit should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lws := wrappers.BuildLeaderWorkerSet(ns.Name).Replica(10)
+				lws.Spec.NetworkConfig = &leaderworkerset.NetworkConfig{SubdomainPolicy: ptr.To(leaderworkerset.SubdomainShared), HostnamePrefix: strings.Repeat("a", 62)}
+				return lws
+			},
+			lwsCreationShouldFail: true,
+		}),
+		ginkgo.Entry("update of hostnamePrefix should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lws := wrappers.BuildLeaderWorkerSet(ns.Name)
+				lws.Spec.NetworkConfig = &leaderworkerset.NetworkConfig{SubdomainPolicy: ptr.To(leaderworkerset.SubdomainShared), HostnamePrefix: "short"}
+				return lws
+			},
+			updateLeaderWorkerSet: func(lws *leaderworkerset.LeaderWorkerSet) {
+				lws.Spec.NetworkConfig.HostnamePrefix = "other"
+			},
+			updateShouldFail: true,
+		}),
+		ginkgo.Entry("creation with startIndex should succeed", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				return wrappers.BuildLeaderWorkerSet(ns.Name).StartIndex(1)
+			},
+			lwsCreationShouldFail: false,
+		}),
+		ginkgo.Entry("creation with negative startIndex should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				return wrappers.BuildLeaderWorkerSet(ns.Name).StartIndex(-1)
+			},
+			lwsCreationShouldFail: true,
+		}),
+		ginkgo.Entry("update of startIndex should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				return wrappers.BuildLeaderWorkerSet(ns.Name).StartIndex(1)
+			},
+			updateLeaderWorkerSet: func(lws *leaderworkerset.LeaderWorkerSet) {
+				lws.Spec.NetworkConfig.StartIndex = 0
+			},
+			updateShouldFail: true,
+		}),
+		ginkgo.Entry("creation with known placeholders in template annotations should succeed", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lws := wrappers.BuildLeaderWorkerSet(ns.Name)
+				lws.Spec.LeaderWorkerTemplate.LeaderTemplate.Annotations = map[string]string{"example.com/group": "{{.GroupIndex}}"}
+				lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Annotations = map[string]string{"example.com/worker": "{{.GroupIndex}}-{{.WorkerIndex}}/{{.Size}}"}
+				return lws
+			},
+			lwsCreationShouldFail: false,
+		}),
+		ginkgo.Entry("creation with unknown placeholders in leader template annotations should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lws := wrappers.BuildLeaderWorkerSet(ns.Name)
+				lws.Spec.LeaderWorkerTemplate.LeaderTemplate.Annotations = map[string]string{"example.com/group": "{{.GroupName}}"}
+				return lws
+			},
+			lwsCreationShouldFail: true,
+		}),
+		ginkgo.Entry("creation with unknown placeholders in worker template annotations should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lws := wrappers.BuildLeaderWorkerSet(ns.Name)
+				lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Annotations = map[string]string{"example.com/worker": "{{.PodIndex}}"}
+				return lws
+			},
+			lwsCreationShouldFail: true,
+		}),
+		ginkgo.Entry("creation with reserved env var in leader template should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lws := wrappers.BuildLeaderWorkerSet(ns.Name)
+				lws.Spec.LeaderWorkerTemplate.LeaderTemplate.Spec.Containers[0].Env = []corev1.EnvVar{{Name: leaderworkerset.LwsLeaderAddress, Value: "leader"}}
+				return lws
+			},
+			lwsCreationShouldFail: true,
+		}),
+		ginkgo.Entry("creation with reserved env var in worker template should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lws := wrappers.BuildLeaderWorkerSet(ns.Name)
+				lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec.Containers[0].Env = []corev1.EnvVar{{Name: leaderworkerset.LwsGroupSize, Value: "4"}}
+				return lws
+			},
+			lwsCreationShouldFail: true,
+		}),
+		ginkgo.Entry("creation with non-reserved env var should succeed", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lws := wrappers.BuildLeaderWorkerSet(ns.Name)
+				lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "LWS_CUSTOM", Value: "value"}}
+				return lws
+			},
+			lwsCreationShouldFail: false,
+		}),
+		ginkgo.Entry("creation with reserved label in leader template should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lws := wrappers.BuildLeaderWorkerSet(ns.Name)
+				lws.Spec.LeaderWorkerTemplate.LeaderTemplate.Labels = map[string]string{leaderworkerset.GroupIndexLabelKey: "0"}
+				return lws
+			},
+			lwsCreationShouldFail: true,
+		}),
+		ginkgo.Entry("creation with reserved label in worker template should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lws := wrappers.BuildLeaderWorkerSet(ns.Name)
+				lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Labels = map[string]string{leaderworkerset.SetNameLabelKey: "test"}
+				return lws
+			},
+			lwsCreationShouldFail: true,
+		}),
+		ginkgo.Entry("creation with non-reserved template labels should succeed", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lws := wrappers.BuildLeaderWorkerSet(ns.Name)
+				lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Labels = map[string]string{"app": "test"}
+				return lws
+			},
+			lwsCreationShouldFail: false,
+		}),
 		ginkgo.Entry("creation with invalid subGroupSize should fail", &testValidationCase{
 			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
 				return wrappers.BuildLeaderWorkerSet(ns.Name).Size(2).SubGroupSize(-1)
@@ -255,6 +533,45 @@ var _ = ginkgo.Describe("leaderworkerset defaulting, creation and update", func(
 			},
 			lwsCreationShouldFail: true,
 		}),
+		ginkgo.Entry("creation with size divisible by subGroupSize should succeed", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				return wrappers.BuildLeaderWorkerSet(ns.Name).Size(4).SubGroupSize(2)
+			},
+			lwsCreationShouldFail: false,
+		}),
+		ginkgo.Entry("creation with subGroupSize equal to size should succeed", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				return wrappers.BuildLeaderWorkerSet(ns.Name).Size(3).SubGroupSize(3)
+			},
+			lwsCreationShouldFail: false,
+		}),
+		ginkgo.Entry("creation with neither size nor size - 1 divisible by subGroupSize should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				return wrappers.BuildLeaderWorkerSet(ns.Name).Size(6).SubGroupSize(4)
+			},
+			lwsCreationShouldFail: true,
+		}),
+		ginkgo.Entry("creation with size - 1 divisible by subGroupSize should succeed", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				return wrappers.BuildLeaderWorkerSet(ns.Name).Size(5).SubGroupSize(4)
+			},
+			lwsCreationShouldFail: false,
+		}),
+		ginkgo.Entry("update of size to neither size nor size - 1 divisible by subGroupSize should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				return wrappers.BuildLeaderWorkerSet(ns.Name).Size(4).SubGroupSize(4)
+			},
+			updateLeaderWorkerSet: func(lws *leaderworkerset.LeaderWorkerSet) {
+				lws.Spec.LeaderWorkerTemplate.Size = ptr.To[int32](6)
+			},
+			updateShouldFail: true,
+		}),
+		ginkgo.Entry("creation with size - 1 divisible by subGroupSize and SubGroupPolicyTypeLeaderExcluded should succeed", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				return wrappers.BuildLeaderWorkerSet(ns.Name).Size(5).SubGroupSize(2).SubGroupType(leaderworkerset.SubGroupPolicyTypeLeaderExcluded)
+			},
+			lwsCreationShouldFail: false,
+		}),
 		ginkgo.Entry("creation where (subGroupSize-1) is not divisible by 1 and SubGroupPolicyTypeLeaderExcluded should fail", &testValidationCase{
 			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
 				return wrappers.BuildLeaderWorkerSet(ns.Name).Size(4).SubGroupSize(2).SubGroupType(leaderworkerset.SubGroupPolicyTypeLeaderExcluded)
@@ -263,16 +580,94 @@ var _ = ginkgo.Describe("leaderworkerset defaulting, creation and update", func(
 		}),
 		ginkgo.Entry("update with invalid replicas should fail (larger than maxInt32)", &testValidationCase{
 			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
-				return wrappers.BuildLeaderWorkerSet(ns.Name).Replica(100000).Size(1)
+				return wrappers.BuildLeaderWorkerSet(ns.Name).Replica(100000).Size(1).LeaderTemplateNil()
 			},
 			updateLeaderWorkerSet: func(lws *leaderworkerset.LeaderWorkerSet) {
 				lws.Spec.LeaderWorkerTemplate.Size = ptr.To[int32](100000000)
 			},
 			updateShouldFail: true,
 		}),
+		ginkgo.Entry("creation with replicas equal to maxReplicas should succeed", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				return wrappers.BuildLeaderWorkerSet(ns.Name).Replica(3).MaxReplicas(3)
+			},
+			lwsCreationShouldFail: false,
+		}),
+		ginkgo.Entry("creation with replicas greater than maxReplicas should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				return wrappers.BuildLeaderWorkerSet(ns.Name).Replica(4).MaxReplicas(3)
+			},
+			lwsCreationShouldFail: true,
+		}),
+		ginkgo.Entry("creation with negative maxReplicas should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				return wrappers.BuildLeaderWorkerSet(ns.Name).Replica(0).MaxReplicas(-1)
+			},
+			lwsCreationShouldFail: true,
+		}),
+		ginkgo.Entry("creation with minReplicas lower than replicas should succeed", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				return wrappers.BuildLeaderWorkerSet(ns.Name).Replica(3).MinReplicas(2)
+			},
+			lwsCreationShouldFail: false,
+		}),
+		ginkgo.Entry("creation with minReplicas greater than replicas should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				return wrappers.BuildLeaderWorkerSet(ns.Name).Replica(2).MinReplicas(3)
+			},
+			lwsCreationShouldFail: true,
+		}),
+		ginkgo.Entry("creation with negative minReplicas should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				return wrappers.BuildLeaderWorkerSet(ns.Name).MinReplicas(-1)
+			},
+			lwsCreationShouldFail: true,
+		}),
+		ginkgo.Entry("creation with activeDeadlineSeconds should succeed", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				return wrappers.BuildLeaderWorkerSet(ns.Name).ActiveDeadlineSeconds(3600)
+			},
+			lwsCreationShouldFail: false,
+		}),
+		ginkgo.Entry("creation with zero activeDeadlineSeconds should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				return wrappers.BuildLeaderWorkerSet(ns.Name).ActiveDeadlineSeconds(0)
+			},
+			lwsCreationShouldFail: true,
+		}),
+		ginkgo.Entry("creation with minReadySeconds should succeed", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				return wrappers.BuildLeaderWorkerSet(ns.Name).MinReadySeconds(30)
+			},
+			lwsCreationShouldFail: false,
+		}),
+		ginkgo.Entry("creation with negative minReadySeconds should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				return wrappers.BuildLeaderWorkerSet(ns.Name).MinReadySeconds(-1)
+			},
+			lwsCreationShouldFail: true,
+		}),
+		ginkgo.Entry("update with replicas greater than maxReplicas should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				return wrappers.BuildLeaderWorkerSet(ns.Name).Replica(2).MaxReplicas(3)
+			},
+			updateLeaderWorkerSet: func(lws *leaderworkerset.LeaderWorkerSet) {
+				lws.Spec.Replicas = ptr.To[int32](4)
+			},
+			updateShouldFail: true,
+		}),
+		ginkgo.Entry("update with maxReplicas lower than replicas should fail", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				return wrappers.BuildLeaderWorkerSet(ns.Name).Replica(2).MaxReplicas(3)
+			},
+			updateLeaderWorkerSet: func(lws *leaderworkerset.LeaderWorkerSet) {
+				lws.Spec.MaxReplicas = ptr.To[int32](1)
+			},
+			updateShouldFail: true,
+		}),
 		ginkgo.Entry("update with invalid replicas should fail (number is negative)", &testValidationCase{
 			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
-				return wrappers.BuildLeaderWorkerSet(ns.Name).Replica(1).Size(1)
+				return wrappers.BuildLeaderWorkerSet(ns.Name).Replica(1).Size(1).LeaderTemplateNil()
 			},
 			updateLeaderWorkerSet: func(lws *leaderworkerset.LeaderWorkerSet) {
 				lws.Spec.Replicas = ptr.To[int32](-1)
@@ -288,14 +683,24 @@ var _ = ginkgo.Describe("leaderworkerset defaulting, creation and update", func(
 			},
 			updateShouldFail: true,
 		}),
-		ginkgo.Entry("number of size can not be updated", &testValidationCase{
+		ginkgo.Entry("number of size can be updated when no rolling update is in progress", &testValidationCase{
 			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
-				return wrappers.BuildLeaderWorkerSet(ns.Name).Replica(1).Size(1)
+				return wrappers.BuildLeaderWorkerSet(ns.Name).Replica(1).Size(1).LeaderTemplateNil()
 			},
 			updateLeaderWorkerSet: func(lws *leaderworkerset.LeaderWorkerSet) {
 				lws.Spec.LeaderWorkerTemplate.Size = ptr.To[int32](2)
 			},
-			updateShouldFail: true,
+			updateShouldFail: false,
+		}),
+		ginkgo.Entry("update with dry-run-plan annotation should succeed", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				return wrappers.BuildLeaderWorkerSet(ns.Name).Replica(2).Size(1).LeaderTemplateNil()
+			},
+			updateLeaderWorkerSet: func(lws *leaderworkerset.LeaderWorkerSet) {
+				lws.Annotations = map[string]string{leaderworkerset.DryRunPlanAnnotationKey: ""}
+				lws.Spec.Replicas = ptr.To[int32](3)
+			},
+			updateShouldFail: false,
 		}),
 		ginkgo.Entry("number of subGroupSize can not be updated", &testValidationCase{
 			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
@@ -327,7 +732,7 @@ var _ = ginkgo.Describe("leaderworkerset defaulting, creation and update", func(
 		}),
 		ginkgo.Entry("number of replicas can be updated", &testValidationCase{
 			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
-				return wrappers.BuildLeaderWorkerSet(ns.Name).Replica(1).Size(1)
+				return wrappers.BuildLeaderWorkerSet(ns.Name).Replica(1).Size(1).LeaderTemplateNil()
 			},
 			updateLeaderWorkerSet: func(lws *leaderworkerset.LeaderWorkerSet) {
 				lws.Spec.Replicas = ptr.To[int32](3)
@@ -344,25 +749,45 @@ var _ = ginkgo.Describe("leaderworkerset defaulting, creation and update", func(
 			},
 			updateShouldFail: false,
 		}),
-		ginkgo.Entry("subdomainPolicy can be updated from UniquePerReplica to Shared", &testValidationCase{
+		ginkgo.Entry("leaderTemplate cannot be removed", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				return wrappers.BuildLeaderWorkerSet(ns.Name)
+			},
+			updateLeaderWorkerSet: func(lws *leaderworkerset.LeaderWorkerSet) {
+				lws.Spec.LeaderWorkerTemplate.LeaderTemplate = nil
+			},
+			updateShouldFail: true,
+		}),
+		ginkgo.Entry("leaderTemplate cannot be added", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lws := wrappers.BuildLeaderWorkerSet(ns.Name)
+				lws.Spec.LeaderWorkerTemplate.LeaderTemplate = nil
+				return lws
+			},
+			updateLeaderWorkerSet: func(lws *leaderworkerset.LeaderWorkerSet) {
+				lws.Spec.LeaderWorkerTemplate.LeaderTemplate = &corev1.PodTemplateSpec{Spec: wrappers.MakeLeaderPodSpec()}
+			},
+			updateShouldFail: true,
+		}),
+		ginkgo.Entry("subdomainPolicy cannot be updated from UniquePerReplica to Shared", &testValidationCase{
 			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
 				return wrappers.BuildLeaderWorkerSet(ns.Name).SubdomainPolicy(leaderworkerset.SubdomainUniquePerReplica)
 			},
 			updateLeaderWorkerSet: func(lws *leaderworkerset.LeaderWorkerSet) {
 				*lws.Spec.NetworkConfig.SubdomainPolicy = leaderworkerset.SubdomainShared
 			},
-			updateShouldFail: false,
+			updateShouldFail: true,
 		}),
-		ginkgo.Entry("subdomainPolicy can be updated from Shared to UniquePerReplica", &testValidationCase{
+		ginkgo.Entry("subdomainPolicy cannot be updated from Shared to UniquePerReplica", &testValidationCase{
 			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
 				return wrappers.BuildLeaderWorkerSet(ns.Name).SubdomainPolicy(leaderworkerset.SubdomainShared)
 			},
 			updateLeaderWorkerSet: func(lws *leaderworkerset.LeaderWorkerSet) {
 				*lws.Spec.NetworkConfig.SubdomainPolicy = leaderworkerset.SubdomainUniquePerReplica
 			},
-			updateShouldFail: false,
+			updateShouldFail: true,
 		}),
-		ginkgo.Entry("subdomainPolicy can be updated from nil to UniquePerReplica", &testValidationCase{
+		ginkgo.Entry("subdomainPolicy cannot be updated from nil to UniquePerReplica", &testValidationCase{
 			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
 				lwsWrapper := wrappers.BuildLeaderWorkerSet(ns.Name)
 				lwsWrapper.Spec.NetworkConfig = nil
@@ -371,7 +796,7 @@ var _ = ginkgo.Describe("leaderworkerset defaulting, creation and update", func(
 			updateLeaderWorkerSet: func(lws *leaderworkerset.LeaderWorkerSet) {
 				*lws.Spec.NetworkConfig.SubdomainPolicy = leaderworkerset.SubdomainUniquePerReplica
 			},
-			updateShouldFail: false,
+			updateShouldFail: true,
 		}),
 		ginkgo.Entry("subdomainPolicy can be updated to nil", &testValidationCase{
 			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
@@ -404,6 +829,20 @@ var _ = ginkgo.Describe("leaderworkerset defaulting, creation and update", func(
 			},
 			lwsCreationShouldFail: true,
 		}),
+		ginkgo.Entry("set rolloutStrategyType to Recreate should succeed", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				return wrappers.BuildLeaderWorkerSet(ns.Name).RolloutStrategy(leaderworkerset.RolloutStrategy{Type: leaderworkerset.RecreateStrategyType})
+			},
+			lwsCreationShouldFail: false,
+		}),
+		ginkgo.Entry("set rolloutStrategyType to Recreate with rollingUpdateConfiguration should be failed", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lws := wrappers.BuildLeaderWorkerSet(ns.Name)
+				lws.Spec.RolloutStrategy.Type = leaderworkerset.RecreateStrategyType
+				return lws
+			},
+			lwsCreationShouldFail: true,
+		}),
 		ginkgo.Entry("set maxUnavailable greater than replicas is allowed", &testValidationCase{
 			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
 				lws := wrappers.BuildLeaderWorkerSet(ns.Name)
@@ -436,6 +875,22 @@ var _ = ginkgo.Describe("leaderworkerset defaulting, creation and update", func(
 			},
 			lwsCreationShouldFail: false,
 		}),
+		ginkgo.Entry("set partition equal to replicas is allowed", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lws := wrappers.BuildLeaderWorkerSet(ns.Name).Replica(2)
+				lws.Spec.RolloutStrategy.RollingUpdateConfiguration.Partition = ptr.To[int32](2)
+				return lws
+			},
+			lwsCreationShouldFail: false,
+		}),
+		ginkgo.Entry("set partition greater than replicas should be failed", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lws := wrappers.BuildLeaderWorkerSet(ns.Name).Replica(2)
+				lws.Spec.RolloutStrategy.RollingUpdateConfiguration.Partition = ptr.To[int32](3)
+				return lws
+			},
+			lwsCreationShouldFail: true,
+		}),
 		ginkgo.Entry("set maxSurge greater than 100% should be failed", &testValidationCase{
 			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
 				lws := wrappers.BuildLeaderWorkerSet(ns.Name)
@@ -460,6 +915,43 @@ var _ = ginkgo.Describe("leaderworkerset defaulting, creation and update", func(
 			},
 			lwsCreationShouldFail: true,
 		}),
+		ginkgo.Entry("set maxUnavailable and maxSurge both to 0% should be failed", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lws := wrappers.BuildLeaderWorkerSet(ns.Name)
+				lws.Spec.RolloutStrategy.RollingUpdateConfiguration.MaxUnavailable = intstr.FromString("0%")
+				lws.Spec.RolloutStrategy.RollingUpdateConfiguration.MaxSurge = intstr.FromString("0%")
+				return lws
+			},
+			lwsCreationShouldFail: true,
+		}),
+		ginkgo.Entry("set maxUnavailable to 0 and maxSurge to 0% should be failed", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lws := wrappers.BuildLeaderWorkerSet(ns.Name)
+				lws.Spec.RolloutStrategy.RollingUpdateConfiguration.MaxUnavailable = intstr.FromInt32(0)
+				lws.Spec.RolloutStrategy.RollingUpdateConfiguration.MaxSurge = intstr.FromString("0%")
+				return lws
+			},
+			lwsCreationShouldFail: true,
+		}),
+		ginkgo.Entry("set maxUnavailable to 0% and maxSurge to 0 should be failed", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				lws := wrappers.BuildLeaderWorkerSet(ns.Name)
+				lws.Spec.RolloutStrategy.RollingUpdateConfiguration.MaxUnavailable = intstr.FromString("0%")
+				lws.Spec.RolloutStrategy.RollingUpdateConfiguration.MaxSurge = intstr.FromInt32(0)
+				return lws
+			},
+			lwsCreationShouldFail: true,
+		}),
+		ginkgo.Entry("set maxUnavailable and maxSurge both to 0 on update should be failed", &testValidationCase{
+			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
+				return wrappers.BuildLeaderWorkerSet(ns.Name)
+			},
+			updateLeaderWorkerSet: func(lws *leaderworkerset.LeaderWorkerSet) {
+				lws.Spec.RolloutStrategy.RollingUpdateConfiguration.MaxUnavailable = intstr.FromInt32(0)
+				lws.Spec.RolloutStrategy.RollingUpdateConfiguration.MaxSurge = intstr.FromString("0%")
+			},
+			updateShouldFail: true,
+		}),
 		ginkgo.Entry("set replica to 0 no matter maxUnavailable or maxSurge is should be allowed", &testValidationCase{
 			makeLeaderWorkerSet: func(ns *corev1.Namespace) *wrappers.LeaderWorkerSetWrapper {
 				lws := wrappers.BuildLeaderWorkerSet(ns.Name)
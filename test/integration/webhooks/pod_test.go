@@ -805,6 +805,80 @@ var _ = ginkgo.Describe("leaderworkerset pod defaulting, creation and update", f
 				return nil
 			},
 		}),
+		ginkgo.Entry("Leader ready readiness gate is injected into worker pods when worker readiness follows leader", &testDefaultingCase{
+			makePod: func(ns *corev1.Namespace) corev1.Pod {
+				return corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-sample-1-1",
+						Namespace: ns.Name,
+						Labels: map[string]string{
+							leaderworkerset.SetNameLabelKey:    "test-sample",
+							leaderworkerset.GroupIndexLabelKey: "1",
+						},
+						Annotations: map[string]string{
+							leaderworkerset.SizeAnnotationKey:                         "2",
+							leaderworkerset.WorkerReadinessFollowsLeaderAnnotationKey: "true",
+						},
+					},
+					Spec: wrappers.MakeWorkerPodSpec(),
+				}
+			},
+			checkExpectedPod: func(expected corev1.Pod, got corev1.Pod) error {
+				if diff := cmp.Diff([]corev1.PodReadinessGate{{ConditionType: leaderworkerset.LeaderReadyPodConditionType}}, got.Spec.ReadinessGates); diff != "" {
+					return errors.New("pod readiness gates mismatch: " + diff)
+				}
+				return nil
+			},
+		}),
+		ginkgo.Entry("Leader ready readiness gate is not injected into worker pods by default", &testDefaultingCase{
+			makePod: func(ns *corev1.Namespace) corev1.Pod {
+				return corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-sample-1-1",
+						Namespace: ns.Name,
+						Labels: map[string]string{
+							leaderworkerset.SetNameLabelKey:    "test-sample",
+							leaderworkerset.GroupIndexLabelKey: "1",
+						},
+						Annotations: map[string]string{
+							leaderworkerset.SizeAnnotationKey: "2",
+						},
+					},
+					Spec: wrappers.MakeWorkerPodSpec(),
+				}
+			},
+			checkExpectedPod: func(expected corev1.Pod, got corev1.Pod) error {
+				if len(got.Spec.ReadinessGates) != 0 {
+					return fmt.Errorf("unexpected pod readiness gates: %v", got.Spec.ReadinessGates)
+				}
+				return nil
+			},
+		}),
+		ginkgo.Entry("Annotation placeholders are expanded for worker pods", &testDefaultingCase{
+			makePod: func(ns *corev1.Namespace) corev1.Pod {
+				return corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-sample-1-2",
+						Namespace: ns.Name,
+						Labels: map[string]string{
+							leaderworkerset.SetNameLabelKey:    "test-sample",
+							leaderworkerset.GroupIndexLabelKey: "1",
+						},
+						Annotations: map[string]string{
+							leaderworkerset.SizeAnnotationKey: "3",
+							"example.com/registration":        "group-{{.GroupIndex}}-worker-{{.WorkerIndex}}-of-{{.Size}}",
+						},
+					},
+					Spec: wrappers.MakeWorkerPodSpec(),
+				}
+			},
+			checkExpectedPod: func(expected corev1.Pod, got corev1.Pod) error {
+				if diff := cmp.Diff("group-1-worker-2-of-3", got.Annotations["example.com/registration"]); diff != "" {
+					return errors.New("pod annotation mismatch: " + diff)
+				}
+				return nil
+			},
+		}),
 	)
 
 	type testValidationCase struct {
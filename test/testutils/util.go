@@ -184,6 +184,8 @@ func createLeaderPods(ctx context.Context, k8sClient client.Client, leaderSts ap
 			},
 			Spec: podTemplateSpec.Spec,
 		}
+		// Scheduling gates are only injected into the leader statefulset template.
+		pod.Spec.SchedulingGates = leaderSts.Spec.Template.Spec.SchedulingGates
 		if lws.Annotations[leaderworkerset.ExclusiveKeyAnnotationKey] != "" {
 			pod.Annotations[leaderworkerset.ExclusiveKeyAnnotationKey] = lws.Annotations[leaderworkerset.ExclusiveKeyAnnotationKey]
 		}
@@ -335,6 +337,20 @@ func SetPodGroupToReady(ctx context.Context, k8sClient client.Client, statefulse
 	}, Timeout, Interval).Should(gomega.Succeed())
 }
 
+// SetWorkerStatefulSetReadyReplicas sets the number of ready worker pods of the given worker statefulset.
+func SetWorkerStatefulSetReadyReplicas(ctx context.Context, k8sClient client.Client, statefulsetName string, lws *leaderworkerset.LeaderWorkerSet, readyReplicas int32) {
+	gomega.Eventually(func() error {
+		var sts appsv1.StatefulSet
+		if err := k8sClient.Get(ctx, types.NamespacedName{Name: statefulsetName, Namespace: lws.Namespace}, &sts); err != nil {
+			return err
+		}
+
+		sts.Status.ReadyReplicas = readyReplicas
+		sts.Status.Replicas = *sts.Spec.Replicas
+		return k8sClient.Status().Update(ctx, &sts)
+	}, Timeout, Interval).Should(gomega.Succeed())
+}
+
 // SetStatefulsetToUnReady set statefulset to unready.
 func SetStatefulsetToUnReady(ctx context.Context, k8sClient client.Client, sts *appsv1.StatefulSet) {
 	sts.Status.CurrentRevision = "fuz"
@@ -389,7 +405,7 @@ func hasAllEnvVarPopulated(pod corev1.Pod, envVars []string) bool {
 }
 
 func HasLWSEnvVarsPopulated(pod corev1.Pod) bool {
-	return hasAllEnvVarPopulated(pod, []string{leaderworkerset.LwsLeaderAddress, leaderworkerset.LwsGroupSize, leaderworkerset.LwsWorkerIndex})
+	return hasAllEnvVarPopulated(pod, []string{leaderworkerset.LwsLeaderAddress, leaderworkerset.LwsGroupSize, leaderworkerset.LwsWorkerIndex, leaderworkerset.LwsPodFQDN, leaderworkerset.LwsIsLeader, leaderworkerset.LwsRank})
 }
 
 func CheckContainerHasCorrectEnvVar(pod corev1.Pod, expect corev1.EnvVar) error {
@@ -501,20 +517,6 @@ func UpdateReplicaCount(ctx context.Context, k8sClient client.Client, lws *leade
 	}, Timeout, Interval).Should(gomega.Succeed())
 }
 
-func UpdateSubdomainPolicy(ctx context.Context, k8sClient client.Client, lws *leaderworkerset.LeaderWorkerSet, subdomainPolicy leaderworkerset.SubdomainPolicy) {
-	gomega.Eventually(func() error {
-		var newLws leaderworkerset.LeaderWorkerSet
-		if err := k8sClient.Get(ctx, types.NamespacedName{Name: lws.Name, Namespace: lws.Namespace}, &newLws); err != nil {
-			return err
-		}
-
-		newLws.Spec.NetworkConfig = &leaderworkerset.NetworkConfig{
-			SubdomainPolicy: &subdomainPolicy,
-		}
-		return k8sClient.Update(ctx, &newLws)
-	}, Timeout, Interval).Should(gomega.Succeed())
-}
-
 func UpdateLeaderTemplate(ctx context.Context, k8sClient client.Client, leaderWorkerSet *leaderworkerset.LeaderWorkerSet) {
 	gomega.Eventually(func() error {
 		var lws leaderworkerset.LeaderWorkerSet
@@ -394,6 +394,42 @@ func ExpectLeaderWorkerSetNoUpgradeInProgress(ctx context.Context, k8sClient cli
 	gomega.Eventually(CheckLeaderWorkerSetHasCondition, Timeout, Interval).WithArguments(ctx, k8sClient, lws, condition).Should(gomega.Equal(true))
 }
 
+func ExpectLeaderWorkerSetPaused(ctx context.Context, k8sClient client.Client, lws *leaderworkerset.LeaderWorkerSet) {
+	ginkgo.By(fmt.Sprintf("checking leaderworkerset status(%s) is true", leaderworkerset.LeaderWorkerSetPaused))
+	condition := metav1.Condition{
+		Type:   string(leaderworkerset.LeaderWorkerSetPaused),
+		Status: metav1.ConditionTrue,
+	}
+	gomega.Eventually(CheckLeaderWorkerSetHasCondition, Timeout, Interval).WithArguments(ctx, k8sClient, lws, condition).Should(gomega.Equal(true))
+}
+
+func ExpectLeaderWorkerSetNotPaused(ctx context.Context, k8sClient client.Client, lws *leaderworkerset.LeaderWorkerSet) {
+	ginkgo.By(fmt.Sprintf("checking leaderworkerset status(%s) is false", leaderworkerset.LeaderWorkerSetPaused))
+	condition := metav1.Condition{
+		Type:   string(leaderworkerset.LeaderWorkerSetPaused),
+		Status: metav1.ConditionFalse,
+	}
+	gomega.Eventually(CheckLeaderWorkerSetHasCondition, Timeout, Interval).WithArguments(ctx, k8sClient, lws, condition).Should(gomega.Equal(true))
+}
+
+func ExpectLeaderWorkerSetSuspended(ctx context.Context, k8sClient client.Client, lws *leaderworkerset.LeaderWorkerSet) {
+	ginkgo.By(fmt.Sprintf("checking leaderworkerset status(%s) is true", leaderworkerset.LeaderWorkerSetSuspended))
+	condition := metav1.Condition{
+		Type:   string(leaderworkerset.LeaderWorkerSetSuspended),
+		Status: metav1.ConditionTrue,
+	}
+	gomega.Eventually(CheckLeaderWorkerSetHasCondition, Timeout, Interval).WithArguments(ctx, k8sClient, lws, condition).Should(gomega.Equal(true))
+}
+
+func ExpectLeaderWorkerSetNotSuspended(ctx context.Context, k8sClient client.Client, lws *leaderworkerset.LeaderWorkerSet) {
+	ginkgo.By(fmt.Sprintf("checking leaderworkerset status(%s) is false", leaderworkerset.LeaderWorkerSetSuspended))
+	condition := metav1.Condition{
+		Type:   string(leaderworkerset.LeaderWorkerSetSuspended),
+		Status: metav1.ConditionFalse,
+	}
+	gomega.Eventually(CheckLeaderWorkerSetHasCondition, Timeout, Interval).WithArguments(ctx, k8sClient, lws, condition).Should(gomega.Equal(true))
+}
+
 func ExpectLeaderWorkerSetStatusReplicas(ctx context.Context, k8sClient client.Client, lws *leaderworkerset.LeaderWorkerSet, readyReplicas, updatedReplicas int) {
 	ginkgo.By("checking leaderworkerset status replicas")
 	gomega.Eventually(func() error {
@@ -410,6 +446,51 @@ func ExpectLeaderWorkerSetStatusReplicas(ctx context.Context, k8sClient client.C
 	}, Timeout, Interval).Should(gomega.Succeed())
 }
 
+func ExpectLeaderWorkerSetProgressingReplicas(ctx context.Context, k8sClient client.Client, lws *leaderworkerset.LeaderWorkerSet, progressingReplicas int) {
+	ginkgo.By("checking leaderworkerset status progressingReplicas")
+	gomega.Eventually(func() error {
+		if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: lws.Namespace, Name: lws.Name}, lws); err != nil {
+			return err
+		}
+		if lws.Status.ProgressingReplicas != int32(progressingReplicas) {
+			return fmt.Errorf("progressingReplicas in status not match, want: %d, got %d", progressingReplicas, lws.Status.ProgressingReplicas)
+		}
+		return nil
+	}, Timeout, Interval).Should(gomega.Succeed())
+}
+
+func ExpectLeaderWorkerSetGroupPhases(ctx context.Context, k8sClient client.Client, lws *leaderworkerset.LeaderWorkerSet, phases []leaderworkerset.GroupPhase) {
+	ginkgo.By("checking leaderworkerset status groupStatuses")
+	gomega.Eventually(func() error {
+		if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: lws.Namespace, Name: lws.Name}, lws); err != nil {
+			return err
+		}
+		if len(lws.Status.GroupStatuses) != len(phases) {
+			return fmt.Errorf("groupStatuses in status not match, want %d groups, got %d", len(phases), len(lws.Status.GroupStatuses))
+		}
+		for i, groupStatus := range lws.Status.GroupStatuses {
+			if groupStatus.Index != int32(i) || groupStatus.Phase != phases[i] {
+				return fmt.Errorf("group %d in status not match, want phase %s, got group %d with phase %s", i, phases[i], groupStatus.Index, groupStatus.Phase)
+			}
+		}
+		return nil
+	}, Timeout, Interval).Should(gomega.Succeed())
+}
+
+func ExpectLeaderPodDrainDeadlineSet(ctx context.Context, k8sClient client.Client, lws *leaderworkerset.LeaderWorkerSet, podName string) {
+	ginkgo.By(fmt.Sprintf("checking leader pod %s has a drain deadline", podName))
+	gomega.Eventually(func() error {
+		var pod corev1.Pod
+		if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: lws.Namespace, Name: podName}, &pod); err != nil {
+			return err
+		}
+		if _, err := time.Parse(time.RFC3339, pod.Annotations[leaderworkerset.DrainDeadlineAnnotationKey]); err != nil {
+			return fmt.Errorf("invalid drain deadline annotation for pod %s: %v", podName, err)
+		}
+		return nil
+	}, Timeout, Interval).Should(gomega.Succeed())
+}
+
 func ExpectLeaderWorkerSetAvailable(ctx context.Context, k8sClient client.Client, lws *leaderworkerset.LeaderWorkerSet, message string) {
 	ginkgo.By(fmt.Sprintf("checking leaderworkerset status(%s) is true", leaderworkerset.LeaderWorkerSetAvailable))
 	condition := metav1.Condition{
@@ -501,6 +582,21 @@ func ExpectSpecifiedWorkerStatefulSetsNotCreated(ctx context.Context, k8sClient
 	}, Timeout, Interval).Should(gomega.Equal(true))
 }
 
+func ExpectLeaderPodSchedulingGated(ctx context.Context, k8sClient client.Client, lws *leaderworkerset.LeaderWorkerSet, podName string, gated bool) {
+	gomega.Eventually(func() (bool, error) {
+		var pod corev1.Pod
+		if err := k8sClient.Get(ctx, types.NamespacedName{Name: podName, Namespace: lws.Namespace}, &pod); err != nil {
+			return false, err
+		}
+		for _, gate := range pod.Spec.SchedulingGates {
+			if gate.Name == leaderworkerset.WorkersReadySchedulingGate {
+				return true, nil
+			}
+		}
+		return false, nil
+	}, Timeout, Interval).Should(gomega.Equal(gated))
+}
+
 func ExpectRevisions(ctx context.Context, k8sClient client.Client, leaderWorkerSet *leaderworkerset.LeaderWorkerSet, numRevisions int) {
 	gomega.Eventually(func() error {
 		selector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{MatchLabels: map[string]string{
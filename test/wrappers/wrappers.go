@@ -44,6 +44,16 @@ func (lwsWrapper *LeaderWorkerSetWrapper) Replica(count int) *LeaderWorkerSetWra
 	return lwsWrapper
 }
 
+func (lwsWrapper *LeaderWorkerSetWrapper) MaxReplicas(count int) *LeaderWorkerSetWrapper {
+	lwsWrapper.Spec.MaxReplicas = ptr.To[int32](int32(count))
+	return lwsWrapper
+}
+
+func (lwsWrapper *LeaderWorkerSetWrapper) MinReplicas(count int) *LeaderWorkerSetWrapper {
+	lwsWrapper.Spec.MinReplicas = ptr.To[int32](int32(count))
+	return lwsWrapper
+}
+
 func (lwsWrapper *LeaderWorkerSetWrapper) MaxUnavailable(value int) *LeaderWorkerSetWrapper {
 	lwsWrapper.Spec.RolloutStrategy.RollingUpdateConfiguration.MaxUnavailable = intstr.FromInt(value)
 	return lwsWrapper
@@ -54,11 +64,31 @@ func (lwsWrapper *LeaderWorkerSetWrapper) MaxSurge(value int) *LeaderWorkerSetWr
 	return lwsWrapper
 }
 
+func (lwsWrapper *LeaderWorkerSetWrapper) DrainGracePeriodSeconds(seconds int) *LeaderWorkerSetWrapper {
+	lwsWrapper.Spec.RolloutStrategy.RollingUpdateConfiguration.DrainGracePeriodSeconds = ptr.To[int32](int32(seconds))
+	return lwsWrapper
+}
+
 func (lwsWrapper *LeaderWorkerSetWrapper) Size(count int) *LeaderWorkerSetWrapper {
 	lwsWrapper.Spec.LeaderWorkerTemplate.Size = ptr.To[int32](int32(count))
 	return lwsWrapper
 }
 
+func (lwsWrapper *LeaderWorkerSetWrapper) MinReadySeconds(seconds int32) *LeaderWorkerSetWrapper {
+	lwsWrapper.Spec.LeaderWorkerTemplate.MinReadySeconds = seconds
+	return lwsWrapper
+}
+
+func (lwsWrapper *LeaderWorkerSetWrapper) OrderedTermination(orderedTermination bool) *LeaderWorkerSetWrapper {
+	lwsWrapper.Spec.LeaderWorkerTemplate.OrderedTermination = orderedTermination
+	return lwsWrapper
+}
+
+func (lwsWrapper *LeaderWorkerSetWrapper) ActiveDeadlineSeconds(seconds int64) *LeaderWorkerSetWrapper {
+	lwsWrapper.Spec.LeaderWorkerTemplate.ActiveDeadlineSeconds = &seconds
+	return lwsWrapper
+}
+
 func (lwsWrapper *LeaderWorkerSetWrapper) WorkerTemplateSpec(spec corev1.PodSpec) *LeaderWorkerSetWrapper {
 	lwsWrapper.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec = spec
 	return lwsWrapper
@@ -78,6 +108,24 @@ func (lwsWrapper *LeaderWorkerSetWrapper) ExclusivePlacement() *LeaderWorkerSetW
 	return lwsWrapper
 }
 
+func (lwsWrapper *LeaderWorkerSetWrapper) PerGroupEnv(groupIndex int32, name, value string) *LeaderWorkerSetWrapper {
+	lwsWrapper.Spec.LeaderWorkerTemplate.PerGroupEnv = append(lwsWrapper.Spec.LeaderWorkerTemplate.PerGroupEnv, leaderworkerset.PerGroupEnv{
+		GroupIndex: groupIndex,
+		Env:        []corev1.EnvVar{{Name: name, Value: value}},
+	})
+	return lwsWrapper
+}
+
+func (lwsWrapper *LeaderWorkerSetWrapper) FailedGroupRetention(retention leaderworkerset.FailedGroupRetentionType) *LeaderWorkerSetWrapper {
+	lwsWrapper.Spec.LeaderWorkerTemplate.FailedGroupRetention = retention
+	return lwsWrapper
+}
+
+func (lwsWrapper *LeaderWorkerSetWrapper) PodFailurePolicy(policy *leaderworkerset.PodFailurePolicy) *LeaderWorkerSetWrapper {
+	lwsWrapper.Spec.LeaderWorkerTemplate.PodFailurePolicy = policy
+	return lwsWrapper
+}
+
 func (lwsWrapper *LeaderWorkerSetWrapper) RestartPolicy(policy leaderworkerset.RestartPolicyType) *LeaderWorkerSetWrapper {
 	lwsWrapper.Spec.LeaderWorkerTemplate.RestartPolicy = policy
 	return lwsWrapper
@@ -93,6 +141,21 @@ func (lwsWrapper *LeaderWorkerSetWrapper) StartupPolicy(strategy leaderworkerset
 	return lwsWrapper
 }
 
+func (lwsWrapper *LeaderWorkerSetWrapper) LeaderReadyConfiguration(config *leaderworkerset.LeaderReadyConfiguration) *LeaderWorkerSetWrapper {
+	lwsWrapper.Spec.LeaderReadyConfiguration = config
+	return lwsWrapper
+}
+
+func (lwsWrapper *LeaderWorkerSetWrapper) ScaleDownPolicy(policy leaderworkerset.ScaleDownPolicyType) *LeaderWorkerSetWrapper {
+	lwsWrapper.Spec.ScaleDownPolicy = policy
+	return lwsWrapper
+}
+
+func (lwsWrapper *LeaderWorkerSetWrapper) Suspend(suspend bool) *LeaderWorkerSetWrapper {
+	lwsWrapper.Spec.Suspend = ptr.To(suspend)
+	return lwsWrapper
+}
+
 func (lwsWrapper *LeaderWorkerSetWrapper) Annotation(annotations map[string]string) *LeaderWorkerSetWrapper {
 	lwsWrapper.Annotations = annotations
 	return lwsWrapper
@@ -126,11 +189,32 @@ func (lwsWrapper *LeaderWorkerSetWrapper) SubdomainPolicy(subdomainPolicy leader
 	return lwsWrapper
 }
 
+func (lwsWrapper *LeaderWorkerSetWrapper) StartIndex(startIndex int32) *LeaderWorkerSetWrapper {
+	if lwsWrapper.Spec.NetworkConfig == nil {
+		lwsWrapper.Spec.NetworkConfig = &leaderworkerset.NetworkConfig{SubdomainPolicy: ptr.To(leaderworkerset.SubdomainShared)}
+	}
+	lwsWrapper.Spec.NetworkConfig.StartIndex = startIndex
+	return lwsWrapper
+}
+
+func (lwsWrapper *LeaderWorkerSetWrapper) PerGroupService(perGroupService bool) *LeaderWorkerSetWrapper {
+	if lwsWrapper.Spec.NetworkConfig == nil {
+		lwsWrapper.Spec.NetworkConfig = &leaderworkerset.NetworkConfig{SubdomainPolicy: ptr.To(leaderworkerset.SubdomainShared)}
+	}
+	lwsWrapper.Spec.NetworkConfig.PerGroupService = perGroupService
+	return lwsWrapper
+}
+
 func (lwsWrapper *LeaderWorkerSetWrapper) SubdomainNil() *LeaderWorkerSetWrapper {
 	lwsWrapper.Spec.NetworkConfig = nil
 	return lwsWrapper
 }
 
+func (lwsWrapper *LeaderWorkerSetWrapper) LeaderTemplateNil() *LeaderWorkerSetWrapper {
+	lwsWrapper.Spec.LeaderWorkerTemplate.LeaderTemplate = nil
+	return lwsWrapper
+}
+
 func BuildBasicLeaderWorkerSet(name, ns string) *LeaderWorkerSetWrapper {
 	return &LeaderWorkerSetWrapper{
 		leaderworkerset.LeaderWorkerSet{
@@ -165,6 +249,7 @@ func BuildLeaderWorkerSet(nsName string) *LeaderWorkerSetWrapper {
 		},
 	}
 	lws.Spec.StartupPolicy = leaderworkerset.LeaderCreatedStartupPolicy
+	lws.Spec.Suspend = ptr.To(false)
 	subdomainPolicy := leaderworkerset.SubdomainShared
 	lws.Spec.NetworkConfig = &leaderworkerset.NetworkConfig{
 		SubdomainPolicy: &subdomainPolicy,